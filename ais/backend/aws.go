@@ -34,6 +34,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	s3manager "github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
@@ -48,6 +49,7 @@ type (
 	}
 	sessConf struct {
 		bck    *cmn.Bck
+		prof   *cmn.S3Profile // named profile, if any (see cmn.BackendConfAWS)
 		region string
 	}
 )
@@ -742,6 +744,7 @@ func (sessConf *sessConf) s3client(tag string) (*s3.Client, error) {
 	var (
 		endpoint = s3Endpoint
 		profile  = awsProfile
+		bckEp    bool
 	)
 	if sessConf.bck != nil && sessConf.bck.Props != nil {
 		if sessConf.region == "" {
@@ -749,13 +752,27 @@ func (sessConf *sessConf) s3client(tag string) (*s3.Client, error) {
 		}
 		if sessConf.bck.Props.Extra.AWS.Endpoint != "" {
 			endpoint = sessConf.bck.Props.Extra.AWS.Endpoint
+			bckEp = true
 		}
 		if sessConf.bck.Props.Extra.AWS.Profile != "" {
 			profile = sessConf.bck.Props.Extra.AWS.Profile
 		}
 	}
 
+	// named S3 profile from cluster config (bucket's own region and endpoint take precedence)
+	if sessConf.prof = s3Profile(profile); sessConf.prof != nil {
+		if sessConf.region == "" {
+			sessConf.region = sessConf.prof.Region
+		}
+		if !bckEp && sessConf.prof.Endpoint != "" {
+			endpoint = sessConf.prof.Endpoint
+		}
+	}
+
 	cid := _cid(profile, sessConf.region, endpoint)
+	if sessConf.prof != nil {
+		cid += "#" + sessConf.prof.String() // (updated profile => new client)
+	}
 	asvc, loaded := clients.Load(cid)
 	if loaded {
 		svc, ok := asvc.(*s3.Client)
@@ -764,7 +781,7 @@ func (sessConf *sessConf) s3client(tag string) (*s3.Client, error) {
 	}
 
 	// slow path
-	cfg, err := loadConfig(endpoint, profile)
+	cfg, err := loadConfig(endpoint, profile, sessConf.prof)
 	if err != nil {
 		return nil, err
 	}
@@ -802,6 +819,9 @@ func (sessConf *sessConf) options(options *s3.Options) {
 			options.UsePathStyle = cmn.Rom.Features().IsSet(feat.S3UsePathStyle)
 		}
 	}
+	if sessConf.prof != nil && sessConf.prof.PathStyle {
+		options.UsePathStyle = true
+	}
 }
 
 func _cid(profile, region, endpoint string) string {
@@ -824,8 +844,23 @@ func _cid(profile, region, endpoint string) string {
 	return sb.String()
 }
 
-// loadConfig create config using default creds from ~/.aws/credentials and environment variables.
-func loadConfig(endpoint, profile string) (aws.Config, error) {
+func s3Profile(name string) *cmn.S3Profile {
+	if name == "" {
+		return nil
+	}
+	awsConf, ok := cmn.GCO.Get().Backend.Get(apc.AWS).(cmn.BackendConfAWS)
+	if !ok {
+		return nil
+	}
+	return awsConf.Profile(name)
+}
+
+// loadConfig create config using default creds from ~/.aws/credentials and environment variables -
+// or, when given named S3 profile, its credentials reference and TLS options
+func loadConfig(endpoint, profile string, prof *cmn.S3Profile) (aws.Config, error) {
+	if prof != nil {
+		return loadProfConfig(endpoint, prof)
+	}
 	// NOTE: The AWS SDK for Go v2, uses lower case header maps by default.
 	cfg, err := config.LoadDefaultConfig(
 		context.Background(),
@@ -841,6 +876,45 @@ func loadConfig(endpoint, profile string) (aws.Config, error) {
 	return cfg, nil
 }
 
+func loadProfConfig(endpoint string, prof *cmn.S3Profile) (aws.Config, error) {
+	transport := cmn.NewTransport(cmn.TransportArgs{})
+	if prof.CABundle != "" || prof.SkipVerify {
+		tlsConf, err := cmn.NewTLS(cmn.TLSArgs{ClientCA: prof.CABundle, SkipVerify: prof.SkipVerify}, false /*intra*/)
+		if err != nil {
+			return aws.Config{}, err
+		}
+		transport.TLSClientConfig = tlsConf
+	}
+	opts := []func(*config.LoadOptions) error{
+		config.WithHTTPClient(tracing.NewTraceableClient(&http.Client{Transport: transport})),
+	}
+	switch {
+	case strings.HasPrefix(prof.Creds, cmn.S3CredsEnv):
+		var (
+			name = strings.TrimPrefix(prof.Creds, cmn.S3CredsEnv)
+			ak   = os.Getenv(name + "_ACCESS_KEY_ID")
+			sk   = os.Getenv(name + "_SECRET_ACCESS_KEY")
+		)
+		if ak == "" || sk == "" {
+			return aws.Config{}, fmt.Errorf("S3 profile credentials %q: %s_ACCESS_KEY_ID and/or %s_SECRET_ACCESS_KEY not set",
+				prof.Creds, name, name)
+		}
+		provider := credentials.NewStaticCredentialsProvider(ak, sk, os.Getenv(name+"_SESSION_TOKEN"))
+		opts = append(opts, config.WithCredentialsProvider(provider))
+	case strings.HasPrefix(prof.Creds, cmn.S3CredsFile):
+		fpath, section := prof.CredsFile()
+		opts = append(opts, config.WithSharedCredentialsFiles([]string{fpath}), config.WithSharedConfigProfile(section))
+	}
+	cfg, err := config.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return cfg, err
+	}
+	if endpoint != "" {
+		cfg.BaseEndpoint = aws.String(endpoint)
+	}
+	return cfg, nil
+}
+
 func getBucketVersioning(svc *s3.Client, bck *cmn.Bck) (enabled bool, errV error) {
 	input := &s3.GetBucketVersioningInput{Bucket: aws.String(bck.Name)}
 	result, err := svc.GetBucketVersioning(context.Background(), input)
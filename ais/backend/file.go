@@ -0,0 +1,402 @@
+//go:build file
+
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// file:// backend
+// - maps remote buckets onto subdirectories of a single root directory (cmn.BackendConfFile)
+//   that must be visible to all targets - e.g., a shared NFS mount;
+// - object name is a (slash-separated) relative path within its bucket's directory;
+// - object version is its modification time (unix nanoseconds).
+
+const fileTmpSuffix = ".ais-tmp" // in-progress PUTs (not listed)
+
+type (
+	filebp struct {
+		t core.TargetPut
+		base
+	}
+	// section of an open file (range read)
+	fileSection struct {
+		*io.SectionReader
+		fh *os.File
+	}
+)
+
+// interface guard
+var _ core.Backend = (*filebp)(nil)
+
+func NewFile(t core.TargetPut, _ *cmn.Config, tstats stats.Tracker) (core.Backend, error) {
+	bp := &filebp{
+		t:    t,
+		base: base{provider: apc.File},
+	}
+	bp.init(t.Snode(), tstats)
+	return bp, nil
+}
+
+func (sec *fileSection) Close() error { return sec.fh.Close() }
+
+//
+// paths
+//
+
+// (root may change at runtime via cluster config update)
+func fileRoot() (string, error) {
+	conf, ok := cmn.GCO.Get().Backend.Get(apc.File).(cmn.BackendConfFile)
+	if !ok || conf.Root == "" {
+		return "", &cmn.ErrMissingBackend{Provider: apc.File}
+	}
+	return conf.Root, nil
+}
+
+func fileBckDir(bck *cmn.Bck) (string, error) {
+	root, err := fileRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, bck.Name), nil
+}
+
+func fileObjPath(lom *core.LOM) (string, int, error) {
+	dir, err := fileBckDir(lom.Bck().RemoteBck())
+	if err != nil {
+		return "", http.StatusBadRequest, err
+	}
+	fqn, err := fileJoin(dir, lom.ObjName)
+	if err != nil {
+		return "", http.StatusBadRequest, err
+	}
+	return fqn, 0, nil
+}
+
+// object's path within its bucket's directory (rejecting names that would resolve outside of it)
+func fileJoin(dir, objName string) (string, error) {
+	fqn := filepath.Join(dir, objName)
+	if !strings.HasPrefix(fqn, dir+string(filepath.Separator)) || cos.IsLastB(objName, '/') {
+		return "", fmt.Errorf("%s: invalid object name %q", apc.File, objName)
+	}
+	return fqn, nil
+}
+
+func fileStatErr(err error, what string) (int, error) {
+	if os.IsNotExist(err) {
+		return http.StatusNotFound, cos.NewErrNotFound(nil, what)
+	}
+	return 0, err
+}
+
+func fileVersion(finfo os.FileInfo) string { return strconv.FormatInt(finfo.ModTime().UnixNano(), 10) }
+
+func setCustomFile(lom *core.LOM, finfo os.FileInfo) {
+	v := fileVersion(finfo)
+	lom.SetVersion(v)
+	lom.SetCustomKey(cmn.SourceObjMD, apc.File)
+	lom.SetCustomKey(cmn.VersionObjMD, v)
+	lom.SetCustomKey(cmn.LastModified, fmtTime(finfo.ModTime()))
+}
+
+//
+// buckets
+//
+
+func (*filebp) HeadBucket(_ context.Context, bck *meta.Bck) (bckProps cos.StrKVs, ecode int, err error) {
+	cloudBck := bck.RemoteBck()
+	dir, err := fileBckDir(cloudBck)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	finfo, err := os.Stat(dir)
+	if err != nil || !finfo.IsDir() {
+		if err == nil || os.IsNotExist(err) {
+			return nil, http.StatusNotFound, cmn.NewErrRemoteBckNotFound(cloudBck)
+		}
+		return nil, 0, err
+	}
+	bckProps = make(cos.StrKVs, 2)
+	bckProps[apc.HdrBackendProvider] = apc.File
+	// (mtime-based versions are always there)
+	bckProps[apc.HdrBucketVerEnabled] = "true"
+	return bckProps, 0, nil
+}
+
+func (*filebp) ListBuckets(cmn.QueryBcks) (bcks cmn.Bcks, ecode int, err error) {
+	root, err := fileRoot()
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	dents, err := os.ReadDir(root)
+	if err != nil {
+		return nil, 0, err
+	}
+	bcks = make(cmn.Bcks, 0, len(dents))
+	for _, dent := range dents {
+		name := dent.Name()
+		if !dent.IsDir() || name[0] == '.' {
+			continue
+		}
+		bck := cmn.Bck{Name: name, Provider: apc.File}
+		if bck.ValidateName() != nil {
+			continue
+		}
+		bcks = append(bcks, bck)
+	}
+	return bcks, 0, nil
+}
+
+//
+// list objects
+//
+
+// (see lsDir)
+func (*filebp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (int, error) {
+	cloudBck := bck.RemoteBck()
+	dir, err := fileBckDir(cloudBck)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return http.StatusNotFound, cmn.NewErrRemoteBckNotFound(cloudBck)
+		}
+		return 0, err
+	}
+	msg.PageSize = calcPageSize(msg.PageSize, bck.MaxPageSize())
+	w := &lsDir{readDir: fileReadDir, msg: msg, lst: lst}
+	if err := w.run(dir); err != nil {
+		return 0, err
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
+		nlog.Infoln("list_objects", cloudBck.Name, len(lst.Entries))
+	}
+	return 0, nil
+}
+
+// regular files and subdirectories (following symlinks), excluding in-progress PUTs
+// and symlinks to directories that would make the walk loop (see fileLoops)
+func fileReadDir(dir string) ([]lsDent, error) {
+	dents, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // (removed meanwhile)
+		}
+		return nil, err
+	}
+	all := make([]lsDent, 0, len(dents))
+	for _, dent := range dents {
+		name := dent.Name()
+		if strings.HasSuffix(name, fileTmpSuffix) {
+			continue
+		}
+		finfo, err := dent.Info()
+		if err != nil {
+			continue // (removed meanwhile)
+		}
+		if finfo.Mode()&os.ModeSymlink != 0 {
+			if finfo, err = os.Stat(filepath.Join(dir, name)); err != nil {
+				continue // dangling
+			}
+			if finfo.IsDir() && fileLoops(dir, finfo) {
+				if cmn.Rom.FastV(4, cos.SmoduleBackend) {
+					nlog.Warningln(apc.File, "skipping symlink", filepath.Join(dir, name), "to a parent directory")
+				}
+				continue
+			}
+		}
+		switch {
+		case finfo.IsDir():
+			all = append(all, lsDent{name: name, child: filepath.Join(dir, name), dir: true})
+		case finfo.Mode().IsRegular():
+			all = append(all, lsDent{name: name, size: finfo.Size(), mtime: finfo.ModTime(), ver: fileVersion(finfo)})
+		}
+	}
+	return all, nil
+}
+
+// returns true if the (symlinked) directory is `dir` itself or any of its parents
+// (same device and inode - see os.SameFile)
+func fileLoops(dir string, finfo os.FileInfo) bool {
+	for p := dir; ; {
+		if pinfo, err := os.Stat(p); err == nil && os.SameFile(pinfo, finfo) {
+			return true
+		}
+		parent := filepath.Dir(p)
+		if parent == p {
+			return false
+		}
+		p = parent
+	}
+}
+
+//
+// objects
+//
+
+func (*filebp) HeadObj(_ context.Context, lom *core.LOM, _ *http.Request) (oa *cmn.ObjAttrs, ecode int, err error) {
+	fqn, ecode, err := fileObjPath(lom)
+	if err != nil {
+		return nil, ecode, err
+	}
+	finfo, err := os.Stat(fqn)
+	if err == nil && !finfo.Mode().IsRegular() {
+		err = os.ErrNotExist
+	}
+	if err != nil {
+		ecode, err = fileStatErr(err, lom.Cname())
+		return nil, ecode, err
+	}
+	v := fileVersion(finfo)
+	oa = &cmn.ObjAttrs{Size: finfo.Size()}
+	oa.CustomMD = make(cos.StrKVs, 3)
+	oa.SetVersion(v)
+	oa.SetCustomKey(cmn.SourceObjMD, apc.File)
+	oa.SetCustomKey(cmn.VersionObjMD, v)
+	oa.SetCustomKey(cmn.LastModified, fmtTime(finfo.ModTime()))
+	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
+		nlog.Infoln("[head_object]", lom.Cname())
+	}
+	return oa, 0, nil
+}
+
+func (bp *filebp) GetObj(ctx context.Context, lom *core.LOM, owt cmn.OWT, _ *http.Request) (int, error) {
+	res := bp.GetObjReader(ctx, lom, 0, 0)
+	if res.Err != nil {
+		return res.ErrCode, res.Err
+	}
+	params := allocPutParams(res, owt)
+	err := bp.t.PutObject(lom, params)
+	core.FreePutParams(params)
+	if err != nil {
+		return 0, err
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
+		nlog.Infoln("[get_object]", lom.Cname())
+	}
+	return 0, nil
+}
+
+func (*filebp) GetObjReader(_ context.Context, lom *core.LOM, offset, length int64) (res core.GetReaderResult) {
+	var fqn string
+	fqn, res.ErrCode, res.Err = fileObjPath(lom)
+	if res.Err != nil {
+		return res
+	}
+	fh, err := os.Open(fqn)
+	if err != nil {
+		res.ErrCode, res.Err = fileStatErr(err, lom.Cname())
+		return res
+	}
+	finfo, err := fh.Stat()
+	if err == nil && !finfo.Mode().IsRegular() {
+		err = fmt.Errorf("%s: %q is not a regular file", apc.File, lom.ObjName)
+		res.ErrCode = http.StatusBadRequest
+	}
+	if err != nil {
+		fh.Close()
+		res.Err = err
+		return res
+	}
+	if length > 0 {
+		if offset+length > finfo.Size() {
+			fh.Close()
+			res.Err = cmn.NewErrRangeNotSatisfiable(nil, nil, finfo.Size())
+			res.ErrCode = http.StatusRequestedRangeNotSatisfiable
+			return res
+		}
+		res.R = &fileSection{io.NewSectionReader(fh, offset, length), fh}
+		res.Size = length
+		return res
+	}
+	setCustomFile(lom, finfo)
+	res.R = fh
+	res.Size = finfo.Size()
+	return res
+}
+
+// write (temp file) => fsync => rename
+func (bp *filebp) PutObj(r io.ReadCloser, lom *core.LOM, _ *http.Request) (int, error) {
+	defer cos.Close(r)
+	fqn, ecode, err := fileObjPath(lom)
+	if err != nil {
+		return ecode, err
+	}
+	dir := filepath.Dir(fqn)
+	if err := cos.CreateDir(dir); err != nil {
+		return 0, err
+	}
+	fh, err := os.CreateTemp(dir, "."+filepath.Base(fqn)+".*"+fileTmpSuffix)
+	if err != nil {
+		return 0, err
+	}
+	tmp := fh.Name()
+	buf, slab := bp.t.PageMM().Alloc()
+	written, err := io.CopyBuffer(fh, r, buf)
+	slab.Free(buf)
+	if err == nil {
+		err = fh.Sync()
+	}
+	if errC := fh.Close(); err == nil {
+		err = errC
+	}
+	if err == nil {
+		err = os.Rename(tmp, fqn)
+	}
+	if err != nil {
+		if errR := cos.RemoveFile(tmp); errR != nil {
+			nlog.Errorln("nested error:", errR, "[", err, "]")
+		}
+		return 0, err
+	}
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return 0, err
+	}
+	setCustomFile(lom, finfo)
+	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
+		nlog.Infoln("[put_object]", lom.Cname(), "size", written)
+	}
+	return 0, nil
+}
+
+func (*filebp) DeleteObj(lom *core.LOM) (int, error) {
+	fqn, ecode, err := fileObjPath(lom)
+	if err != nil {
+		return ecode, err
+	}
+	if err := os.Remove(fqn); err != nil {
+		return fileStatErr(err, lom.Cname())
+	}
+	// remove emptied-out parent directories (best effort)
+	dir, _ := fileBckDir(lom.Bck().RemoteBck())
+	for parent := filepath.Dir(fqn); parent != dir && strings.HasPrefix(parent, dir); parent = filepath.Dir(parent) {
+		if os.Remove(parent) != nil {
+			break
+		}
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
+		nlog.Infoln("[delete_object]", lom.Cname())
+	}
+	return 0, nil
+}
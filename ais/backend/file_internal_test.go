@@ -0,0 +1,121 @@
+//go:build file
+
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// bucket directory:
+//
+//	a.txt
+//	b/c.txt
+//	b/d/e.txt
+//	b/loop -> ..          (skipped: parent)
+//	b/self -> .           (skipped: itself)
+//	f -> b/d              (followed)
+//	g.txt -> a.txt        (followed)
+//	h -> nonexistent      (dangling: skipped)
+//	i.txt.ais-tmp         (in-progress PUT: skipped)
+func fileTestTree(tb testing.TB) string {
+	dir := tb.TempDir()
+	for _, name := range []string{"a.txt", "b/c.txt", "b/d/e.txt", "i.txt" + fileTmpSuffix} {
+		fqn := filepath.Join(dir, name)
+		tassert.CheckFatal(tb, os.MkdirAll(filepath.Dir(fqn), 0o755))
+		tassert.CheckFatal(tb, os.WriteFile(fqn, []byte(name), 0o644))
+	}
+	for link, target := range map[string]string{"b/loop": "..", "b/self": ".", "f": "b/d", "g.txt": "a.txt", "h": "nonexistent"} {
+		tassert.CheckFatal(tb, os.Symlink(target, filepath.Join(dir, link)))
+	}
+	return dir
+}
+
+func TestFileReadDir(t *testing.T) {
+	dir := fileTestTree(t)
+
+	dents, err := fileReadDir(dir)
+	tassert.CheckFatal(t, err)
+	var names []string
+	for _, dent := range dents {
+		if dent.dir {
+			names = append(names, dent.name+"/")
+		} else {
+			names = append(names, dent.name)
+			tassert.Errorf(t, dent.size > 0 && dent.ver != "", "%s: expected size and version", dent.name)
+		}
+	}
+	slices.Sort(names)
+	tassert.Errorf(t, slices.Equal(names, []string{"a.txt", "b/", "f/", "g.txt"}), "root: got %v", names)
+
+	dents, err = fileReadDir(filepath.Join(dir, "b"))
+	tassert.CheckFatal(t, err)
+	names = names[:0]
+	for _, dent := range dents {
+		names = append(names, dent.name)
+	}
+	slices.Sort(names)
+	tassert.Errorf(t, slices.Equal(names, []string{"c.txt", "d"}), "b: got %v (expecting symlink loops skipped)", names)
+
+	// removed meanwhile
+	dents, err = fileReadDir(filepath.Join(dir, "nonexistent"))
+	tassert.Errorf(t, err == nil && len(dents) == 0, "expected no entries, got %v (%v)", dents, err)
+}
+
+func TestFileObjPath(t *testing.T) {
+	const dir = "/mnt/shared/bck"
+	for _, objName := range []string{"../other/obj", "a/../../obj", "..", "a/", "/"} {
+		_, err := fileJoin(dir, objName)
+		tassert.Errorf(t, err != nil, "%q: expected error", objName)
+	}
+	for objName, expected := range map[string]string{"obj": dir + "/obj", "a/b/c": dir + "/a/b/c", "a/../b": dir + "/b"} {
+		fqn, err := fileJoin(dir, objName)
+		tassert.CheckError(t, err)
+		tassert.Errorf(t, fqn == expected, "%q: expected %q, got %q", objName, expected, fqn)
+	}
+}
+
+func TestFileListPages(t *testing.T) {
+	var (
+		dir = fileTestTree(t)
+		ls  = func(msg *apc.LsoMsg) []string {
+			var (
+				names []string
+				lst   = &cmn.LsoRes{}
+			)
+			for {
+				w := &lsDir{readDir: fileReadDir, msg: msg, lst: lst}
+				tassert.CheckFatal(t, w.run(dir))
+				for _, en := range lst.Entries {
+					names = append(names, en.Name)
+				}
+				if lst.ContinuationToken == "" {
+					return names
+				}
+				msg.ContinuationToken, lst.ContinuationToken = lst.ContinuationToken, ""
+			}
+		}
+		all = []string{"a.txt", "b/c.txt", "b/d/e.txt", "f/e.txt", "g.txt"}
+	)
+	for _, pageSize := range []int64{1, 2, 3, 100} {
+		names := ls(&apc.LsoMsg{PageSize: pageSize})
+		tassert.Errorf(t, slices.Equal(names, all), "page size %d: expected %v, got %v", pageSize, all, names)
+	}
+
+	names := ls(&apc.LsoMsg{PageSize: 1, Prefix: "b/"})
+	tassert.Errorf(t, slices.Equal(names, []string{"b/c.txt", "b/d/e.txt"}), "prefix: got %v", names)
+
+	names = ls(&apc.LsoMsg{PageSize: 2, Flags: apc.LsNoRecursion})
+	expected := []string{"a.txt", "b/", "f/", "g.txt"}
+	tassert.Errorf(t, slices.Equal(names, expected), "non-recursive: expected %v, got %v", expected, names)
+}
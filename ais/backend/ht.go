@@ -8,9 +8,14 @@ package backend
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html"
 	"io"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -20,6 +25,8 @@ import (
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/stats"
+
+	jsoniter "github.com/json-iterator/go"
 )
 
 type (
@@ -85,14 +92,154 @@ func (htbp *htbp) HeadBucket(ctx context.Context, bck *meta.Bck) (bckProps cos.S
 	return
 }
 
-func (*htbp) ListObjects(*meta.Bck, *apc.LsoMsg, *cmn.LsoRes) (ecode int, err error) {
-	debug.Assert(false)
-	return
+// list objects by parsing index pages of the bucket's directory and (up to
+// `extra.http.list_depth` levels of) its subdirectories (see lsDir)
+func (htbp *htbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (int, error) {
+	if !bck.IsListableHT() {
+		return http.StatusNotImplemented, cmn.NewErrUnsupp("list", bck.Cname("")+" (to enable, set 'extra.http.list_depth')")
+	}
+	root := bck.Props.Extra.HTTP.OrigURLBck
+	if !cos.IsLastB(root, '/') {
+		root += "/"
+	}
+	msg.PageSize = calcPageSize(msg.PageSize, bck.MaxPageSize())
+	w := &lsDir{readDir: htbp.readIndex, msg: msg, lst: lst, maxDepth: bck.Props.Extra.HTTP.ListDepth}
+	if err := w.run(root); err != nil {
+		var herr *htErrStatus
+		if errors.As(err, &herr) {
+			return herr.status, err
+		}
+		return 0, err
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
+		nlog.Infoln("[list_objects]", root, len(lst.Entries))
+	}
+	return 0, nil
 }
 
-func (*htbp) ListBuckets(cmn.QueryBcks) (bcks cmn.Bcks, ecode int, err error) {
-	debug.Assert(false)
-	return
+// HTTP(S) buckets are defined by their respective URLs and exist only in BMD
+func (htbp *htbp) ListBuckets(qbck cmn.QueryBcks) (cmn.Bcks, int, error) {
+	qbck.Provider = apc.HT
+	return htbp.t.Bowner().Get().Select(&qbck), 0, nil
+}
+
+//
+// HTTP index pages
+//
+
+const htMaxIndexSize = 64 * cos.MiB
+
+type htErrStatus struct {
+	url    string
+	status int
+}
+
+func (e *htErrStatus) Error() string {
+	return fmt.Sprintf("GET(%s) failed, status %d", e.url, e.status)
+}
+
+// read and parse directory index page: nginx `autoindex_format json` or HTML
+// (Apache and nginx autoindex, and the like)
+func (htbp *htbp) readIndex(dirURL string) ([]lsDent, error) {
+	req, err := http.NewRequest(http.MethodGet, dirURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(cos.HdrAccept, cos.ContentJSON+", text/html;q=0.9, */*;q=0.1")
+	resp, err := htbp.client(dirURL).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &htErrStatus{dirURL, resp.StatusCode}
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, htMaxIndexSize))
+	if err != nil {
+		return nil, err
+	}
+	base, err := url.Parse(dirURL)
+	if err != nil {
+		return nil, err
+	}
+	ctype := resp.Header.Get(cos.HdrContentType)
+	if strings.Contains(ctype, "json") || (ctype == "" && len(body) > 0 && body[0] == '[') {
+		return parseIndexJSON(body, base)
+	}
+	return parseIndexHTML(body, base), nil
+}
+
+// nginx: [{"name":"abc", "type":"file", "mtime":"Thu, 17 Oct 2024 09:00:00 GMT", "size":1024}, ...]
+func parseIndexJSON(body []byte, base *url.URL) ([]lsDent, error) {
+	var ents []struct {
+		Name  string `json:"name"`
+		Type  string `json:"type"`
+		Mtime string `json:"mtime"`
+		Size  int64  `json:"size"`
+	}
+	if err := jsoniter.Unmarshal(body, &ents); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON index %q: %v", base, err)
+	}
+	dents := make([]lsDent, 0, len(ents))
+	for i := range ents {
+		ent := &ents[i]
+		if ent.Name == "" || ent.Name == "." || ent.Name == ".." || strings.Contains(ent.Name, "/") {
+			continue
+		}
+		switch ent.Type {
+		case "directory":
+			child := base.ResolveReference(&url.URL{Path: ent.Name + "/"})
+			dents = append(dents, lsDent{name: ent.Name, child: child.String(), dir: true})
+		case "file":
+			dent := lsDent{name: ent.Name, size: ent.Size}
+			if mtime, err := http.ParseTime(ent.Mtime); err == nil {
+				dent.mtime = mtime
+			}
+			dents = append(dents, dent)
+		}
+	}
+	return dents, nil
+}
+
+var htHrefRe = regexp.MustCompile(`(?i)<a\s[^>]*?href\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+// HTML: links to the immediate children of the directory, with subdirectories
+// ending with '/'; sorting links (e.g., Apache "?C=N;O=D"), parent directory,
+// and links elsewhere are ignored; no size or mtime
+func parseIndexHTML(body []byte, base *url.URL) []lsDent {
+	var (
+		dents = make([]lsDent, 0, 64)
+		seen  = make(map[string]struct{}, 64)
+	)
+	for _, m := range htHrefRe.FindAllSubmatch(body, -1) {
+		href := string(m[1])
+		if href == "" {
+			href = string(m[2])
+		}
+		href = html.UnescapeString(href)
+		if href == "" || strings.ContainsAny(href, "?#") {
+			continue
+		}
+		u, err := base.Parse(href)
+		if err != nil || u.Scheme != base.Scheme || u.Host != base.Host || !strings.HasPrefix(u.Path, base.Path) {
+			continue
+		}
+		rel := u.Path[len(base.Path):]
+		name, dir := strings.CutSuffix(rel, "/")
+		if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+			continue
+		}
+		if _, ok := seen[rel]; ok {
+			continue // (e.g., Apache FancyIndexing: icon and name linking the same entry)
+		}
+		seen[rel] = struct{}{}
+		if dir {
+			dents = append(dents, lsDent{name: name, child: u.String(), dir: true})
+		} else {
+			dents = append(dents, lsDent{name: name})
+		}
+	}
+	return dents
 }
 
 func getOriginalURL(ctx context.Context, bck *meta.Bck, objName string) (string, error) {
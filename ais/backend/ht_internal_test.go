@@ -0,0 +1,166 @@
+//go:build ht
+
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+const (
+	// nginx: autoindex on; autoindex_format json;
+	htNginxJSON = `[
+{ "name":"a.txt", "type":"file", "mtime":"Thu, 17 Oct 2024 09:00:00 GMT", "size":1024 },
+{ "name":"b", "type":"directory", "mtime":"Thu, 17 Oct 2024 09:00:00 GMT" },
+{ "name":"c d.tar", "type":"file", "mtime":"bad time", "size":7 },
+{ "name":"lnk", "type":"other", "mtime":"Thu, 17 Oct 2024 09:00:00 GMT" }
+]`
+	// nginx: autoindex on;
+	htNginxHTML = `<html>
+<head><title>Index of /data/</title></head>
+<body>
+<h1>Index of /data/</h1><hr><pre><a href="../">../</a>
+<a href="a.txt">a.txt</a>                                              17-Oct-2024 09:00    1024
+<a href="b/">b/</a>                                                    17-Oct-2024 09:00       -
+<a href="c%20d.tar">c d.tar</a>                                        17-Oct-2024 09:00       7
+</pre><hr></body>
+</html>`
+	// Apache: mod_autoindex with FancyIndexing
+	htApacheHTML = `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 3.2 Final//EN">
+<html>
+ <head>
+  <title>Index of /data</title>
+ </head>
+ <body>
+<h1>Index of /data</h1>
+  <table>
+   <tr><th valign="top"><img src="/icons/blank.gif" alt="[ICO]"></th><th><a href="?C=N;O=D">Name</a></th><th><a href="?C=M;O=A">Last modified</a></th><th><a href="?C=S;O=A">Size</a></th></tr>
+   <tr><td valign="top"><img src="/icons/back.gif" alt="[PARENTDIR]"></td><td><a href="/">Parent Directory</a></td><td>&nbsp;</td><td align="right">  - </td></tr>
+   <tr><td valign="top"><a href="a.txt"><img src="/icons/text.gif" alt="[TXT]"></a></td><td><a href="a.txt">a.txt</a></td><td align="right">2024-10-17 09:00  </td><td align="right">1.0K</td></tr>
+   <tr><td valign="top"><a href='b/'><img src="/icons/folder.gif" alt="[DIR]"></a></td><td><a href='b/'>b/</a></td><td align="right">2024-10-17 09:00  </td><td align="right">  - </td></tr>
+   <tr><td valign="top"><img src="/icons/unknown.gif" alt="[   ]"></td><td><a HREF="c%20d.tar">c d.tar</a></td><td align="right">2024-10-17 09:00  </td><td align="right">  7 </td></tr>
+   <tr><td valign="top"><img src="/icons/unknown.gif" alt="[   ]"></td><td><a href="http://example.org/data/x.txt">x.txt</a></td><td>&nbsp;</td><td align="right">  1 </td></tr>
+   <tr><td valign="top"><img src="/icons/unknown.gif" alt="[   ]"></td><td><a href="/other/y.txt">y.txt</a></td><td>&nbsp;</td><td align="right">  1 </td></tr>
+  </table>
+<address>Apache/2.4.62 (Unix) Server at localhost Port 80</address>
+</body></html>`
+)
+
+// name (subdirectories end with '/') and child URL
+func htDents(dents []lsDent) (names, children []string) {
+	for i := range dents {
+		if dents[i].dir {
+			names = append(names, dents[i].name+"/")
+			children = append(children, dents[i].child)
+		} else {
+			names = append(names, dents[i].name)
+		}
+	}
+	return names, children
+}
+
+func TestParseIndex(t *testing.T) {
+	base, err := url.Parse("http://localhost/data/")
+	tassert.CheckFatal(t, err)
+
+	tests := []struct {
+		name   string
+		body   string
+		json   bool
+		names  []string
+		sizes  []int64
+		mtimes bool
+	}{
+		{name: "nginx-json", body: htNginxJSON, json: true,
+			names: []string{"a.txt", "b/", "c d.tar"}, sizes: []int64{1024, 0, 7}, mtimes: true},
+		{name: "nginx-html", body: htNginxHTML, names: []string{"a.txt", "b/", "c d.tar"}},
+		{name: "apache-html", body: htApacheHTML, names: []string{"a.txt", "b/", "c d.tar"}},
+		{name: "empty-html", body: "<html><body></body></html>"},
+		{name: "empty-json", body: "[]", json: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var dents []lsDent
+			if test.json {
+				dents, err = parseIndexJSON([]byte(test.body), base)
+				tassert.CheckFatal(t, err)
+			} else {
+				dents = parseIndexHTML([]byte(test.body), base)
+			}
+			names, children := htDents(dents)
+			tassert.Fatalf(t, slices.Equal(names, test.names), "expected %v, got %v", test.names, names)
+			for _, child := range children {
+				tassert.Errorf(t, child == "http://localhost/data/b/", "unexpected child URL %q", child)
+			}
+			for i, size := range test.sizes {
+				tassert.Errorf(t, dents[i].size == size, "%s: expected size %d, got %d", names[i], size, dents[i].size)
+			}
+			if test.mtimes {
+				tassert.Errorf(t, !dents[0].mtime.IsZero(), "%s: expected mtime", names[0])
+				tassert.Errorf(t, dents[2].mtime.IsZero(), "%s: unexpected mtime", names[2])
+			}
+		})
+	}
+
+	_, err = parseIndexJSON([]byte("<html>"), base)
+	tassert.Errorf(t, err != nil, "expected error parsing HTML as JSON")
+}
+
+// max depth: deeper subdirectories are listed as such
+func TestLsDirMaxDepth(t *testing.T) {
+	// a.txt, b/c.txt, b/d/e.txt, b/d/f/g.txt
+	tree := map[string][]lsDent{
+		"/":       {{name: "a.txt"}, {name: "b", child: "/b/", dir: true}},
+		"/b/":     {{name: "c.txt"}, {name: "d", child: "/b/d/", dir: true}},
+		"/b/d/":   {{name: "e.txt"}, {name: "f", child: "/b/d/f/", dir: true}},
+		"/b/d/f/": {{name: "g.txt"}},
+	}
+	readDir := func(dir string) ([]lsDent, error) { return tree[dir], nil }
+
+	tests := []struct {
+		prefix   string
+		names    []string
+		maxDepth int
+		err      bool
+	}{
+		{maxDepth: 0, names: []string{"a.txt", "b/c.txt", "b/d/e.txt", "b/d/f/g.txt"}},
+		{maxDepth: 1, names: []string{"a.txt", "b/"}},
+		{maxDepth: 2, names: []string{"a.txt", "b/c.txt", "b/d/"}},
+		{maxDepth: 2, prefix: "b/d", names: []string{"b/d/"}},
+		{maxDepth: 2, prefix: "b/d/", names: []string{"b/d/"}},
+		{maxDepth: 2, prefix: "b/d/e", err: true},
+		{maxDepth: 3, prefix: "b/d/", names: []string{"b/d/e.txt", "b/d/f/"}},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("depth-%d/prefix-%q", test.maxDepth, test.prefix), func(t *testing.T) {
+			var (
+				msg = &apc.LsoMsg{Prefix: test.prefix, PageSize: 100}
+				lst = &cmn.LsoRes{}
+				w   = &lsDir{readDir: readDir, msg: msg, lst: lst, maxDepth: test.maxDepth}
+			)
+			err := w.run("/")
+			if test.err {
+				tassert.Fatalf(t, err != nil, "expected error")
+				return
+			}
+			tassert.CheckFatal(t, err)
+			var names []string
+			for _, en := range lst.Entries {
+				names = append(names, en.Name)
+				tassert.Errorf(t, en.IsDir() == strings.HasSuffix(en.Name, "/"), "%s: unexpected flags %x", en.Name, en.Flags)
+			}
+			tassert.Errorf(t, slices.Equal(names, test.names), "expected %v, got %v", test.names, names)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+//go:build file || ht
+
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// List objects in a (remote) directory tree - a shared filesystem (file://) or
+// HTTP index pages (ht://) - in lexicographical order of the resulting object names.
+// Given that a subdirectory "d" sorts as "d/", walking each directory in the order of
+// its entries makes it possible to resume from continuation token (the last listed name),
+// prune subtrees that precede the token or don't match the prefix, and stop as soon
+// as the page is full.
+
+type (
+	lsDent struct {
+		mtime time.Time
+		name  string // base name
+		child string // subdirectory to read next (path or URL)
+		ver   string // version, if available
+		size  int64
+		dir   bool
+	}
+	lsDir struct {
+		readDir  func(dir string) ([]lsDent, error)
+		msg      *apc.LsoMsg
+		lst      *cmn.LsoRes
+		maxDepth int // max directory levels, including the root (0: unlimited)
+		wantMD   bool
+		wantCMD  bool
+		done     bool
+	}
+	lsKey struct {
+		dent *lsDent
+		key  string // relative name; directories end with '/'
+	}
+)
+
+var errLsDirDone = errors.New("page done")
+
+// (the caller is expected to have set msg.PageSize)
+func (w *lsDir) run(root string) error {
+	w.wantMD = !w.msg.IsFlagSet(apc.LsNameOnly) && !w.msg.IsFlagSet(apc.LsNameSize)
+	w.wantCMD = w.msg.WantProp(apc.GetPropsCustom)
+	w.lst.Entries = w.lst.Entries[:0]
+	if err := w.walk(root, "", 1); err != nil && err != errLsDirDone {
+		return err
+	}
+	if w.done {
+		w.lst.ContinuationToken = w.lst.Entries[len(w.lst.Entries)-1].Name
+	}
+	return nil
+}
+
+func (w *lsDir) walk(dir, rel string, depth int) error {
+	dents, err := w.readDir(dir)
+	if err != nil {
+		return err
+	}
+	var (
+		prefix = w.msg.Prefix
+		token  = w.msg.ContinuationToken
+		all    = make([]lsKey, 0, len(dents))
+	)
+	for i := range dents {
+		dent := &dents[i]
+		key := rel + dent.name
+		if dent.dir {
+			key += "/"
+			// prune: nothing under this directory matches the prefix...
+			if !strings.HasPrefix(key, prefix) && !strings.HasPrefix(prefix, key) {
+				continue
+			}
+			// ...or is past the continuation token
+			if token != "" && key <= token && !strings.HasPrefix(token, key) {
+				continue
+			}
+		} else if !strings.HasPrefix(key, prefix) || (token != "" && key <= token) {
+			continue
+		}
+		all = append(all, lsKey{dent, key})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].key < all[j].key })
+
+	for _, k := range all {
+		switch {
+		case !k.dent.dir:
+			w.add(k.key, k.dent)
+		case w.msg.IsFlagSet(apc.LsNoRecursion) && !strings.HasPrefix(prefix, k.key):
+			// non-recursive: list (matching) subdirectory as such
+			w.addDir(k.key, token)
+		case w.maxDepth > 0 && depth >= w.maxDepth:
+			// too deep: same as above unless the prefix itself goes deeper
+			if !strings.HasPrefix(k.key, prefix) {
+				return fmt.Errorf("prefix %q exceeds max listing depth %d", prefix, w.maxDepth)
+			}
+			w.addDir(k.key, token)
+		default:
+			if err := w.walk(k.dent.child, k.key, depth+1); err != nil {
+				return err
+			}
+		}
+		if int64(len(w.lst.Entries)) >= w.msg.PageSize {
+			w.done = true
+			return errLsDirDone
+		}
+	}
+	return nil
+}
+
+func (w *lsDir) addDir(name, token string) {
+	if !w.msg.IsFlagSet(apc.LsNoDirs) && (token == "" || name > token) {
+		w.lst.Entries = append(w.lst.Entries, &cmn.LsoEnt{Name: name, Flags: apc.EntryIsDir})
+	}
+}
+
+func (w *lsDir) add(name string, dent *lsDent) {
+	en := &cmn.LsoEnt{Name: name, Size: dent.size}
+	if w.wantMD {
+		en.Version = dent.ver
+		if w.wantCMD && !dent.mtime.IsZero() {
+			en.Custom = cmn.CustomProps2S(cmn.LastModified, fmtTime(dent.mtime))
+		}
+	}
+	w.lst.Entries = append(w.lst.Entries, en)
+}
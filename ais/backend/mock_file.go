@@ -0,0 +1,18 @@
+//go:build !file
+
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+func NewFile(core.TargetPut, *cmn.Config, stats.Tracker) (core.Backend, error) {
+	return nil, &cmn.ErrInitBackend{Provider: apc.File}
+}
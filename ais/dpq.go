@@ -30,6 +30,9 @@ type dpq struct {
 	arch struct {
 		path, mime, regx, mmode string // QparamArchpath et al. (plus archmode below)
 	}
+	mpt struct {
+		id, part string // QparamMptUploadID, QparamMptPartNum
+	}
 
 	ptime       string // req timestamp at calling/redirecting proxy (QparamUnixTime)
 	uuid        string // xaction
@@ -38,6 +41,9 @@ type dpq struct {
 	fltPresence string // QparamFltPresence
 	etlName     string // QparamETLName
 	binfo       string // bucket info, with or without requirement to summarize remote obj-s
+	uquota      string // QparamUserQuota (see tgtquota.go)
+	urate       string // QparamUserRate (see ratelim.go)
+	usig        string // QparamUserSig (ditto)
 
 	skipVC        bool // QparamSkipVC (skip loading existing object's metadata)
 	isGFN         bool // QparamIsGFNRequest
@@ -137,6 +143,20 @@ func (dpq *dpq) parse(rawQuery string) (err error) {
 			}
 		case apc.QparamOWT:
 			dpq.owt = value
+		case apc.QparamMptUploadID:
+			dpq.mpt.id = value
+		case apc.QparamMptPartNum:
+			dpq.mpt.part = value
+		case apc.QparamUserQuota:
+			if dpq.uquota, err = url.QueryUnescape(value); err != nil {
+				return
+			}
+		case apc.QparamUserRate:
+			if dpq.urate, err = url.QueryUnescape(value); err != nil {
+				return
+			}
+		case apc.QparamUserSig:
+			dpq.usig = value
 
 		case apc.QparamFltPresence:
 			dpq.fltPresence = value
@@ -166,9 +186,20 @@ func (dpq *dpq) parse(rawQuery string) (err error) {
 	if err == nil && iters >= maxNumQparams {
 		err = errors.New("exceeded max number of dpq iterations: " + strconv.Itoa(iters))
 	}
+	if err == nil && (dpq.uquota != "" || dpq.urate != "") {
+		err = dpq.checkUserLimits()
+	}
 	return err
 }
 
+// user limits are only accepted from the redirecting proxy (see p.userLimits)
+func (dpq *dpq) checkUserLimits() error {
+	if dpq.ptime == "" || !userLimitsValid(dpq.usig, dpq.uquota, dpq.urate) {
+		return errors.New("invalid query: user limits can only be specified by AIS proxy")
+	}
+	return nil
+}
+
 func _dpqKeqV(s string) (string, string, bool) {
 	if i := strings.IndexByte(s, '='); i > 0 {
 		return s[:i], s[i+1:], true
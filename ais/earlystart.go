@@ -351,6 +351,9 @@ func (p *proxy) primaryStartup(loadedSmap *smapX, config *cmn.Config, ntargets i
 	if etlMD.Version > 0 {
 		_ = p.metasyncer.sync(revsPair{etlMD, actMsgExt})
 	}
+	if smd := p.sched.get(); smd.Version > 0 {
+		_ = p.metasyncer.sync(revsPair{smd, actMsgExt})
+	}
 
 	// 11. Clear regpool
 	p.reg.mu.Lock()
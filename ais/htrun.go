@@ -22,6 +22,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/env"
 	"github.com/NVIDIA/aistore/cmn"
@@ -75,6 +76,7 @@ type htrun struct {
 	}
 	keepalive keepaliver
 	statsT    stats.Tracker
+	rlim      ratelim // rate limiting: token buckets (see ratelim.go)
 	si        *meta.Snode
 	gmm       *memsys.MMSA // system pagesize-based memory manager and slab allocator
 	smm       *memsys.MMSA // small-size allocator (up to 4K)
@@ -2312,6 +2314,33 @@ func (h *htrun) uptime2hdr(hdr http.Header) {
 	hdr.Set(apc.HdrClusterUptime, strconv.FormatInt(now-h.startup.cluster.Load(), 10))
 }
 
+// S3 API: respond to CORS preflight (OPTIONS) request, or add Access-Control-* headers
+// to the response to cross-origin request - as per bucket's CORS rules (see cmn/cors.go)
+func (h *htrun) corsS3(w http.ResponseWriter, r *http.Request, items []string) (preflight bool) {
+	preflight = r.Method == http.MethodOptions
+	if !preflight && r.Header.Get(cos.HdrOrigin) == "" {
+		return
+	}
+	if len(items) == 0 {
+		if preflight {
+			s3.WriteErr(w, r, errS3Req, 0)
+		}
+		return
+	}
+	bck, err, ecode := meta.InitByNameOnly(items[0], h.owner.bmd)
+	switch {
+	case err != nil:
+		if preflight {
+			s3.WriteErr(w, r, err, ecode)
+		}
+	case preflight:
+		s3.Preflight(w, r, &bck.Props.CORS)
+	default:
+		s3.SetCORSHeaders(w.Header(), r, &bck.Props.CORS)
+	}
+	return
+}
+
 // NOTE: not checking vs Smap (yet)
 func isT2TPut(hdr http.Header) bool { return hdr != nil && hdr.Get(apc.HdrT2TPutterID) != "" }
 
@@ -86,8 +86,9 @@ const (
 	revsConfTag  = "Conf"
 	revsTokenTag = "token"
 	revsEtlMDTag = "EtlMD"
+	revsSchedTag = "Sched" // scheduled jobs (proxies only)
 
-	revsMaxTags   = 6         // NOTE
+	revsMaxTags   = 7         // NOTE
 	revsActionTag = "-action" // prefix revs tag
 )
 
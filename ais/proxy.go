@@ -35,6 +35,7 @@ import (
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ext/dsort"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/stats"
@@ -62,6 +63,7 @@ type (
 		rproxy     reverseProxy
 		notifs     notifs
 		lstca      lstca
+		sched      schedOwner
 		reg        struct {
 			pool nodeRegPool
 			mu   sync.RWMutex
@@ -188,6 +190,7 @@ func (p *proxy) Run() error {
 
 	p.owner.bmd.init() // initialize owner and load BMD
 	p.owner.etl.init() // initialize owner and load EtlMD
+	p.sched.init(config)
 
 	core.Pinit()
 
@@ -201,6 +204,7 @@ func (p *proxy) Run() error {
 	p.notifs.init(p)
 	p.ic.init(p)
 	p.qm.init()
+	hk.Reg("sched-jobs"+hk.NameSuffix, p.schedHK, schedInterval)
 
 	//
 	// REST API: register proxy handlers and start listening
@@ -214,6 +218,7 @@ func (p *proxy) Run() error {
 		{r: apc.Download, h: p.dloadHandler, net: accessNetPublic},
 		{r: apc.ETL, h: p.etlHandler, net: accessNetPublic},
 		{r: apc.Sort, h: p.dsortHandler, net: accessNetPublic},
+		{r: apc.GetBatch, h: p.gbHandler, net: accessNetPublic},
 
 		{r: apc.IC, h: p.ic.handler, net: accessNetIntraControl},
 		{r: apc.Daemon, h: p.daemonHandler, net: accessNetPublicControl},
@@ -426,8 +431,9 @@ func (p *proxy) _parseReqTry(w http.ResponseWriter, r *http.Request, bckArgs *bc
 		return
 	}
 	bckArgs.bck, bckArgs.query = apireq.bck, apireq.query
-	bck, err = bckArgs.initAndTry()
 	objName = apireq.items[1]
+	bckArgs.objName = objName
+	bck, err = bckArgs.initAndTry()
 
 	apiReqFree(apireq)
 	freeBctx(bckArgs) // caller does alloc
@@ -722,6 +728,7 @@ func (p *proxy) httpobjget(w http.ResponseWriter, r *http.Request, origURLBck ..
 		bckArgs.bck = apireq.bck
 		bckArgs.dpq = apireq.dpq
 		bckArgs.perms = apc.AceGET
+		bckArgs.objName = apireq.items[1]
 		bckArgs.createAIS = false
 	}
 	if len(origURLBck) > 0 {
@@ -741,6 +748,10 @@ func (p *proxy) httpobjget(w http.ResponseWriter, r *http.Request, origURLBck ..
 		p.writeErr(w, r, err)
 		return
 	}
+	if err := p.throttle(r, bck); err != nil {
+		p.writeErr(w, r, err, http.StatusTooManyRequests, Silent)
+		return
+	}
 
 	started := time.Now()
 
@@ -757,6 +768,7 @@ func (p *proxy) httpobjget(w http.ResponseWriter, r *http.Request, origURLBck ..
 	}
 
 	redirectURL := p.redirectURL(r, tsi, started, cmn.NetIntraData, netPub)
+	redirectURL += p.userLimits(r.Header, false /*put*/)
 	http.Redirect(w, r, redirectURL, http.StatusMovedPermanently)
 
 	// 4. stats
@@ -799,6 +811,7 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiRe
 		bckArgs.w = w
 		bckArgs.r = r
 		bckArgs.perms = perms
+		bckArgs.objName = apireq.items[1]
 		bckArgs.createAIS = false
 	}
 	bckArgs.bck, bckArgs.dpq = apireq.bck, apireq.dpq
@@ -822,6 +835,10 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiRe
 		p.writeErr(w, r, err)
 		return
 	}
+	if err := p.throttle(r, bck); err != nil {
+		p.writeErr(w, r, err, http.StatusTooManyRequests, Silent)
+		return
+	}
 	if nodeID == "" {
 		tsi, netPub, err = smap.HrwMultiHome(bck.MakeUname(objName))
 		if err != nil {
@@ -848,6 +865,9 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiRe
 	}
 
 	redirectURL := p.redirectURL(r, tsi, started, cmn.NetIntraData, netPub)
+	if !appendTyProvided {
+		redirectURL += p.userLimits(r.Header, true /*put*/)
+	}
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
 
 	// 4. stats
@@ -873,6 +893,10 @@ func (p *proxy) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		p.writeErr(w, r, err)
 		return
 	}
+	if err := p.throttle(r, bck); err != nil {
+		p.writeErr(w, r, err, http.StatusTooManyRequests, Silent)
+		return
+	}
 	smap := p.owner.smap.get()
 	tsi, err := smap.HrwName2T(bck.MakeUname(objName))
 	if err != nil {
@@ -1031,6 +1055,7 @@ func (p *proxy) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 		newRMD, msgRMD, errRMD       = p.extractRMD(payload, caller)
 		newEtlMD, msgEtlMD, errEtlMD = p.extractEtlMD(payload, caller)
 		revokedTokens, errTokens     = p.extractRevokedTokenList(payload, caller)
+		newSched, msgSched, errSched = p.extractSchedMD(payload, caller)
 	)
 	// 2. apply
 	if errConf == nil && newConf != nil {
@@ -1051,12 +1076,16 @@ func (p *proxy) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 	if errTokens == nil && revokedTokens != nil {
 		_ = p.authn.updateRevokedList(revokedTokens)
 	}
+	if errSched == nil && newSched != nil {
+		errSched = p.receiveSchedMD(newSched, msgSched, payload)
+	}
 	// 3. respond
-	if errConf == nil && errSmap == nil && errBMD == nil && errRMD == nil && errTokens == nil && errEtlMD == nil {
+	if errConf == nil && errSmap == nil && errBMD == nil && errRMD == nil && errTokens == nil && errEtlMD == nil &&
+		errSched == nil {
 		return
 	}
 	p.fillNsti(nsti)
-	retErr := err.message(errConf, errSmap, errBMD, errRMD, errEtlMD, errTokens)
+	retErr := err.message(errConf, errSmap, errBMD, errRMD, errEtlMD, errTokens, errSched)
 	p.writeErr(w, r, retErr, http.StatusConflict)
 }
 
@@ -1382,7 +1411,7 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			fltPresence, _ = strconv.Atoi(v)
 		}
 		debug.Assertf(fltPresence != apc.FltExistsOutside, "(flt %d=\"outside\") not implemented yet", fltPresence)
-		if !apc.IsFltPresent(fltPresence) && (bckFrom.IsCloud() || bckFrom.IsRemoteAIS()) {
+		if !apc.IsFltPresent(fltPresence) && (bckFrom.IsCloud() || bckFrom.IsRemoteAIS() || bckFrom.IsListableHT()) {
 			lstcx := &lstcx{
 				p:       p,
 				bckFrom: bckFrom,
@@ -1641,6 +1670,17 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 		}
 	}
 
+	// filtering by object tags implies listing in-cluster objects (and no caching)
+	if len(lsmsg.Tags) > 0 {
+		if err := cmn.ValidateObjTags(lsmsg.Tags); err != nil {
+			p.statsT.IncErr(stats.ErrListCount)
+			p.writeErr(w, r, err)
+			return
+		}
+		lsmsg.SetFlag(apc.LsObjCached)
+		lsmsg.ClearFlag(apc.UseListObjsCache)
+	}
+
 	// default props & flags => user-provided message
 	switch {
 	case lsmsg.Props == "":
@@ -1655,7 +1695,7 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 	case lsmsg.Props == apc.GetPropsNameSize:
 		lsmsg.SetFlag(apc.LsNameSize)
 	}
-	if bck.IsHT() || lsmsg.IsFlagSet(apc.LsArchDir) {
+	if (bck.IsHT() && !bck.IsListableHT()) || lsmsg.IsFlagSet(apc.LsArchDir) {
 		lsmsg.SetFlag(apc.LsObjCached)
 	}
 
@@ -1820,7 +1860,8 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 	if err != nil {
 		return
 	}
-	if msg.Action == apc.ActRenameObject {
+	switch msg.Action {
+	case apc.ActRenameObject, apc.ActMptCreate, apc.ActMptComplete, apc.ActMptAbort:
 		apireq.after = 2
 	}
 	if err := p.parseReq(w, r, apireq); err != nil {
@@ -1894,6 +1935,16 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 		}
 		objName := msg.Name
 		p.redirectAction(w, r, bck, objName, msg)
+	case apc.ActMptCreate, apc.ActMptComplete, apc.ActMptAbort:
+		if err := p.checkAccess(w, r, bck, apc.AcePUT); err != nil {
+			return
+		}
+		if msg.Action != apc.ActMptCreate && msg.Name == "" {
+			p.writeErrf(w, r, "%s: missing upload ID", msg.Action)
+			return
+		}
+		// all requests of a given upload go to the same target (see tgtmpt.go)
+		p.redirectAction(w, r, bck, apireq.items[1], msg)
 	default:
 		p.writeErrAct(w, r, msg.Action)
 	}
@@ -2135,6 +2186,10 @@ func (p *proxy) httpobjhead(w http.ResponseWriter, r *http.Request, origURLBck .
 	if err != nil {
 		return
 	}
+	if err := p.throttle(r, bck); err != nil {
+		p.writeErr(w, r, err, http.StatusTooManyRequests, Silent)
+		return
+	}
 
 	// TODO: control plane multihoming: return LRU data plane interface - here and elsewhere (bcast)
 
@@ -2270,7 +2325,9 @@ func (p *proxy) redirectURL(r *http.Request, si *meta.Snode, ts time.Time, netIn
 	}
 	redirect = nodeURL + r.URL.Path + "?"
 	if r.URL.RawQuery != "" {
-		redirect += r.URL.RawQuery + "&"
+		if q := stripUserLimits(r.URL.RawQuery); q != "" {
+			redirect += q + "&"
+		}
 	}
 
 	query := url.Values{
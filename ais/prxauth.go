@@ -238,6 +238,9 @@ func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
 //	Exceptions:
 //	- read-only access to a bucket is always granted
 //	- PATCH cannot be forbidden
+//
+// In both cases, bucket policy (if defined) takes precedence: explicitly denied operation
+// is always forbidden, explicitly allowed one does not require any other permissions.
 func (p *proxy) checkAccess(w http.ResponseWriter, r *http.Request, bck *meta.Bck, ace apc.AccessAttrs) (err error) {
 	if err = p.access(r.Header, bck, ace); err != nil {
 		p.writeErr(w, r, err, aceErrToCode(err))
@@ -256,14 +259,24 @@ func aceErrToCode(err error) (status int) {
 	return status
 }
 
-func (p *proxy) access(hdr http.Header, bck *meta.Bck, ace apc.AccessAttrs) (err error) {
+func (p *proxy) access(hdr http.Header, bck *meta.Bck, ace apc.AccessAttrs) error {
+	return p.accessObj(hdr, bck, "", ace)
+}
+
+// same as above for a given named object (when known), to evaluate bucket policy (cmn/policy.go)
+func (p *proxy) accessObj(hdr http.Header, bck *meta.Bck, objName string, ace apc.AccessAttrs) (err error) {
 	var (
-		tk     *tok.Token
-		bucket *cmn.Bck
+		tk        *tok.Token
+		bucket    *cmn.Bck
+		principal string
+		policy    *cmn.PolicyConf
 	)
 	if p.checkIntraCall(hdr, false /*from primary*/) == nil {
 		return nil
 	}
+	if bck != nil && bck.Props != nil && bck.Props.Policy.IsSet() {
+		policy = &bck.Props.Policy
+	}
 	if cmn.Rom.AuthEnabled() { // config.Auth.Enabled
 		tk, err = p.validateToken(hdr)
 		if err != nil {
@@ -271,8 +284,34 @@ func (p *proxy) access(hdr http.Header, bck *meta.Bck, ace apc.AccessAttrs) (err
 			if err == tok.ErrNoToken && bck != nil && bck.IsHT() {
 				err = nil
 			}
+			// anonymous access: only if explicitly allowed by bucket policy
+			if err == tok.ErrNoToken && policy != nil {
+				if allowed, _ := policy.Eval("", objName, ace); allowed == ace {
+					err = nil
+				}
+			}
 			return err
 		}
+		principal = tk.UserID
+	}
+	if policy != nil {
+		granted, denied := policy.Eval(principal, objName, ace)
+		if !cmn.Rom.AuthEnabled() || tk.IsAdmin {
+			denied &^= (apc.AcePATCH | apc.AceBckSetACL) // (never lock out)
+		}
+		if denied != 0 {
+			op := denied.Describe(true /*all*/) + " (bucket policy)"
+			if objName != "" {
+				return cmn.NewObjectAccessDenied(bck.Cname(objName), op, bck.Props.Access&^denied)
+			}
+			return cmn.NewBucketAccessDenied(bck.String(), op, bck.Props.Access&^denied)
+		}
+		ace &^= granted
+		if ace == 0 {
+			return nil
+		}
+	}
+	if cmn.Rom.AuthEnabled() {
 		uid := p.owner.smap.Get().UUID
 		if bck != nil {
 			bucket = bck.Bucket()
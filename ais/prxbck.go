@@ -37,6 +37,7 @@ type bctx struct {
 
 	reqBody []byte          // request body of original request
 	perms   apc.AccessAttrs // apc.AceGET, apc.AcePATCH etc.
+	objName string          // when accessing a single named object (see bucket policy)
 
 	// 5 user or caller-provided control flags followed by
 	// 3 result flags
@@ -176,7 +177,7 @@ rerr:
 
 // (compare w/ accessSupported)
 func (bctx *bctx) accessAllowed(bck *meta.Bck) (ecode int, err error) {
-	err = bctx.p.access(bctx.r.Header, bck, bctx.perms)
+	err = bctx.p.accessObj(bctx.r.Header, bck, bctx.objName, bctx.perms)
 	ecode = aceErrToCode(err)
 	return ecode, err
 }
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -75,6 +76,8 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.xquery(w, r, what, query)
 	case apc.WhatAllRunningXacts:
 		p.xgetRunning(w, r, what, query)
+	case apc.WhatSchedules:
+		p.schedList(w, r, what)
 	case apc.WhatNodeStats, apc.WhatNodeStatsV322:
 		p.qcluStats(w, r, what, query)
 	case apc.WhatSysInfo:
@@ -864,7 +867,7 @@ func (p *proxy) _joinedFinal(ctx *smapModifier, clone *smapX) {
 		bmd       = p.owner.bmd.get()
 		etlMD     = p.owner.etl.get()
 		actMsgExt = p.newAmsg(ctx.msg, bmd)
-		pairs     = make([]revsPair, 0, 5)
+		pairs     = make([]revsPair, 0, 6)
 	)
 	// when targets join as well (redundant?, minor)
 	config, err := p.ensureConfigURLs()
@@ -882,6 +885,9 @@ func (p *proxy) _joinedFinal(ctx *smapModifier, clone *smapX) {
 	if etlMD != nil && etlMD.version() > 0 {
 		pairs = append(pairs, revsPair{etlMD, actMsgExt})
 	}
+	if smd := p.sched.get(); smd.version() > 0 {
+		pairs = append(pairs, revsPair{smd, actMsgExt})
+	}
 
 	reb := ctx.rmdCtx != nil && ctx.rmdCtx.rebID != ""
 	if !reb {
@@ -1024,6 +1030,13 @@ func (p *proxy) cluputMsg(w http.ResponseWriter, r *http.Request) {
 			p.writeErr(w, r, err)
 			return
 		}
+		if !opts.SkipWriteBack {
+			if ecode, err := p.flushRmTargets(msg, nil); err != nil {
+				freeBcArgs(args)
+				p.writeErr(w, r, err, ecode)
+				return
+			}
+		}
 		args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathDae.S, Body: cos.MustMarshal(msg)}
 		args.to = core.AllNodes
 		_ = p.bcastGroup(args)
@@ -1051,6 +1064,10 @@ func (p *proxy) cluputMsg(w http.ResponseWriter, r *http.Request) {
 		p.xstart(w, r, msg)
 	case apc.ActXactStop:
 		p.xstop(w, r, msg)
+	case apc.ActSchedAdd:
+		p.schedAdd(w, r, msg)
+	case apc.ActSchedRemove:
+		p.schedRemove(w, r, msg)
 
 	// internal
 	case apc.ActBumpMetasync:
@@ -1064,7 +1081,7 @@ func (p *proxy) cluputMsg(w http.ResponseWriter, r *http.Request) {
 
 func (p *proxy) setCluCfgPersistent(w http.ResponseWriter, r *http.Request, toUpdate *cmn.ConfigToSet, msg *apc.ActMsg) {
 	ctx := &configModifier{
-		pre:      _setConfPre,
+		pre:      p._setConfPre,
 		final:    p._syncConfFinal,
 		msg:      msg,
 		toUpdate: toUpdate,
@@ -1175,14 +1192,62 @@ func (p *proxy) setCluCfgTransient(w http.ResponseWriter, r *http.Request, toUpd
 	freeBcArgs(args)
 }
 
-func _setConfPre(ctx *configModifier, clone *globalConfig) (updated bool, err error) {
+func (p *proxy) _setConfPre(ctx *configModifier, clone *globalConfig) (updated bool, err error) {
+	var profiles map[string]*cmn.S3Profile
+	if ctx.toUpdate.Backend != nil {
+		profiles = s3Profiles(&clone.Backend)
+	}
 	if err = clone.Apply(ctx.toUpdate, apc.Cluster); err != nil {
 		return
 	}
+	if len(profiles) > 0 {
+		if err = p.checkS3Profiles(profiles, s3Profiles(&clone.Backend)); err != nil {
+			return
+		}
+	}
 	updated = true
 	return
 }
 
+func s3Profiles(conf *cmn.BackendConf) map[string]*cmn.S3Profile {
+	v := conf.Get(apc.AWS)
+	if v == nil {
+		return nil
+	}
+	var awsConf cmn.BackendConfAWS
+	cos.MustMorphMarshal(v, &awsConf)
+	return awsConf.Profiles
+}
+
+// refuse to remove S3 profiles that are still referenced by buckets (`extra.aws.profile`)
+func (p *proxy) checkS3Profiles(from, to map[string]*cmn.S3Profile) error {
+	var (
+		bmd  = p.owner.bmd.get()
+		refs = make(map[string][]string, len(from))
+	)
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		name := bck.Props.Extra.AWS.Profile
+		if _, ok := from[name]; ok && name != "" {
+			if _, ok := to[name]; !ok {
+				refs[name] = append(refs[name], bck.Cname(""))
+			}
+		}
+		return false
+	})
+	if len(refs) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	name, bcks := names[0], refs[names[0]]
+	sort.Strings(bcks)
+	return cmn.NewErrFailedTo(p, "remove", "S3 profile "+strconv.Quote(name),
+		fmt.Errorf("still used by %s", strings.Join(bcks, ", ")), http.StatusConflict)
+}
+
 func (p *proxy) _syncConfFinal(ctx *configModifier, clone *globalConfig) {
 	wg := p.metasyncer.sync(revsPair{clone, p.newAmsg(ctx.msg, nil)})
 	if ctx.wait {
@@ -1451,6 +1516,12 @@ func (p *proxy) rmNode(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg)
 			p.writeErr(w, r, cmn.NewErrFailedTo(p, msg.Action, si, err), ecode)
 		}
 	default: // target
+		if !inMaint && !opts.SkipWriteBack && msg.Action != apc.ActShutdownNode {
+			if ecode, err := p.flushRmTargets(msg, si); err != nil {
+				p.writeErr(w, r, cmn.NewErrFailedTo(p, msg.Action, si, err), ecode)
+				return
+			}
+		}
 		reb := !opts.SkipRebalance && cmn.GCO.Get().Rebalance.Enabled && !inMaint
 		nlog.Infof("%s: %s reb=%t", p, msg.Action, reb)
 		if reb {
@@ -1474,6 +1545,34 @@ func (p *proxy) rmNode(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg)
 	}
 }
 
+// pre-phase of putting target(s) in maintenance and decommissioning - prior to modifying Smap:
+// write back dirty (not yet written back) objects; a target refuses (409) while any remain
+// (see tgtwback.go); `si == nil` - all targets
+func (p *proxy) flushRmTargets(msg *apc.ActMsg, si *meta.Snode) (int, error) {
+	var (
+		amsg    = &apc.ActMsg{Action: apc.ActFlushWriteBack, Name: msg.Action, Value: msg.Value}
+		args    = allocBcArgs()
+		timeout = cmn.Rom.CplaneOperation() + cmn.GCO.Get().Timeout.MaxHostBusy.D()
+	)
+	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathDae.S, Body: cos.MustMarshal(amsg)}
+	args.timeout = timeout
+	if si == nil {
+		args.to = core.Targets
+	} else {
+		args.to = core.SelectedNodes
+		args.nodes = []meta.NodeMap{{si.ID(): si}}
+	}
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	defer freeBcastRes(results)
+	for _, res := range results {
+		if res.err != nil {
+			return res.status, res.toErr()
+		}
+	}
+	return 0, nil
+}
+
 func (p *proxy) rmTarget(si *meta.Snode, msg *apc.ActMsg, reb bool) (rebID string, err error) {
 	var ctx *smapModifier
 	if ctx, err = p.mcastMaint(msg, si, reb, false /*maintPostReb*/); err != nil {
@@ -1984,6 +2083,10 @@ func (p *proxy) rmNodeFinal(msg *apc.ActMsg, si *meta.Snode, ctx *smapModifier)
 		body  = cos.MustMarshal(msg)
 		sname = node.StringEx()
 	)
+	if node.IsTarget() && (msg.Action == apc.ActStartMaintenance || msg.Action == apc.ActDecommissionNode) {
+		// allow for flushing write-back journal (see tgtwback.go and p.flushRmTargets)
+		timeout += cmn.GCO.Get().Timeout.MaxHostBusy.D()
+	}
 	cargs.si, cargs.timeout = node, timeout
 	switch msg.Action {
 	case apc.ActShutdownNode, apc.ActRmNodeUnsafe, apc.ActStartMaintenance, apc.ActDecommissionNode:
@@ -0,0 +1,48 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestCheckS3Profiles(t *testing.T) {
+	p := &proxy{htrun: htrun{si: newSnode("p1", apc.Proxy, meta.NetInfo{}, meta.NetInfo{}, meta.NetInfo{})}}
+	p.owner.bmd = newBMDOwnerPrx(cmn.GCO.Get())
+
+	bmd := newBucketMD()
+	for name, profile := range map[string]string{"b1": "minio", "b2": "", "b3": "minio", "b4": "shared"} {
+		props := &cmn.Bprops{}
+		props.Extra.AWS.Profile = profile
+		bmd.add(meta.NewBck(name, apc.AWS, cmn.NsGlobal), props)
+	}
+	p.owner.bmd.put(bmd)
+
+	var (
+		minio = &cmn.S3Profile{Endpoint: "http://minio:9000"}
+		ceph  = &cmn.S3Profile{Endpoint: "http://ceph:7480"}
+		all   = map[string]*cmn.S3Profile{"minio": minio, "ceph": ceph}
+	)
+	// adding, updating, or removing unused profiles is fine
+	tassert.CheckError(t, p.checkS3Profiles(all, all))
+	tassert.CheckError(t, p.checkS3Profiles(all, map[string]*cmn.S3Profile{"minio": ceph}))
+	// ("shared": not a cluster-config profile)
+	tassert.CheckError(t, p.checkS3Profiles(map[string]*cmn.S3Profile{"minio": minio}, map[string]*cmn.S3Profile{"minio": minio}))
+
+	// removing profile that's in use is not
+	for _, to := range []map[string]*cmn.S3Profile{{"ceph": ceph}, nil} {
+		err := p.checkS3Profiles(all, to)
+		tassert.Fatalf(t, err != nil, "expected error removing profile in use")
+		msg := err.Error()
+		tassert.Errorf(t, strings.Contains(msg, `"minio"`) && strings.Contains(msg, "s3://b1, s3://b3"),
+			"expected profile and bucket names, got %q", msg)
+	}
+}
@@ -0,0 +1,132 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// GET /v1/gb (multi-object GET; body: cmn.GetBatchMsg)
+// - init and check access to all named buckets and objects;
+// - begin get-batch on all targets;
+// - redirect to the designated target (see xact/xs/getbatch.go)
+func (p *proxy) gbHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.WriteErr405(w, r, http.MethodGet)
+		return
+	}
+	if _, err := p.parseURL(w, r, apc.URLPathGB.L, 0, false); err != nil {
+		return
+	}
+	msg := &cmn.GetBatchMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if err := msg.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if msg.Mime == "" {
+		msg.Mime = archive.ExtTar
+	} else {
+		mime, err := archive.Mime(msg.Mime, "")
+		if err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		msg.Mime = mime
+	}
+
+	// buckets
+	bcks := make(map[string]*meta.Bck, 2)
+	for i := range msg.Items {
+		item := &msg.Items[i]
+		uname := item.Bck.MakeUname("")
+		bck, ok := bcks[cos.UnsafeS(uname)]
+		if !ok {
+			var err error
+			bckArgs := allocBctx()
+			{
+				bckArgs.p = p
+				bckArgs.w = w
+				bckArgs.r = r
+				bckArgs.bck = meta.CloneBck(&item.Bck)
+				bckArgs.perms = apc.AceGET
+				bckArgs.createAIS = false
+			}
+			bck, err = bckArgs.initAndTry()
+			freeBctx(bckArgs)
+			if err != nil {
+				p.statsT.IncErr(stats.ErrGetCount)
+				return
+			}
+			if err := p.throttle(r, bck); err != nil {
+				p.writeErr(w, r, err, http.StatusTooManyRequests, Silent)
+				return
+			}
+			bcks[cos.UnsafeS(uname)] = bck
+		}
+		item.Bck = *bck.Bucket() // normalized
+
+		// (bucket policy may deny access to selected objects and prefixes)
+		if err := p.accessObj(r.Header, bck, item.ObjName, apc.AceGET); err != nil {
+			p.writeErr(w, r, err, aceErrToCode(err))
+			p.statsT.IncErr(stats.ErrGetCount)
+			return
+		}
+	}
+
+	// begin
+	var (
+		started = time.Now()
+		xid     = cos.GenUUID()
+		smap    = p.owner.smap.get()
+	)
+	tsi, err := smap.HrwTargetTask(xid)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodPost,
+		Path:   apc.URLPathGB.S,
+		Query:  url.Values{apc.QparamUUID: []string{xid}},
+		Body:   cos.MustMarshal(msg),
+	}
+	args.smap = smap
+	args.timeout = cmn.Rom.MaxKeepalive()
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			err = res.toErr()
+			break
+		}
+	}
+	freeBcastRes(results)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+
+	// redirect
+	if cmn.Rom.FastV(5, cos.SmoduleAIS) {
+		nlog.Infoln("get-batch[", xid, "] num items:", len(msg.Items), "=>", tsi.StringEx())
+	}
+	redirectURL := p.redirectURL(r, tsi, started, cmn.NetIntraData)
+	redirectURL += "&" + apc.QparamUUID + "=" + xid
+	http.Redirect(w, r, redirectURL, http.StatusMovedPermanently)
+}
@@ -0,0 +1,49 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// object- and prefix-level deny statements apply to get-batch items
+func TestGetBatchPolicyDeny(t *testing.T) {
+	ni := newNetInfo("p1")
+	p := &proxy{htrun: htrun{si: newSnode("p1", apc.Proxy, ni, ni, ni), statsT: mock.NewStatsTracker()}}
+	p.owner.bmd = newBMDOwnerPrx(cmn.GCO.Get())
+	p.owner.smap = newSmapOwner(cmn.GCO.Get())
+	p.owner.smap.put(newSmap())
+
+	var (
+		bck   = meta.NewBck("gbpol", apc.AIS, cmn.NsGlobal)
+		props = defaultBckProps(bckPropsArgs{bck: bck})
+	)
+	props.Policy.Statements = []cmn.PolicyStatement{
+		{Effect: cmn.PolicyDeny, Principals: []string{cmn.PolicyAnyone}, Objects: []string{"secret/*"}, Access: apc.AceGET},
+	}
+	bmd := newBucketMD()
+	bmd.add(bck, props)
+	p.owner.bmd.put(bmd)
+
+	msg := &cmn.GetBatchMsg{Items: []cmn.GetBatchItem{
+		{Bck: *bck.Bucket(), ObjName: "public/a"},
+		{Bck: *bck.Bucket(), ObjName: "secret/b"},
+	}}
+	r := httptest.NewRequest(http.MethodGet, apc.URLPathGB.S, bytes.NewReader(cos.MustMarshal(msg)))
+	w := httptest.NewRecorder()
+	p.gbHandler(w, r)
+	tassert.Errorf(t, w.Code == http.StatusForbidden, "expected %d, got %d (%s)", http.StatusForbidden, w.Code, w.Body.String())
+	tassert.Errorf(t, bytes.Contains(w.Body.Bytes(), []byte("secret/b")), "expected denied object in %q", w.Body.String())
+}
@@ -45,6 +45,9 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if p.corsS3(w, r, apiItems) {
+		return // (preflight)
+	}
 
 	switch r.Method {
 	case http.MethodHead:
@@ -78,8 +81,43 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 			_, policy    = q[s3.QparamPolicy]
 			_, cors      = q[s3.QparamCORS]
 			_, acl       = q[s3.QparamACL]
+			_, objLock   = q[s3.QparamObjLock]
+			_, encrypt   = q[s3.QparamEncryption]
+			_, notif     = q[s3.QparamNotification]
 		)
-		if lifecycle || policy || cors || acl {
+		if len(apiItems) == 1 {
+			switch {
+			case encrypt:
+				// perms: apc.AceBckHEAD
+				p.getBckSSES3(w, r, apiItems[0])
+				return
+			case objLock:
+				// perms: apc.AceBckHEAD
+				p.getBckObjLockS3(w, r, apiItems[0])
+				return
+			case cors:
+				// perms: apc.AceBckHEAD
+				p.getBckCORSS3(w, r, apiItems[0])
+				return
+			case notif:
+				// perms: apc.AceBckHEAD
+				p.getBckNotifS3(w, r, apiItems[0])
+				return
+			case lifecycle:
+				// perms: apc.AceBckHEAD
+				p.getBckLifecycleS3(w, r, apiItems[0])
+				return
+			case policy:
+				// perms: apc.AceBckHEAD
+				p.getBckPolicyS3(w, r, apiItems[0])
+				return
+			case acl:
+				// perms: apc.AceBckHEAD
+				p.getBckACLS3(w, r, apiItems[0])
+				return
+			}
+		}
+		if lifecycle || policy || cors || acl || objLock || encrypt || notif {
 			p.unsupported(w, r, apiItems[0])
 			return
 		}
@@ -90,6 +128,11 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.getBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if q.Has(s3.QparamVersions) {
+				// perms: apc.AceObjLIST
+				p.listObjVersionsS3(w, r, apiItems[0], q)
+				return
+			}
 			// perms: apc.AceObjLIST
 			p.listObjectsS3(w, r, apiItems[0], q)
 			return
@@ -109,11 +152,46 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.putBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if _, lifecycle := q[s3.QparamLifecycle]; lifecycle {
+				// perms: apc.AcePATCH
+				p.putBckLifecycleS3(w, r, apiItems[0])
+				return
+			}
+			if _, policy := q[s3.QparamPolicy]; policy {
+				// perms: apc.AceBckSetACL
+				p.putBckPolicyS3(w, r, apiItems[0])
+				return
+			}
+			if _, cors := q[s3.QparamCORS]; cors {
+				// perms: apc.AcePATCH
+				p.putBckCORSS3(w, r, apiItems[0])
+				return
+			}
+			if _, notif := q[s3.QparamNotification]; notif {
+				// perms: apc.AcePATCH
+				p.putBckNotifS3(w, r, apiItems[0])
+				return
+			}
+			if _, acl := q[s3.QparamACL]; acl {
+				// perms: apc.AceBckSetACL
+				p.putBckACLS3(w, r, apiItems[0])
+				return
+			}
+			if _, objLock := q[s3.QparamObjLock]; objLock {
+				// perms: apc.AcePATCH
+				p.putBckObjLockS3(w, r, apiItems[0])
+				return
+			}
+			if _, encrypt := q[s3.QparamEncryption]; encrypt {
+				// perms: apc.AcePATCH
+				p.putBckSSES3(w, r, apiItems[0])
+				return
+			}
 			// perms: apc.AceCreateBucket
 			p.putBckS3(w, r, apiItems[0])
 			return
 		}
-		// perms: apc.AcePUT
+		// perms: apc.AcePUT (and apc.AceBckSetACL to bypass governance-mode retention)
 		p.putObjS3(w, r, apiItems)
 	case http.MethodPost:
 		q := r.URL.Query()
@@ -143,15 +221,35 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.delMultipleObjs(w, r, apiItems[0])
 				return
 			}
+			if _, lifecycle := q[s3.QparamLifecycle]; lifecycle {
+				// perms: apc.AcePATCH
+				p.delBckLifecycleS3(w, r, apiItems[0])
+				return
+			}
+			if _, policy := q[s3.QparamPolicy]; policy {
+				// perms: apc.AceBckSetACL
+				p.delBckPolicyS3(w, r, apiItems[0])
+				return
+			}
+			if _, cors := q[s3.QparamCORS]; cors {
+				// perms: apc.AcePATCH
+				p.delBckCORSS3(w, r, apiItems[0])
+				return
+			}
+			if _, encrypt := q[s3.QparamEncryption]; encrypt {
+				// perms: apc.AcePATCH
+				p.delBckSSES3(w, r, apiItems[0])
+				return
+			}
 			// perms: apc.AceDestroyBucket
 			p.delBckS3(w, r, apiItems[0])
 			return
 		}
-		// perms: apc.AceObjDELETE
+		// perms: apc.AceObjDELETE (apc.AcePUT to delete object tags; apc.AceBckSetACL to bypass governance)
 		p.delObjS3(w, r, apiItems)
 	default:
 		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodHead,
-			http.MethodPost, http.MethodPut)
+			http.MethodPost, http.MethodPut, http.MethodOptions)
 	}
 }
 
@@ -227,11 +325,11 @@ func (p *proxy) handleMptUpload(w http.ResponseWriter, r *http.Request, parts []
 	if bck == nil {
 		return
 	}
-	if err := p.access(r.Header, bck, apc.AcePUT); err != nil {
+	objName := s3.ObjName(parts)
+	if err := p.accessObj(r.Header, bck, objName, apc.AcePUT); err != nil {
 		s3.WriteErr(w, r, err, http.StatusForbidden)
 		return
 	}
-	objName := s3.ObjName(parts)
 	if err := cmn.ValidOname(objName); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
@@ -460,7 +558,8 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 	if bckSrc == nil {
 		return
 	}
-	if err := p.access(r.Header, bckSrc, apc.AceGET); err != nil {
+	objName := strings.Trim(parts[1], "/")
+	if err := p.accessObj(r.Header, bckSrc, objName, apc.AceGET); err != nil {
 		s3.WriteErr(w, r, err, http.StatusForbidden)
 		return
 	}
@@ -469,12 +568,11 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 	if bckDst == nil {
 		return
 	}
-	if err := bckDst.Allow(apc.AcePUT); err != nil {
+	if err := p.accessObj(r.Header, bckDst, s3.ObjName(items), apc.AcePUT); err != nil {
 		s3.WriteErr(w, r, err, http.StatusForbidden)
 		return
 	}
 
-	objName := strings.Trim(parts[1], "/")
 	smap := p.owner.smap.get()
 	si, err := smap.HrwName2T(bckSrc.MakeUname(objName))
 	if err != nil {
@@ -496,19 +594,27 @@ func (p *proxy) directPutObjS3(w http.ResponseWriter, r *http.Request, items []s
 	if bck == nil {
 		return
 	}
-	if err := p.access(r.Header, bck, apc.AcePUT); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
-		return
-	}
 	if len(items) < 2 {
 		s3.WriteErr(w, r, errS3Obj, 0)
 		return
 	}
 	objName := s3.ObjName(items)
+	ace := apc.AcePUT
+	if s3.BypassGovernance(r.Header) {
+		ace |= apc.AceBckSetACL
+	}
+	if err := p.accessObj(r.Header, bck, objName, ace); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
 	if err := cmn.ValidOname(objName); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if err := p.throttle(r, bck); err != nil {
+		s3.WriteErr(w, r, err, http.StatusTooManyRequests)
+		return
+	}
 
 	smap := p.owner.smap.get()
 	si, netPub, err := smap.HrwMultiHome(bck.MakeUname(objName))
@@ -522,6 +628,7 @@ func (p *proxy) directPutObjS3(w http.ResponseWriter, r *http.Request, items []s
 	started := time.Now()
 
 	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraData, netPub)
+	redirectURL += p.userLimits(r.Header, true /*put*/)
 	p.s3Redirect(w, r, si, redirectURL, bck.Name)
 }
 
@@ -531,7 +638,11 @@ func (p *proxy) getObjS3(w http.ResponseWriter, r *http.Request, items []string,
 	if bck == nil {
 		return
 	}
-	if err := p.access(r.Header, bck, apc.AceGET); err != nil {
+	var objName string
+	if len(items) > 1 {
+		objName = s3.ObjName(items)
+	}
+	if err := p.accessObj(r.Header, bck, objName, apc.AceGET); err != nil {
 		s3.WriteErr(w, r, err, http.StatusForbidden)
 		return
 	}
@@ -543,11 +654,14 @@ func (p *proxy) getObjS3(w http.ResponseWriter, r *http.Request, items []string,
 		s3.WriteErr(w, r, errS3Obj, 0)
 		return
 	}
-	objName := s3.ObjName(items)
 	if err := cmn.ValidOname(objName); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if err := p.throttle(r, bck); err != nil {
+		s3.WriteErr(w, r, err, http.StatusTooManyRequests)
+		return
+	}
 
 	smap := p.owner.smap.get()
 	si, netPub, err := smap.HrwMultiHome(bck.MakeUname(objName))
@@ -561,9 +675,59 @@ func (p *proxy) getObjS3(w http.ResponseWriter, r *http.Request, items []string,
 	started := time.Now()
 
 	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraData, netPub)
+	redirectURL += p.userLimits(r.Header, false /*put*/)
 	p.s3Redirect(w, r, si, redirectURL, bck.Name)
 }
 
+// GET /s3/<bucket-name>?versions
+// (noncurrent versions are stored by targets - see core/lversion.go)
+func (p *proxy) listObjVersionsS3(w http.ResponseWriter, r *http.Request, bucket string, q url.Values) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceObjLIST); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if !bck.IsAIS() {
+		p.unsupported(w, r, bucket)
+		return
+	}
+	// bcast & aggregate
+	var (
+		smap = p.owner.smap.get()
+		all  = s3.NewListVersionsResult(bck.Name, q)
+	)
+	for _, si := range smap.Tmap {
+		cargs := allocCargs()
+		{
+			cargs.si = si
+			cargs.req = cmn.HreqArgs{Method: http.MethodGet, Base: si.URL(cmn.NetPublic), Path: r.URL.Path, Query: q}
+		}
+		res := p.call(cargs, smap)
+		b, err := res.bytes, res.err
+		freeCargs(cargs)
+		freeCR(res)
+		if err == nil {
+			results := &s3.ListVersionsResult{}
+			if err = xml.Unmarshal(b, results); err == nil {
+				all.Merge(results)
+				continue
+			}
+		}
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	all.Finalize()
+
+	sgl := p.gmm.NewSGL(0)
+	all.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
 // GET /s3/<bucket-name>/<object-name> with `s3.QparamMptUploads`
 func (p *proxy) listMultipart(w http.ResponseWriter, r *http.Request, bck *meta.Bck, q url.Values) {
 	smap := p.owner.smap.get()
@@ -621,15 +785,19 @@ func (p *proxy) headObjS3(w http.ResponseWriter, r *http.Request, items []string
 	if bck == nil {
 		return
 	}
-	if err := p.access(r.Header, bck, apc.AceObjHEAD); err != nil {
+	objName := s3.ObjName(items)
+	if err := p.accessObj(r.Header, bck, objName, apc.AceObjHEAD); err != nil {
 		s3.WriteErr(w, r, err, http.StatusForbidden)
 		return
 	}
-	objName := s3.ObjName(items)
 	if err := cmn.ValidOname(objName); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if err := p.throttle(r, bck); err != nil {
+		s3.WriteErr(w, r, err, http.StatusTooManyRequests)
+		return
+	}
 	smap := p.owner.smap.get()
 	si, err := smap.HrwName2T(bck.MakeUname(objName))
 	if err != nil {
@@ -640,6 +808,7 @@ func (p *proxy) headObjS3(w http.ResponseWriter, r *http.Request, items []string
 		nlog.Infoln(r.Method, bck.Cname(objName), "=>", si.StringEx())
 	}
 
+	s3.DelCORSHeaders(w.Header()) // (the target will add its own)
 	p.reverseNodeRequest(w, r, si)
 }
 
@@ -653,15 +822,26 @@ func (p *proxy) delObjS3(w http.ResponseWriter, r *http.Request, items []string)
 	if bck == nil {
 		return
 	}
-	if err := p.access(r.Header, bck, apc.AceObjDELETE); err != nil {
+	objName := s3.ObjName(items)
+	ace := apc.AceObjDELETE
+	if r.URL.Query().Has(s3.QparamTagging) {
+		ace = apc.AcePUT // (updating object metadata)
+	}
+	if s3.BypassGovernance(r.Header) {
+		ace |= apc.AceBckSetACL
+	}
+	if err := p.accessObj(r.Header, bck, objName, ace); err != nil {
 		s3.WriteErr(w, r, err, http.StatusForbidden)
 		return
 	}
-	objName := s3.ObjName(items)
 	if err := cmn.ValidOname(objName); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if err := p.throttle(r, bck); err != nil {
+		s3.WriteErr(w, r, err, http.StatusTooManyRequests)
+		return
+	}
 
 	smap := p.owner.smap.get()
 	si, err := smap.HrwName2T(bck.MakeUname(objName))
@@ -691,7 +871,7 @@ func (p *proxy) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	sgl.Free()
 }
 
-// GET /s3/<bucket-name>?lifecycle|cors|policy|acl
+// GET /s3/<bucket-name>/<object-name>?acl (and other bucket-level subresources)
 func (p *proxy) unsupported(w http.ResponseWriter, r *http.Request, bucket string) {
 	if _, err, ecode := meta.InitByNameOnly(bucket, p.owner.bmd); err != nil {
 		s3.WriteErr(w, r, err, ecode)
@@ -720,21 +900,491 @@ func (p *proxy) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	propsToUpdate := cmn.BpropsToSet{
 		Versioning: &cmn.VersionConfToSet{Enabled: &enabled},
 	}
-	// make and validate new props
-	nprops, err := p.makeNewBckProps(bck, &propsToUpdate)
+	if enabled && bck.IsAIS() && bck.Props.Versioning.Retain == 0 {
+		// S3 keeps all noncurrent versions; we keep up to the max (see core/lversion.go)
+		retain := cmn.MaxRetainVersions
+		propsToUpdate.Versioning.Retain = &retain
+	}
+	p.setBpropsS3(w, r, msg, bck, &propsToUpdate)
+}
+
+// GET /s3/<bucket-name>?lifecycle
+func (p *proxy) getBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckHEAD); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if len(bck.Props.Lifecycle.Rules) == 0 {
+		s3.WriteErr(w, r, s3.NewErrNoLifecycle(bucket), http.StatusNotFound)
+		return
+	}
+	resp := s3.NewLifecycleConfiguration(&bck.Props.Lifecycle)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?lifecycle
+func (p *proxy) putBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	body, err := cos.ReadAllN(r.Body, r.ContentLength)
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	if _, err := p.setBprops(msg, bck, nprops); err != nil {
+	conf, err := s3.DecodeLifecycle(body)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	propsToUpdate := cmn.BpropsToSet{
+		Lifecycle: &cmn.LifecycleConfToSet{Rules: &conf.Rules},
+	}
+	p.setBpropsS3(w, r, msg, bck, &propsToUpdate)
+}
+
+// DELETE /s3/<bucket-name>?lifecycle
+func (p *proxy) delBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if len(bck.Props.Lifecycle.Rules) > 0 {
+		propsToUpdate := cmn.BpropsToSet{
+			Lifecycle: &cmn.LifecycleConfToSet{Rules: &[]cmn.LifecycleRule{}},
+		}
+		if !p.setBpropsS3(w, r, msg, bck, &propsToUpdate) {
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /s3/<bucket-name>?policy
+func (p *proxy) getBckPolicyS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckHEAD); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if len(bck.Props.Policy.Statements) == 0 {
+		s3.WriteErr(w, r, s3.NewErrNoPolicy(bucket), http.StatusNotFound)
+		return
+	}
+	doc := s3.NewPolicyDocument(&bck.Props.Policy, bucket)
+	w.Header().Set(cos.HdrContentType, cos.ContentJSON)
+	w.Write(cos.MustMarshal(doc))
+}
+
+// PUT /s3/<bucket-name>?policy
+func (p *proxy) putBckPolicyS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckSetACL); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	body, err := cos.ReadAllN(r.Body, r.ContentLength)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := s3.DecodePolicy(body, bucket)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	propsToUpdate := cmn.BpropsToSet{
+		Policy: &cmn.PolicyConfToSet{Statements: &conf.Statements},
+	}
+	if p.setBpropsS3(w, r, msg, bck, &propsToUpdate) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// DELETE /s3/<bucket-name>?policy
+func (p *proxy) delBckPolicyS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckSetACL); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if len(bck.Props.Policy.Statements) > 0 {
+		propsToUpdate := cmn.BpropsToSet{
+			Policy: &cmn.PolicyConfToSet{Statements: &[]cmn.PolicyStatement{}},
+		}
+		if !p.setBpropsS3(w, r, msg, bck, &propsToUpdate) {
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /s3/<bucket-name>?acl
+func (p *proxy) getBckACLS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckHEAD); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	resp := s3.NewAccessControlPolicy(bck.Props.Policy.Grants)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?acl
+// (canned ACL via `x-amz-acl` header or AccessControlPolicy in the request body)
+func (p *proxy) putBckACLS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckSetACL); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	body, err := cos.ReadAllN(r.Body, r.ContentLength)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	grants, err := s3.DecodeACL(r.Header, body)
+	if err != nil {
 		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	propsToUpdate := cmn.BpropsToSet{
+		Policy: &cmn.PolicyConfToSet{Grants: &grants},
+	}
+	p.setBpropsS3(w, r, msg, bck, &propsToUpdate)
+}
+
+// GET /s3/<bucket-name>?cors
+func (p *proxy) getBckCORSS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckHEAD); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if !bck.Props.CORS.IsSet() {
+		s3.WriteErr(w, r, s3.NewErrNoCORS(bucket), http.StatusNotFound)
+		return
 	}
+	resp := s3.NewCORSConfiguration(&bck.Props.CORS)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?cors
+func (p *proxy) putBckCORSS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	body, err := cos.ReadAllN(r.Body, r.ContentLength)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := s3.DecodeCORS(body)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	propsToUpdate := cmn.BpropsToSet{
+		CORS: &cmn.CORSConfToSet{Rules: &conf.Rules},
+	}
+	p.setBpropsS3(w, r, msg, bck, &propsToUpdate)
+}
+
+// DELETE /s3/<bucket-name>?cors
+func (p *proxy) delBckCORSS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if bck.Props.CORS.IsSet() {
+		propsToUpdate := cmn.BpropsToSet{
+			CORS: &cmn.CORSConfToSet{Rules: &[]cmn.CORSRule{}},
+		}
+		if !p.setBpropsS3(w, r, msg, bck, &propsToUpdate) {
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /s3/<bucket-name>?notification
+// (empty configuration when not configured, as per S3)
+func (p *proxy) getBckNotifS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckHEAD); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	resp := s3.NewNotificationConfiguration(&bck.Props.Events)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?notification
+// (replaces all rules; empty configuration disables notifications)
+func (p *proxy) putBckNotifS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	body, err := cos.ReadAllN(r.Body, r.ContentLength)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := s3.DecodeNotification(body)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	propsToUpdate := cmn.BpropsToSet{
+		Events: &cmn.EventsConfToSet{Rules: &conf.Rules},
+	}
+	p.setBpropsS3(w, r, msg, bck, &propsToUpdate)
+}
+
+// GET /s3/<bucket-name>?object-lock
+func (p *proxy) getBckObjLockS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckHEAD); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if !bck.Props.ObjLock.Enabled {
+		s3.WriteErr(w, r, s3.NewErrNoObjLock(bucket), http.StatusNotFound)
+		return
+	}
+	resp := s3.NewObjLockConfiguration(&bck.Props.ObjLock)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?object-lock
+// (enables object lock, if need be, and sets the default retention)
+func (p *proxy) putBckObjLockS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	body, err := cos.ReadAllN(r.Body, r.ContentLength)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := s3.DecodeObjLockConf(body)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	propsToUpdate := cmn.BpropsToSet{
+		ObjLock: &cmn.ObjLockConfToSet{Enabled: &conf.Enabled, Mode: &conf.Mode, Days: &conf.Days},
+	}
+	p.setBpropsS3(w, r, msg, bck, &propsToUpdate)
+}
+
+// GET /s3/<bucket-name>?encryption
+func (p *proxy) getBckSSES3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckHEAD); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if !bck.Props.SSE.Enabled {
+		s3.WriteErr(w, r, s3.NewErrNoSSE(bucket), http.StatusNotFound)
+		return
+	}
+	resp := s3.NewSSEConfiguration(&bck.Props.SSE)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?encryption
+// (enables default server-side encryption of all newly written objects)
+func (p *proxy) putBckSSES3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	body, err := cos.ReadAllN(r.Body, r.ContentLength)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := s3.DecodeSSEConf(body, &bck.Props.SSE)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	propsToUpdate := cmn.BpropsToSet{
+		SSE: &cmn.SSEConfToSet{Enabled: &conf.Enabled, Provider: &conf.Provider, KeyID: &conf.KeyID},
+	}
+	p.setBpropsS3(w, r, msg, bck, &propsToUpdate)
+}
+
+// DELETE /s3/<bucket-name>?encryption
+// (objects that are already encrypted remain encrypted)
+func (p *proxy) delBckSSES3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if bck.Props.SSE.Enabled {
+		propsToUpdate := cmn.BpropsToSet{SSE: &cmn.SSEConfToSet{Enabled: apc.Ptr(false)}}
+		if !p.setBpropsS3(w, r, msg, bck, &propsToUpdate) {
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
 }
 
 //
 // misc. utils
 //
 
+// make and validate new props, and update BMD
+func (p *proxy) setBpropsS3(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg, bck *meta.Bck, propsToUpdate *cmn.BpropsToSet) bool {
+	nprops, err := p.makeNewBckProps(bck, propsToUpdate)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return false
+	}
+	if _, err := p.setBprops(msg, bck, nprops); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return false
+	}
+	return true
+}
+
 func (p *proxy) initByNameOnly(w http.ResponseWriter, r *http.Request, bucket string) *meta.Bck {
 	bck, err, ecode := meta.InitByNameOnly(bucket, p.owner.bmd)
 	if err != nil {
@@ -751,6 +1401,7 @@ func (p *proxy) s3Redirect(w http.ResponseWriter, r *http.Request, si *meta.Snod
 	if cmn.Rom.Features().IsSet(feat.S3ReverseProxy) {
 		// [intra-cluster communications]
 		// instead of regular HTTP redirect (below) reverse-proxy S3 API call to a designated target
+		s3.DelCORSHeaders(w.Header()) // (ditto)
 		p.reverseNodeRequest(w, r, si)
 		return
 	}
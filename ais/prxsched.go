@@ -0,0 +1,399 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	ratomic "sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Scheduled jobs (see cmn/sched.go):
+// - SchedMD is replicated (via metasync) and persisted by proxies only (targets ignore it);
+// - the primary periodically checks (housekeeping callback) and fires all jobs that are due,
+//   sending each job's original action message to itself - via public network and
+//   intra-cluster headers, same as any other API call;
+// - the resulting xaction IDs (or errors) are then recorded as the jobs' most recent runs.
+
+const (
+	schedInterval = 30 * time.Second // housekeeping
+	schedTimeout  = time.Minute      // to start a job
+)
+
+type (
+	schedMD struct {
+		cmn.SchedMD
+	}
+	schedOwner struct {
+		smd   ratomic.Pointer[schedMD]
+		fpath string
+		sync.Mutex
+		busy atomic.Bool // firing
+	}
+	schedModifier struct {
+		pre   func(ctx *schedModifier, clone *schedMD) error
+		final func(ctx *schedModifier, clone *schedMD)
+
+		job  *cmn.SchedJob
+		runs map[string]cmn.SchedRun // job ID => the latest run
+		msg  *apc.ActMsg
+		id   string
+	}
+)
+
+var schedMDImmSize int64
+
+// interface guard
+var _ revs = (*schedMD)(nil)
+
+func newSchedMD() *schedMD {
+	return &schedMD{cmn.SchedMD{Jobs: make(cmn.SchedJobs, 4)}}
+}
+
+// as revs
+func (*schedMD) tag() string        { return revsSchedTag }
+func (smd *schedMD) version() int64 { return smd.Version }
+func (*schedMD) uuid() string       { return "" }
+func (*schedMD) jit(p *proxy) revs  { return p.sched.get() }
+func (*schedMD) sgl() *memsys.SGL   { return nil }
+
+func (smd *schedMD) marshal() []byte {
+	sgl := memsys.PageMM().NewSGL(schedMDImmSize)
+	err := jsp.Encode(sgl, smd, smd.JspOpts())
+	debug.AssertNoErr(err)
+	schedMDImmSize = max(schedMDImmSize, sgl.Len())
+	b := sgl.ReadAll()
+	sgl.Free()
+	return b
+}
+
+// shallow: jobs are treated as immutable (see _schedRecordPre)
+func (smd *schedMD) clone() *schedMD {
+	dst := &schedMD{cmn.SchedMD{Jobs: make(cmn.SchedJobs, len(smd.Jobs)+1), Version: smd.Version}}
+	for id, job := range smd.Jobs {
+		dst.Jobs[id] = job
+	}
+	return dst
+}
+
+////////////////
+// schedOwner //
+////////////////
+
+func (so *schedOwner) init(config *cmn.Config) {
+	so.fpath = filepath.Join(config.ConfigDir, fname.Sched)
+	smd := newSchedMD()
+	if _, err := jsp.LoadMeta(so.fpath, smd); err != nil {
+		if !os.IsNotExist(err) {
+			nlog.Errorf("failed to load %s from %s, err: %v", smd, so.fpath, err)
+		}
+		smd = newSchedMD() // (partially decoded)
+	} else {
+		nlog.Infoln("loaded", smd.String())
+	}
+	so.put(smd)
+}
+
+func (so *schedOwner) get() *schedMD    { return so.smd.Load() }
+func (so *schedOwner) put(smd *schedMD) { so.smd.Store(smd) }
+
+func (so *schedOwner) putPersist(smd *schedMD, payload msPayload) (err error) {
+	var wto cos.WriterTo2
+	if payload != nil {
+		if b := payload[revsSchedTag]; b != nil {
+			wto = cos.NewBuffer(b) // write metasync-sent bytes directly (no json)
+		}
+	}
+	if err = jsp.SaveMeta(so.fpath, smd, wto); err == nil {
+		so.put(smd)
+	}
+	return err
+}
+
+func (so *schedOwner) modify(ctx *schedModifier) (clone *schedMD, err error) {
+	so.Lock()
+	clone = so.get().clone()
+	if err = ctx.pre(ctx, clone); err == nil {
+		clone.Version++
+		err = so.putPersist(clone, nil)
+	}
+	so.Unlock()
+	if err == nil && ctx.final != nil {
+		ctx.final(ctx, clone)
+	}
+	return clone, err
+}
+
+//
+// metasync Rx
+//
+
+func (p *proxy) extractSchedMD(payload msPayload, caller string) (newMD *schedMD, msg *actMsgExt, err error) {
+	b, ok := payload[revsSchedTag]
+	if !ok {
+		return
+	}
+	newMD, msg = newSchedMD(), &actMsgExt{}
+	if _, err1 := jsp.Decode(io.NopCloser(bytes.NewBuffer(b)), newMD, newMD.JspOpts(), "extractSchedMD"); err1 != nil {
+		err = fmt.Errorf(cmn.FmtErrUnmarshal, p, "new SchedMD", cos.BHead(b), err1)
+		return
+	}
+	if msgValue, ok := payload[revsSchedTag+revsActionTag]; ok {
+		if err1 := jsoniter.Unmarshal(msgValue, msg); err1 != nil {
+			err = fmt.Errorf(cmn.FmtErrUnmarshal, p, "action message", cos.BHead(msgValue), err1)
+			return
+		}
+	}
+	smd := p.sched.get()
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		logmsync(smd.Version, newMD, msg, caller)
+	}
+	if newMD.version() <= smd.version() && msg.Action != apc.ActPrimaryForce {
+		if newMD.version() < smd.version() {
+			err = newErrDowngrade(p.si, smd.String(), newMD.String())
+		}
+		newMD = nil
+	}
+	return
+}
+
+func (p *proxy) receiveSchedMD(newMD *schedMD, msg *actMsgExt, payload msPayload) (err error) {
+	p.sched.Lock()
+	smd := p.sched.get()
+	if newMD.version() <= smd.version() && msg.Action != apc.ActPrimaryForce {
+		p.sched.Unlock()
+		if newMD.version() < smd.version() {
+			err = newErrDowngrade(p.si, smd.String(), newMD.String())
+		}
+		return
+	}
+	err = p.sched.putPersist(newMD, payload)
+	p.sched.Unlock()
+	return
+}
+
+//
+// API: add, remove, and list scheduled jobs
+//
+
+// PUT /v1/cluster (apc.ActSchedAdd)
+func (p *proxy) schedAdd(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	job := &cmn.SchedJob{}
+	if err := cos.MorphMarshal(msg.Value, job); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	if err := p.schedValidate(job); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	job.Created = time.Now().UnixNano()
+	job.Runs = nil
+	ctx := &schedModifier{
+		pre:   _schedAddPre,
+		final: p._schedFinal,
+		job:   job,
+		msg:   msg,
+	}
+	if _, err := p.sched.modify(ctx); err != nil {
+		p.writeErr(w, r, err)
+	}
+}
+
+func (p *proxy) schedValidate(job *cmn.SchedJob) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+	if job.Msg.Action == apc.ActXactStart {
+		var xargs xact.ArgsMsg
+		if err := cos.MorphMarshal(job.Msg.Value, &xargs); err != nil {
+			return fmt.Errorf(cmn.FmtErrMorphUnmarshal, p.si, job.Msg.Action, job.Msg.Value, err)
+		}
+		kind, _ := xact.GetKindName(xargs.Kind)
+		if dtor, ok := xact.Table[kind]; !ok || !dtor.Startable {
+			return fmt.Errorf("scheduled job %q: xaction %q is not startable", job.ID, xargs.Kind)
+		}
+	}
+	if job.Bck.IsEmpty() {
+		return nil
+	}
+	// NOTE: remote buckets may be added to BMD on the fly (when the job runs)
+	bck := meta.CloneBck(&job.Bck)
+	if err := bck.Init(p.owner.bmd); err != nil && !cmn.IsErrRemoteBckNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func _schedAddPre(ctx *schedModifier, clone *schedMD) error {
+	return clone.Add(ctx.job)
+}
+
+// PUT /v1/cluster (apc.ActSchedRemove)
+func (p *proxy) schedRemove(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	if msg.Name == "" {
+		p.writeErrf(w, r, "%s: scheduled job ID is missing", p)
+		return
+	}
+	ctx := &schedModifier{
+		pre:   p._schedRemovePre,
+		final: p._schedFinal,
+		id:    msg.Name,
+		msg:   msg,
+	}
+	if _, err := p.sched.modify(ctx); err != nil {
+		if cos.IsNotExist(err, 0) {
+			p.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			p.writeErr(w, r, err)
+		}
+	}
+}
+
+func (p *proxy) _schedRemovePre(ctx *schedModifier, clone *schedMD) error {
+	if _, ok := clone.Jobs[ctx.id]; !ok {
+		return cos.NewErrNotFound(p, "scheduled job "+ctx.id)
+	}
+	delete(clone.Jobs, ctx.id)
+	return nil
+}
+
+func (p *proxy) _schedFinal(ctx *schedModifier, clone *schedMD) {
+	wg := p.metasyncer.sync(revsPair{clone, p.newAmsg(ctx.msg, nil)})
+	if ctx.runs == nil {
+		wg.Wait() // (API call)
+	}
+}
+
+// GET /v1/cluster?what=schedules
+// (any proxy - replicated)
+func (p *proxy) schedList(w http.ResponseWriter, r *http.Request, what string) {
+	smd := p.sched.get()
+	p.writeJSON(w, r, smd.Jobs.Sorted(), what)
+}
+
+//
+// run scheduled jobs (primary only)
+//
+
+// periodic (housekeeping) callback
+func (p *proxy) schedHK(int64) time.Duration {
+	smd := p.sched.get()
+	if len(smd.Jobs) == 0 || !p.ClusterStarted() {
+		return schedInterval
+	}
+	smap := p.owner.smap.get()
+	if !smap.IsPrimary(p.si) || p.owner.rmd.starting.Load() {
+		return schedInterval
+	}
+	if !p.sched.busy.CAS(false, true) {
+		return schedInterval // still firing
+	}
+	go p.schedRun(smd, time.Now())
+	return schedInterval
+}
+
+func (p *proxy) schedRun(smd *schedMD, now time.Time) {
+	var runs map[string]cmn.SchedRun
+	for _, job := range smd.Jobs.Sorted() {
+		next, err := job.Next()
+		if err != nil || next.IsZero() || next.After(now) {
+			continue
+		}
+		run := cmn.SchedRun{Started: now.UnixNano()}
+		run.Xid, err = p.schedFire(job)
+		if err != nil {
+			run.Err = err.Error()
+			nlog.Errorln(p.String(), "scheduled job", job.ID, "["+job.Msg.Action+"]:", err)
+		} else {
+			nlog.Infoln(p.String(), "scheduled job", job.ID, "["+job.Msg.Action+"]:", run.Xid)
+		}
+		if runs == nil {
+			runs = make(map[string]cmn.SchedRun, 2)
+		}
+		runs[job.ID] = run
+	}
+	if len(runs) > 0 {
+		ctx := &schedModifier{
+			pre:   _schedRecordPre,
+			final: p._schedFinal,
+			runs:  runs,
+			msg:   &apc.ActMsg{Action: "sched-run"},
+		}
+		if _, err := p.sched.modify(ctx); err != nil {
+			nlog.Errorln(p.String(), "failed to record scheduled runs:", err)
+		}
+	}
+	p.sched.busy.Store(false)
+}
+
+// copy-on-write (see clone)
+func _schedRecordPre(ctx *schedModifier, clone *schedMD) error {
+	for id, run := range ctx.runs {
+		job, ok := clone.Jobs[id]
+		if !ok {
+			continue // removed in the meantime
+		}
+		cjob := *job
+		cjob.Runs = append([]cmn.SchedRun(nil), job.Runs...)
+		cjob.AddRun(run)
+		clone.Jobs[id] = &cjob
+	}
+	return nil
+}
+
+// send the job's action message to self and return resulting xaction ID
+func (p *proxy) schedFire(job *cmn.SchedJob) (string, error) {
+	var (
+		smap  = p.owner.smap.get()
+		q     = job.Bck.NewQuery()
+		cargs = allocCargs()
+	)
+	cargs.si = p.si
+	cargs.timeout = schedTimeout
+	cargs.req = cmn.HreqArgs{
+		Base:   p.si.URL(cmn.NetPublic),
+		Body:   cos.MustMarshal(&job.Msg),
+		Header: http.Header{cos.HdrContentType: []string{cos.ContentJSON}},
+		Query:  q,
+	}
+	switch job.Msg.Action {
+	case apc.ActXactStart:
+		cargs.req.Method, cargs.req.Path = http.MethodPut, apc.URLPathClu.S
+	case apc.ActSummaryBck:
+		cargs.req.Method, cargs.req.Path = http.MethodGet, apc.URLPathBuckets.Join(job.Bck.Name)
+	case apc.ActCopyBck:
+		_ = job.BckTo.AddUnameToQuery(q, apc.QparamBckTo)
+		cargs.req.Method, cargs.req.Path = http.MethodPost, apc.URLPathBuckets.Join(job.Bck.Name)
+	case apc.ActPrefetchObjects:
+		cargs.req.Method, cargs.req.Path = http.MethodPost, apc.URLPathBuckets.Join(job.Bck.Name)
+	default:
+		debug.Assert(false, job.Msg.Action)
+	}
+	res := p.call(cargs, smap)
+	freeCargs(cargs)
+	xid, err := string(res.bytes), res.toErr()
+	freeCR(res)
+	return xid, err
+}
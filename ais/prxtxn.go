@@ -865,6 +865,9 @@ func (p *proxy) beginRmTarget(si *meta.Snode, msg *apc.ActMsg) error {
 
 // destroy bucket: { begin -- commit }
 func (p *proxy) destroyBucket(msg *apc.ActMsg, bck *meta.Bck) error {
+	if bck.Props != nil && bck.Props.ObjLock.Enabled {
+		return cmn.NewErrObjLocked(bck.Cname(""), "object lock enabled: cannot destroy bucket")
+	}
 	nlp := newBckNLP(bck)
 	nlp.Lock()
 	defer nlp.Unlock()
@@ -1104,6 +1107,30 @@ func (p *proxy) makeNewBckProps(bck *meta.Bck, propsToUpdate *cmn.BpropsToSet, c
 			return
 		}
 	}
+	switch {
+	case bprops.ObjLock.Enabled && !nprops.ObjLock.Enabled:
+		err = fmt.Errorf("%s: once enabled, object lock cannot be disabled (%s)", p.si, bck)
+		return
+	case nprops.ObjLock.Enabled && !bck.IsAIS():
+		err = fmt.Errorf("%s: object lock is only supported for ais:// buckets (%s)", p.si, bck)
+		return
+	case nprops.SSE.Enabled && !bck.IsAIS():
+		err = fmt.Errorf("%s: server-side encryption is only supported for ais:// buckets (%s)", p.si, bck)
+		return
+	case nprops.Compression.Enabled && !bck.IsAIS():
+		err = fmt.Errorf("%s: compression at rest is only supported for ais:// buckets (%s)", p.si, bck)
+		return
+	case nprops.Compression.Enabled && nprops.SSE.Enabled:
+		err = fmt.Errorf("%s: compression and server-side encryption are mutually exclusive (%s)", p.si, bck)
+		return
+	case nprops.Dedup.Enabled && !bck.IsAIS():
+		err = fmt.Errorf("%s: deduplication is only supported for ais:// buckets (%s)", p.si, bck)
+		return
+	case nprops.Dedup.Enabled && (nprops.SSE.Enabled || nprops.Compression.Enabled || nprops.EC.Enabled):
+		err = fmt.Errorf("%s: deduplication is mutually exclusive with encryption, compression, and erasure coding (%s)",
+			p.si, bck)
+		return
+	}
 	if bprops.EC.Enabled && nprops.EC.Enabled {
 		sameSlices := bprops.EC.DataSlices == nprops.EC.DataSlices && bprops.EC.ParitySlices == nprops.EC.ParitySlices
 		sameLimit := bprops.EC.ObjSizeLimit == nprops.EC.ObjSizeLimit
@@ -0,0 +1,291 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/api/env"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// rate limiting (see cmn/ratelim.go):
+// - proxy: requests per second - per bucket, per client (IP address), and per AuthN user;
+// - target: bytes per second - per bucket and per AuthN user (the latter via proxy's redirect);
+// - token buckets are created on demand and removed when idle.
+
+const (
+	rlimIdle = 10 * time.Minute // remove token buckets idle for longer
+	rlimIval = time.Minute      // housekeeping interval
+)
+
+type (
+	ratelim struct {
+		tbs map[string]*cos.TokenBucket
+		mu  sync.Mutex
+	}
+
+	// GET and PUT data transfers
+	throttledReader struct {
+		r      io.Reader
+		statsT stats.Tracker
+		tbs    []*cos.TokenBucket
+	}
+	throttledReadCloser struct {
+		throttledReader
+		c io.Closer
+	}
+
+	// AuthN user's bandwidth limit (via proxy's redirect - see p.userLimits)
+	userRate struct {
+		id  string
+		bps int64
+	}
+)
+
+/////////////
+// ratelim //
+/////////////
+
+// returns token bucket that admits `rate` per second (with bursts of up to one second's worth);
+// updates the rate if changed
+func (rl *ratelim) get(key string, rate int64) *cos.TokenBucket {
+	var (
+		r     = float64(rate)
+		first bool
+	)
+	rl.mu.Lock()
+	tb, ok := rl.tbs[key]
+	if !ok {
+		if rl.tbs == nil {
+			rl.tbs = make(map[string]*cos.TokenBucket, 16)
+			first = true
+		}
+		tb = cos.NewTokenBucket(r, r)
+		rl.tbs[key] = tb
+	}
+	rl.mu.Unlock()
+
+	if ok {
+		tb.SetRate(r, r)
+	} else if first {
+		hk.Reg("rate-limit"+hk.NameSuffix, rl.housekeep, rlimIval)
+	}
+	return tb
+}
+
+func (rl *ratelim) housekeep(int64) time.Duration {
+	rl.mu.Lock()
+	for key, tb := range rl.tbs {
+		if tb.Since() > rlimIdle {
+			delete(rl.tbs, key)
+		}
+	}
+	rl.mu.Unlock()
+	return rlimIval
+}
+
+//
+// proxy: requests per second
+//
+
+// returns ErrRateLimited when the request exceeds the bucket's, client's, or user's rate
+func (p *proxy) throttle(r *http.Request, bck *meta.Bck) error {
+	var (
+		conf = &bck.Props.RateLimit
+		auth = cmn.Rom.AuthEnabled()
+	)
+	if !conf.Enabled && !auth {
+		return nil
+	}
+	if p.checkIntraCall(r.Header, false /*from primary*/) == nil {
+		return nil
+	}
+	if conf.Enabled {
+		bid := strconv.FormatUint(bck.Props.BID, 36)
+		if conf.Ops > 0 {
+			nps := p.owner.smap.get().CountActivePs()
+			if !p.rlim.get("b:"+bid, cmn.RateShare(conf.Ops, nps)).Allow(1) {
+				return p.throttled(bck.Cname(""))
+			}
+		}
+		if conf.ClientOps > 0 {
+			ip := clientIP(r)
+			if !p.rlim.get("c:"+bid+":"+ip, conf.ClientOps).Allow(1) {
+				return p.throttled("client " + ip)
+			}
+		}
+	}
+	if auth {
+		tk, err := p.validateToken(r.Header)
+		if err != nil || tk.IsAdmin || tk.RateLimit == nil || tk.RateLimit.Ops <= 0 {
+			return nil
+		}
+		nps := p.owner.smap.get().CountActivePs()
+		if !p.rlim.get("u:"+tk.UserID, cmn.RateShare(tk.RateLimit.Ops, nps)).Allow(1) {
+			return p.throttled("user " + tk.UserID)
+		}
+	}
+	return nil
+}
+
+func (p *proxy) throttled(what string) error {
+	p.statsT.Inc(stats.ThrottleCount)
+	return cmn.NewErrRateLimited(what)
+}
+
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// when the user has quota and/or bandwidth limit, pass them to the target that will enforce them
+// (returns URL query suffix to append to the redirect URL; see tgtquota.go and throttleReader below)
+func (p *proxy) userLimits(hdr http.Header, put bool) (s string) {
+	if !cmn.Rom.AuthEnabled() {
+		return ""
+	}
+	tk, err := p.validateToken(hdr)
+	if err != nil || tk.IsAdmin {
+		return ""
+	}
+	var uquota, urate string
+	if put && tk.Quota != nil && (tk.Quota.Size > 0 || tk.Quota.Objs > 0) {
+		uquota = strconv.FormatInt(int64(tk.Quota.Size), 10) + "," + strconv.FormatInt(tk.Quota.Objs, 10) + "," + tk.UserID
+		s = "&" + apc.QparamUserQuota + "=" + url.QueryEscape(uquota)
+	}
+	if tk.RateLimit != nil && tk.RateLimit.Bps > 0 {
+		urate = strconv.FormatInt(int64(tk.RateLimit.Bps), 10) + "," + tk.UserID
+		s += "&" + apc.QparamUserRate + "=" + url.QueryEscape(urate)
+	}
+	if s != "" {
+		s += "&" + apc.QparamUserSig + "=" + userLimitsSig(uquota, urate)
+	}
+	return s
+}
+
+// remove user limits (if any) from the client's query - only the proxy itself can specify them
+func stripUserLimits(rawQuery string) string {
+	parts := strings.Split(rawQuery, "&")
+	out := parts[:0]
+	for _, kv := range parts {
+		k, _, _ := strings.Cut(kv, "=")
+		if uk, err := url.QueryUnescape(k); err == nil {
+			k = uk
+		}
+		switch k {
+		case apc.QparamUserQuota, apc.QparamUserRate, apc.QparamUserSig:
+			continue
+		}
+		out = append(out, kv)
+	}
+	return strings.Join(out, "&")
+}
+
+// HMAC-SHA256 of the user limits keyed by the cluster's AuthN secret (shared by all nodes)
+func userLimitsSig(uquota, urate string) string {
+	secret := cos.Right(cmn.GCO.Get().Auth.Secret, os.Getenv(env.AuthN.SecretKey))
+	mac := hmac.New(sha256.New, cos.UnsafeB(secret))
+	mac.Write(cos.UnsafeB(uquota))
+	mac.Write([]byte{'\n'})
+	mac.Write(cos.UnsafeB(urate))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func userLimitsValid(sig, uquota, urate string) bool {
+	return sig != "" && hmac.Equal(cos.UnsafeB(sig), cos.UnsafeB(userLimitsSig(uquota, urate)))
+}
+
+//
+// target: bytes per second
+//
+
+// "<bps>,<user-id>"
+func parseUserRate(s string) *userRate {
+	bps, id, ok := strings.Cut(s, ",")
+	if !ok || id == "" {
+		return nil
+	}
+	n, err := strconv.ParseInt(bps, 10, 64)
+	if err != nil || n <= 0 {
+		return nil
+	}
+	return &userRate{id: id, bps: n}
+}
+
+// wrap GET or PUT reader to comply with the bucket's and user's bandwidth limits
+// (returns the reader as is when there are no limits)
+func (t *target) throttleReader(r io.Reader, bck *meta.Bck, ur *userRate) io.Reader {
+	tbs := t._throttle(bck, ur)
+	if len(tbs) == 0 {
+		return r
+	}
+	return &throttledReader{r: r, statsT: t.statsT, tbs: tbs}
+}
+
+// (ditto)
+func (t *target) throttleReadCloser(rc io.ReadCloser, bck *meta.Bck, ur *userRate) io.ReadCloser {
+	tbs := t._throttle(bck, ur)
+	if len(tbs) == 0 {
+		return rc
+	}
+	return &throttledReadCloser{throttledReader{r: rc, statsT: t.statsT, tbs: tbs}, rc}
+}
+
+func (t *target) _throttle(bck *meta.Bck, ur *userRate) (tbs []*cos.TokenBucket) {
+	conf := &bck.Props.RateLimit
+	if (!conf.Enabled || conf.Bps <= 0) && ur == nil {
+		return nil
+	}
+	nts := t.owner.smap.get().CountActiveTs()
+	if conf.Enabled && conf.Bps > 0 {
+		bid := strconv.FormatUint(bck.Props.BID, 36)
+		tbs = append(tbs, t.rlim.get("B:"+bid, cmn.RateShare(int64(conf.Bps), nts)))
+	}
+	if ur != nil {
+		tbs = append(tbs, t.rlim.get("U:"+ur.id, cmn.RateShare(ur.bps, nts)))
+	}
+	return tbs
+}
+
+/////////////////////
+// throttledReader //
+/////////////////////
+
+func (tr *throttledReader) Read(b []byte) (n int, err error) {
+	n, err = tr.r.Read(b)
+	if n <= 0 {
+		return n, err
+	}
+	var wait time.Duration
+	for _, tb := range tr.tbs {
+		wait = max(wait, tb.Reserve(float64(n)))
+	}
+	if wait > 0 {
+		time.Sleep(wait)
+		tr.statsT.Add(stats.ThrottleLatencyTotal, int64(wait))
+	}
+	return n, err
+}
+
+func (trc *throttledReadCloser) Close() error { return trc.c.Close() }
@@ -0,0 +1,80 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// client cannot specify (i.e., forge) user limits
+func TestUserLimitsRedirect(t *testing.T) {
+	var (
+		ni  = newNetInfo("t1")
+		tsi = newSnode("t1", apc.Target, ni, ni, ni)
+		p   = &proxy{htrun: htrun{si: newSnode("p1", apc.Proxy, ni, ni, ni)}}
+	)
+	q := url.Values{
+		apc.QparamProvider:  []string{apc.AIS},
+		apc.QparamUserQuota: []string{"0,0,admin"},
+		apc.QparamUserRate:  []string{"1,bob"},
+		apc.QparamUserSig:   []string{"abc"},
+	}
+	r := httptest.NewRequest(http.MethodPut, "/v1/objects/bck/obj?"+q.Encode(), http.NoBody)
+	redirect := p.redirectURL(r, tsi, time.Now(), "")
+
+	u, err := url.Parse(redirect)
+	tassert.CheckFatal(t, err)
+	rq := u.Query()
+	for _, k := range []string{apc.QparamUserQuota, apc.QparamUserRate, apc.QparamUserSig} {
+		tassert.Errorf(t, !rq.Has(k), "expected %q to be removed from %q", k, redirect)
+	}
+	tassert.Errorf(t, rq.Get(apc.QparamProvider) == apc.AIS, "expected provider in %q", redirect)
+
+	// target: accepting only the limits signed by proxy (and only via redirect)
+	uquota, urate := "1024,10,alice", "1000,alice"
+	tests := []struct {
+		query string
+		ok    bool
+	}{
+		{apc.QparamUserQuota + "=" + url.QueryEscape(uquota), false},
+		{apc.QparamUserQuota + "=" + url.QueryEscape(uquota) + "&" + apc.QparamUnixTime + "=1", false},
+		{apc.QparamUserQuota + "=" + url.QueryEscape(uquota) + "&" + apc.QparamUnixTime + "=1&" +
+			apc.QparamUserSig + "=" + userLimitsSig("1024,10,bob", ""), false},
+		{apc.QparamUserQuota + "=" + url.QueryEscape(uquota) + "&" + apc.QparamUnixTime + "=1&" +
+			apc.QparamUserSig + "=" + userLimitsSig(uquota, ""), true},
+		// rate limit charged to (or escaped via) another user ID
+		{apc.QparamUserRate + "=" + url.QueryEscape(urate) + "&" + apc.QparamUnixTime + "=1", false},
+		{apc.QparamUserRate + "=" + url.QueryEscape(urate) + "&" + apc.QparamUnixTime + "=1&" +
+			apc.QparamUserSig + "=" + userLimitsSig("", "1000,bob"), false},
+		// (the signature covers both)
+		{apc.QparamUserQuota + "=" + url.QueryEscape(uquota) + "&" + apc.QparamUserRate + "=" + url.QueryEscape(urate) +
+			"&" + apc.QparamUnixTime + "=1&" + apc.QparamUserSig + "=" + userLimitsSig(uquota, ""), false},
+		{apc.QparamUserQuota + "=" + url.QueryEscape(uquota) + "&" + apc.QparamUserRate + "=" + url.QueryEscape(urate) +
+			"&" + apc.QparamUnixTime + "=1&" + apc.QparamUserSig + "=" + userLimitsSig(uquota, urate), true},
+	}
+	for _, test := range tests {
+		dpq := &dpq{}
+		err := dpq.parse(test.query)
+		if test.ok {
+			tassert.CheckError(t, err)
+			tassert.Errorf(t, dpq.uquota == uquota || dpq.urate == urate, "%q: expected user limits", test.query)
+		} else {
+			tassert.Errorf(t, err != nil && strings.Contains(err.Error(), "user limits"), "%q: expected error, got %v", test.query, err)
+		}
+	}
+}
+
+func newNetInfo(id string) meta.NetInfo {
+	return meta.NetInfo{Hostname: "localhost", Port: "8080", URL: "http://" + id + ":8080"}
+}
@@ -0,0 +1,176 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Bucket ACLs map onto bucket policy grants (cmn.PolicyGrant). AIS does not have per-object ACLs,
+// and so READ (WRITE) permission granted on a bucket applies to all its objects, e.g.:
+// "public-read" canned ACL allows anyone (including anonymous users) to list the bucket and read
+// its objects.
+//
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/acl-overview.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketAcl.html
+
+const (
+	HdrACL = "x-amz-acl" // canned ACL
+
+	aclPrivate         = "private"
+	aclPublicRead      = "public-read"
+	aclPublicReadWrite = "public-read-write"
+
+	aclGroupAllUsers = "http://acs.amazonaws.com/groups/global/AllUsers"
+	aclXsiNs         = "http://www.w3.org/2001/XMLSchema-instance"
+	aclTypeUser      = "CanonicalUser"
+	aclTypeGroup     = "Group"
+)
+
+// ACL permission => AIS permissions
+var aclPerms = []struct {
+	perm   string
+	access apc.AccessAttrs
+}{
+	{"FULL_CONTROL", apc.AceGET | apc.AceObjHEAD | apc.AceObjLIST | apc.AceBckHEAD | apc.AcePUT | apc.AceAPPEND |
+		apc.AceObjDELETE | apc.AceBckSetACL},
+	{"READ", apc.AceGET | apc.AceObjHEAD | apc.AceObjLIST | apc.AceBckHEAD},
+	{"WRITE", apc.AcePUT | apc.AceAPPEND | apc.AceObjDELETE},
+	{"READ_ACP", apc.AceBckHEAD},
+	{"WRITE_ACP", apc.AceBckSetACL},
+}
+
+type (
+	AccessControlPolicy struct {
+		XMLName xml.Name   `xml:"AccessControlPolicy"`
+		Ns      string     `xml:"xmlns,attr,omitempty"`
+		Owner   *BckOwner  `xml:"Owner,omitempty"`
+		Grants  []ACLGrant `xml:"AccessControlList>Grant"`
+	}
+	ACLGrant struct {
+		Grantee    ACLGrantee `xml:"Grantee"`
+		Permission string     `xml:"Permission"`
+	}
+	ACLGrantee struct {
+		XsiNs string `xml:"xmlns:xsi,attr,omitempty"`
+		Type  string `xml:"xsi:type,attr,omitempty"` // (when decoding, grantee type is inferred from ID vs URI)
+		ID    string `xml:"ID,omitempty"`
+		URI   string `xml:"URI,omitempty"`
+	}
+)
+
+func (r *AccessControlPolicy) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// S3 => AIS
+func (r *AccessControlPolicy) ToGrants() ([]cmn.PolicyGrant, error) {
+	grants := make([]cmn.PolicyGrant, 0, len(r.Grants))
+	for _, g := range r.Grants {
+		var principal string
+		switch {
+		case g.Grantee.URI == aclGroupAllUsers:
+			principal = cmn.PolicyAnyone
+		case g.Grantee.URI != "":
+			return nil, cmn.NewErrNotImpl("grant", "access to group "+g.Grantee.URI)
+		case g.Grantee.ID != "":
+			if r.Owner != nil && g.Grantee.ID == r.Owner.ID {
+				continue // (implied)
+			}
+			principal = g.Grantee.ID
+		default:
+			return nil, fmt.Errorf("bucket ACL: grantee must have ID or URI (permission %q)", g.Permission)
+		}
+		access, err := aclToAccess(g.Permission)
+		if err != nil {
+			return nil, err
+		}
+		grants = _addGrant(grants, principal, access)
+	}
+	return grants, nil
+}
+
+// canned ACL => AIS
+func CannedToGrants(canned string) ([]cmn.PolicyGrant, error) {
+	read, _ := aclToAccess("READ")
+	write, _ := aclToAccess("WRITE")
+	switch canned {
+	case aclPrivate:
+		return []cmn.PolicyGrant{}, nil
+	case aclPublicRead:
+		return []cmn.PolicyGrant{{Principal: cmn.PolicyAnyone, Access: read}}, nil
+	case aclPublicReadWrite:
+		return []cmn.PolicyGrant{{Principal: cmn.PolicyAnyone, Access: read | write}}, nil
+	default:
+		return nil, cmn.NewErrNotImpl("set", "canned ACL "+canned)
+	}
+}
+
+func DecodeACL(hdr http.Header, body []byte) ([]cmn.PolicyGrant, error) {
+	if canned := hdr.Get(HdrACL); canned != "" {
+		return CannedToGrants(canned)
+	}
+	var r AccessControlPolicy
+	if err := xml.Unmarshal(body, &r); err != nil {
+		return nil, err
+	}
+	return r.ToGrants()
+}
+
+func _addGrant(grants []cmn.PolicyGrant, principal string, access apc.AccessAttrs) []cmn.PolicyGrant {
+	for i := range grants {
+		if grants[i].Principal == principal {
+			grants[i].Access |= access
+			return grants
+		}
+	}
+	return append(grants, cmn.PolicyGrant{Principal: principal, Access: access})
+}
+
+func aclToAccess(perm string) (apc.AccessAttrs, error) {
+	for _, p := range aclPerms {
+		if p.perm == perm {
+			return p.access, nil
+		}
+	}
+	return 0, fmt.Errorf("bucket ACL: invalid permission %q", perm)
+}
+
+// AIS => S3
+// (bucket owner is always granted full control)
+func NewAccessControlPolicy(grants []cmn.PolicyGrant) *AccessControlPolicy {
+	r := &AccessControlPolicy{Ns: s3Namespace, Owner: &BckOwner{ID: bckOwnerID}}
+	r.Grants = append(r.Grants, newACLGrant(bckOwnerID, aclPerms[0].perm))
+	for i := range grants {
+		var (
+			g       = &grants[i]
+			covered apc.AccessAttrs
+		)
+		for _, p := range aclPerms {
+			if g.Access.Has(p.access) && covered&p.access != p.access {
+				r.Grants = append(r.Grants, newACLGrant(g.Principal, p.perm))
+				covered |= p.access
+			}
+		}
+	}
+	return r
+}
+
+func newACLGrant(principal, perm string) ACLGrant {
+	grantee := ACLGrantee{XsiNs: aclXsiNs, Type: aclTypeUser, ID: principal}
+	if principal == cmn.PolicyAnyone {
+		grantee = ACLGrantee{XsiNs: aclXsiNs, Type: aclTypeGroup, URI: aclGroupAllUsers}
+	}
+	return ACLGrant{Grantee: grantee, Permission: perm}
+}
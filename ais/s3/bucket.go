@@ -54,7 +54,7 @@ func NewListBucketResult() (r *ListBucketResult) {
 		Owner:   BckOwner{Name: "ListAllMyBucketsResult"},
 		Buckets: make([]*Bucket, 0, 8),
 	}
-	r.Owner.ID = "1" // to satisfy s3
+	r.Owner.ID = bckOwnerID
 	return
 }
 
@@ -9,8 +9,10 @@ const (
 	QparamVersioning        = "versioning"
 	QparamLifecycle         = "lifecycle"
 	QparamCORS              = "cors"
+	QparamNotification      = "notification"
 	QparamPolicy            = "policy"
 	QparamACL               = "acl"
+	QparamTagging           = "tagging"
 	QparamMultiDelete       = "delete"
 	QparamMaxKeys           = "max-keys"
 	QparamPrefix            = "prefix"
@@ -18,6 +20,20 @@ const (
 	QparamStartAfter        = "start-after"
 	QparamDelimiter         = "delimiter"
 
+	// versioning
+	QparamVersions        = "versions"
+	QparamVersionID       = "versionId"
+	QparamKeyMarker       = "key-marker"
+	QparamVersionIDMarker = "version-id-marker"
+
+	// object lock
+	QparamObjLock   = "object-lock"
+	QparamRetention = "retention"
+	QparamLegalHold = "legal-hold"
+
+	// server-side encryption
+	QparamEncryption = "encryption"
+
 	// multipart
 	QparamMptUploads        = "uploads"
 	QparamMptUploadID       = "uploadId"
@@ -41,6 +57,7 @@ const (
 	MaxPartsPerUpload = 10000
 
 	s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01"
+	bckOwnerID  = "1" // to satisfy s3 (AIS buckets do not have owners)
 
 	AISRegion = "ais"
 	AISServer = "AIStore"
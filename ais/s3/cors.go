@@ -0,0 +1,177 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/cors.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketCors.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTOPTIONSobject.html
+
+type (
+	CORSConfiguration struct {
+		XMLName xml.Name    `xml:"CORSConfiguration"`
+		Ns      string      `xml:"xmlns,attr,omitempty"`
+		Rules   []*CORSRule `xml:"CORSRule"`
+	}
+	CORSRule struct {
+		ID             string   `xml:"ID,omitempty"`
+		AllowedOrigins []string `xml:"AllowedOrigin"`
+		AllowedMethods []string `xml:"AllowedMethod"`
+		AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+		ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+		MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+	}
+)
+
+var (
+	errEmptyCORS     = errors.New("CORS configuration must contain at least one rule")
+	errCORSPreflight = errors.New("invalid CORS preflight request: missing Origin and/or Access-Control-Request-Method")
+)
+
+func (r *CORSConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// S3 => AIS
+func (r *CORSConfiguration) ToConf() (*cmn.CORSConf, error) {
+	conf := &cmn.CORSConf{Rules: make([]cmn.CORSRule, 0, len(r.Rules))}
+	for _, rule := range r.Rules {
+		conf.Rules = append(conf.Rules, cmn.CORSRule{
+			ID:             rule.ID,
+			AllowedOrigins: rule.AllowedOrigins,
+			AllowedMethods: rule.AllowedMethods,
+			AllowedHeaders: rule.AllowedHeaders,
+			ExposeHeaders:  rule.ExposeHeaders,
+			MaxAgeSeconds:  rule.MaxAgeSeconds,
+		})
+	}
+	if err := conf.ValidateAsProps(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func DecodeCORS(body []byte) (*cmn.CORSConf, error) {
+	var r CORSConfiguration
+	if err := xml.Unmarshal(body, &r); err != nil {
+		return nil, err
+	}
+	if len(r.Rules) == 0 {
+		return nil, errEmptyCORS
+	}
+	return r.ToConf()
+}
+
+// AIS => S3
+func NewCORSConfiguration(conf *cmn.CORSConf) *CORSConfiguration {
+	r := &CORSConfiguration{Ns: s3Namespace, Rules: make([]*CORSRule, 0, len(conf.Rules))}
+	for i := range conf.Rules {
+		rule := &conf.Rules[i]
+		r.Rules = append(r.Rules, &CORSRule{
+			ID:             rule.ID,
+			AllowedOrigins: rule.AllowedOrigins,
+			AllowedMethods: rule.AllowedMethods,
+			AllowedHeaders: rule.AllowedHeaders,
+			ExposeHeaders:  rule.ExposeHeaders,
+			MaxAgeSeconds:  rule.MaxAgeSeconds,
+		})
+	}
+	return r
+}
+
+func NewErrNoCORS(bucket string) error {
+	return NewErrCode("NoSuchCORSConfiguration", "bucket "+bucket+": the CORS configuration does not exist")
+}
+
+func newErrCORSForbidden() error {
+	return NewErrCode("AccessForbidden", "CORSResponse: this CORS request is not allowed")
+}
+
+//
+// CORS response headers
+//
+
+// SetCORSHeaders adds Access-Control-* headers to the response to a cross-origin (actual) request
+// if the latter is allowed by one of the bucket's CORS rules.
+func SetCORSHeaders(hdr http.Header, r *http.Request, conf *cmn.CORSConf) {
+	origin := r.Header.Get(cos.HdrOrigin)
+	if origin == "" || !conf.IsSet() {
+		return
+	}
+	if rule := conf.Match(origin, r.Method, nil); rule != nil {
+		setCORS(hdr, rule, origin)
+	}
+}
+
+// DelCORSHeaders removes Access-Control-* headers that were previously set by the SetCORSHeaders
+// (e.g., when reverse-proxying the request to the node that will set them again).
+func DelCORSHeaders(hdr http.Header) {
+	for _, h := range []string{cos.HdrACAllowOrigin, cos.HdrACAllowCredentials, cos.HdrACAllowMethods,
+		cos.HdrACExposeHeaders, cos.HdrACMaxAge, cos.HdrVary} {
+		hdr.Del(h)
+	}
+}
+
+// Preflight responds to the CORS preflight (OPTIONS) request.
+func Preflight(w http.ResponseWriter, r *http.Request, conf *cmn.CORSConf) {
+	var (
+		reqHeaders []string
+		origin     = r.Header.Get(cos.HdrOrigin)
+		method     = r.Header.Get(cos.HdrACRequestMethod)
+	)
+	if origin == "" || method == "" {
+		WriteErr(w, r, errCORSPreflight, http.StatusBadRequest)
+		return
+	}
+	if s := r.Header.Get(cos.HdrACRequestHeaders); s != "" {
+		for _, h := range strings.Split(s, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				reqHeaders = append(reqHeaders, h)
+			}
+		}
+	}
+	rule := conf.Match(origin, method, reqHeaders)
+	if rule == nil {
+		WriteErr(w, r, newErrCORSForbidden(), http.StatusForbidden)
+		return
+	}
+	hdr := w.Header()
+	setCORS(hdr, rule, origin)
+	if len(reqHeaders) > 0 {
+		hdr.Set(cos.HdrACAllowHeaders, strings.Join(reqHeaders, ", "))
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func setCORS(hdr http.Header, rule *cmn.CORSRule, origin string) {
+	if rule.AnyOrigin() {
+		hdr.Set(cos.HdrACAllowOrigin, "*")
+	} else {
+		hdr.Set(cos.HdrACAllowOrigin, origin)
+		hdr.Set(cos.HdrACAllowCredentials, "true")
+	}
+	hdr.Set(cos.HdrACAllowMethods, strings.Join(rule.AllowedMethods, ", "))
+	if len(rule.ExposeHeaders) > 0 {
+		hdr.Set(cos.HdrACExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+	}
+	if rule.MaxAgeSeconds > 0 {
+		hdr.Set(cos.HdrACMaxAge, strconv.Itoa(rule.MaxAgeSeconds))
+	}
+	hdr.Set(cos.HdrVary, cos.HdrOrigin+", "+cos.HdrACRequestHeaders+", "+cos.HdrACRequestMethod)
+}
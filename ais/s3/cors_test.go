@@ -0,0 +1,104 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn/cos"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const corsXML = `<CORSConfiguration>
+  <CORSRule>
+    <ID>viewer</ID>
+    <AllowedOrigin>https://*.example.com</AllowedOrigin>
+    <AllowedMethod>GET</AllowedMethod>
+    <AllowedMethod>HEAD</AllowedMethod>
+    <AllowedHeader>x-amz-*</AllowedHeader>
+    <AllowedHeader>Range</AllowedHeader>
+    <ExposeHeader>ETag</ExposeHeader>
+    <MaxAgeSeconds>3000</MaxAgeSeconds>
+  </CORSRule>
+  <CORSRule>
+    <AllowedOrigin>*</AllowedOrigin>
+    <AllowedMethod>GET</AllowedMethod>
+  </CORSRule>
+</CORSConfiguration>`
+
+var _ = Describe("CORS", func() {
+	It("should decode CORS configuration and match requests", func() {
+		conf, err := s3.DecodeCORS([]byte(corsXML))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.Rules).To(HaveLen(2))
+		Expect(conf.Rules[0].AllowedHeaders).To(Equal([]string{"x-amz-*", "Range"}))
+		Expect(conf.Rules[0].MaxAgeSeconds).To(Equal(3000))
+
+		rule := conf.Match("https://data.example.com", http.MethodHead, []string{"X-Amz-Date", "range"})
+		Expect(rule).NotTo(BeNil())
+		Expect(rule.ID).To(Equal("viewer"))
+
+		rule = conf.Match("https://other.org", http.MethodGet, nil)
+		Expect(rule).NotTo(BeNil())
+		Expect(rule.AnyOrigin()).To(BeTrue())
+
+		Expect(conf.Match("https://other.org", http.MethodHead, nil)).To(BeNil())
+		Expect(conf.Match("https://data.example.com", http.MethodGet, []string{"Authorization"})).To(BeNil())
+	})
+
+	It("should respond to preflight requests", func() {
+		conf, err := s3.DecodeCORS([]byte(corsXML))
+		Expect(err).NotTo(HaveOccurred())
+
+		r := httptest.NewRequest(http.MethodOptions, "/s3/bck/obj", http.NoBody)
+		r.Header.Set(cos.HdrOrigin, "https://data.example.com")
+		r.Header.Set(cos.HdrACRequestMethod, http.MethodGet)
+		r.Header.Set(cos.HdrACRequestHeaders, "x-amz-date, range")
+		w := httptest.NewRecorder()
+		s3.Preflight(w, r, conf)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get(cos.HdrACAllowOrigin)).To(Equal("https://data.example.com"))
+		Expect(w.Header().Get(cos.HdrACAllowMethods)).To(Equal("GET, HEAD"))
+		Expect(w.Header().Get(cos.HdrACAllowHeaders)).To(Equal("x-amz-date, range"))
+		Expect(w.Header().Get(cos.HdrACMaxAge)).To(Equal("3000"))
+
+		r.Header.Set(cos.HdrACRequestMethod, http.MethodPut)
+		w = httptest.NewRecorder()
+		s3.Preflight(w, r, conf)
+		Expect(w.Code).To(Equal(http.StatusForbidden))
+		Expect(w.Header().Get(cos.HdrACAllowOrigin)).To(BeEmpty())
+	})
+
+	It("should set response headers for actual requests", func() {
+		conf, err := s3.DecodeCORS([]byte(corsXML))
+		Expect(err).NotTo(HaveOccurred())
+
+		r := httptest.NewRequest(http.MethodGet, "/s3/bck/obj", http.NoBody)
+		r.Header.Set(cos.HdrOrigin, "https://other.org")
+		hdr := http.Header{}
+		s3.SetCORSHeaders(hdr, r, conf)
+		Expect(hdr.Get(cos.HdrACAllowOrigin)).To(Equal("*"))
+		Expect(hdr.Get(cos.HdrACAllowCredentials)).To(BeEmpty())
+
+		s3.DelCORSHeaders(hdr)
+		Expect(hdr).To(BeEmpty())
+	})
+
+	It("should reject invalid configurations", func() {
+		for _, body := range []string{
+			`<CORSConfiguration></CORSConfiguration>`,
+			`<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`,
+			`<CORSConfiguration><CORSRule><AllowedOrigin>*.*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+		} {
+			_, err := s3.DecodeCORS([]byte(body))
+			Expect(err).To(HaveOccurred(), body)
+		}
+	})
+})
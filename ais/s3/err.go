@@ -20,12 +20,23 @@ import (
 
 const ErrPrefix = "aws-error"
 
-type Error struct {
-	Code      string
-	Message   string
-	Resource  string
-	RequestID string `xml:"RequestId"`
-}
+type (
+	Error struct {
+		Code      string
+		Message   string
+		Resource  string
+		RequestID string `xml:"RequestId"`
+	}
+	// error with a specific S3 error code, e.g. "NoSuchLifecycleConfiguration"
+	ErrCode struct {
+		code string
+		msg  string
+	}
+)
+
+func NewErrCode(code, msg string) *ErrCode { return &ErrCode{code: code, msg: msg} }
+
+func (e *ErrCode) Error() string { return e.msg }
 
 func (e *Error) mustMarshal(sgl *memsys.SGL) {
 	sgl.Write([]byte(xml.Header))
@@ -48,6 +59,11 @@ func WriteMptErr(w http.ResponseWriter, r *http.Request, err error, ecode int, l
 	WriteErr(w, r, e, ecode)
 }
 
+func isErrCode(err error) bool {
+	_, ok := err.(*ErrCode)
+	return ok
+}
+
 func WriteErr(w http.ResponseWriter, r *http.Request, err error, ecode int) {
 	var (
 		out       Error
@@ -55,16 +71,41 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, ecode int) {
 		ok        bool
 		allocated bool
 	)
+	switch e := err.(type) {
+	case *cmn.ErrSSECKey:
+		if ecode == 0 {
+			ecode = e.Status()
+		}
+	case *cmn.ErrPrecondition:
+		if ecode == 0 {
+			ecode = e.Status()
+		}
+	}
 	if in, ok = err.(*cmn.ErrHTTP); !ok {
 		in = cmn.InitErrHTTP(r, err, ecode)
 		allocated = true
 	}
 	out.Message = in.Message
 	switch {
+	case isErrCode(err):
+		out.Code = err.(*ErrCode).code
 	case cmn.IsErrBucketAlreadyExists(err):
 		out.Code = "BucketAlreadyExists"
 	case cmn.IsErrBckNotFound(err):
 		out.Code = "NoSuchBucket"
+	case cmn.IsErrObjLocked(err):
+		out.Code = "AccessDenied"
+	case cmn.IsErrPrecondition(err):
+		out.Code = "PreconditionFailed"
+	case cmn.IsErrQuotaExceeded(err):
+		out.Code = "QuotaExceeded"
+	case cmn.IsErrRateLimited(err):
+		out.Code = "SlowDown"
+	case cmn.IsErrSSECKey(err):
+		out.Code = "InvalidRequest"
+		if in.Status == http.StatusForbidden {
+			out.Code = "AccessDenied"
+		}
 	case in.TypeCode != "":
 		out.Code = in.TypeCode
 	default:
@@ -0,0 +1,201 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLifecycleConfiguration.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketLifecycleConfiguration.html
+
+const (
+	lcyStatusEnabled  = "Enabled"
+	lcyStatusDisabled = "Disabled"
+)
+
+type (
+	LifecycleConfiguration struct {
+		XMLName xml.Name         `xml:"LifecycleConfiguration"`
+		Ns      string           `xml:"xmlns,attr,omitempty"`
+		Rules   []*LifecycleRule `xml:"Rule"`
+	}
+	LifecycleRule struct {
+		Filter                         *LifecycleFilter         `xml:"Filter,omitempty"`
+		Expiration                     *LifecycleExpiration     `xml:"Expiration,omitempty"`
+		NoncurrentVersionExpiration    *LifecycleNoncurrent     `xml:"NoncurrentVersionExpiration,omitempty"`
+		AbortIncompleteMultipartUpload *LifecycleAbortMpt       `xml:"AbortIncompleteMultipartUpload,omitempty"`
+		ID                             string                   `xml:"ID,omitempty"`
+		Prefix                         string                   `xml:"Prefix,omitempty"` // deprecated (but still used) legacy filter
+		Status                         string                   `xml:"Status"`
+		Transitions                    []*LifecycleUnsupportedX `xml:"Transition,omitempty"`
+	}
+	LifecycleFilter struct {
+		Tag    *Tag          `xml:"Tag,omitempty"`
+		And    *LifecycleAnd `xml:"And,omitempty"`
+		Prefix string        `xml:"Prefix,omitempty"`
+	}
+	LifecycleAnd struct {
+		Prefix string `xml:"Prefix,omitempty"`
+		Tags   []Tag  `xml:"Tag,omitempty"`
+	}
+	Tag struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	}
+	LifecycleExpiration struct {
+		Date string `xml:"Date,omitempty"`
+		Days int    `xml:"Days,omitempty"`
+	}
+	LifecycleNoncurrent struct {
+		NoncurrentDays int `xml:"NoncurrentDays"`
+	}
+	LifecycleAbortMpt struct {
+		DaysAfterInitiation int `xml:"DaysAfterInitiation"`
+	}
+	// storage class transitions - not supported
+	LifecycleUnsupportedX struct {
+		StorageClass string `xml:"StorageClass"`
+	}
+)
+
+func (r *LifecycleConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// S3 => AIS
+func (r *LifecycleConfiguration) ToConf() (*cmn.LifecycleConf, error) {
+	conf := &cmn.LifecycleConf{Rules: make([]cmn.LifecycleRule, 0, len(r.Rules))}
+	for i, rule := range r.Rules {
+		var lr cmn.LifecycleRule
+		if err := rule.toRule(&lr); err != nil {
+			return nil, err
+		}
+		if lr.ID == "" {
+			lr.ID = fmt.Sprintf("rule-%d", i+1) // (S3 generates random ID)
+		}
+		conf.Rules = append(conf.Rules, lr)
+	}
+	if err := conf.ValidateAsProps(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func (rule *LifecycleRule) toRule(lr *cmn.LifecycleRule) error {
+	switch rule.Status {
+	case lcyStatusEnabled:
+		lr.Enabled = true
+	case lcyStatusDisabled:
+	default:
+		return fmt.Errorf("lifecycle rule %q: invalid status %q", rule.ID, rule.Status)
+	}
+	if len(rule.Transitions) > 0 {
+		return cmn.NewErrNotImpl("configure", "storage class transitions (lifecycle rule "+rule.ID+")")
+	}
+	lr.ID = rule.ID
+	lr.Prefix = rule.Prefix
+	if f := rule.Filter; f != nil {
+		if f.Prefix != "" {
+			lr.Prefix = f.Prefix
+		}
+		if f.Tag != nil {
+			lr.Tags = cos.StrKVs{f.Tag.Key: f.Tag.Value}
+		}
+		if f.And != nil {
+			if f.And.Prefix != "" {
+				lr.Prefix = f.And.Prefix
+			}
+			if len(f.And.Tags) > 0 {
+				lr.Tags = make(cos.StrKVs, len(f.And.Tags))
+				for _, tag := range f.And.Tags {
+					lr.Tags[tag.Key] = tag.Value
+				}
+			}
+		}
+	}
+	if e := rule.Expiration; e != nil {
+		if e.Date != "" {
+			return cmn.NewErrNotImpl("configure", "date-based expiration (lifecycle rule "+rule.ID+")")
+		}
+		lr.ExpirationDays = e.Days
+	}
+	if nv := rule.NoncurrentVersionExpiration; nv != nil {
+		lr.NoncurrentDays = nv.NoncurrentDays
+	}
+	if am := rule.AbortIncompleteMultipartUpload; am != nil {
+		lr.AbortMptDays = am.DaysAfterInitiation
+	}
+	return nil
+}
+
+// AIS => S3
+func NewLifecycleConfiguration(conf *cmn.LifecycleConf) *LifecycleConfiguration {
+	r := &LifecycleConfiguration{Ns: s3Namespace, Rules: make([]*LifecycleRule, 0, len(conf.Rules))}
+	for i := range conf.Rules {
+		var (
+			lr   = &conf.Rules[i]
+			rule = &LifecycleRule{ID: lr.ID, Status: lcyStatusDisabled}
+		)
+		if lr.Enabled {
+			rule.Status = lcyStatusEnabled
+		}
+		switch len(lr.Tags) {
+		case 0:
+			rule.Filter = &LifecycleFilter{Prefix: lr.Prefix}
+		case 1:
+			if lr.Prefix == "" {
+				for k, v := range lr.Tags {
+					rule.Filter = &LifecycleFilter{Tag: &Tag{Key: k, Value: v}}
+				}
+				break
+			}
+			fallthrough
+		default:
+			and := &LifecycleAnd{Prefix: lr.Prefix, Tags: make([]Tag, 0, len(lr.Tags))}
+			for k, v := range lr.Tags {
+				and.Tags = append(and.Tags, Tag{Key: k, Value: v})
+			}
+			rule.Filter = &LifecycleFilter{And: and}
+		}
+		if lr.ExpirationDays > 0 {
+			rule.Expiration = &LifecycleExpiration{Days: lr.ExpirationDays}
+		}
+		if lr.NoncurrentDays > 0 {
+			rule.NoncurrentVersionExpiration = &LifecycleNoncurrent{NoncurrentDays: lr.NoncurrentDays}
+		}
+		if lr.AbortMptDays > 0 {
+			rule.AbortIncompleteMultipartUpload = &LifecycleAbortMpt{DaysAfterInitiation: lr.AbortMptDays}
+		}
+		r.Rules = append(r.Rules, rule)
+	}
+	return r
+}
+
+func NewErrNoLifecycle(bucket string) error {
+	return NewErrCode("NoSuchLifecycleConfiguration", "bucket "+bucket+": the lifecycle configuration does not exist")
+}
+
+var errEmptyLifecycle = errors.New("lifecycle configuration must contain at least one rule")
+
+func DecodeLifecycle(body []byte) (*cmn.LifecycleConf, error) {
+	var r LifecycleConfiguration
+	if err := xml.Unmarshal(body, &r); err != nil {
+		return nil, err
+	}
+	if len(r.Rules) == 0 {
+		return nil, errEmptyLifecycle
+	}
+	return r.ToConf()
+}
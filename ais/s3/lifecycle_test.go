@@ -0,0 +1,96 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3_test
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const lcyXML = `<LifecycleConfiguration>
+  <Rule>
+    <ID>logs</ID>
+    <Filter><Prefix>logs/</Prefix></Filter>
+    <Status>Enabled</Status>
+    <Expiration><Days>30</Days></Expiration>
+    <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+  </Rule>
+  <Rule>
+    <ID>train</ID>
+    <Filter><And><Prefix>data/</Prefix><Tag><Key>split</Key><Value>train</Value></Tag></And></Filter>
+    <Status>Disabled</Status>
+    <Expiration><Days>1</Days></Expiration>
+  </Rule>
+  <Rule>
+    <Prefix>old/</Prefix>
+    <Status>Enabled</Status>
+    <NoncurrentVersionExpiration><NoncurrentDays>10</NoncurrentDays></NoncurrentVersionExpiration>
+  </Rule>
+</LifecycleConfiguration>`
+
+var _ = Describe("Lifecycle", func() {
+	It("should decode S3 lifecycle configuration", func() {
+		conf, err := s3.DecodeLifecycle([]byte(lcyXML))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.Rules).To(HaveLen(3))
+
+		Expect(conf.Rules[0].ID).To(Equal("logs"))
+		Expect(conf.Rules[0].Prefix).To(Equal("logs/"))
+		Expect(conf.Rules[0].Enabled).To(BeTrue())
+		Expect(conf.Rules[0].ExpirationDays).To(Equal(30))
+		Expect(conf.Rules[0].AbortMptDays).To(Equal(7))
+
+		Expect(conf.Rules[1].Enabled).To(BeFalse())
+		Expect(conf.Rules[1].Tags).To(Equal(cos.StrKVs{"split": "train"}))
+
+		Expect(conf.Rules[2].ID).To(Equal("rule-3"))
+		Expect(conf.Rules[2].Prefix).To(Equal("old/"))
+		Expect(conf.Rules[2].NoncurrentDays).To(Equal(10))
+	})
+
+	It("should expire objects and abort uploads as per rules", func() {
+		conf, err := s3.DecodeLifecycle([]byte(lcyXML))
+		Expect(err).NotTo(HaveOccurred())
+		var (
+			now = time.Now()
+			old = now.Add(-31 * 24 * time.Hour)
+		)
+		Expect(conf.Expired("logs/a", nil, old, now)).NotTo(BeNil())
+		Expect(conf.Expired("logs/a", nil, now, now)).To(BeNil())
+		Expect(conf.Expired("data/a", cos.StrKVs{cmn.TagsObjMD: "split=train"}, old, now)).To(BeNil()) // disabled
+		Expect(conf.AbortMpt("logs/a", old, now)).To(BeTrue())
+		Expect(conf.AbortMpt("data/a", old, now)).To(BeFalse())
+		Expect(conf.NoncurrentExpired("old/a", old, now)).To(BeTrue())
+	})
+
+	It("should round-trip", func() {
+		conf, err := s3.DecodeLifecycle([]byte(lcyXML))
+		Expect(err).NotTo(HaveOccurred())
+		b, err := xml.Marshal(s3.NewLifecycleConfiguration(conf))
+		Expect(err).NotTo(HaveOccurred())
+		conf2, err := s3.DecodeLifecycle(b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf2).To(Equal(conf))
+	})
+
+	It("should reject invalid configurations", func() {
+		for _, body := range []string{
+			`<LifecycleConfiguration></LifecycleConfiguration>`,
+			`<LifecycleConfiguration><Rule><ID>a</ID><Status>Enabled</Status></Rule></LifecycleConfiguration>`,
+			`<LifecycleConfiguration><Rule><ID>a</ID><Status>On</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`,
+			`<LifecycleConfiguration><Rule><ID>a</ID><Status>Enabled</Status><Expiration><Date>2030-01-01T00:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`,
+		} {
+			_, err := s3.DecodeLifecycle([]byte(body))
+			Expect(err).To(HaveOccurred(), body)
+		}
+	})
+})
@@ -9,13 +9,16 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/cmn/sse"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
@@ -23,15 +26,17 @@ import (
 // NOTE: xattr stores only the (*) marked attributes
 type (
 	MptPart struct {
-		MD5  string // MD5 of the part (*)
-		FQN  string // FQN of the corresponding workfile
-		Size int64  // part size in bytes (*)
-		Num  int32  // part number (*)
+		MD5   string // MD5 of the part (*)
+		FQN   string // FQN of the corresponding workfile
+		Nonce string // SSE-C: the workfile is encrypted with customer-provided key and this (raw) nonce
+		Size  int64  // part size in bytes (*)
+		Num   int32  // part number (*)
 	}
 	mpt struct {
 		ctime   time.Time // InitUpload time
 		bckName string
 		objName string
+		keyMD5  string     // SSE-C: MD5 of the customer-provided key
 		parts   []*MptPart // by part number
 	}
 	uploads map[string]*mpt // by upload ID
@@ -43,7 +48,8 @@ var (
 )
 
 // Start miltipart upload
-func InitUpload(id, bckName, objName string) {
+// (non-nil `ssec` - customer-provided key - must then accompany each part and the completion)
+func InitUpload(id, bckName, objName string, ssec []byte) {
 	mu.Lock()
 	if ups == nil {
 		ups = make(uploads, 8)
@@ -54,18 +60,50 @@ func InitUpload(id, bckName, objName string) {
 		parts:   make([]*MptPart, 0, iniCapParts),
 		ctime:   time.Now(),
 	}
+	if ssec != nil {
+		ups[id].keyMD5 = sse.KeyMD5(ssec)
+	}
 	mu.Unlock()
 }
 
+// CheckUploadSSEC validates customer-provided key (nil if not provided) against the upload
+func CheckUploadSSEC(id string, ssec []byte) error {
+	mu.RLock()
+	mpt, ok := ups[id]
+	mu.RUnlock()
+	if !ok {
+		return cos.NewErrNotFound(nil, "upload "+id)
+	}
+	what := "upload " + id
+	switch {
+	case mpt.keyMD5 != "" && ssec == nil:
+		return cmn.NewErrSSECKey(what, "upload was initiated with a customer-provided key that must be specified",
+			http.StatusBadRequest)
+	case mpt.keyMD5 != "" && sse.KeyMD5(ssec) != mpt.keyMD5:
+		return cmn.NewErrSSECKey(what, "customer-provided key does not match", http.StatusForbidden)
+	case mpt.keyMD5 == "" && ssec != nil:
+		return cmn.NewErrSSECKey(what, "upload was initiated without a customer-provided key", http.StatusBadRequest)
+	}
+	return nil
+}
+
 // Add part to an active upload.
 // Some clients may omit size and md5. Only partNum is must-have.
 // md5 and fqn is filled by a target after successful saving the data to a workfile.
+// Uploading the same part number again replaces the part (e.g., when retrying).
 func AddPart(id string, npart *MptPart) (err error) {
 	mu.Lock()
 	mpt, ok := ups[id]
 	if !ok {
 		err = fmt.Errorf("upload %q not found (%s, %d)", id, npart.FQN, npart.Num)
 	} else {
+		for i, part := range mpt.parts {
+			if part.Num == npart.Num {
+				mpt.parts[i] = npart
+				mu.Unlock()
+				return nil
+			}
+		}
 		mpt.parts = append(mpt.parts, npart)
 	}
 	mu.Unlock()
@@ -98,6 +136,24 @@ func CheckParts(id string, parts []types.CompletedPart) ([]*MptPart, error) {
 	return nparts, nil
 }
 
+// CheckPartMD5 validates client-provided part's ETag (that is, MD5 of the part's content)
+func CheckPartMD5(id string, num int32, etag string) error {
+	mu.RLock()
+	defer mu.RUnlock()
+	mpt, ok := ups[id]
+	if !ok {
+		return cos.NewErrNotFound(nil, "upload "+id)
+	}
+	part := mpt.getPart(num)
+	if part == nil {
+		return fmt.Errorf("upload %q: part %d not found", id, num)
+	}
+	if part.MD5 != strings.Trim(etag, `"`) {
+		return fmt.Errorf("upload %q: part %d ETag mismatch (%q vs %q)", id, num, etag, part.MD5)
+	}
+	return nil
+}
+
 func ParsePartNum(s string) (int32, error) {
 	partNum, err := strconv.ParseInt(s, 10, 32)
 	if err != nil {
@@ -148,6 +204,19 @@ func CleanupUpload(id, fqn string, aborted bool) (exists bool) {
 	return true
 }
 
+// Stale returns IDs of the given bucket's uploads that must be aborted
+// as per the bucket's lifecycle configuration.
+func Stale(bckName string, conf *cmn.LifecycleConf, now time.Time) (ids []string) {
+	mu.RLock()
+	for id, mpt := range ups {
+		if mpt.bckName == bckName && conf.AbortMpt(mpt.objName, mpt.ctime, now) {
+			ids = append(ids, id)
+		}
+	}
+	mu.RUnlock()
+	return
+}
+
 func ListUploads(bckName, idMarker string, maxUploads int) (result *ListMptUploadsResult) {
 	mu.RLock()
 	results := make([]UploadInfoResult, 0, len(ups))
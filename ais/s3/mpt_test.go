@@ -0,0 +1,53 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3_test
+
+import (
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MultipartUpload", func() {
+	const id = "mpt-upload"
+
+	BeforeEach(func() {
+		s3.InitUpload(id, "bck", "obj", nil)
+	})
+	AfterEach(func() {
+		s3.CleanupUpload(id, "", true /*aborted*/)
+	})
+
+	It("should replace re-uploaded part", func() {
+		Expect(s3.AddPart(id, &s3.MptPart{MD5: "aaa", FQN: "/tmp/p1", Size: 10, Num: 1})).To(Succeed())
+		Expect(s3.AddPart(id, &s3.MptPart{MD5: "bbb", FQN: "/tmp/p2", Size: 20, Num: 2})).To(Succeed())
+		Expect(s3.AddPart(id, &s3.MptPart{MD5: "ccc", FQN: "/tmp/p1", Size: 30, Num: 1})).To(Succeed())
+
+		size, err := s3.ObjSize(id)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(size).To(Equal(int64(50)))
+
+		nparts, err := s3.CheckParts(id, []types.CompletedPart{{PartNumber: apc.Ptr[int32](1)}, {PartNumber: apc.Ptr[int32](2)}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nparts).To(HaveLen(2))
+		Expect(nparts[0].MD5).To(Equal("ccc"))
+	})
+
+	It("should validate part ETag", func() {
+		Expect(s3.AddPart(id, &s3.MptPart{MD5: "aaa", FQN: "/tmp/p1", Size: 10, Num: 1})).To(Succeed())
+
+		Expect(s3.CheckPartMD5(id, 1, "aaa")).To(Succeed())
+		Expect(s3.CheckPartMD5(id, 1, `"aaa"`)).To(Succeed())
+		Expect(s3.CheckPartMD5(id, 1, "bbb")).NotTo(Succeed())
+		Expect(s3.CheckPartMD5(id, 2, "aaa")).NotTo(Succeed())
+
+		err := s3.CheckPartMD5("no-such-upload", 1, "aaa")
+		Expect(cos.IsNotExist(err, 0)).To(BeTrue())
+	})
+})
@@ -0,0 +1,120 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/EventNotifications.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketNotificationConfiguration.html
+//
+// AIS delivers events to webhooks: the destination "ARN" (topic, queue, or cloud function)
+// is expected to be an http(s) URL; on the way out, all rules are reported as topic configurations.
+
+const (
+	evFilterPrefix = "prefix"
+	evFilterSuffix = "suffix"
+	evS3Prefix     = "s3:"
+)
+
+type (
+	NotificationConfiguration struct {
+		XMLName   xml.Name       `xml:"NotificationConfiguration"`
+		Ns        string         `xml:"xmlns,attr,omitempty"`
+		Topics    []*EventTarget `xml:"TopicConfiguration,omitempty"`
+		Queues    []*EventTarget `xml:"QueueConfiguration,omitempty"`
+		Functions []*EventTarget `xml:"CloudFunctionConfiguration,omitempty"`
+	}
+	EventTarget struct {
+		ID       string       `xml:"Id,omitempty"`
+		Topic    string       `xml:"Topic,omitempty"`
+		Queue    string       `xml:"Queue,omitempty"`
+		Function string       `xml:"CloudFunction,omitempty"`
+		Events   []string     `xml:"Event"`
+		Filter   *EventFilter `xml:"Filter,omitempty"`
+	}
+	EventFilter struct {
+		Rules []EventFilterRule `xml:"S3Key>FilterRule"`
+	}
+	EventFilterRule struct {
+		Name  string `xml:"Name"`
+		Value string `xml:"Value"`
+	}
+)
+
+func (r *NotificationConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// S3 => AIS
+// (empty configuration removes all rules)
+func DecodeNotification(body []byte) (*cmn.EventsConf, error) {
+	var r NotificationConfiguration
+	if err := xml.Unmarshal(body, &r); err != nil {
+		return nil, err
+	}
+	conf := &cmn.EventsConf{Rules: make([]cmn.EventRule, 0, len(r.Topics)+len(r.Queues)+len(r.Functions))}
+	for _, targets := range [][]*EventTarget{r.Topics, r.Queues, r.Functions} {
+		for _, et := range targets {
+			rule := cmn.EventRule{
+				ID:     et.ID,
+				URL:    cos.Right(cos.Right(et.Function, et.Queue), et.Topic),
+				Events: et.Events,
+			}
+			if et.Filter != nil {
+				for _, fr := range et.Filter.Rules {
+					switch strings.ToLower(fr.Name) {
+					case evFilterPrefix:
+						rule.Prefix = fr.Value
+					case evFilterSuffix:
+						rule.Suffix = fr.Value
+					default:
+						return nil, NewErrCode("InvalidArgument", "invalid filter rule name "+fr.Name)
+					}
+				}
+			}
+			conf.Rules = append(conf.Rules, rule)
+		}
+	}
+	if err := conf.ValidateAsProps(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// AIS => S3
+func NewNotificationConfiguration(conf *cmn.EventsConf) *NotificationConfiguration {
+	r := &NotificationConfiguration{Ns: s3Namespace, Topics: make([]*EventTarget, 0, len(conf.Rules))}
+	for i := range conf.Rules {
+		rule := &conf.Rules[i]
+		et := &EventTarget{ID: rule.ID, Topic: rule.URL, Events: make([]string, 0, len(rule.Events))}
+		for _, ev := range rule.Events {
+			if !strings.HasPrefix(ev, evS3Prefix) {
+				ev = evS3Prefix + ev
+			}
+			et.Events = append(et.Events, ev)
+		}
+		if rule.Prefix != "" || rule.Suffix != "" {
+			et.Filter = &EventFilter{}
+			if rule.Prefix != "" {
+				et.Filter.Rules = append(et.Filter.Rules, EventFilterRule{Name: evFilterPrefix, Value: rule.Prefix})
+			}
+			if rule.Suffix != "" {
+				et.Filter.Rules = append(et.Filter.Rules, EventFilterRule{Name: evFilterSuffix, Value: rule.Suffix})
+			}
+		}
+		r.Topics = append(r.Topics, et)
+	}
+	return r
+}
@@ -0,0 +1,82 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3_test
+
+import (
+	"encoding/xml"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const notifXML = `<NotificationConfiguration>
+  <TopicConfiguration>
+    <Id>images</Id>
+    <Topic>https://indexer.example.com/hook</Topic>
+    <Event>s3:ObjectCreated:*</Event>
+    <Filter>
+      <S3Key>
+        <FilterRule><Name>prefix</Name><Value>images/</Value></FilterRule>
+        <FilterRule><Name>Suffix</Name><Value>.jpg</Value></FilterRule>
+      </S3Key>
+    </Filter>
+  </TopicConfiguration>
+  <QueueConfiguration>
+    <Id>audit</Id>
+    <Queue>http://audit.local:8000/events</Queue>
+    <Event>s3:ObjectRemoved:Delete</Event>
+    <Event>ObjectRemoved:Evict</Event>
+  </QueueConfiguration>
+</NotificationConfiguration>`
+
+var _ = Describe("Notification", func() {
+	It("should decode notification configuration and match events", func() {
+		conf, err := s3.DecodeNotification([]byte(notifXML))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.Rules).To(HaveLen(2))
+		Expect(conf.Rules[0].URL).To(Equal("https://indexer.example.com/hook"))
+		Expect(conf.Rules[0].Prefix).To(Equal("images/"))
+		Expect(conf.Rules[0].Suffix).To(Equal(".jpg"))
+		Expect(conf.Rules[1].URL).To(Equal("http://audit.local:8000/events"))
+
+		rules := conf.Match(cmn.EvObjCreatedPut, "images/cat.jpg")
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].ID).To(Equal("images"))
+		Expect(conf.Match(cmn.EvObjCreatedCopy, "images/cat.png")).To(BeEmpty())
+		Expect(conf.Match(cmn.EvObjRemovedDelete, "images/cat.jpg")).To(HaveLen(1))
+		Expect(conf.Match(cmn.EvObjRemovedEvict, "any")).To(HaveLen(1))
+		Expect(conf.Match(cmn.EvObjCreatedRestore, "docs/a.txt")).To(BeEmpty())
+	})
+
+	It("should round-trip AIS => S3 => AIS", func() {
+		conf, err := s3.DecodeNotification([]byte(notifXML))
+		Expect(err).NotTo(HaveOccurred())
+		b, err := xml.Marshal(s3.NewNotificationConfiguration(conf))
+		Expect(err).NotTo(HaveOccurred())
+		conf2, err := s3.DecodeNotification(b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf2.Rules).To(HaveLen(2))
+		Expect(conf2.Rules[1].Events).To(Equal([]string{"s3:ObjectRemoved:Delete", "s3:ObjectRemoved:Evict"}))
+	})
+
+	It("should accept empty and reject invalid configurations", func() {
+		conf, err := s3.DecodeNotification([]byte(`<NotificationConfiguration/>`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.IsSet()).To(BeFalse())
+
+		_, err = s3.DecodeNotification([]byte(`<NotificationConfiguration><TopicConfiguration>
+			<Topic>arn:aws:sns:us-east-1:123456789012:topic</Topic><Event>s3:ObjectCreated:*</Event>
+			</TopicConfiguration></NotificationConfiguration>`))
+		Expect(err).To(HaveOccurred())
+
+		_, err = s3.DecodeNotification([]byte(`<NotificationConfiguration><TopicConfiguration>
+			<Topic>http://hook</Topic><Event>s3:ObjectRestore:Post</Event>
+			</TopicConfiguration></NotificationConfiguration>`))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,261 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Object lock (WORM) - see cmn/objlock.go
+//
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-lock.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLockConfiguration.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectRetention.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLegalHold.html
+
+const (
+	HdrObjLockMode        = "x-amz-object-lock-mode"
+	HdrObjLockRetainUntil = "x-amz-object-lock-retain-until-date"
+	HdrObjLockLegalHold   = "x-amz-object-lock-legal-hold"
+	HdrBypassGovernance   = "x-amz-bypass-governance-retention"
+)
+
+const (
+	objLockEnabled = "Enabled"
+	legalHoldON    = "ON"
+	legalHoldOFF   = "OFF"
+)
+
+type (
+	ObjectLockConfiguration struct {
+		XMLName           xml.Name     `xml:"ObjectLockConfiguration"`
+		Ns                string       `xml:"xmlns,attr,omitempty"`
+		ObjectLockEnabled string       `xml:"ObjectLockEnabled,omitempty"`
+		Rule              *ObjLockRule `xml:"Rule,omitempty"`
+	}
+	ObjLockRule struct {
+		DefaultRetention DefaultRetention `xml:"DefaultRetention"`
+	}
+	DefaultRetention struct {
+		Mode  string `xml:"Mode"`
+		Days  int    `xml:"Days,omitempty"`
+		Years int    `xml:"Years,omitempty"`
+	}
+
+	Retention struct {
+		XMLName         xml.Name `xml:"Retention"`
+		Ns              string   `xml:"xmlns,attr,omitempty"`
+		Mode            string   `xml:"Mode,omitempty"`
+		RetainUntilDate string   `xml:"RetainUntilDate,omitempty"`
+	}
+	LegalHold struct {
+		XMLName xml.Name `xml:"LegalHold"`
+		Ns      string   `xml:"xmlns,attr,omitempty"`
+		Status  string   `xml:"Status"`
+	}
+)
+
+func NewErrNoObjLock(bucket string) error {
+	return NewErrCode("ObjectLockConfigurationNotFoundError", "object lock configuration does not exist for bucket "+bucket)
+}
+
+func newErrInvalidRetention(msg string) error {
+	return NewErrCode("InvalidRequest", msg)
+}
+
+// S3 (e.g. "GOVERNANCE") => AIS (cmn.ObjLockGovernance)
+func aisLockMode(mode string) (string, error) {
+	m := strings.ToLower(mode)
+	if err := cmn.ValidateObjLockMode(m); err != nil {
+		return "", newErrInvalidRetention(err.Error())
+	}
+	return m, nil
+}
+
+/////////////////////////////
+// ObjectLockConfiguration //
+/////////////////////////////
+
+// AIS => S3
+func NewObjLockConfiguration(conf *cmn.ObjLockConf) *ObjectLockConfiguration {
+	r := &ObjectLockConfiguration{Ns: s3Namespace, ObjectLockEnabled: objLockEnabled}
+	if conf.Mode != "" && conf.Days > 0 {
+		r.Rule = &ObjLockRule{DefaultRetention{Mode: strings.ToUpper(conf.Mode), Days: conf.Days}}
+	}
+	return r
+}
+
+func (r *ObjectLockConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// S3 => AIS
+func DecodeObjLockConf(body []byte) (*cmn.ObjLockConf, error) {
+	var r ObjectLockConfiguration
+	if err := xml.Unmarshal(body, &r); err != nil {
+		return nil, err
+	}
+	if r.ObjectLockEnabled != objLockEnabled {
+		return nil, newErrInvalidRetention("ObjectLockEnabled must be \"" + objLockEnabled + "\"")
+	}
+	conf := &cmn.ObjLockConf{Enabled: true}
+	if r.Rule == nil {
+		return conf, nil
+	}
+	dr := &r.Rule.DefaultRetention
+	mode, err := aisLockMode(dr.Mode)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case dr.Days > 0 && dr.Years > 0:
+		return nil, newErrInvalidRetention("default retention: Days and Years are mutually exclusive")
+	case dr.Days > 0:
+		conf.Days = dr.Days
+	case dr.Years > 0:
+		conf.Days = dr.Years * 365
+	default:
+		return nil, newErrInvalidRetention("default retention: expecting positive Days or Years")
+	}
+	conf.Mode = mode
+	if err := conf.ValidateAsProps(); err != nil {
+		return nil, newErrInvalidRetention(err.Error())
+	}
+	return conf, nil
+}
+
+///////////////
+// Retention //
+///////////////
+
+func NewRetention(r *cmn.ObjRetention) *Retention {
+	out := &Retention{Ns: s3Namespace}
+	if r.IsSet() {
+		out.Mode = strings.ToUpper(r.Mode)
+		out.RetainUntilDate = r.Until.UTC().Format(time.RFC3339)
+	}
+	return out
+}
+
+func (r *Retention) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// S3 => AIS (empty Retention removes the retention)
+func DecodeRetention(body []byte) (r cmn.ObjRetention, err error) {
+	var in Retention
+	if err = xml.Unmarshal(body, &in); err != nil {
+		return
+	}
+	return parseRetention(in.Mode, in.RetainUntilDate)
+}
+
+func parseRetention(mode, until string) (r cmn.ObjRetention, err error) {
+	if mode == "" && until == "" {
+		return
+	}
+	if mode == "" || until == "" {
+		err = newErrInvalidRetention("retention mode and retain-until date must be specified together")
+		return
+	}
+	if r.Mode, err = aisLockMode(mode); err != nil {
+		return
+	}
+	if r.Until, err = time.Parse(time.RFC3339, until); err != nil {
+		err = newErrInvalidRetention("invalid retain-until date: " + err.Error())
+	}
+	return
+}
+
+///////////////
+// LegalHold //
+///////////////
+
+func NewLegalHold(on bool) *LegalHold {
+	out := &LegalHold{Ns: s3Namespace, Status: legalHoldOFF}
+	if on {
+		out.Status = legalHoldON
+	}
+	return out
+}
+
+func (r *LegalHold) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+func DecodeLegalHold(body []byte) (bool, error) {
+	var in LegalHold
+	if err := xml.Unmarshal(body, &in); err != nil {
+		return false, err
+	}
+	return parseLegalHold(in.Status)
+}
+
+func parseLegalHold(status string) (bool, error) {
+	switch status {
+	case legalHoldON:
+		return true, nil
+	case legalHoldOFF:
+		return false, nil
+	default:
+		return false, errors.New("invalid legal hold status \"" + status + "\" (expecting ON or OFF)")
+	}
+}
+
+//
+// PUT and HEAD headers
+//
+
+// ObjLockFromHeaders parses PUT request's retention and legal hold headers, if any,
+// and stores the result in the (new) object's custom metadata
+func ObjLockFromHeaders(hdr http.Header, md cos.StrKVs) error {
+	r, err := parseRetention(hdr.Get(HdrObjLockMode), hdr.Get(HdrObjLockRetainUntil))
+	if err != nil {
+		return err
+	}
+	if r.IsSet() {
+		if !r.Until.After(time.Now()) {
+			return newErrInvalidRetention("retain-until date must be in the future")
+		}
+		r.ToMD(md)
+	}
+	if s := hdr.Get(HdrObjLockLegalHold); s != "" {
+		on, err := parseLegalHold(s)
+		if err != nil {
+			return newErrInvalidRetention(err.Error())
+		}
+		cmn.SetObjLegalHold(md, on)
+	}
+	return nil
+}
+
+func SetObjLockHeaders(hdr http.Header, md cos.StrKVs) {
+	if r := cmn.GetObjRetention(md); r.IsSet() {
+		hdr.Set(HdrObjLockMode, strings.ToUpper(r.Mode))
+		hdr.Set(HdrObjLockRetainUntil, r.Until.UTC().Format(time.RFC3339))
+	}
+	if cmn.ObjLegalHold(md) {
+		hdr.Set(HdrObjLockLegalHold, legalHoldON)
+	}
+}
+
+func BypassGovernance(hdr http.Header) bool {
+	return cos.IsParseBool(hdr.Get(HdrBypassGovernance))
+}
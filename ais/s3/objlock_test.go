@@ -0,0 +1,116 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3_test
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ObjectLock", func() {
+	It("should decode and encode object lock configuration", func() {
+		body := []byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled>` +
+			`<Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>`)
+		conf, err := s3.DecodeObjLockConf(body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.Enabled).To(BeTrue())
+		Expect(conf.Mode).To(Equal(cmn.ObjLockCompliance))
+		Expect(conf.Days).To(Equal(365))
+
+		b, err := xml.Marshal(s3.NewObjLockConfiguration(conf))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(ContainSubstring("<Mode>COMPLIANCE</Mode><Days>365</Days>"))
+
+		// no default retention
+		conf, err = s3.DecodeObjLockConf([]byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.Enabled).To(BeTrue())
+		Expect(conf.Days).To(BeZero())
+
+		_, err = s3.DecodeObjLockConf([]byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled>` +
+			`<Rule><DefaultRetention><Mode>FOREVER</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`))
+		Expect(err).To(HaveOccurred())
+		_, err = s3.DecodeObjLockConf([]byte(`<ObjectLockConfiguration></ObjectLockConfiguration>`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should parse retention and legal hold", func() {
+		until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		body := []byte(`<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>` + until.Format(time.RFC3339) +
+			`</RetainUntilDate></Retention>`)
+		r, err := s3.DecodeRetention(body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Mode).To(Equal(cmn.ObjLockGovernance))
+		Expect(r.Until.Equal(until)).To(BeTrue())
+
+		r, err = s3.DecodeRetention([]byte(`<Retention></Retention>`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.IsSet()).To(BeFalse())
+
+		on, err := s3.DecodeLegalHold([]byte(`<LegalHold><Status>ON</Status></LegalHold>`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(on).To(BeTrue())
+		_, err = s3.DecodeLegalHold([]byte(`<LegalHold><Status>maybe</Status></LegalHold>`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should convert PUT headers to object metadata and back", func() {
+		until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		hdr := http.Header{}
+		hdr.Set(s3.HdrObjLockMode, "COMPLIANCE")
+		hdr.Set(s3.HdrObjLockRetainUntil, until.Format(time.RFC3339))
+		hdr.Set(s3.HdrObjLockLegalHold, "ON")
+		md := cos.StrKVs{}
+		Expect(s3.ObjLockFromHeaders(hdr, md)).NotTo(HaveOccurred())
+		Expect(cmn.ObjLegalHold(md)).To(BeTrue())
+
+		out := http.Header{}
+		s3.SetObjLockHeaders(out, md)
+		Expect(out.Get(s3.HdrObjLockMode)).To(Equal("COMPLIANCE"))
+		Expect(out.Get(s3.HdrObjLockRetainUntil)).To(Equal(until.Format(time.RFC3339)))
+		Expect(out.Get(s3.HdrObjLockLegalHold)).To(Equal("ON"))
+
+		hdr.Set(s3.HdrObjLockRetainUntil, time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+		Expect(s3.ObjLockFromHeaders(hdr, cos.StrKVs{})).To(HaveOccurred())
+	})
+
+	It("should enforce retention and legal hold", func() {
+		var (
+			now = time.Now()
+			md  = cos.StrKVs{}
+		)
+		gov := cmn.ObjRetention{Mode: cmn.ObjLockGovernance, Until: now.Add(time.Hour)}
+		gov.ToMD(md)
+		err := cmn.CheckObjLock(md, "ais://b/o", now, false)
+		Expect(cmn.IsErrObjLocked(err)).To(BeTrue())
+		Expect(cmn.CheckObjLock(md, "ais://b/o", now, true /*bypass*/)).NotTo(HaveOccurred())
+		Expect(cmn.CheckObjLock(md, "ais://b/o", now.Add(2*time.Hour), false)).NotTo(HaveOccurred())
+
+		cmn.SetObjLegalHold(md, true)
+		Expect(cmn.CheckObjLock(md, "ais://b/o", now.Add(2*time.Hour), true)).To(HaveOccurred())
+		cmn.SetObjLegalHold(md, false)
+
+		// compliance: can only be extended
+		comp := cmn.ObjRetention{Mode: cmn.ObjLockCompliance, Until: now.Add(time.Hour)}
+		shorter := cmn.ObjRetention{Mode: cmn.ObjLockCompliance, Until: now.Add(time.Minute)}
+		longer := cmn.ObjRetention{Mode: cmn.ObjLockCompliance, Until: now.Add(2 * time.Hour)}
+		Expect(comp.CheckUpdate(&shorter, "o", now, true)).To(HaveOccurred())
+		Expect(comp.CheckUpdate(&cmn.ObjRetention{}, "o", now, true)).To(HaveOccurred())
+		Expect(comp.CheckUpdate(&longer, "o", now, false)).NotTo(HaveOccurred())
+
+		// governance: shortening requires bypass
+		Expect(gov.CheckUpdate(&cmn.ObjRetention{}, "o", now, false)).To(HaveOccurred())
+		Expect(gov.CheckUpdate(&cmn.ObjRetention{}, "o", now, true)).NotTo(HaveOccurred())
+		Expect(gov.CheckUpdate(&longer, "o", now, false)).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,309 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// IAM-style bucket policies (a subset thereof):
+// - Effect: Allow | Deny
+// - Principal: "*" or {"AWS": [user IDs or IAM user ARNs]} - maps to AuthN user IDs
+// - Action: s3 actions listed below (`polActions`), and "s3:*"
+// - Resource: the bucket itself ("arn:aws:s3:::bucket"), and its objects, with optional trailing wildcard
+//   ("arn:aws:s3:::bucket/prefix*")
+// Conditions, NotPrincipal, NotAction, and NotResource are not supported.
+//
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/bucket-policies.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketPolicy.html
+
+const (
+	polVersion    = "2012-10-17"
+	polEffAllow   = "Allow"
+	polEffDeny    = "Deny"
+	polArnPrefix  = "arn:aws:s3:::"
+	polUserArnSep = ":user/"
+	polAnyAction  = "s3:*"
+)
+
+// all bucket-scoped permissions
+const polAccessAll = apc.AceListBuckets - 1
+
+// s3 action => AIS permissions (order matters when converting back)
+var polActions = []struct {
+	action string
+	access apc.AccessAttrs
+}{
+	{"s3:GetObject", apc.AceGET | apc.AceObjHEAD},
+	{"s3:PutObject", apc.AcePUT | apc.AceAPPEND},
+	{"s3:DeleteObject", apc.AceObjDELETE},
+	{"s3:ListBucket", apc.AceObjLIST | apc.AceBckHEAD},
+	{"s3:PutBucketPolicy", apc.AceBckSetACL},
+	{"s3:PutLifecycleConfiguration", apc.AcePATCH},
+	{"s3:GetObjectVersion", apc.AceGET | apc.AceObjHEAD},
+	{"s3:DeleteObjectVersion", apc.AceObjDELETE},
+	{"s3:AbortMultipartUpload", apc.AcePUT},
+	{"s3:ListMultipartUploadParts", apc.AcePUT},
+	{"s3:ListBucketMultipartUploads", apc.AceObjLIST},
+	{"s3:GetBucketPolicy", apc.AceBckHEAD},
+	{"s3:DeleteBucketPolicy", apc.AceBckSetACL},
+	{"s3:GetBucketAcl", apc.AceBckHEAD},
+	{"s3:PutBucketAcl", apc.AceBckSetACL},
+	{"s3:GetBucketVersioning", apc.AceBckHEAD},
+	{"s3:PutBucketVersioning", apc.AcePATCH},
+	{"s3:GetLifecycleConfiguration", apc.AceBckHEAD},
+	{"s3:GetObjectTagging", apc.AceGET},
+	{"s3:PutObjectTagging", apc.AcePUT},
+	{"s3:DeleteObjectTagging", apc.AcePUT},
+	{"s3:ListBucketVersions", apc.AceObjLIST},
+	{"s3:GetObjectRetention", apc.AceGET},
+	{"s3:PutObjectRetention", apc.AcePUT},
+	{"s3:GetObjectLegalHold", apc.AceGET},
+	{"s3:PutObjectLegalHold", apc.AcePUT},
+	{"s3:GetBucketObjectLockConfiguration", apc.AceBckHEAD},
+	{"s3:PutBucketObjectLockConfiguration", apc.AcePATCH},
+	{"s3:BypassGovernanceRetention", apc.AceBckSetACL},
+	{"s3:GetEncryptionConfiguration", apc.AceBckHEAD},
+	{"s3:PutEncryptionConfiguration", apc.AcePATCH},
+}
+
+type (
+	PolicyDocument struct {
+		Version   string             `json:"Version,omitempty"`
+		ID        string             `json:"Id,omitempty"`
+		Statement []*PolicyStatement `json:"Statement"`
+	}
+	PolicyStatement struct {
+		Sid          string           `json:"Sid,omitempty"`
+		Effect       string           `json:"Effect"`
+		Principal    *PolicyPrincipal `json:"Principal,omitempty"`
+		Action       StrList          `json:"Action"`
+		Resource     StrList          `json:"Resource"`
+		Condition    any              `json:"Condition,omitempty"`
+		NotPrincipal any              `json:"NotPrincipal,omitempty"`
+		NotAction    any              `json:"NotAction,omitempty"`
+		NotResource  any              `json:"NotResource,omitempty"`
+	}
+	// "*" or {"AWS": ...}
+	PolicyPrincipal struct {
+		AWS    StrList `json:"AWS"`
+		anyone bool
+	}
+	// JSON string or array of strings
+	StrList []string
+)
+
+var errEmptyPolicy = errors.New("bucket policy must contain at least one statement")
+
+/////////////
+// StrList //
+/////////////
+
+func (l *StrList) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := cos.JSON.Unmarshal(b, &s); err == nil {
+		*l = StrList{s}
+		return nil
+	}
+	var ss []string
+	if err := cos.JSON.Unmarshal(b, &ss); err != nil {
+		return err
+	}
+	*l = ss
+	return nil
+}
+
+func (l StrList) MarshalJSON() ([]byte, error) {
+	if len(l) == 1 {
+		return cos.JSON.Marshal(l[0])
+	}
+	return cos.JSON.Marshal([]string(l))
+}
+
+/////////////////////
+// PolicyPrincipal //
+/////////////////////
+
+func (p *PolicyPrincipal) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := cos.JSON.Unmarshal(b, &s); err == nil {
+		if s != cmn.PolicyAnyone {
+			return fmt.Errorf("bucket policy: invalid principal %q", s)
+		}
+		p.anyone = true
+		return nil
+	}
+	var m map[string]StrList
+	if err := cos.JSON.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	for k, v := range m {
+		if k != "AWS" {
+			return cmn.NewErrNotImpl("configure", "bucket policy principal type "+k)
+		}
+		p.AWS = v
+	}
+	return nil
+}
+
+func (p *PolicyPrincipal) MarshalJSON() ([]byte, error) {
+	if p.anyone {
+		return cos.JSON.Marshal(cmn.PolicyAnyone)
+	}
+	return cos.JSON.Marshal(map[string]StrList{"AWS": p.AWS})
+}
+
+// user IDs; IAM user ARNs are reduced to user names
+func (p *PolicyPrincipal) ids() (ids []string) {
+	if p.anyone {
+		return []string{cmn.PolicyAnyone}
+	}
+	for _, s := range p.AWS {
+		if i := strings.LastIndex(s, polUserArnSep); i >= 0 {
+			s = s[i+len(polUserArnSep):]
+		}
+		ids = append(ids, s)
+	}
+	return ids
+}
+
+////////////////////
+// PolicyDocument //
+////////////////////
+
+func DecodePolicy(body []byte, bucket string) (*cmn.PolicyConf, error) {
+	var doc PolicyDocument
+	if err := cos.JSON.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Statement) == 0 {
+		return nil, errEmptyPolicy
+	}
+	return doc.ToConf(bucket)
+}
+
+// S3 => AIS
+func (doc *PolicyDocument) ToConf(bucket string) (*cmn.PolicyConf, error) {
+	conf := &cmn.PolicyConf{Statements: make([]cmn.PolicyStatement, 0, len(doc.Statement))}
+	for _, st := range doc.Statement {
+		var ps cmn.PolicyStatement
+		if err := st.toStatement(&ps, bucket); err != nil {
+			return nil, err
+		}
+		conf.Statements = append(conf.Statements, ps)
+	}
+	if err := conf.ValidateAsProps(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func (st *PolicyStatement) toStatement(ps *cmn.PolicyStatement, bucket string) error {
+	if st.Condition != nil || st.NotPrincipal != nil || st.NotAction != nil || st.NotResource != nil {
+		return cmn.NewErrNotImpl("configure", "bucket policy conditions and negations (statement "+st.Sid+")")
+	}
+	ps.Sid = st.Sid
+	switch st.Effect {
+	case polEffAllow:
+		ps.Effect = cmn.PolicyAllow
+	case polEffDeny:
+		ps.Effect = cmn.PolicyDeny
+	default:
+		return fmt.Errorf("bucket policy: statement %q: invalid effect %q", st.Sid, st.Effect)
+	}
+	if st.Principal == nil {
+		return fmt.Errorf("bucket policy: statement %q: missing principal", st.Sid)
+	}
+	ps.Principals = st.Principal.ids()
+	for _, action := range st.Action {
+		access, err := actionToAccess(action)
+		if err != nil {
+			return err
+		}
+		ps.Access |= access
+	}
+	for _, res := range st.Resource {
+		name, ok := strings.CutPrefix(res, polArnPrefix)
+		if !ok {
+			return fmt.Errorf("bucket policy: statement %q: invalid resource %q", st.Sid, res)
+		}
+		bck, objName, _ := strings.Cut(name, "/")
+		if bck != bucket {
+			return fmt.Errorf("bucket policy: statement %q: resource %q does not belong to bucket %q", st.Sid, res, bucket)
+		}
+		if objName == "" {
+			ps.Bucket = true
+		} else {
+			ps.Objects = append(ps.Objects, objName)
+		}
+	}
+	return nil
+}
+
+func actionToAccess(action string) (apc.AccessAttrs, error) {
+	if action == polAnyAction {
+		return polAccessAll, nil
+	}
+	for _, a := range polActions {
+		if strings.EqualFold(a.action, action) {
+			return a.access, nil
+		}
+	}
+	return 0, cmn.NewErrNotImpl("configure", "bucket policy action "+action)
+}
+
+// AIS => S3
+func NewPolicyDocument(conf *cmn.PolicyConf, bucket string) *PolicyDocument {
+	doc := &PolicyDocument{Version: polVersion, Statement: make([]*PolicyStatement, 0, len(conf.Statements))}
+	for i := range conf.Statements {
+		var (
+			ps = &conf.Statements[i]
+			st = &PolicyStatement{Sid: ps.Sid, Effect: polEffAllow, Principal: &PolicyPrincipal{}}
+		)
+		if ps.Effect == cmn.PolicyDeny {
+			st.Effect = polEffDeny
+		}
+		for _, p := range ps.Principals {
+			if p == cmn.PolicyAnyone {
+				st.Principal = &PolicyPrincipal{anyone: true}
+				break
+			}
+			st.Principal.AWS = append(st.Principal.AWS, p)
+		}
+		st.Action = accessToActions(ps.Access)
+		if ps.Bucket {
+			st.Resource = append(st.Resource, polArnPrefix+bucket)
+		}
+		for _, o := range ps.Objects {
+			st.Resource = append(st.Resource, polArnPrefix+bucket+"/"+o)
+		}
+		doc.Statement = append(doc.Statement, st)
+	}
+	return doc
+}
+
+func accessToActions(access apc.AccessAttrs) (actions StrList) {
+	if access&polAccessAll == polAccessAll {
+		return StrList{polAnyAction}
+	}
+	var covered apc.AccessAttrs
+	for _, a := range polActions {
+		if access.Has(a.access) && covered&a.access != a.access {
+			actions = append(actions, a.action)
+			covered |= a.access
+		}
+	}
+	return actions
+}
+
+func NewErrNoPolicy(bucket string) error {
+	return NewErrCode("NoSuchBucketPolicy", "bucket "+bucket+": the bucket policy does not exist")
+}
@@ -0,0 +1,158 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3_test
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const policyJSON = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "PublicRead",
+      "Effect": "Allow",
+      "Principal": "*",
+      "Action": ["s3:GetObject", "s3:ListBucket"],
+      "Resource": ["arn:aws:s3:::abc", "arn:aws:s3:::abc/public/*"]
+    },
+    {
+      "Sid": "AliceWrites",
+      "Effect": "Allow",
+      "Principal": {"AWS": "arn:aws:iam::123456789012:user/alice"},
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::abc/alice/*"
+    },
+    {
+      "Sid": "NoSecrets",
+      "Effect": "Deny",
+      "Principal": "*",
+      "Action": "s3:*",
+      "Resource": "arn:aws:s3:::abc/public/secret*"
+    }
+  ]
+}`
+
+var _ = Describe("Policy", func() {
+	It("should decode and evaluate S3 bucket policy", func() {
+		conf, err := s3.DecodePolicy([]byte(policyJSON), "abc")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.Statements).To(HaveLen(3))
+
+		st := &conf.Statements[0]
+		Expect(st.Effect).To(Equal(cmn.PolicyAllow))
+		Expect(st.Principals).To(Equal([]string{cmn.PolicyAnyone}))
+		Expect(st.Bucket).To(BeTrue())
+		Expect(st.Objects).To(Equal([]string{"public/*"}))
+		Expect(st.Access.Has(apc.AceGET | apc.AceObjHEAD | apc.AceObjLIST)).To(BeTrue())
+		Expect(conf.Statements[1].Principals).To(Equal([]string{"alice"}))
+
+		// anonymous
+		allowed, denied := conf.Eval("", "public/a.txt", apc.AceGET)
+		Expect(allowed).To(Equal(apc.AceGET))
+		Expect(denied).To(BeZero())
+		allowed, _ = conf.Eval("", "", apc.AceObjLIST)
+		Expect(allowed).To(Equal(apc.AceObjLIST))
+		allowed, _ = conf.Eval("", "private/a.txt", apc.AceGET)
+		Expect(allowed).To(BeZero())
+		allowed, _ = conf.Eval("", "alice/a.txt", apc.AcePUT)
+		Expect(allowed).To(BeZero())
+
+		// named user
+		allowed, _ = conf.Eval("alice", "alice/a.txt", apc.AcePUT)
+		Expect(allowed).To(Equal(apc.AcePUT))
+		allowed, _ = conf.Eval("bob", "alice/a.txt", apc.AcePUT)
+		Expect(allowed).To(BeZero())
+
+		// deny wins
+		allowed, denied = conf.Eval("alice", "public/secret.txt", apc.AceGET)
+		Expect(allowed).To(BeZero())
+		Expect(denied).To(Equal(apc.AceGET))
+	})
+
+	It("should convert bucket policy back to S3", func() {
+		conf, err := s3.DecodePolicy([]byte(policyJSON), "abc")
+		Expect(err).NotTo(HaveOccurred())
+		doc := s3.NewPolicyDocument(conf, "abc")
+		conf2, err := s3.DecodePolicy(cos.MustMarshal(doc), "abc")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf2).To(Equal(conf))
+	})
+
+	It("should reject unsupported or invalid policies", func() {
+		for _, body := range []string{
+			`{"Statement": []}`,
+			`{"Statement": [{"Effect": "Maybe", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::abc/*"}]}`,
+			`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::abc/*"}]}`,
+			`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::xyz/*"}]}`,
+			`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::abc/*/x"}]}`,
+			`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:Frobnicate", "Resource": "arn:aws:s3:::abc/*"}]}`,
+			`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::abc/*",
+			  "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}}]}`,
+		} {
+			_, err := s3.DecodePolicy([]byte(body), "abc")
+			Expect(err).To(HaveOccurred(), body)
+		}
+	})
+})
+
+var _ = Describe("ACL", func() {
+	It("should map canned ACLs", func() {
+		hdr := http.Header{}
+		hdr.Set(s3.HdrACL, "public-read")
+		grants, err := s3.DecodeACL(hdr, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grants).To(HaveLen(1))
+		Expect(grants[0].Principal).To(Equal(cmn.PolicyAnyone))
+		Expect(grants[0].Access.Has(apc.AceGET | apc.AceObjLIST)).To(BeTrue())
+		Expect(grants[0].Access & apc.AcePUT).To(BeZero())
+
+		hdr.Set(s3.HdrACL, "private")
+		grants, err = s3.DecodeACL(hdr, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grants).To(BeEmpty())
+
+		hdr.Set(s3.HdrACL, "bucket-owner-full-control")
+		_, err = s3.DecodeACL(hdr, nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should decode and encode AccessControlPolicy", func() {
+		body := `<AccessControlPolicy>
+  <Owner><ID>1</ID></Owner>
+  <AccessControlList>
+    <Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>1</ID></Grantee><Permission>FULL_CONTROL</Permission></Grant>
+    <Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>bob</ID></Grantee><Permission>READ</Permission></Grant>
+    <Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>bob</ID></Grantee><Permission>WRITE</Permission></Grant>
+  </AccessControlList>
+</AccessControlPolicy>`
+		grants, err := s3.DecodeACL(http.Header{}, []byte(body))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grants).To(HaveLen(1))
+		Expect(grants[0].Principal).To(Equal("bob"))
+		Expect(grants[0].Access.Has(apc.AceGET | apc.AcePUT | apc.AceObjDELETE)).To(BeTrue())
+
+		conf := &cmn.PolicyConf{Grants: grants}
+		allowed, _ := conf.Eval("bob", "any", apc.AcePUT)
+		Expect(allowed).To(Equal(apc.AcePUT))
+		allowed, _ = conf.Eval("", "any", apc.AcePUT)
+		Expect(allowed).To(BeZero())
+
+		out, err := xml.Marshal(s3.NewAccessControlPolicy(grants))
+		Expect(err).NotTo(HaveOccurred())
+		grants2, err := s3.DecodeACL(http.Header{}, out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grants2).To(Equal(grants))
+	})
+})
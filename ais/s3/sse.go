@@ -0,0 +1,183 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/sse"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Server-side encryption at rest - see cmn/sse.go
+//
+// Both "AES256" (SSE-S3) and "aws:kms" (SSE-KMS) map onto bucket's (or default) key provider;
+// with "aws:kms", the specified key ID selects the provider's master key.
+//
+// SSE-C: the client provides (base64-encoded) 256-bit key and its MD5 with each request;
+// the key is never stored (see cmn/sse.go).
+//
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/serv-side-encryption.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketEncryption.html
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/ServerSideEncryptionCustomerKeys.html
+
+const (
+	HdrSSE         = "x-amz-server-side-encryption"
+	HdrSSEKMSKeyID = "x-amz-server-side-encryption-aws-kms-key-id"
+
+	HdrSSECAlgorithm = "x-amz-server-side-encryption-customer-algorithm"
+	HdrSSECKey       = "x-amz-server-side-encryption-customer-key"
+	HdrSSECKeyMD5    = "x-amz-server-side-encryption-customer-key-MD5"
+
+	// copy source (CopyObject)
+	HdrCopySSECAlgorithm = "x-amz-copy-source-server-side-encryption-customer-algorithm"
+	HdrCopySSECKey       = "x-amz-copy-source-server-side-encryption-customer-key"
+	HdrCopySSECKeyMD5    = "x-amz-copy-source-server-side-encryption-customer-key-MD5"
+
+	SSEAlgAES256 = "AES256"
+	SSEAlgKMS    = "aws:kms"
+)
+
+type (
+	ServerSideEncryptionConfiguration struct {
+		XMLName xml.Name   `xml:"ServerSideEncryptionConfiguration"`
+		Ns      string     `xml:"xmlns,attr,omitempty"`
+		Rules   []*SSERule `xml:"Rule"`
+	}
+	SSERule struct {
+		Default          SSEDefault `xml:"ApplyServerSideEncryptionByDefault"`
+		BucketKeyEnabled bool       `xml:"BucketKeyEnabled,omitempty"`
+	}
+	SSEDefault struct {
+		SSEAlgorithm   string `xml:"SSEAlgorithm"`
+		KMSMasterKeyID string `xml:"KMSMasterKeyID,omitempty"`
+	}
+)
+
+func NewErrNoSSE(bucket string) error {
+	return NewErrCode("ServerSideEncryptionConfigurationNotFoundError",
+		"server-side encryption configuration does not exist for bucket "+bucket)
+}
+
+func newErrInvalidSSE(msg string) error {
+	return NewErrCode("InvalidArgument", msg)
+}
+
+// AIS => S3
+func NewSSEConfiguration(conf *cmn.SSEConf) *ServerSideEncryptionConfiguration {
+	def := SSEDefault{SSEAlgorithm: SSEAlgAES256}
+	if conf.KeyID != "" {
+		def = SSEDefault{SSEAlgorithm: SSEAlgKMS, KMSMasterKeyID: conf.KeyID}
+	}
+	return &ServerSideEncryptionConfiguration{Ns: s3Namespace, Rules: []*SSERule{{Default: def}}}
+}
+
+func (r *ServerSideEncryptionConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// S3 => AIS (keeping bucket's current key provider, if any)
+func DecodeSSEConf(body []byte, curr *cmn.SSEConf) (*cmn.SSEConf, error) {
+	var in ServerSideEncryptionConfiguration
+	if err := xml.Unmarshal(body, &in); err != nil {
+		return nil, err
+	}
+	if len(in.Rules) != 1 {
+		return nil, newErrInvalidSSE("expecting exactly one server-side encryption rule")
+	}
+	conf, err := sseConf(in.Rules[0].Default.SSEAlgorithm, in.Rules[0].Default.KMSMasterKeyID, curr)
+	if err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func sseConf(alg, kid string, curr *cmn.SSEConf) (*cmn.SSEConf, error) {
+	conf := &cmn.SSEConf{Provider: curr.Provider, Enabled: true}
+	switch alg {
+	case SSEAlgAES256:
+		if kid != "" {
+			return nil, newErrInvalidSSE("KMS key ID requires " + SSEAlgKMS + " encryption")
+		}
+	case SSEAlgKMS:
+		conf.KeyID = kid
+	default:
+		return nil, newErrInvalidSSE("unsupported server-side encryption algorithm \"" + alg + "\"")
+	}
+	if _, err := sse.GetProvider(conf.Provider); err != nil {
+		return nil, newErrInvalidSSE(err.Error())
+	}
+	return conf, nil
+}
+
+// SSEFromHeaders returns encryption requested by PUT (or copy) request, or nil if none
+func SSEFromHeaders(hdr http.Header, bprops *cmn.SSEConf) (*cmn.SSEConf, error) {
+	alg := hdr.Get(HdrSSE)
+	if alg == "" {
+		return nil, nil
+	}
+	conf, err := sseConf(alg, hdr.Get(HdrSSEKMSKeyID), bprops)
+	if err != nil {
+		return nil, err
+	}
+	if conf.KeyID == "" {
+		conf.KeyID = bprops.KeyID
+	}
+	return conf, nil
+}
+
+// SSECFromHeaders returns customer-provided key (SSE-C), or nil if none;
+// `copySrc` selects the headers that specify the key of the copy source
+func SSECFromHeaders(hdr http.Header, copySrc bool) ([]byte, error) {
+	hdrAlg, hdrKey, hdrMD5 := HdrSSECAlgorithm, HdrSSECKey, HdrSSECKeyMD5
+	if copySrc {
+		hdrAlg, hdrKey, hdrMD5 = HdrCopySSECAlgorithm, HdrCopySSECKey, HdrCopySSECKeyMD5
+	}
+	alg, skey, md5 := hdr.Get(hdrAlg), hdr.Get(hdrKey), hdr.Get(hdrMD5)
+	if alg == "" && skey == "" && md5 == "" {
+		return nil, nil
+	}
+	if alg != SSEAlgAES256 {
+		return nil, newErrInvalidSSE("invalid " + hdrAlg + " \"" + alg + "\" (expecting " + SSEAlgAES256 + ")")
+	}
+	key, err := base64.StdEncoding.DecodeString(skey)
+	if err != nil || len(key) != sse.KeySize {
+		return nil, newErrInvalidSSE("invalid " + hdrKey + " (expecting base64-encoded 256-bit key)")
+	}
+	if md5 != sse.KeyMD5(key) {
+		return nil, newErrInvalidSSE("the calculated MD5 hash of the key did not match the hash that was provided")
+	}
+	return key, nil
+}
+
+// SetSSEHeaders sets PUT, GET, and HEAD response headers for encrypted objects
+func SetSSEHeaders(hdr http.Header, md cos.StrKVs) {
+	if _, ok := md[cmn.SSENonceObjMD]; !ok {
+		return
+	}
+	provider := md[cmn.SSEProviderObjMD]
+	if provider == sse.ProviderCustomer {
+		SetSSECHeaders(hdr, md[cmn.SSECKeyMD5ObjMD])
+		return
+	}
+	if provider == "" || provider == sse.ProviderKeyfile {
+		hdr.Set(HdrSSE, SSEAlgAES256)
+		return
+	}
+	hdr.Set(HdrSSE, SSEAlgKMS)
+	hdr.Set(HdrSSEKMSKeyID, md[cmn.SSEKeyIDObjMD])
+}
+
+func SetSSECHeaders(hdr http.Header, keyMD5 string) {
+	hdr.Set(HdrSSECAlgorithm, SSEAlgAES256)
+	hdr.Set(HdrSSECKeyMD5, keyMD5)
+}
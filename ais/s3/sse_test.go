@@ -0,0 +1,139 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/xml"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/sse"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServerSideEncryption", func() {
+	It("should decode and encode bucket encryption configuration", func() {
+		body := []byte(`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault>` +
+			`<SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>k1</KMSMasterKeyID>` +
+			`</ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`)
+		conf, err := s3.DecodeSSEConf(body, &cmn.SSEConf{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.Enabled).To(BeTrue())
+		Expect(conf.KeyID).To(Equal("k1"))
+
+		b, err := xml.Marshal(s3.NewSSEConfiguration(conf))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(ContainSubstring("<SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>k1</KMSMasterKeyID>"))
+
+		conf, err = s3.DecodeSSEConf([]byte(`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault>`+
+			`<SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`),
+			&cmn.SSEConf{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.KeyID).To(BeEmpty())
+
+		_, err = s3.DecodeSSEConf([]byte(`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault>`+
+			`<SSEAlgorithm>DES</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`),
+			&cmn.SSEConf{})
+		Expect(err).To(HaveOccurred())
+		_, err = s3.DecodeSSEConf([]byte(`<ServerSideEncryptionConfiguration></ServerSideEncryptionConfiguration>`), &cmn.SSEConf{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should parse request headers and set response headers", func() {
+		bprops := &cmn.SSEConf{KeyID: "dflt"}
+		hdr := http.Header{}
+		conf, err := s3.SSEFromHeaders(hdr, bprops)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(BeNil())
+
+		hdr.Set(s3.HdrSSE, s3.SSEAlgAES256)
+		conf, err = s3.SSEFromHeaders(hdr, bprops)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.Enabled).To(BeTrue())
+		Expect(conf.KeyID).To(Equal("dflt"))
+
+		hdr.Set(s3.HdrSSEKMSKeyID, "k2")
+		_, err = s3.SSEFromHeaders(hdr, bprops)
+		Expect(err).To(HaveOccurred())
+
+		hdr.Set(s3.HdrSSE, s3.SSEAlgKMS)
+		conf, err = s3.SSEFromHeaders(hdr, bprops)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.KeyID).To(Equal("k2"))
+
+		resp := http.Header{}
+		s3.SetSSEHeaders(resp, cos.StrKVs{cmn.ETag: "abc"})
+		Expect(resp.Get(s3.HdrSSE)).To(BeEmpty())
+		md := cos.StrKVs{cmn.SSEKeyObjMD: "xyz", cmn.SSENonceObjMD: "abc", cmn.SSEProviderObjMD: sse.ProviderKeyfile}
+		s3.SetSSEHeaders(resp, md)
+		Expect(resp.Get(s3.HdrSSE)).To(Equal(s3.SSEAlgAES256))
+	})
+
+	It("should parse and validate customer-provided keys (SSE-C)", func() {
+		key := make([]byte, sse.KeySize)
+		rand.Read(key)
+		skey, md5 := base64.StdEncoding.EncodeToString(key), sse.KeyMD5(key)
+
+		hdr := http.Header{}
+		ckey, err := s3.SSECFromHeaders(hdr, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ckey).To(BeNil())
+
+		hdr.Set(s3.HdrSSECAlgorithm, s3.SSEAlgAES256)
+		hdr.Set(s3.HdrSSECKey, skey)
+		_, err = s3.SSECFromHeaders(hdr, false)
+		Expect(err).To(HaveOccurred()) // missing MD5
+
+		hdr.Set(s3.HdrSSECKeyMD5, md5)
+		ckey, err = s3.SSECFromHeaders(hdr, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ckey).To(Equal(key))
+
+		// copy source
+		ckey, err = s3.SSECFromHeaders(hdr, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ckey).To(BeNil())
+		hdr.Set(s3.HdrCopySSECAlgorithm, s3.SSEAlgAES256)
+		hdr.Set(s3.HdrCopySSECKey, base64.StdEncoding.EncodeToString(key[:16]))
+		hdr.Set(s3.HdrCopySSECKeyMD5, sse.KeyMD5(key[:16]))
+		_, err = s3.SSECFromHeaders(hdr, true)
+		Expect(err).To(HaveOccurred()) // not a 256-bit key
+
+		hdr.Set(s3.HdrSSECKeyMD5, sse.KeyMD5(key[1:]))
+		_, err = s3.SSECFromHeaders(hdr, false)
+		Expect(err).To(HaveOccurred())
+		hdr.Set(s3.HdrSSECKeyMD5, md5)
+		hdr.Set(s3.HdrSSECAlgorithm, s3.SSEAlgKMS)
+		_, err = s3.SSECFromHeaders(hdr, false)
+		Expect(err).To(HaveOccurred())
+
+		resp := http.Header{}
+		md := cos.StrKVs{cmn.SSECKeyMD5ObjMD: md5, cmn.SSENonceObjMD: "abc", cmn.SSEProviderObjMD: sse.ProviderCustomer}
+		s3.SetSSEHeaders(resp, md)
+		Expect(resp.Get(s3.HdrSSE)).To(BeEmpty())
+		Expect(resp.Get(s3.HdrSSECAlgorithm)).To(Equal(s3.SSEAlgAES256))
+		Expect(resp.Get(s3.HdrSSECKeyMD5)).To(Equal(md5))
+	})
+
+	It("should require upload's customer-provided key", func() {
+		key := make([]byte, sse.KeySize)
+		rand.Read(key)
+		s3.InitUpload("ssec-upload", "bck", "obj", key)
+		defer s3.CleanupUpload("ssec-upload", "", true /*aborted*/)
+
+		Expect(s3.CheckUploadSSEC("ssec-upload", key)).To(Succeed())
+		err := s3.CheckUploadSSEC("ssec-upload", nil)
+		Expect(cmn.IsErrSSECKey(err)).To(BeTrue())
+		other := make([]byte, sse.KeySize)
+		err = s3.CheckUploadSSEC("ssec-upload", other)
+		Expect(err.(*cmn.ErrSSECKey).Status()).To(Equal(http.StatusForbidden))
+	})
+})
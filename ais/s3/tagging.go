@@ -0,0 +1,67 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Object tags are stored in the object's custom metadata (see cmn/objtags.go).
+//
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-tagging.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectTagging.html
+
+const (
+	HdrTagging      = "x-amz-tagging"       // PUT: URL-encoded tags, e.g. "k1=v1&k2=v2"
+	HdrTaggingCount = "x-amz-tagging-count" // GET and HEAD: number of tags
+)
+
+type Tagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	Ns      string   `xml:"xmlns,attr,omitempty"`
+	TagSet  []Tag    `xml:"TagSet>Tag"`
+}
+
+func (r *Tagging) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// S3 => AIS
+func DecodeTagging(body []byte) (cos.StrKVs, error) {
+	var r Tagging
+	if err := xml.Unmarshal(body, &r); err != nil {
+		return nil, err
+	}
+	tags := make(cos.StrKVs, len(r.TagSet))
+	for _, tag := range r.TagSet {
+		if _, ok := tags[tag.Key]; ok {
+			return nil, NewErrCode("InvalidTag", fmt.Sprintf("duplicate tag key %q", tag.Key))
+		}
+		tags[tag.Key] = tag.Value
+	}
+	if err := cmn.ValidateObjTags(tags); err != nil {
+		return nil, NewErrCode("InvalidTag", err.Error())
+	}
+	return tags, nil
+}
+
+// AIS => S3 (sorted by key)
+func NewTagging(tags cos.StrKVs) *Tagging {
+	r := &Tagging{Ns: s3Namespace, TagSet: make([]Tag, 0, len(tags))}
+	for k, v := range tags {
+		r.TagSet = append(r.TagSet, Tag{Key: k, Value: v})
+	}
+	sort.Slice(r.TagSet, func(i, j int) bool { return r.TagSet[i].Key < r.TagSet[j].Key })
+	return r
+}
@@ -0,0 +1,60 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3_test
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tagging", func() {
+	It("should decode and encode object tags", func() {
+		body := `<Tagging><TagSet>
+  <Tag><Key>split</Key><Value>train</Value></Tag>
+  <Tag><Key>project</Key><Value>a b&amp;c</Value></Tag>
+</TagSet></Tagging>`
+		tags, err := s3.DecodeTagging([]byte(body))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tags).To(Equal(cos.StrKVs{"split": "train", "project": "a b&c"}))
+
+		s := cmn.ObjTagsToS(tags)
+		Expect(s).To(Equal("project=a+b%26c&split=train"))
+		md := cos.StrKVs{cmn.TagsObjMD: s}
+		Expect(cmn.ObjTags(md)).To(Equal(tags))
+		Expect(cmn.MatchObjTags(md, cos.StrKVs{"split": "train"})).To(BeTrue())
+		Expect(cmn.MatchObjTags(md, cos.StrKVs{"split": "test"})).To(BeFalse())
+		Expect(cmn.MatchObjTags(nil, cos.StrKVs{"split": "train"})).To(BeFalse())
+		Expect(cmn.MatchObjTags(nil, nil)).To(BeTrue())
+
+		out, err := xml.Marshal(s3.NewTagging(tags))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Index(string(out), "project")).To(BeNumerically("<", strings.Index(string(out), "split")))
+		tags2, err := s3.DecodeTagging(out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tags2).To(Equal(tags))
+	})
+
+	It("should reject invalid tags", func() {
+		_, err := s3.DecodeTagging([]byte(`<Tagging><TagSet>
+  <Tag><Key>a</Key><Value>1</Value></Tag><Tag><Key>a</Key><Value>2</Value></Tag>
+</TagSet></Tagging>`))
+		Expect(err).To(HaveOccurred())
+
+		_, err = cmn.ParseObjTags("k=" + strings.Repeat("v", 257))
+		Expect(err).To(HaveOccurred())
+		_, err = cmn.ParseObjTags("a=1&a=2")
+		Expect(err).To(HaveOccurred())
+		tags, err := cmn.ParseObjTags("a=1&b=")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tags).To(Equal(cos.StrKVs{"a": "1", "b": ""}))
+	})
+})
@@ -0,0 +1,217 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/Versioning.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectVersions.html
+//
+// Noncurrent versions are kept on disk for ais:// buckets only (see core/lversion.go).
+
+const (
+	HdrDeleteMarker = "x-amz-delete-marker"
+
+	nullVersionID  = "null"
+	defaultMaxKeys = 1000
+)
+
+type (
+	ListVersionsResult struct {
+		XMLName             xml.Name             `xml:"ListVersionsResult"`
+		Ns                  string               `xml:"xmlns,attr"`
+		Name                string               `xml:"Name"`
+		Prefix              string               `xml:"Prefix"`
+		KeyMarker           string               `xml:"KeyMarker"`
+		VersionIDMarker     string               `xml:"VersionIdMarker"`
+		NextKeyMarker       string               `xml:"NextKeyMarker,omitempty"`
+		NextVersionIDMarker string               `xml:"NextVersionIdMarker,omitempty"`
+		Versions            []*ObjVersionInfo    `xml:"Version"`
+		DeleteMarkers       []*DeleteMarkerEntry `xml:"DeleteMarker"`
+		MaxKeys             int                  `xml:"MaxKeys"`
+		IsTruncated         bool                 `xml:"IsTruncated"`
+	}
+	ObjVersionInfo struct {
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag,omitempty"`
+		Class        string `xml:"StorageClass"`
+		Size         int64  `xml:"Size"`
+		IsLatest     bool   `xml:"IsLatest"`
+	}
+	DeleteMarkerEntry struct {
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		LastModified string `xml:"LastModified"`
+		IsLatest     bool   `xml:"IsLatest"`
+	}
+)
+
+// (key, version) - to sort and paginate versions and delete markers together
+type verEntry struct {
+	key, ver string
+	v        *ObjVersionInfo
+	m        *DeleteMarkerEntry
+}
+
+func NewListVersionsResult(bucket string, q url.Values) *ListVersionsResult {
+	r := &ListVersionsResult{
+		Ns:              s3Namespace,
+		Name:            bucket,
+		Prefix:          q.Get(QparamPrefix),
+		KeyMarker:       q.Get(QparamKeyMarker),
+		VersionIDMarker: q.Get(QparamVersionIDMarker),
+		MaxKeys:         defaultMaxKeys,
+	}
+	if s := q.Get(QparamMaxKeys); s != "" {
+		if n, err := strconv.Atoi(s); err == nil && n >= 0 && n < defaultMaxKeys {
+			r.MaxKeys = n
+		}
+	}
+	return r
+}
+
+func (r *ListVersionsResult) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// returns true if (key, version) is to be listed given the prefix and markers
+func (r *ListVersionsResult) Want(key, ver string) bool {
+	if !strings.HasPrefix(key, r.Prefix) {
+		return false
+	}
+	switch {
+	case r.KeyMarker == "":
+		return true
+	case key != r.KeyMarker:
+		return key > r.KeyMarker
+	case r.VersionIDMarker == "":
+		return false // (all versions of the key-marker were listed)
+	default:
+		return verLess(ver, r.VersionIDMarker) // (newest first)
+	}
+}
+
+func (r *ListVersionsResult) Add(key string, v *core.ObjVersion, isLatest bool) {
+	var (
+		ver          = VersionID(v.Version)
+		lastModified = cos.FormatTime(v.Mtime.UTC(), cos.ISO8601)
+	)
+	if v.DeleteMarker {
+		r.DeleteMarkers = append(r.DeleteMarkers, &DeleteMarkerEntry{
+			Key: key, VersionID: ver, LastModified: lastModified, IsLatest: isLatest,
+		})
+		return
+	}
+	oi := &ObjVersionInfo{
+		Key: key, VersionID: ver, LastModified: lastModified, Size: v.Size, IsLatest: isLatest,
+	}
+	if v.Cksum != nil && v.Cksum.Ty() == cos.ChecksumMD5 {
+		oi.ETag = `"` + v.Cksum.Val() + `"`
+	}
+	r.Versions = append(r.Versions, oi)
+}
+
+func (r *ListVersionsResult) Len() int { return len(r.Versions) + len(r.DeleteMarkers) }
+
+// Merge adds versions and delete markers from another (target's) result
+func (r *ListVersionsResult) Merge(other *ListVersionsResult) {
+	r.Versions = append(r.Versions, other.Versions...)
+	r.DeleteMarkers = append(r.DeleteMarkers, other.DeleteMarkers...)
+	r.IsTruncated = r.IsTruncated || other.IsTruncated
+}
+
+// Finalize sorts all entries by key (ascending) and version (newest first),
+// and paginates the result as per max-keys
+func (r *ListVersionsResult) Finalize() {
+	all := make([]verEntry, 0, r.Len())
+	for _, v := range r.Versions {
+		all = append(all, verEntry{key: v.Key, ver: v.VersionID, v: v})
+	}
+	for _, m := range r.DeleteMarkers {
+		all = append(all, verEntry{key: m.Key, ver: m.VersionID, m: m})
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].key != all[j].key {
+			return all[i].key < all[j].key
+		}
+		return verLess(all[j].ver, all[i].ver)
+	})
+	if len(all) > r.MaxKeys {
+		all = all[:r.MaxKeys]
+		r.IsTruncated = true
+	}
+	r.Versions, r.DeleteMarkers = r.Versions[:0], r.DeleteMarkers[:0]
+	for _, e := range all {
+		if e.v != nil {
+			r.Versions = append(r.Versions, e.v)
+		} else {
+			r.DeleteMarkers = append(r.DeleteMarkers, e.m)
+		}
+	}
+	r.NextKeyMarker, r.NextVersionIDMarker = "", ""
+	if r.IsTruncated && len(all) > 0 {
+		last := all[len(all)-1]
+		r.NextKeyMarker, r.NextVersionIDMarker = last.key, last.ver
+	}
+}
+
+// AIS => S3 version ID
+func VersionID(ver string) string {
+	if ver == "" {
+		return nullVersionID
+	}
+	return ver
+}
+
+// (ais:// buckets with versioning enabled; compare with remote buckets in `headObjS3`)
+func SetVersionHeader(hdr http.Header, lom *core.LOM) {
+	if lom.Bck().IsAIS() && lom.VersionConf().Enabled {
+		hdr.Set(cos.S3VersionHeader, VersionID(lom.Version()))
+	}
+}
+
+// S3 => AIS version ID
+func AisVersion(ver string) string {
+	if ver == nullVersionID {
+		return ""
+	}
+	return ver
+}
+
+// numeric (ais) versions compare as numbers
+func verLess(a, b string) bool {
+	na, erra := strconv.ParseInt(a, 10, 64)
+	nb, errb := strconv.ParseInt(b, 10, 64)
+	switch {
+	case erra == nil && errb == nil:
+		return na < nb
+	case erra == nil: // "null" is the oldest
+		return false
+	case errb == nil:
+		return true
+	default:
+		return a < b
+	}
+}
+
+func NewErrNoSuchVersion(cname, ver string) error {
+	return NewErrCode("NoSuchVersion", cname+": version "+ver+" does not exist")
+}
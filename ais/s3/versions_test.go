@@ -0,0 +1,100 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3_test
+
+import (
+	"encoding/xml"
+	"net/url"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/core"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListObjectVersions", func() {
+	now := time.Now()
+
+	// two targets, each returning its own part of the bucket
+	targetResults := func(q url.Values) []*s3.ListVersionsResult {
+		t1 := s3.NewListVersionsResult("abc", q)
+		for _, ver := range []string{"3", "2", "1"} {
+			if t1.Want("a", ver) {
+				t1.Add("a", &core.ObjVersion{Version: ver, Size: 1, Mtime: now}, ver == "3")
+			}
+		}
+		t2 := s3.NewListVersionsResult("abc", q)
+		for _, ver := range []string{"12", "11"} {
+			if t2.Want("b", ver) {
+				t2.Add("b", &core.ObjVersion{Version: ver, Mtime: now, DeleteMarker: ver == "12"}, ver == "12")
+			}
+		}
+		if t2.Want("c", "") {
+			t2.Add("c", &core.ObjVersion{Size: 2, Mtime: now}, true)
+		}
+		return []*s3.ListVersionsResult{t1, t2}
+	}
+
+	merge := func(q url.Values) *s3.ListVersionsResult {
+		all := s3.NewListVersionsResult("abc", q)
+		for _, res := range targetResults(q) {
+			b, err := xml.Marshal(res)
+			Expect(err).NotTo(HaveOccurred())
+			other := &s3.ListVersionsResult{}
+			Expect(xml.Unmarshal(b, other)).NotTo(HaveOccurred())
+			all.Merge(other)
+		}
+		all.Finalize()
+		return all
+	}
+
+	It("should merge, sort, and paginate versions and delete markers", func() {
+		all := merge(url.Values{})
+		Expect(all.IsTruncated).To(BeFalse())
+		Expect(all.Versions).To(HaveLen(5))
+		Expect(all.DeleteMarkers).To(HaveLen(1))
+		Expect(all.DeleteMarkers[0].Key).To(Equal("b"))
+		Expect(all.DeleteMarkers[0].IsLatest).To(BeTrue())
+
+		keys := make([]string, 0, len(all.Versions))
+		for _, v := range all.Versions {
+			keys = append(keys, v.Key+"@"+v.VersionID)
+		}
+		Expect(keys).To(Equal([]string{"a@3", "a@2", "a@1", "b@11", "c@null"}))
+
+		q := url.Values{}
+		q.Set(s3.QparamMaxKeys, "2")
+		all = merge(q)
+		Expect(all.IsTruncated).To(BeTrue())
+		Expect(all.Len()).To(Equal(2))
+		Expect(all.NextKeyMarker).To(Equal("a"))
+		Expect(all.NextVersionIDMarker).To(Equal("2"))
+
+		// next page
+		q = url.Values{}
+		q.Set(s3.QparamKeyMarker, "a")
+		q.Set(s3.QparamVersionIDMarker, "2")
+		all = merge(q)
+		Expect(all.IsTruncated).To(BeFalse())
+		Expect(all.Versions[0].Key + "@" + all.Versions[0].VersionID).To(Equal("a@1"))
+		Expect(all.Len()).To(Equal(4))
+	})
+
+	It("should filter by prefix and key marker", func() {
+		q := url.Values{}
+		q.Set(s3.QparamPrefix, "b")
+		all := merge(q)
+		Expect(all.Versions).To(HaveLen(1))
+		Expect(all.DeleteMarkers).To(HaveLen(1))
+
+		q = url.Values{}
+		q.Set(s3.QparamKeyMarker, "b")
+		all = merge(q)
+		Expect(all.Len()).To(Equal(1))
+		Expect(all.Versions[0].Key).To(Equal("c"))
+	})
+})
@@ -38,6 +38,7 @@ import (
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/health"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/reb"
@@ -69,6 +70,9 @@ type (
 		reb          *reb.Reb
 		res          *res.Res
 		transactions transactions
+		quotas       quotas
+		events       evdisp // bucket event notifications (see tgtevents.go)
+		wb           wback  // write-back of remote buckets (see tgtwback.go)
 		regstate     regstate
 	}
 )
@@ -138,6 +142,8 @@ func (t *target) _initBuiltTagged(tstats *stats.Trunner, config *cmn.Config) err
 			add, err = backend.NewAzure(t, tstats)
 		case apc.HT:
 			add, err = backend.NewHT(t, config, tstats)
+		case apc.File:
+			add, err = backend.NewFile(t, config, tstats)
 		case apc.AIS:
 			continue
 		default:
@@ -331,6 +337,9 @@ func (t *target) Run() error {
 	// register object type and workfile type
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	fs.CSM.Reg(fs.VersionType, &fs.VersionContentResolver{})
+	fs.CSM.Reg(fs.ArchIdxType, &fs.ArchIdxContentResolver{})
+	fs.CSM.Reg(fs.DedupType, &fs.DedupContentResolver{})
 
 	// Init meta-owners and load local instances
 	if prev := t.owner.bmd.init(); prev {
@@ -397,19 +406,26 @@ func (t *target) Run() error {
 	mirror.Init()
 
 	xreg.RegWithHK()
+	hk.Reg(apc.ActLifecycle+hk.NameSuffix, t.lcyHK, lcyInterval)
+	hk.Reg(apc.ActLRU+"-ttl"+hk.NameSuffix, t.lruTTLHK, lruTTLInterval)
 
 	marked := xreg.GetResilverMarked()
 	if marked.Interrupted || daemon.resilver.required {
 		go t.goresilver(marked.Interrupted)
 	}
 
+	t.events.init(t, db)
+	t.wb.init(t, db)
+
 	dsort.Tinit(t.statsT, db, config)
 	dload.Init(t.statsT, db, &config.Client)
 
 	err = t.htrun.run(config)
 
-	etl.StopAll() // stop all running ETLs if any
-	cos.Close(db) // close kv db
+	etl.StopAll()   // stop all running ETLs if any
+	t.events.stop() // (event notifications backlog)
+	t.wb.stop()     // (write-back journal persists across restarts)
+	cos.Close(db)   // close kv db
 
 	// gracefully
 	fs.RemoveMarker(fname.NodeRestartedPrev, t.statsT)
@@ -491,6 +507,7 @@ func (t *target) initRecvHandlers() {
 	networkHandlers := []networkHandler{
 		{r: apc.Buckets, h: t.bucketHandler, net: accessNetAll},
 		{r: apc.Objects, h: t.objectHandler, net: accessNetAll},
+		{r: apc.GetBatch, h: t.gbHandler, net: accessNetAll},
 		{r: apc.Daemon, h: t.daemonHandler, net: accessNetPublicControl},
 		{r: apc.Metasync, h: t.metasyncHandler, net: accessNetIntraControl},
 		{r: apc.Health, h: t.healthHandler, net: accessNetPublicControl},
@@ -725,6 +742,18 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 		return lom, err
 	}
 
+	// S3 SSE-C
+	var ssec []byte
+	if dpq.isS3 {
+		var err error
+		if ssec, err = s3.SSECFromHeaders(r.Header, false); err != nil {
+			return lom, err
+		}
+	}
+
+	// conditional GET
+	pc := cmn.ParsePreconds(r.Header)
+
 	// GET: regular | archive | range
 	goi := allocGOI()
 	{
@@ -742,12 +771,14 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 		goi.w = w
 		goi.ctx = context.Background()
 		goi.ranges = byteRanges{Range: r.Header.Get(cos.HdrRange), Size: 0}
+		goi.ssec = ssec
+		goi.pc = pc
 		goi.latestVer = _validateWarmGet(goi.lom, dpq.latestVer) // apc.QparamLatestVer || versioning.*_warm_get
 	}
 	if dpq.isArch() {
-		if goi.ranges.Range != "" {
+		if goi.ranges.Range != "" && dpq.arch.path == "" {
 			details := fmt.Sprintf("range: %s, arch query: %s", goi.ranges.Range, goi.dpq._archstr())
-			return lom, cmn.NewErrUnsupp("range-read archived content", details)
+			return lom, cmn.NewErrUnsupp("range-read multiple archived files", details)
 		}
 		if dpq.arch.path != "" {
 			if strings.HasPrefix(dpq.arch.path, lom.ObjName) {
@@ -766,6 +797,18 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 
 	// do
 	if ecode, err := goi.getObject(); err != nil {
+		if cmn.IsErrPrecondition(err) {
+			if isNotModified(err) {
+				writeNotModified(w, goi.lom, dpq.isS3)
+			} else if dpq.isS3 {
+				s3.WriteErr(w, r, err, 0)
+			} else {
+				t.writeErr(w, r, err, http.StatusPreconditionFailed, Silent)
+			}
+			lom = goi.lom
+			freeGOI(goi)
+			return lom, nil
+		}
 		t.statsT.IncErr(stats.ErrGetCount)
 		if goi.isIOErr {
 			t.statsT.IncErr(stats.IOErrGetCount)
@@ -882,6 +925,8 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiR
 			return
 		}
 		t.statsT.IncErr(stats.ErrAppendCount)
+	case apireq.dpq.mpt.id != "": // apc.QparamMptUploadID
+		ecode, err = t.putMptPartNative(w, r, lom, apireq.dpq)
 	default:
 		poi := allocPOI()
 		{
@@ -902,7 +947,9 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiR
 		freePOI(poi)
 	}
 	if err != nil {
-		t.FSHC(err, lom.Mountpath(), "") // TODO -- FIXME: removed from the place where happened, fqn missing...
+		if !cmn.IsErrPrecondition(err) && !cmn.IsErrQuotaExceeded(err) {
+			t.FSHC(err, lom.Mountpath(), "") // TODO -- FIXME: removed from the place where happened, fqn missing...
+		}
 		t.writeErr(w, r, err, ecode)
 	}
 }
@@ -960,7 +1007,10 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *api
 		t.writeErrf(w, r, "%s: %s-%s(obj) is expected to be redirected", t.si, r.Method, msg.Action)
 		return
 	}
-	var lom *core.LOM
+	var (
+		lom   *core.LOM
+		ecode int
+	)
 	switch msg.Action {
 	case apc.ActRenameObject:
 		lom = core.AllocLOM(apireq.items[1])
@@ -999,12 +1049,21 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *api
 
 			// lom is eventually freed by x-blob
 		}
+	case apc.ActMptCreate, apc.ActMptComplete, apc.ActMptAbort:
+		lom = core.AllocLOM(apireq.items[1])
+		if err = lom.InitBck(apireq.bck.Bucket()); err != nil {
+			break
+		}
+		if ecode, err = t.mptNative(w, lom, msg); err == nil {
+			core.FreeLOM(lom)
+			lom = nil
+		}
 	default:
 		t.writeErrAct(w, r, msg.Action)
 		return
 	}
 	if err != nil {
-		t.writeErr(w, r, err)
+		t.writeErr(w, r, err, ecode)
 		core.FreeLOM(lom)
 	}
 }
@@ -1025,10 +1084,14 @@ func (t *target) httpobjhead(w http.ResponseWriter, r *http.Request, apireq *api
 	}
 	lom := core.AllocLOM(objName)
 	ecode, err := t.objHead(r, w.Header(), query, bck, lom)
-	core.FreeLOM(lom)
-	if err != nil {
+	switch {
+	case err == nil:
+	case isNotModified(err):
+		writeNotModified(w, lom, false /*S3*/)
+	default:
 		t._erris(w, r, err, ecode, cos.IsParseBool(query.Get(apc.QparamSilent)))
 	}
+	core.FreeLOM(lom)
 }
 
 // NOTE: sets whdr.ContentLength = obj-size, with no response body
@@ -1054,6 +1117,12 @@ func (t *target) objHead(r *http.Request, whdr http.Header, q url.Values, bck *m
 		if apc.IsFltNoProps(fltPresence) {
 			return
 		}
+		// conditional HEAD
+		if pc := cmn.ParsePreconds(r.Header); pc != nil {
+			if err = evalPrecondRead(pc, lom); err != nil {
+				return err.(*cmn.ErrPrecondition).Status(), err
+			}
+		}
 		if fltPresence == apc.FltExistsOutside {
 			err = fmt.Errorf(fmtOutside, lom.Cname(), fltPresence)
 			return
@@ -1079,6 +1148,9 @@ func (t *target) objHead(r *http.Request, whdr http.Header, q url.Values, bck *m
 	op := cmn.ObjectProps{Name: lom.ObjName, Bck: *lom.Bucket(), Present: exists}
 	if exists {
 		op.ObjAttrs = *lom.ObjAttrs()
+		if lom.IsEncoded() {
+			op.ObjAttrs.Size = lom.PlainSize()
+		}
 		op.Location = lom.Location()
 		op.Mirror.Copies = lom.NumCopies()
 		if lom.HasCopies() {
@@ -1138,6 +1210,9 @@ func (t *target) objHead(r *http.Request, whdr http.Header, q url.Values, bck *m
 
 	// to header
 	cmn.ToHeader(&op.ObjAttrs, whdr, op.ObjAttrs.Size)
+	if exists {
+		setETag(whdr, lom)
+	}
 	if op.ObjAttrs.Cksum == nil {
 		// cos.Cksum does not have default nil/zero value (reflection)
 		op.ObjAttrs.Cksum = cos.NewCksum("", "")
@@ -1238,6 +1313,7 @@ func (t *target) putApndArch(r *http.Request, lom *core.LOM, started int64, dpq
 		r:        r.Body,
 		filename: filename,
 		mime:     mime,
+		uq:       parseUserQuota(dpq.uquota),
 		put:      false, // below
 	}
 	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
@@ -1263,10 +1339,14 @@ func (t *target) putApndArch(r *http.Request, lom *core.LOM, started int64, dpq
 	return a.do()
 }
 
-func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
+func (t *target) DeleteObject(lom *core.LOM, evict bool) (int, error) {
+	return t.delObject(lom, evict, false /*bypass governance*/)
+}
+
+func (t *target) delObject(lom *core.LOM, evict, bypass bool) (code int, err error) {
 	var isback bool
 	lom.Lock(true)
-	code, err, isback = t.delobj(lom, evict)
+	code, err, isback = t.delobj(lom, evict, bypass)
 	lom.Unlock(true)
 
 	// special corner-case retry (quote):
@@ -1284,6 +1364,13 @@ func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
 	switch {
 	case err == nil:
 		t.statsT.Inc(stats.DeleteCount)
+		if code != http.StatusNotFound {
+			ev := cmn.EvObjRemovedDelete
+			if evict {
+				ev = cmn.EvObjRemovedEvict
+			}
+			t.ObjEvent(lom, ev)
+		}
 	case cos.IsNotExist(err, code) || cmn.IsErrObjNought(err):
 		if !evict {
 			t.statsT.IncErr(stats.ErrDeleteCount) // TODO: count GET/PUT/DELETE remote errors on a per-backend...
@@ -1300,7 +1387,7 @@ func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
 }
 
 // NOTE: s3 will return err=nil with OK status to indicate (not deleting) non-existing object (see also aws.go)
-func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
+func (t *target) delobj(lom *core.LOM, evict, bypass bool) (int, error, bool) {
 	var (
 		aisErr, backendErr         error
 		aisErrCode, backendErrCode int
@@ -1324,6 +1411,18 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 		}
 	} else {
 		delFromAIS = true
+		// object lock (when keeping noncurrent versions, delete adds delete marker - see DelCurrent)
+		if evict || lom.RetainVersions() == 0 {
+			if err := lom.CheckLock(bypass); err != nil {
+				return http.StatusForbidden, err, false
+			}
+		}
+		// not yet written back (see tgtwback.go)
+		if evict && lom.IsDirty() {
+			if ecode, err := t.wb.evict(lom); err != nil {
+				return ecode, err, true
+			}
+		}
 	}
 
 	// do
@@ -1332,7 +1431,11 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 	}
 	if delFromAIS {
 		size := lom.Lsize()
-		aisErr = lom.RemoveObj()
+		if retain := lom.RetainVersions(); retain > 0 && !evict {
+			aisErr = lom.DelCurrent(retain)
+		} else {
+			aisErr = lom.RemoveObj()
+		}
 		if aisErr != nil {
 			if !os.IsNotExist(aisErr) {
 				if backendErr != nil {
@@ -1350,8 +1453,14 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 				cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
 			)
 		}
+		if aisErr == nil {
+			t.quotas.del(lom, size)
+		}
 	}
 	if backendErr != nil {
+		if delFromAIS && lom.IsDirty() && cos.IsNotExist(backendErr, backendErrCode) {
+			return aisErrCode, aisErr, false // never written back
+		}
 		return backendErrCode, backendErr, true
 	}
 	return aisErrCode, aisErr, false
@@ -1368,6 +1477,13 @@ func (t *target) objMv(lom *core.LOM, msg *apc.ActMsg) (err error) {
 	if msg.Name == lom.ObjName {
 		return fmt.Errorf("%s: cannot rename/move object %s onto itself", t.si, lom)
 	}
+	if lom.Bprops().ObjLock.Enabled {
+		if err := lom.Load(true /*cache it*/, false /*locked*/); err == nil {
+			if err := lom.CheckLock(false /*bypass*/); err != nil {
+				return err
+			}
+		}
+	}
 
 	buf, slab := t.gmm.Alloc()
 	coiParams := xs.AllocCOI()
@@ -90,6 +90,12 @@ func TestGetFromArch(t *testing.T) {
 				{
 					ext: archive.ExtTarLz4, nested: true, autodetect: true, mime: true,
 				},
+				{
+					ext: archive.ExtTarZst, nested: true, autodetect: true, mime: false,
+				},
+				{
+					ext: archive.ExtTarZst, nested: false, autodetect: false, mime: true,
+				},
 			}
 		)
 		if testing.Short() {
@@ -268,6 +274,9 @@ func testArch(t *testing.T, bck *meta.Bck) {
 			{
 				ext: archive.ExtTarLz4, list: false,
 			},
+			{
+				ext: archive.ExtTarZst, list: true, apnd: true,
+			},
 		}
 	)
 	if testing.Short() {
@@ -494,6 +503,9 @@ func TestAppendToArch(t *testing.T) {
 			{
 				ext: archive.ExtTarLz4, multi: true,
 			},
+			{
+				ext: archive.ExtTarZst, multi: false,
+			},
 		}
 	)
 	if !testing.Short() { // test-long, and see one other Skip below
@@ -0,0 +1,127 @@
+// Package integration_test.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package integration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools"
+	"github.com/NVIDIA/aistore/tools/readers"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/tools/trand"
+)
+
+func TestGetBatch(t *testing.T) {
+	const (
+		numObjs   = 40
+		shardName = "shard.tar"
+	)
+	var (
+		proxyURL   = tools.RandomProxyURL(t)
+		baseParams = tools.BaseAPIParams(proxyURL)
+	)
+	runProviderTests(t, func(t *testing.T, bck *meta.Bck) {
+		var (
+			contents = make(map[string][]byte, numObjs+2)
+			items    = make([]cmn.GetBatchItem, 0, numObjs+2)
+			shard    bytes.Buffer
+			archived = []byte(trand.String(1000))
+		)
+		// objects
+		for i := range numObjs {
+			objName := fmt.Sprintf("gb/obj-%03d", i)
+			data := []byte(trand.String(100 + i*10))
+			_, err := api.PutObject(&api.PutArgs{
+				BaseParams: baseParams,
+				Bck:        bck.Clone(),
+				ObjName:    objName,
+				Reader:     readers.NewBytes(data),
+				Size:       uint64(len(data)),
+			})
+			tassert.CheckFatal(t, err)
+			contents[objName] = data
+		}
+		// shard
+		aw := archive.NewWriter(archive.ExtTar, &shard, nil, nil)
+		tassert.CheckFatal(t, aw.Write("a/b/c.txt", cos.SimpleOAH{Size: int64(len(archived))}, bytes.NewReader(archived)))
+		aw.Fini()
+		_, err := api.PutObject(&api.PutArgs{
+			BaseParams: baseParams,
+			Bck:        bck.Clone(),
+			ObjName:    shardName,
+			Reader:     readers.NewBytes(shard.Bytes()),
+			Size:       uint64(shard.Len()),
+		})
+		tassert.CheckFatal(t, err)
+		t.Cleanup(func() {
+			for objName := range contents {
+				api.DeleteObject(baseParams, bck.Clone(), objName)
+			}
+			api.DeleteObject(baseParams, bck.Clone(), shardName)
+		})
+
+		// in reverse order, with archived file and a missing object in the middle
+		for i := numObjs - 1; i >= 0; i-- {
+			items = append(items, cmn.GetBatchItem{Bck: bck.Clone(), ObjName: fmt.Sprintf("gb/obj-%03d", i)})
+			if i == numObjs/2 {
+				items = append(items,
+					cmn.GetBatchItem{Bck: bck.Clone(), ObjName: shardName, ArchPath: "a/b/c.txt"},
+					cmn.GetBatchItem{Bck: bck.Clone(), ObjName: "gb/does-not-exist"},
+				)
+			}
+		}
+		contents[shardName+"/a/b/c.txt"] = archived
+
+		for _, onMissing := range []string{apc.GbMissingSkip, apc.GbMissingPlaceholder, apc.GbMissingError} {
+			t.Run(onMissing, func(t *testing.T) {
+				var (
+					out bytes.Buffer
+					msg = &cmn.GetBatchMsg{Items: items, OnMissing: onMissing, OnlyObjName: true}
+				)
+				_, err := api.GetBatch(baseParams, msg, &out)
+				if onMissing == apc.GbMissingError {
+					tassert.Fatalf(t, err != nil, "expecting error (missing object)")
+					return
+				}
+				tassert.CheckFatal(t, err)
+
+				var (
+					tr  = tar.NewReader(&out)
+					cnt int
+				)
+				for i := range items {
+					name := msg.NameInArch(i)
+					data, ok := contents[name]
+					if !ok && onMissing == apc.GbMissingSkip {
+						continue
+					}
+					hdr, err := tr.Next()
+					tassert.CheckFatal(t, err)
+					if !ok {
+						name = apc.GbMissingPrefix + name
+					}
+					tassert.Fatalf(t, hdr.Name == name, "expected %q, got %q (item %d)", name, hdr.Name, i)
+					b, err := io.ReadAll(tr)
+					tassert.CheckFatal(t, err)
+					tassert.Errorf(t, bytes.Equal(b, data), "%s: content mismatch", name)
+					cnt++
+				}
+				_, err = tr.Next()
+				tassert.Errorf(t, err == io.EOF, "expecting EOF, got %v", err)
+				tassert.Errorf(t, cnt >= numObjs+1, "expecting at least %d entries, got %d", numObjs+1, cnt)
+			})
+		}
+	})
+}
@@ -338,6 +338,8 @@ func (t *target) bsumm(w http.ResponseWriter, r *http.Request, phase string, bck
 		} else {
 			w.WriteHeader(http.StatusPartialContent)
 		}
+	} else {
+		t.quotas.seed(xsumm) // (see tgtquota.go)
 	}
 	t.writeJSON(w, r, result, xsumm.Name())
 }
@@ -371,6 +373,10 @@ func (t *target) httpbckdelete(w http.ResponseWriter, r *http.Request, apireq *a
 			return
 		}
 		// arrived via p.destroyBucketData()
+		if err := t.wb.flushBck(apireq.bck, cmn.GCO.Get().Timeout.MaxHostBusy.D()); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
 		var (
 			wg  = &sync.WaitGroup{}
 			nlp = newBckNLP(apireq.bck)
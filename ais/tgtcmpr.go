@@ -0,0 +1,87 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/compr"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// compression at rest (see cmn/compr.go and core/lcmpr.go)
+// NOTE: is called after poi.sseConf() that takes precedence and clears at-rest metadata as needed
+
+// returns compression to apply when writing poi.lom, or nil if none
+// (`size`: plaintext size, if known)
+func (poi *putOI) cmprConf(size int64) *cmn.CompressionConf {
+	lom := poi.lom
+	switch poi.owt {
+	case cmn.OwtRebalance, cmn.OwtGetTryLock, cmn.OwtGetLock, cmn.OwtGet, cmn.OwtGetPrefetchLock:
+		return nil // as is
+	case cmn.OwtCopy, cmn.OwtCopySameBucket:
+		if lom.IsEncoded() {
+			return nil // (see sseConf)
+		}
+	}
+	return cmprKeep(lom, size)
+}
+
+// bucket's compression, if enabled and applicable
+func cmprKeep(lom *core.LOM, size int64) *cmn.CompressionConf {
+	conf := &lom.Bprops().Compression
+	if !conf.Enabled || !lom.Bck().IsAIS() {
+		return nil
+	}
+	if size > 0 && size < int64(conf.MinSize) {
+		return nil
+	}
+	return conf
+}
+
+// compressing variant of poi.write() (see writeAtRest);
+// content that does not compress well enough is written as is (and carries no compression metadata)
+func (poi *putOI) writeCmpr(conf *cmn.CompressionConf) (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, err error) {
+	var (
+		cw      *compr.Writer
+		written int64
+		algo    = conf.AlgoX()
+	)
+	buf, slab, lmfh, written, err = poi.writeAtRest(func(w io.Writer) (atRestW, error) {
+		var errN error
+		cw, errN = compr.NewWriter(w, algo, int64(conf.MinSize), conf.MinSavingsX())
+		return cw, errN
+	})
+	if err == nil && cw.Compressed() {
+		poi.lom.SetCompressed(algo, written)
+	}
+	return
+}
+
+// compress work file that was written by other means (than poi.write) - see encWork
+func (poi *putOI) cmprWork(conf *cmn.CompressionConf) error {
+	return poi.rework(fs.WorkfileCompress, "compress", func() ([]byte, *memsys.Slab, cos.LomWriter, error) {
+		return poi.writeCmpr(conf)
+	})
+}
+
+// (putA2I) compress resulting shard
+func (a *putA2I) compress(conf *cmn.CompressionConf, fqn string) (cmprFQN string, err error) {
+	poi := allocPOI()
+	{
+		poi.t = a.t
+		poi.lom = a.lom
+		poi.workFQN = fqn
+		poi.owt = cmn.OwtArchive
+	}
+	err = poi.cmprWork(conf)
+	cmprFQN = poi.workFQN
+	freePOI(poi)
+	return cmprFQN, err
+}
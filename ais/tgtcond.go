@@ -0,0 +1,103 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+)
+
+// conditional GET, HEAD, and PUT (see cmn/precond.go):
+// - preconditions are evaluated against the in-cluster object;
+// - GET and HEAD: under the object's rlock, right before transmitting (or reporting) the object;
+// - PUT: once upfront (to avoid receiving the content in vain) and then again under wlock -
+//   right before the new content replaces the current one; the latter, however, is done
+//   _after_ writing remote buckets' objects to their respective backends.
+
+// entity tags and last-modified time of a loaded object
+func precondObj(lom *core.LOM) *cmn.PrecondObj {
+	po := &cmn.PrecondObj{Exists: true}
+	if etag, ok := lom.GetCustomKey(cmn.ETag); ok {
+		po.Tags = append(po.Tags, strings.Trim(etag, `"`))
+	}
+	if cksum := lom.Checksum(); !cksum.IsEmpty() {
+		po.Tags = append(po.Tags, cksum.Val())
+	}
+	if finfo, err := os.Stat(lom.FQN); err == nil {
+		po.Mtime = finfo.ModTime()
+	} else {
+		po.Mtime = lom.Atime()
+	}
+	return po
+}
+
+// evaluate (GET and HEAD) preconditions against a loaded object
+func evalPrecondRead(pc *cmn.Preconds, lom *core.LOM) error {
+	po := precondObj(lom)
+	if status := pc.Eval(po, true /*read*/); status != 0 {
+		return cmn.NewErrPrecondition(lom.Cname(), status)
+	}
+	return nil
+}
+
+// native GET and HEAD: ETag is the object's checksum unless provided by remote backend
+// (compare with s3.SetEtag)
+func setETag(hdr http.Header, lom *core.LOM) {
+	if hdr.Get(cos.HdrETag) != "" {
+		return
+	}
+	if cksum := lom.Checksum(); !cksum.IsEmpty() {
+		hdr.Set(cos.HdrETag, `"`+cksum.Val()+`"`)
+	}
+}
+
+// 304 comes with no body
+func writeNotModified(w http.ResponseWriter, lom *core.LOM, isS3 bool) {
+	hdr := w.Header()
+	if isS3 {
+		hdr.Del(cos.HdrETag)
+		if etag, ok := lom.GetCustomKey(cmn.ETag); ok {
+			hdr.Set(cos.HdrETag, etag)
+		} else if cksum := lom.Checksum(); cksum.Type() == cos.ChecksumMD5 {
+			hdr.Set(cos.HdrETag, `"`+cksum.Val()+`"`)
+		}
+	} else {
+		setETag(hdr, lom)
+	}
+	if finfo, err := os.Stat(lom.FQN); err == nil {
+		hdr.Set(cos.HdrLastModified, finfo.ModTime().UTC().Format(http.TimeFormat))
+	}
+	w.WriteHeader(http.StatusNotModified)
+}
+
+func isNotModified(err error) bool {
+	e, ok := err.(*cmn.ErrPrecondition)
+	return ok && e.Status() == http.StatusNotModified
+}
+
+// PUT: evaluate preconditions against the current (in-cluster) version of the object;
+// `locked` when called under wlock
+func (poi *putOI) precond(locked bool) (int, error) {
+	cur := core.AllocLOM(poi.lom.ObjName)
+	defer core.FreeLOM(cur)
+	if err := cur.InitBck(poi.lom.Bucket()); err != nil {
+		return 0, err
+	}
+	po := &cmn.PrecondObj{}
+	if err := cur.Load(false /*cache it*/, locked); err == nil {
+		po = precondObj(cur)
+	} else if !cos.IsNotExist(err, 0) {
+		return 0, err
+	}
+	if status := poi.pc.Eval(po, false /*read*/); status != 0 {
+		return status, cmn.NewErrPrecondition(poi.lom.Cname(), status)
+	}
+	return 0, nil
+}
@@ -187,8 +187,19 @@ func (t *target) daeputMsg(w http.ResponseWriter, r *http.Request) {
 		if !t.ensureIntraControl(w, r, true /* from primary */) {
 			return
 		}
+		// (dirty objects, if any, written in the meantime - see p.flushRmTargets)
+		t.wb.flushNode(msg.Action, cmn.GCO.Get().Timeout.MaxHostBusy.D(), true /*proceed anyway*/)
 		t.statsT.SetFlag(cos.NodeAlerts, cos.MaintenanceMode)
 		t.termKaliveX(msg.Action, true)
+	case apc.ActFlushWriteBack:
+		// pre-phase: refuse to be put in maintenance or decommissioned (msg.Name)
+		// while dirty objects remain
+		if !t.ensureIntraControl(w, r, true /* from primary */) {
+			return
+		}
+		if err := t.wb.flushNode(msg.Name, cmn.GCO.Get().Timeout.MaxHostBusy.D(), false /*skip*/); err != nil {
+			t.writeErr(w, r, err, http.StatusConflict)
+		}
 	case apc.ActShutdownCluster, apc.ActShutdownNode:
 		if !t.ensureIntraControl(w, r, true /* from primary */) {
 			return
@@ -210,6 +221,7 @@ func (t *target) daeputMsg(w http.ResponseWriter, r *http.Request) {
 			t.writeErr(w, r, err)
 			return
 		}
+		t.wb.flushNode(msg.Action, cmn.GCO.Get().Timeout.MaxHostBusy.D(), true /*proceed anyway*/)
 		t.termKaliveX(msg.Action, opts.NoShutdown)
 		t.decommission(msg.Action, &opts)
 	default:
@@ -278,6 +290,8 @@ func (t *target) enableBackend(w http.ResponseWriter, r *http.Request, items []s
 			bp, err = backend.NewGCP(t, t.statsT)
 		case apc.Azure:
 			bp, err = backend.NewAzure(t, t.statsT)
+		case apc.File:
+			bp, err = backend.NewFile(t, config, t.statsT)
 		}
 		if err != nil {
 			debug.AssertNoErr(err) // (unlikely)
@@ -0,0 +1,89 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/dedup"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// content-defined deduplication (see cmn/dedup.go and core/ldedup.go)
+// NOTE: is called after poi.sseConf() and poi.cmprConf()
+// NOTE: dedup chunks are target-local - other targets always send plain content
+// (see coi.send, goi.plain, and reb), which is why rebalance is deduplicated upon arrival
+
+// returns dedup config to apply when writing poi.lom, or nil if none
+// (`size`: plaintext size, if known)
+func (poi *putOI) dedupConf(size int64) *cmn.DedupConf {
+	lom := poi.lom
+	switch poi.owt {
+	case cmn.OwtGetTryLock, cmn.OwtGetLock, cmn.OwtGet, cmn.OwtGetPrefetchLock:
+		return nil // as is
+	case cmn.OwtRebalance, cmn.OwtCopy, cmn.OwtCopySameBucket:
+		if lom.IsEncoded() {
+			return nil // encrypted or compressed content along with its metadata (see sseConf)
+		}
+	}
+	return dedupKeep(lom, size)
+}
+
+// bucket's dedup config, if enabled and applicable
+func dedupKeep(lom *core.LOM, size int64) *cmn.DedupConf {
+	conf := &lom.Bprops().Dedup
+	if !conf.Enabled || !lom.Bck().IsAIS() {
+		return nil
+	}
+	if size > 0 && size < int64(conf.MinSize) {
+		return nil
+	}
+	return conf
+}
+
+// deduplicating variant of poi.write() (see writeAtRest)
+func (poi *putOI) writeDedup(conf *cmn.DedupConf) (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, err error) {
+	var written int64
+	buf, slab, lmfh, written, err = poi.writeAtRest(func(w io.Writer) (atRestW, error) {
+		dw, errN := poi.lom.NewDedupWriter(w, conf)
+		if errN != nil {
+			return nil, errN
+		}
+		return dw, nil
+	})
+	if err == nil {
+		poi.lom.SetDeduped(written)
+	}
+	return
+}
+
+// interface guard
+var _ atRestW = (*dedup.Writer)(nil)
+
+// deduplicate work file that was written by other means (than poi.write) - see encWork
+func (poi *putOI) dedupWork(conf *cmn.DedupConf) error {
+	return poi.rework(fs.WorkfileDedup, "dedup", func() ([]byte, *memsys.Slab, cos.LomWriter, error) {
+		return poi.writeDedup(conf)
+	})
+}
+
+// (putA2I) deduplicate resulting shard
+func (a *putA2I) dedup(conf *cmn.DedupConf, fqn string) (ddFQN string, err error) {
+	poi := allocPOI()
+	{
+		poi.t = a.t
+		poi.lom = a.lom
+		poi.workFQN = fqn
+		poi.owt = cmn.OwtArchive
+	}
+	err = poi.dedupWork(conf)
+	ddFQN = poi.workFQN
+	freePOI(poi)
+	return ddFQN, err
+}
@@ -0,0 +1,271 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+// bucket event notifications (see cmn/events.go):
+// - events get generated upon object creation and removal (see t.ObjEvent callers);
+// - each event is delivered to the webhooks of all matching rules by a small pool of workers,
+//   with a few immediate retries;
+// - events that cannot be delivered (or queued) are stored in the target's kvdb ("backlog"),
+//   to be redelivered periodically (in order) and across restarts - until delivered or expired.
+
+const (
+	evCollection   = "bucket-events" // kvdb collection
+	evQueueSize    = 4096            // in-memory queue
+	evWorkers      = 4
+	evRetries      = 3           // immediate delivery attempts
+	evBackoff      = time.Second // initial backoff between immediate retries
+	evTimeout      = 10 * time.Second
+	evRedeliverIvl = 30 * time.Second
+	evMaxAge       = 24 * time.Hour // undelivered events older than that get dropped
+)
+
+type (
+	// queued event and its destination
+	evmsg struct {
+		URL    string          `json:"url"`
+		Record cmn.EventRecord `json:"record"`
+		Queued int64           `json:"queued,string"` // unix nanoseconds
+	}
+	evdisp struct {
+		t            *target
+		db           kvdb.Driver
+		client       *http.Client
+		workCh       chan *evmsg
+		stopCh       cos.StopCh
+		wg           sync.WaitGroup
+		seq          atomic.Uint64
+		redelivering atomic.Bool
+	}
+)
+
+// t.ObjEvent implements core.Target
+func (t *target) ObjEvent(lom *core.LOM, event string) {
+	conf := &lom.Bprops().Events
+	if !conf.IsSet() || t.events.db == nil {
+		return
+	}
+	rules := conf.Match(event, lom.ObjName)
+	if len(rules) == 0 {
+		return
+	}
+	var (
+		now = time.Now()
+		bck = lom.Bucket()
+		rec = cmn.EventRecord{
+			EventVersion: cmn.EvMsgVersion,
+			EventSource:  cmn.EvSource,
+			EventTime:    now.UTC().Format(time.RFC3339Nano),
+			EventName:    event,
+			Node:         t.SID(),
+			S3: cmn.EventS3{
+				Bucket: cmn.EventBucket{Name: bck.Name, Provider: bck.Provider},
+				Object: cmn.EventObject{
+					Key:       lom.ObjName,
+					VersionID: lom.Version(true /*special*/),
+					Sequencer: t.events.sequencer(now),
+					Size:      lom.Lsize(true /*not loaded*/),
+				},
+			},
+		}
+	)
+	if !bck.Ns.IsGlobal() {
+		rec.S3.Bucket.Ns = bck.Ns.Uname()
+	}
+	if cksum := lom.Checksum(); cksum != nil && cksum.Ty() != cos.ChecksumNone {
+		rec.S3.Object.ETag = cksum.Val()
+	}
+	for _, rule := range rules {
+		msg := &evmsg{URL: rule.URL, Record: rec, Queued: now.UnixNano()}
+		msg.Record.S3.ConfigurationID = rule.ID
+		t.events.post(msg)
+	}
+}
+
+////////////
+// evdisp //
+////////////
+
+func (d *evdisp) init(t *target, db kvdb.Driver) {
+	d.t = t
+	d.db = db
+	d.client = cmn.NewClient(cmn.TransportArgs{Timeout: evTimeout, UseHTTPProxyEnv: true})
+	d.workCh = make(chan *evmsg, evQueueSize)
+	d.stopCh.Init()
+	for range evWorkers {
+		d.wg.Add(1)
+		go d.work()
+	}
+	hk.Reg(evCollection+hk.NameSuffix, d.housekeep, evRedeliverIvl)
+}
+
+// stop workers and save in-memory queue to the backlog
+func (d *evdisp) stop() {
+	if d.db == nil {
+		return
+	}
+	d.stopCh.Close()
+	d.wg.Wait()
+	for {
+		select {
+		case msg := <-d.workCh:
+			d.persist(msg)
+		default:
+			return
+		}
+	}
+}
+
+// sortable and unique (within this target)
+func (d *evdisp) sequencer(now time.Time) string {
+	return fmt.Sprintf("%016X%08X", now.UnixNano(), uint32(d.seq.Inc()))
+}
+
+func (d *evdisp) post(msg *evmsg) {
+	select {
+	case d.workCh <- msg:
+	default:
+		d.persist(msg) // queue full
+	}
+}
+
+func (d *evdisp) work() {
+	defer d.wg.Done()
+	for {
+		select {
+		case msg := <-d.workCh:
+			if err := d.deliverRetry(msg); err != nil {
+				nlog.Warningln(d.t.String(), "failed to deliver", msg.Record.EventName, "to", msg.URL, "-", err, "- queuing")
+				d.persist(msg)
+			}
+		case <-d.stopCh.Listen():
+			return
+		}
+	}
+}
+
+func (d *evdisp) deliverRetry(msg *evmsg) (err error) {
+	sleep := evBackoff
+	for i := range evRetries {
+		if err = d.deliver(msg); err == nil {
+			return nil
+		}
+		if i < evRetries-1 {
+			select {
+			case <-time.After(sleep):
+				sleep *= 2
+			case <-d.stopCh.Listen():
+				return err
+			}
+		}
+	}
+	return err
+}
+
+func (d *evdisp) deliver(msg *evmsg) error {
+	body := cos.MustMarshal(&cmn.EventMsg{Records: []cmn.EventRecord{msg.Record}})
+	req, err := http.NewRequest(http.MethodPost, msg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(cos.HdrContentType, cos.ContentJSON)
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+//
+// backlog
+//
+
+func (d *evdisp) persist(msg *evmsg) {
+	key := msg.Record.S3.Object.Sequencer + "-" + strconv.Itoa(int(d.seq.Inc()))
+	if err := d.db.Set(evCollection, key, msg); err != nil {
+		nlog.Errorln(d.t.String(), "failed to queue", msg.Record.EventName, "event:", err)
+	}
+}
+
+func (d *evdisp) del(key string) {
+	if err := d.db.Delete(evCollection, key); err != nil {
+		nlog.Errorln(d.t.String(), "failed to remove queued event:", err)
+	}
+}
+
+func (d *evdisp) housekeep(int64) time.Duration {
+	if d.redelivering.CAS(false, true) {
+		go d.redeliver()
+	}
+	return evRedeliverIvl
+}
+
+// redeliver backlog in order; skip destinations that fail (until next time)
+func (d *evdisp) redeliver() {
+	defer d.redelivering.Store(false)
+	keys, err := d.db.List(evCollection, "")
+	if err != nil || len(keys) == 0 {
+		return
+	}
+	sort.Strings(keys)
+	var (
+		failed  = make(map[string]struct{}, 2)
+		expired int
+		cutoff  = time.Now().Add(-evMaxAge).UnixNano()
+	)
+	for _, key := range keys {
+		select {
+		case <-d.stopCh.Listen():
+			return
+		default:
+		}
+		msg := &evmsg{}
+		if err := d.db.Get(evCollection, key, msg); err != nil {
+			continue
+		}
+		if msg.Queued < cutoff {
+			expired++
+			d.del(key)
+			continue
+		}
+		if _, ok := failed[msg.URL]; ok {
+			continue
+		}
+		if err := d.deliver(msg); err != nil {
+			failed[msg.URL] = struct{}{}
+			continue
+		}
+		d.del(key)
+	}
+	if expired > 0 {
+		nlog.Warningln(d.t.String(), "dropped", expired, "undelivered events older than", evMaxAge)
+	}
+	if len(failed) > 0 && cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infoln(d.t.String(), "event webhooks unavailable:", len(failed))
+	}
+}
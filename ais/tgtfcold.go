@@ -6,6 +6,7 @@ package ais
 
 import (
 	"io"
+	"net/http"
 
 	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/cmn"
@@ -96,9 +97,10 @@ func (goi *getOI) coldReopen(res *core.GetReaderResult) error {
 		return err
 	}
 	var (
-		hrng   *htrange
+		rngs   []htrange
 		size             = lom.Lsize()
 		reader io.Reader = lmfh
+		ctype            = cos.ContentBinary
 		whdr             = goi.w.Header()
 	)
 	if goi.ranges.Range != "" {
@@ -107,19 +109,30 @@ func (goi *getOI) coldReopen(res *core.GetReaderResult) error {
 		if goi.ranges.Size > 0 {
 			rsize = goi.ranges.Size
 		}
-		if hrng, _, err = goi.rngToHeader(whdr, rsize); err != nil {
+		if rngs, _, err = goi.rngToHeader(whdr, rsize); err != nil {
 			goi._cleanup(revert, lmfh, buf, slab, err, "(seek)")
 			return err
 		}
-		size = hrng.Length
-		reader = io.NewSectionReader(lmfh, hrng.Start, hrng.Length)
+		switch len(rngs) {
+		case 0:
+		case 1:
+			size = rngs[0].Length
+			reader = io.NewSectionReader(lmfh, rngs[0].Start, rngs[0].Length)
+		default:
+			reader, size, ctype = mpByteranges(rngs, rsize, lmfh)
+		}
 	}
 
-	whdr.Set(cos.HdrContentType, cos.ContentBinary)
-	cmn.ToHeader(lom.ObjAttrs(), whdr, size)
-	if goi.dpq.isS3 {
-		// (expecting user to set bucket checksum = md5)
-		s3.SetEtag(whdr, goi.lom)
+	whdr.Set(cos.HdrContentType, ctype)
+	if len(rngs) > 1 {
+		cmn.ToHeader(lom.ObjAttrs(), whdr, size, cos.NoneCksum)
+		goi.w.WriteHeader(http.StatusPartialContent)
+	} else {
+		cmn.ToHeader(lom.ObjAttrs(), whdr, size)
+		if goi.dpq.isS3 {
+			// (expecting user to set bucket checksum = md5)
+			s3.SetEtag(whdr, goi.lom)
+		}
 	}
 
 	written, err = cos.CopyBuffer(goi.w, reader, buf)
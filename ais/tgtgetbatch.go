@@ -0,0 +1,84 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+// multi-object GET (see xact/xs/getbatch.go)
+// - POST /v1/gb?uuid=... (begin; intra-cluster)
+// - GET  /v1/gb?uuid=... (redirected by proxy)
+func (t *target) gbHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := t.parseURL(w, r, apc.URLPathGB.L, 0, false); err != nil {
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		t.httpgbget(w, r)
+	case http.MethodPost:
+		t.httpgbpost(w, r)
+	default:
+		cmn.WriteErr405(w, r, http.MethodGet, http.MethodPost)
+	}
+}
+
+func (t *target) httpgbpost(w http.ResponseWriter, r *http.Request) {
+	if err := t.checkIntraCall(r.Header, false); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	xid := r.URL.Query().Get(apc.QparamUUID)
+	if xid == "" {
+		t.writeErrMsg(w, r, "get-batch: missing "+apc.QparamUUID)
+		return
+	}
+	msg := &cmn.GetBatchMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	rns := xreg.RenewGetBatch(xid, msg)
+	if rns.Err != nil {
+		t.writeErr(w, r, rns.Err)
+	}
+}
+
+func (t *target) httpgbget(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if isRedirect(query) == "" && t.checkIntraCall(r.Header, false) != nil {
+		t.writeErrf(w, r, "%s: get-batch is expected to be redirected (remaddr=%s)", t.si, r.RemoteAddr)
+		return
+	}
+	xid := query.Get(apc.QparamUUID)
+	xctn, err := xreg.GetXact(xid)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	r.Body.Close() // (the list is already known)
+	xgb, ok := xctn.(*xs.XactGetBatch)
+	if !ok || xgb == nil || xgb.Finished() {
+		t.writeErr(w, r, cos.NewErrNotFound(t, "get-batch["+xid+"]"), http.StatusNotFound)
+		return
+	}
+	w.Header().Set(cos.HdrContentType, xgb.ContentType())
+	n, err := xgb.Serve(w)
+	if err == nil {
+		return
+	}
+	if n == 0 {
+		w.Header().Del(cos.HdrContentType)
+		t.writeErr(w, r, err)
+		return
+	}
+	nlog.Errorln(t.String(), xgb.Name(), "failed after writing", n, "bytes:", err)
+}
@@ -81,7 +81,8 @@ func (t *target) PutObject(lom *core.LOM, params *core.PutParams) error {
 	}
 	_, err := poi.putObject()
 	freePOI(poi)
-	debug.Assert(err != nil || params.Size <= 0 || params.Size == lom.Lsize(true), lom.String(), params.Size, lom.Lsize(true))
+	debug.Assert(err != nil || params.Size <= 0 || params.Size == lom.Lsize(true) || lom.IsEncoded(),
+		lom.String(), params.Size, lom.Lsize(true))
 	return err
 }
 
@@ -269,6 +270,13 @@ func (t *target) _promLocal(params *core.PromoteParams, lom *core.LOM) (fileSize
 	if err = lom.Load(true /*cache it*/, false /*locked*/); err == nil && !params.OverwriteDst {
 		return
 	}
+	// quota: check before (possibly) using the source itself as work file (compare with poi.fini)
+	if fi, errV := os.Stat(params.SrcFQN); errV == nil {
+		var qd quotaDelta
+		if ecode, err = t.quota(&qd, lom, cmn.OwtPromote, nil /*user quota*/, fi.Size(), false /*locked*/); err != nil {
+			return
+		}
+	}
 	if params.DeleteSrc {
 		// To use `params.SrcFQN` as `workFQN`, make sure both are
 		// located on the same filesystem. About "filesystem sharing" see also:
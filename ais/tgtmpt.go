@@ -0,0 +1,371 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/cmn/sse"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Multipart upload: native API (api.CreateMptUpload et al.) and the part-handling
+// shared with S3 (see tgts3mpt.go):
+// - upload state is kept in memory (ais/s3/mpt.go), by upload ID;
+// - each part is written into its own workfile: <upload-id>.<part-number>.<obj-name>;
+// - completion concatenates the parts and finalizes the resulting object.
+// All requests of a given upload get redirected (by object name) to the same target.
+
+// mptCopyCB copies part's content into a given writer and returns the number of bytes copied
+// along with remote ETag, if any
+type mptCopyCB func(w io.Writer) (size int64, etag string, ecode int, err error)
+
+func validatePartNum(uploadID, part string) (int32, error) {
+	if part == "" {
+		return 0, fmt.Errorf("upload %q: missing part number", uploadID)
+	}
+	partNum, err := s3.ParsePartNum(part)
+	if err != nil {
+		return 0, err
+	}
+	if partNum < 1 || partNum > s3.MaxPartsPerUpload {
+		return 0, fmt.Errorf("upload %q: invalid part number %d, must be between 1 and %d",
+			uploadID, partNum, s3.MaxPartsPerUpload)
+	}
+	return partNum, nil
+}
+
+// write upload part into its workfile and add it to the upload;
+// - compute part's MD5 unless provided by remote backend (as ETag);
+// - optionally, validate part's SHA256 (S3)
+func (t *target) writeMptPart(lom *core.LOM, uploadID string, partNum int32, ssec []byte, partSHA string,
+	cp mptCopyCB) (npart *s3.MptPart, ecode int, err error) {
+	var (
+		etag         string
+		size         int64
+		ew           *sse.Writer
+		params       *sse.Params
+		checkPartSHA = partSHA != "" && partSHA != cos.S3UnsignedPayload
+		cksumSHA     = &cos.CksumHash{}
+		cksumMD5     = &cos.CksumHash{}
+		remote       = lom.Bck().IsRemoteS3()
+	)
+	// workfile name format: <upload-id>.<part-number>.<obj-name>
+	prefix := uploadID + "." + strconv.FormatInt(int64(partNum), 10)
+	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, prefix)
+	partFh, errC := lom.CreatePart(wfqn)
+	if errC != nil {
+		return nil, 0, errC
+	}
+	var partW io.Writer = partFh
+
+	if checkPartSHA {
+		cksumSHA = cos.NewCksumHash(cos.ChecksumSHA256)
+	}
+	if ssec != nil {
+		// SSE-C: not keeping plaintext parts either
+		if params, err = sse.NewCustomerParams(ssec); err == nil {
+			ew, err = sse.NewWriter(partFh, params)
+		}
+		if err != nil {
+			cos.Close(partFh)
+			cos.RemoveFile(wfqn)
+			return nil, 0, err
+		}
+		partW = ew
+	}
+
+	if !remote {
+		cksumMD5 = cos.NewCksumHash(cos.ChecksumMD5)
+	}
+
+	// write
+	mw := multiWriter(cksumMD5.H, cksumSHA.H, partW)
+	size, etag, ecode, err = cp(mw)
+	if ew != nil && err == nil {
+		err = ew.Close()
+	}
+	cos.Close(partFh)
+	if err != nil {
+		if nerr := cos.RemoveFile(wfqn); nerr != nil && !os.IsNotExist(nerr) {
+			nlog.Errorf(fmtNested, t, err, "remove", wfqn, nerr)
+		}
+		return nil, ecode, err
+	}
+
+	// finalize the part (expecting the part's remote etag to be md5 checksum)
+	md5 := etag
+	if cksumMD5.H != nil {
+		debug.Assert(etag == "")
+		cksumMD5.Finalize()
+		md5 = cksumMD5.Value()
+	}
+	if checkPartSHA {
+		cksumSHA.Finalize()
+		recvSHA := cos.NewCksum(cos.ChecksumSHA256, partSHA)
+		if !cksumSHA.Equal(recvSHA) {
+			detail := fmt.Sprintf("upload %q, %s, part %d", uploadID, lom, partNum)
+			return nil, http.StatusInternalServerError, cos.NewErrDataCksum(&cksumSHA.Cksum, recvSHA, detail)
+		}
+	}
+	npart = &s3.MptPart{
+		MD5:  md5,
+		FQN:  wfqn,
+		Size: size,
+		Num:  partNum,
+	}
+	if params != nil {
+		npart.Nonce = string(params.Nonce)
+	}
+	err = s3.AddPart(uploadID, npart)
+	return npart, 0, err
+}
+
+// mptComplete concatenates uploaded parts and finalizes the resulting object
+// - cksumType: checksum to compute over the (stored) content
+// - etag: remote ETag, if already completed remotely (S3); otherwise, computed here
+// upload's state and parts are removed regardless of the outcome
+func (t *target) mptComplete(lom *core.LOM, uploadID string, parts []types.CompletedPart, ssec []byte,
+	cksumType, etag string, owt cmn.OWT) (_ string, ecode int, _ error) {
+	size, err := s3.ObjSize(uploadID)
+	if err != nil {
+		return "", 0, err
+	}
+	started := time.Now()
+
+	// 1. sort and check parts
+	sort.Slice(parts, func(i, j int) bool {
+		return *parts[i].PartNumber < *parts[j].PartNumber
+	})
+	nparts, err := s3.CheckParts(uploadID, parts)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// 2. <upload-id>.complete.<obj-name>
+	var (
+		mw          io.Writer
+		ew          *sse.Writer
+		actualCksum = cos.NewCksumHash(cksumType)
+	)
+	prefix := uploadID + ".complete"
+	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, prefix)
+	wfh, errC := lom.CreateWork(wfqn)
+	if errC != nil {
+		return "", 0, errC
+	}
+	mw = multiWriter(actualCksum.H, wfh)
+	if ssec != nil {
+		// SSE-C: decrypt parts and encrypt the result (the checksum is computed over the latter)
+		params, err := lom.InitSSEC(ssec)
+		if err == nil {
+			ew, err = sse.NewWriter(mw, params)
+		}
+		if err != nil {
+			cos.Close(wfh)
+			cos.RemoveFile(wfqn)
+			return "", 0, err
+		}
+		mw = ew
+	}
+
+	// 3. write
+	buf, slab := t.gmm.Alloc()
+	concatMD5, written, errA := _appendMpt(nparts, buf, mw, ssec)
+	slab.Free(buf)
+	if ew != nil && errA == nil {
+		errA = ew.Close()
+	}
+
+	if lom.IsFeatureSet(feat.FsyncPUT) {
+		errS := wfh.Sync()
+		debug.AssertNoErr(errS)
+	}
+	cos.Close(wfh)
+
+	if errA == nil && written != size {
+		errA = fmt.Errorf("upload %q %q: expected full size=%d, got %d", uploadID, lom.Cname(), size, written)
+	}
+	if errA != nil {
+		if nerr := cos.RemoveFile(wfqn); nerr != nil && !os.IsNotExist(nerr) {
+			nlog.Errorf(fmtNested, t, errA, "remove", wfqn, nerr)
+		}
+		return "", 0, errA
+	}
+
+	// 4. compute resulting checksum and, optionally, ETag
+	if cksumType != cos.ChecksumNone {
+		actualCksum.Finalize()
+		lom.SetCksum(actualCksum.Cksum.Clone())
+	} else {
+		lom.SetCksum(cos.NoneCksum)
+	}
+	if etag == "" {
+		debug.Assert(concatMD5 != "")
+		resMD5 := cos.NewCksumHash(cos.ChecksumMD5)
+		_, err = resMD5.H.Write([]byte(concatMD5))
+		debug.AssertNoErr(err)
+		resMD5.Finalize()
+		etag = `"` + resMD5.Value() + cmn.AwsMultipartDelim + strconv.Itoa(len(parts)) + `"`
+	}
+
+	// 5. finalize
+	lom.SetSize(size)
+	if ew != nil {
+		lom.SetSize(ew.Size())
+		lom.SetCustomKey(cmn.SSESizeObjMD, strconv.FormatInt(size, 10))
+	}
+	lom.SetCustomKey(cmn.ETag, etag)
+
+	poi := allocPOI()
+	{
+		poi.t = t
+		poi.atime = started.UnixNano()
+		poi.lom = lom
+		poi.workFQN = wfqn
+		poi.owt = owt
+		poi.sseDone = ew != nil
+	}
+	ecode, err = poi.finalize()
+	freePOI(poi)
+
+	// 6. cleanup parts - unconditionally
+	exists := s3.CleanupUpload(uploadID, lom.FQN, false /*aborted*/)
+	debug.Assert(exists)
+
+	return etag, ecode, err
+}
+
+func _appendMpt(nparts []*s3.MptPart, buf []byte, mw io.Writer, ssec []byte) (concatMD5 string, written int64, err error) {
+	for _, partInfo := range nparts {
+		var (
+			partFh   *os.File
+			partR    io.Reader
+			partSize int64
+		)
+		concatMD5 += partInfo.MD5
+		if partFh, err = os.Open(partInfo.FQN); err != nil {
+			return "", 0, err
+		}
+		partR = partFh
+		if partInfo.Nonce != "" {
+			params := &sse.Params{Key: ssec, Nonce: []byte(partInfo.Nonce)}
+			if partR, err = sse.NewReader(partFh, params, partInfo.Size); err != nil {
+				cos.Close(partFh)
+				return "", 0, err
+			}
+		}
+		partSize, err = io.CopyBuffer(mw, partR, buf)
+		cos.Close(partFh)
+		if err != nil {
+			return "", 0, err
+		}
+		written += partSize
+	}
+	return concatMD5, written, nil
+}
+
+//
+// native API
+//
+
+// POST /v1/objects/bucket-name/object-name {action: apc.ActMptCreate | apc.ActMptComplete | apc.ActMptAbort}
+// (upload ID: msg.Name)
+func (t *target) mptNative(w http.ResponseWriter, lom *core.LOM, msg *apc.ActMsg) (int, error) {
+	switch msg.Action {
+	case apc.ActMptCreate:
+		uploadID := cos.GenUUID()
+		s3.InitUpload(uploadID, lom.Bck().Name, lom.ObjName, nil)
+		writeXid(w, uploadID)
+	case apc.ActMptComplete:
+		return t.completeMptNative(lom, msg)
+	case apc.ActMptAbort:
+		if !s3.CleanupUpload(msg.Name, "", true /*aborted*/) {
+			return http.StatusNotFound, cos.NewErrNotFound(t, "upload "+msg.Name)
+		}
+	default:
+		debug.Assert(false, msg.Action)
+	}
+	return 0, nil
+}
+
+func (t *target) completeMptNative(lom *core.LOM, msg *apc.ActMsg) (int, error) {
+	var (
+		cmsg     apc.MptCompleteMsg
+		parts    []types.CompletedPart
+		uploadID = msg.Name
+	)
+	if err := cos.MorphMarshal(msg.Value, &cmsg); err != nil {
+		return 0, fmt.Errorf(cmn.FmtErrMorphUnmarshal, t, msg.Action, msg.Value, err)
+	}
+	if len(cmsg.Parts) == 0 {
+		return 0, fmt.Errorf("upload %q: empty list of upload parts", uploadID)
+	}
+	for _, part := range cmsg.Parts {
+		if part.ETag != "" {
+			if err := s3.CheckPartMD5(uploadID, part.Num, part.ETag); err != nil {
+				return 0, err
+			}
+		}
+		parts = append(parts, types.CompletedPart{PartNumber: apc.Ptr(part.Num)})
+	}
+	if err := s3.CheckUploadSSEC(uploadID, nil); err != nil {
+		return 0, err
+	}
+	// (remote bucket: PUT the resulting object as a whole)
+	_, ecode, err := t.mptComplete(lom, uploadID, parts, nil, lom.CksumType(), "", cmn.OwtPut)
+	if err == nil {
+		t.statsT.Inc(stats.PutCount)
+	}
+	return ecode, err
+}
+
+// PUT /v1/objects/bucket-name/object-name?mpt_upload_id=...&mpt_part_num=...
+func (t *target) putMptPartNative(w http.ResponseWriter, r *http.Request, lom *core.LOM, dpq *dpq) (int, error) {
+	started := mono.NanoTime()
+	partNum, err := validatePartNum(dpq.mpt.id, dpq.mpt.part)
+	if err != nil {
+		return 0, err
+	}
+	if err := s3.CheckUploadSSEC(dpq.mpt.id, nil); err != nil {
+		return 0, err
+	}
+	cp := func(w io.Writer) (int64, string, int, error) {
+		buf, slab := t.gmm.Alloc()
+		size, err := io.CopyBuffer(w, r.Body, buf)
+		slab.Free(buf)
+		return size, "", 0, err
+	}
+	npart, ecode, err := t.writeMptPart(lom, dpq.mpt.id, partNum, nil, "", cp)
+	if err != nil {
+		return ecode, err
+	}
+	w.Header().Set(cos.HdrETag, npart.MD5)
+
+	delta := mono.SinceNano(started)
+	t.statsT.AddMany(
+		cos.NamedVal64{Name: stats.PutSize, Value: npart.Size},
+		cos.NamedVal64{Name: stats.PutLatency, Value: delta},
+		cos.NamedVal64{Name: stats.PutLatencyTotal, Value: delta},
+	)
+	return 0, nil
+}
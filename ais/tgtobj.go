@@ -67,6 +67,14 @@ type (
 		t2t        bool          // by another target
 		skipEC     bool          // do not erasure-encode when finalizing
 		skipVC     bool          // skip loading existing Version and skip comparing Checksums (skip VC)
+		sse        *cmn.SSEConf  // encryption requested by (S3) client (see cmn/sse.go)
+		ssec       []byte        // customer-provided key (S3 SSE-C)
+		pc         *cmn.Preconds // conditional PUT (If-Match et al.)
+		uq         *userQuota    // AuthN user's quota (see tgtquota.go)
+		qd         quotaDelta    // usage delta to apply upon success
+		ur         *userRate     // AuthN user's bandwidth limit (see ratelim.go)
+		bypassGov  bool          // bypass governance-mode retention when overwriting (see cmn/objlock.go)
+		sseDone    bool          // encrypted or compressed (or neither) when writing
 		coldGET    bool          // (one implication: proceed to write)
 		remoteErr  bool          // to exclude `putRemote` errors when counting soft IO errors
 	}
@@ -78,18 +86,20 @@ type (
 		t          *target         // this
 		lom        *core.LOM       // obj
 		dpq        *dpq
-		ranges     byteRanges // range read (see https://www.rfc-editor.org/rfc/rfc7233#section-2.1)
-		atime      int64      // access time.Now()
-		ltime      int64      // mono.NanoTime, to measure latency
-		rstarttime int64      // mono.NanoTime, mark start of remote GET to measure latency
-		rltime     int64      // mono.NanoTime, to measure remote bucket latency
-		chunked    bool       // chunked transfer (en)coding: https://tools.ietf.org/html/rfc7230#page-36
-		unlocked   bool       // internal
-		verchanged bool       // version changed
-		retry      bool       // once
-		cold       bool       // true if executed backend.Get
-		latestVer  bool       // QparamLatestVer || 'versioning.*_warm_get'
-		isIOErr    bool       // to count GET error as a "IO error"; see `Trunner._softErrs()`
+		ranges     byteRanges    // range read (see https://www.rfc-editor.org/rfc/rfc7233#section-2.1)
+		ssec       []byte        // customer-provided key (S3 SSE-C)
+		pc         *cmn.Preconds // conditional GET (If-None-Match et al.)
+		atime      int64         // access time.Now()
+		ltime      int64         // mono.NanoTime, to measure latency
+		rstarttime int64         // mono.NanoTime, mark start of remote GET to measure latency
+		rltime     int64         // mono.NanoTime, to measure remote bucket latency
+		chunked    bool          // chunked transfer (en)coding: https://tools.ietf.org/html/rfc7230#page-36
+		unlocked   bool          // internal
+		verchanged bool          // version changed
+		retry      bool          // once
+		cold       bool          // true if executed backend.Get
+		latestVer  bool          // QparamLatestVer || 'versioning.*_warm_get'
+		isIOErr    bool          // to count GET error as a "IO error"; see `Trunner._softErrs()`
 	}
 	_uplock struct {
 		config  *cmn.Config
@@ -137,6 +147,8 @@ type (
 		mime     string        // format
 		started  int64         // time of receiving
 		size     int64         // aka Content-Length
+		uq       *userQuota    // AuthN user's quota (see tgtquota.go)
+		qd       quotaDelta    // usage delta to apply upon success
 		put      bool          // overwrite
 	}
 )
@@ -152,9 +164,20 @@ func (poi *putOI) do(resphdr http.Header, r *http.Request, dpq *dpq) (int, error
 		poi.r = r.Body
 		poi.resphdr = resphdr
 		poi.workFQN = fs.CSM.Gen(poi.lom, fs.WorkfileType, fs.WorkfilePut)
+		poi.lom.ClearAtRest() // (previous version)
 		poi.cksumToUse = poi.lom.ObjAttrs().FromHeader(r.Header)
 		poi.owt = cmn.OwtPut // default
 	}
+	if !poi.t2t {
+		poi.lom.ClearAtRest() // not accepting SSE metadata from clients
+		poi.pc = cmn.ParsePreconds(r.Header)
+		if dpq.uquota != "" {
+			poi.uq = parseUserQuota(dpq.uquota)
+		}
+		if dpq.urate != "" {
+			poi.ur = parseUserRate(dpq.urate)
+		}
+	}
 	if dpq.owt != "" {
 		poi.owt.FromS(dpq.owt)
 	}
@@ -179,6 +202,19 @@ func (poi *putOI) do(resphdr http.Header, r *http.Request, dpq *dpq) (int, error
 
 func (poi *putOI) putObject() (ecode int, err error) {
 	poi.ltime = mono.NanoTime()
+	if poi.pc != nil {
+		if ecode, err = poi.precond(false /*locked*/); err != nil {
+			cos.DrainReader(poi.r)
+			return ecode, err
+		}
+	}
+	// quota: check upfront (to avoid receiving the content in vain) and then again when finalizing
+	if poi.restful && !poi.t2t {
+		if ecode, err = poi.t.quota(&poi.qd, poi.lom, poi.owt, poi.uq, poi.size, false /*locked*/); err != nil {
+			cos.DrainReader(poi.r)
+			return ecode, err
+		}
+	}
 	// PUT is a no-op if the checksums do match
 	if !poi.skipVC && !poi.coldGET && !poi.cksumToUse.IsEmpty() {
 		if poi.lom.EqCksum(poi.cksumToUse) {
@@ -190,6 +226,10 @@ func (poi *putOI) putObject() (ecode int, err error) {
 		}
 	}
 
+	// bandwidth limits (not throttling the drains above)
+	if poi.restful && !poi.t2t {
+		poi.r = poi.t.throttleReadCloser(poi.r, poi.lom.Bck(), poi.ur)
+	}
 	buf, slab, lmfh, erw := poi.write()
 	poi._cleanup(buf, slab, lmfh, erw)
 	if erw != nil {
@@ -226,7 +266,8 @@ rerr:
 	if poi.owt == cmn.OwtPut && poi.restful && !poi.t2t {
 		poi.t.statsT.IncErr(stats.ErrPutCount)
 		if err != cmn.ErrSkip && !poi.remoteErr && err != io.ErrUnexpectedEOF &&
-			!cos.IsRetriableConnErr(err) && !cos.IsErrMvToVirtDir(err) {
+			!cos.IsRetriableConnErr(err) && !cos.IsErrMvToVirtDir(err) && !cmn.IsErrPrecondition(err) &&
+			!cmn.IsErrQuotaExceeded(err) {
 			poi.t.statsT.IncErr(stats.IOErrPutCount)
 			if cmn.Rom.FastV(4, cos.SmoduleAIS) {
 				nlog.Warningln("io-error [", err, "]", poi.loghdr())
@@ -323,8 +364,40 @@ func (poi *putOI) fini() (ecode int, err error) {
 		lom = poi.lom
 		bck = lom.Bck()
 	)
-	// put remote
-	if bck.IsRemote() && poi.owt < cmn.OwtRebalance {
+	// encrypt, compress, or deduplicate at rest (content that was not written via poi.write)
+	if !poi.sseDone {
+		if conf := poi.sseConf(); conf != nil {
+			if err = poi.encWork(conf); err != nil {
+				return 0, err
+			}
+		} else if conf := poi.cmprConf(lom.Lsize()); conf != nil {
+			if err = poi.cmprWork(conf); err != nil {
+				return 0, err
+			}
+		} else if conf := poi.dedupConf(lom.Lsize()); conf != nil {
+			if err = poi.dedupWork(conf); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	// put remote (or write back later - see tgtwback.go)
+	var dirty bool
+	if poi.owt < cmn.OwtRebalance {
+		lom.DelCustomKey(cmn.DirtyObjMD) // (e.g., copied from a dirty source)
+	}
+	switch {
+	case bck.IsRemote() && poi.owt < cmn.OwtRebalance && lom.IsWriteBack():
+		if poi.owt == cmn.OwtPut {
+			lom.ObjAttrs().DelStdCustom()
+		}
+		markDirty(lom)
+		dirty = true
+	case bck.IsRemote() && poi.owt < cmn.OwtRebalance:
+		// quota: check prior to writing remotely (and re-evaluate under wlock - see below)
+		if ecode, err = poi.t.quota(&poi.qd, lom, poi.owt, poi.uq, lom.Lsize(), false /*locked*/); err != nil {
+			return ecode, err
+		}
 		ecode, err = poi.putRemote()
 		if err != nil {
 			loghdr := poi.loghdr()
@@ -341,6 +414,8 @@ func (poi *putOI) fini() (ecode int, err error) {
 			}
 			nlog.Infof("PUT (%s): retried OK", loghdr)
 		}
+	case poi.owt == cmn.OwtRebalance:
+		dirty = lom.IsDirty() // migrated along with its "dirty" marker
 	}
 
 	// locking strategies: optimistic and otherwise
@@ -361,10 +436,41 @@ func (poi *putOI) fini() (ecode int, err error) {
 		lom.SetAtimeUnix(poi.atime)
 	}
 
+	// conditional PUT: re-evaluate under wlock
+	if poi.pc != nil {
+		if ecode, err = poi.precond(true /*locked*/); err != nil {
+			return ecode, err
+		}
+	}
+
+	// quota: ditto (the previous owner and size - see tgtquota.go)
+	if ecode, err = poi.t.quota(&poi.qd, lom, poi.owt, poi.uq, lom.Lsize(), true /*locked*/); err != nil {
+		return ecode, err
+	}
+
+	// object lock: no overwriting locked objects unless keeping noncurrent versions
+	// (OwtNone: completing S3 multipart upload)
+	if (poi.owt < cmn.OwtRebalance || poi.owt == cmn.OwtNone) && bck.Props.ObjLock.Enabled {
+		if lom.RetainVersions() == 0 {
+			if err = lom.CheckLockCurrent(poi.bypassGov); err != nil {
+				return http.StatusForbidden, err
+			}
+		}
+		lom.SetDefaultRetention()
+	}
+
 	// ais versioning
+	var retain int
 	if bck.IsAIS() && lom.VersionConf().Enabled {
 		if poi.owt < cmn.OwtRebalance {
-			if poi.skipVC {
+			if retain = lom.RetainVersions(); retain > 0 {
+				// keep the current version as noncurrent (see core/lversion.go)
+				var next string
+				if next, err = lom.ArchiveVersion(); err != nil {
+					return 0, err
+				}
+				lom.SetVersion(next)
+			} else if poi.skipVC {
 				err = lom.IncVersion()
 				debug.AssertNoErr(err)
 			} else if remSrc, ok := lom.GetCustomKey(cmn.SourceObjMD); !ok || remSrc == "" {
@@ -375,7 +481,17 @@ func (poi *putOI) fini() (ecode int, err error) {
 		}
 	}
 
+	// write-ahead journal (see tgtwback.go)
+	if dirty {
+		if err = poi.t.wb.journal(lom); err != nil {
+			return 0, err
+		}
+	}
+
 	// done
+	if poi.qd.bu != nil {
+		poi.qd.setOwner(lom)
+	}
 	if err = lom.RenameFinalize(poi.workFQN); err != nil {
 		return 0, err
 	}
@@ -387,7 +503,44 @@ func (poi *putOI) fini() (ecode int, err error) {
 	if lom.AtimeUnix() == 0 { // (is set when migrating within cluster; prefetch special case)
 		lom.SetAtimeUnix(poi.atime)
 	}
-	return 0, lom.PersistMain()
+	if err = lom.PersistMain(); err != nil {
+		return 0, err
+	}
+	if lom.IsFeatureSet(feat.IndexArchOnPUT) {
+		if err := lom.IndexArch(); err != nil {
+			nlog.Warningln(poi.loghdr(), "failed to index:", err)
+		}
+	}
+	if poi.qd.bu != nil {
+		poi.t.quotas.apply(&poi.qd)
+	}
+	if retain > 0 {
+		lom.TrimVersions(retain)
+	}
+	if dirty {
+		poi.t.wb.post(lom.Uname())
+	}
+	if ev := poi.event(); ev != "" {
+		poi.t.ObjEvent(lom, ev)
+	}
+	return 0, nil
+}
+
+// bucket event notification (see tgtevents.go)
+func (poi *putOI) event() string {
+	switch poi.owt {
+	case cmn.OwtPut, cmn.OwtArchive, cmn.OwtNone: // (OwtNone: completing S3 multipart upload)
+		if poi.xctn != nil && poi.xctn.Kind() == apc.ActDownload {
+			return cmn.EvObjCreatedDownload
+		}
+		return cmn.EvObjCreatedPut
+	case cmn.OwtPromote:
+		return cmn.EvObjCreatedPromote
+	case cmn.OwtCopy, cmn.OwtTransform:
+		return cmn.EvObjCreatedCopy
+	default:
+		return ""
+	}
 }
 
 // via backend.PutObj()
@@ -432,6 +585,16 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 		}{}
 		ckconf = poi.lom.CksumConf()
 	)
+	poi.sseDone = true
+	if conf := poi.sseConf(); conf != nil {
+		return poi.writeEnc(conf)
+	}
+	if conf := poi.cmprConf(poi.size); conf != nil {
+		return poi.writeCmpr(conf)
+	}
+	if conf := poi.dedupConf(poi.size); conf != nil {
+		return poi.writeDedup(conf)
+	}
 	if lmfh, err = poi.lom.CreateWork(poi.workFQN); err != nil {
 		return
 	}
@@ -706,6 +869,11 @@ do:
 
 	// read locally and stream back
 fin:
+	if goi.pc != nil && !goi.dpq.isGFN {
+		if err = evalPrecondRead(goi.pc, goi.lom); err != nil {
+			return 0, err
+		}
+	}
 	ecode, err = goi.txfini()
 	if err == nil {
 		return 0, nil
@@ -986,8 +1154,9 @@ func (goi *getOI) getFromNeighbor(lom *core.LOM, tsi *meta.Snode) bool {
 
 func (goi *getOI) txfini() (ecode int, err error) {
 	var (
-		lmfh *os.File
-		hrng *htrange
+		fh   *os.File
+		lmfh cos.LomReader
+		rngs []htrange
 		fqn  = goi.lom.FQN
 		dpq  = goi.dpq
 	)
@@ -996,7 +1165,7 @@ func (goi *getOI) txfini() (ecode int, err error) {
 	}
 	// open
 	// TODO -- FIXME: use lom.Open() instead of os.Open(); TestECChecksum
-	lmfh, err = os.Open(fqn)
+	fh, err = os.Open(fqn)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// NOTE: retry only once and only when ec-enabled - see goi.restoreFromAny()
@@ -1009,23 +1178,44 @@ func (goi *getOI) txfini() (ecode int, err error) {
 		}
 		return ecode, err
 	}
+	lmfh = fh
+	if goi.plain() {
+		if lmfh, err = goi.lom.NewPlainReader(fh, goi.ssec); err != nil {
+			ecode = http.StatusInternalServerError
+			if e, ok := err.(*cmn.ErrSSECKey); ok {
+				ecode = e.Status()
+			}
+			return ecode, err
+		}
+	} else if goi.ssec != nil && !dpq.isGFN {
+		if err = goi.lom.CheckSSEC(goi.ssec); err != nil {
+			cos.Close(fh)
+			return http.StatusBadRequest, err
+		}
+	}
 
 	whdr := goi.w.Header()
 
 	// transmit (range, arch, regular)
 	switch {
-	case goi.ranges.Range != "":
-		debug.Assert(!dpq.isArch())
-		rsize := goi.lom.Lsize()
+	case goi.ranges.Range != "" && !dpq.isArch():
+		rsize := goi.lom.PlainSize()
 		if goi.ranges.Size > 0 {
 			rsize = goi.ranges.Size
 		}
-		if hrng, ecode, err = goi.rngToHeader(whdr, rsize); err != nil {
+		if rngs, ecode, err = goi.rngToHeader(whdr, rsize); err != nil {
 			break
 		}
-		err = goi._txrng(fqn, lmfh, whdr, hrng)
+		switch len(rngs) {
+		case 0:
+			err = goi._txreg(fqn, lmfh, whdr)
+		case 1:
+			err = goi._txrng(fqn, lmfh, whdr, &rngs[0])
+		default:
+			err = goi._txmrng(fqn, lmfh, whdr, rngs, rsize)
+		}
 	case dpq.isArch():
-		err = goi._txarch(fqn, lmfh, whdr)
+		ecode, err = goi._txarch(fqn, lmfh, whdr)
 	default:
 		err = goi._txreg(fqn, lmfh, whdr)
 	}
@@ -1034,7 +1224,13 @@ func (goi *getOI) txfini() (ecode int, err error) {
 	return ecode, err
 }
 
-func (goi *getOI) _txrng(fqn string, lmfh *os.File, whdr http.Header, hrng *htrange) (err error) {
+// decrypt or decompress content at rest unless serving another target (get-from-neighbor);
+// deduplicated content is always reassembled (dedup chunks are target-local)
+func (goi *getOI) plain() bool {
+	return goi.lom.IsDeduped() || (!goi.dpq.isGFN && goi.lom.IsEncoded())
+}
+
+func (goi *getOI) _txrng(fqn string, lmfh cos.LomReader, whdr http.Header, hrng *htrange) (err error) {
 	var (
 		r     io.Reader
 		lom   = goi.lom
@@ -1042,6 +1238,9 @@ func (goi *getOI) _txrng(fqn string, lmfh *os.File, whdr http.Header, hrng *htra
 		cksum = lom.Checksum()
 		size  int64
 	)
+	if goi.plain() {
+		cksum = cos.NoneCksum // (stored checksum is computed over encrypted content)
+	}
 	ckconf := lom.CksumConf()
 	cksumRange := ckconf.Type != cos.ChecksumNone && ckconf.EnableReadRange
 	size = hrng.Length
@@ -1073,20 +1272,40 @@ func (goi *getOI) _txrng(fqn string, lmfh *os.File, whdr http.Header, hrng *htra
 	return err
 }
 
+// multi-range: multipart/byteranges (no range checksums)
+func (goi *getOI) _txmrng(fqn string, lmfh cos.LomReader, whdr http.Header, rngs []htrange, rsize int64) error {
+	r, size, ctype := mpByteranges(rngs, rsize, lmfh)
+	whdr.Set(cos.HdrContentType, ctype)
+	cmn.ToHeader(goi.lom.ObjAttrs(), whdr, size, cos.NoneCksum)
+	goi.w.WriteHeader(http.StatusPartialContent)
+
+	buf, slab := goi.t.gmm.AllocSize(min(size, memsys.DefaultBuf2Size))
+	err := goi.transmit(r, buf, fqn)
+	slab.Free(buf)
+	return err
+}
+
 // in particular, setup reader and writer and set headers
-func (goi *getOI) _txreg(fqn string, lmfh *os.File, whdr http.Header) (err error) {
+func (goi *getOI) _txreg(fqn string, lmfh cos.LomReader, whdr http.Header) (err error) {
 	var (
 		dpq   = goi.dpq
 		lom   = goi.lom
 		cksum = lom.Checksum()
 		size  = lom.Lsize()
 	)
+	if goi.plain() {
+		cksum, size = cos.NoneCksum, lom.PlainSize()
+	}
 	// set response header
 	whdr.Set(cos.HdrContentType, cos.ContentBinary)
 	cmn.ToHeader(lom.ObjAttrs(), whdr, size, cksum)
 	if dpq.isS3 {
 		// (expecting user to set bucket checksum = md5)
 		s3.SetEtag(whdr, lom)
+		s3.SetVersionHeader(whdr, lom)
+		s3.SetSSEHeaders(whdr, lom.GetCustomMD())
+	} else {
+		setETag(whdr, lom)
 	}
 
 	buf, slab := goi.t.gmm.AllocSize(min(size, memsys.DefaultBuf2Size))
@@ -1096,7 +1315,7 @@ func (goi *getOI) _txreg(fqn string, lmfh *os.File, whdr http.Header) (err error
 }
 
 // TODO: checksum
-func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
+func (goi *getOI) _txarch(fqn string, lmfh cos.LomReader, whdr http.Header) (int, error) {
 	var (
 		ar  archive.Reader
 		dpq = goi.dpq
@@ -1104,36 +1323,54 @@ func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
 	)
 	mime, err := archive.MimeFile(lmfh, goi.t.smm, dpq.arch.mime, lom.ObjName)
 	if err != nil {
-		return err
-	}
-	ar, err = archive.NewReader(mime, lmfh, lom.Lsize())
-	if err != nil {
-		return fmt.Errorf("failed to open %s: %w", lom.Cname(), err)
+		return 0, err
 	}
 
 	// single
 	if dpq.arch.path != "" {
 		debug.Assert(dpq.arch.mmode == "", dpq.arch.mmode)
-		var csl cos.ReadCloseSizer
-		csl, err = ar.ReadOne(dpq.arch.path)
+		var (
+			csl cos.ReadCloseSizer
+			idx *archive.Index
+		)
+		// use (or build) random-access index, if supported; otherwise, scan
+		if idx, err = lom.ArchIndex(lmfh, mime); err != nil {
+			nlog.Warningln(goi.t.String(), "failed to index", lom.Cname(), "-", err)
+		}
+		if idx != nil {
+			csl, err = idx.ReadOne(lmfh, dpq.arch.path)
+		} else {
+			if ar, err = archive.NewReader(mime, lmfh, lom.PlainSize()); err != nil {
+				return 0, fmt.Errorf("failed to open %s: %w", lom.Cname(), err)
+			}
+			csl, err = ar.ReadOne(dpq.arch.path)
+		}
 		if err != nil {
 			goi.isIOErr = true
-			return cmn.NewErrFailedTo(goi.t, "extract "+dpq._archstr()+" from", lom.Cname(), err)
+			return 0, cmn.NewErrFailedTo(goi.t, "extract "+dpq._archstr()+" from", lom.Cname(), err)
 		}
 		if csl == nil {
-			return cos.NewErrNotFound(goi.t, dpq._archstr()+" in "+lom.Cname())
+			return 0, cos.NewErrNotFound(goi.t, dpq._archstr()+" in "+lom.Cname())
 		}
 		// found
-		whdr.Set(cos.HdrContentType, cos.ContentBinary)
-		buf, slab := goi.t.gmm.AllocSize(min(csl.Size(), memsys.DefaultBuf2Size))
-		err = goi.transmit(csl, buf, fqn)
-		slab.Free(buf)
+		var ecode int
+		if goi.ranges.Range != "" {
+			ecode, err = goi._txarng(fqn, csl, whdr)
+		} else {
+			whdr.Set(cos.HdrContentType, cos.ContentBinary)
+			buf, slab := goi.t.gmm.AllocSize(min(csl.Size(), memsys.DefaultBuf2Size))
+			err = goi.transmit(csl, buf, fqn)
+			slab.Free(buf)
+		}
 		csl.Close()
-		return err
+		return ecode, err
 	}
 
 	// multi match; writing & streaming tar =>(directly)=> response writer
 	debug.Assert(dpq.arch.mmode != "")
+	if ar, err = archive.NewReader(mime, lmfh, lom.PlainSize()); err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", lom.Cname(), err)
+	}
 	rcb := _newRcb(goi.w)
 	whdr.Set(cos.HdrContentType, cos.ContentTar)
 	err = ar.ReadUntil(rcb, dpq.arch.regx, dpq.arch.mmode)
@@ -1143,13 +1380,55 @@ func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
 	}
 	if err == nil && rcb.num == 0 {
 		// none found
-		return cos.NewErrNotFound(goi.t, dpq._archstr()+" in "+lom.Cname())
+		return 0, cos.NewErrNotFound(goi.t, dpq._archstr()+" in "+lom.Cname())
 	}
 	rcb.fini()
-	return err
+	return 0, err
+}
+
+// range read(s) within archived file, where the latter is read sequentially:
+// - single range: skip to the start and read the range
+// - multiple ranges: stream multipart/byteranges skipping in between (ranges are sorted and coalesced)
+func (goi *getOI) _txarng(fqn string, csl cos.ReadCloseSizer, whdr http.Header) (int, error) {
+	var (
+		r     io.Reader
+		fsize = csl.Size()
+	)
+	rngs, ecode, err := goi.rngToHeader(whdr, fsize)
+	if err != nil {
+		return ecode, err
+	}
+	whdr.Set(cos.HdrContentType, cos.ContentBinary)
+	switch len(rngs) {
+	case 0:
+		r = csl
+	case 1:
+		if _, err := io.CopyN(io.Discard, csl, rngs[0].Start); err != nil {
+			goi.isIOErr = true
+			return 0, err
+		}
+		r = io.LimitReader(csl, rngs[0].Length)
+		whdr.Set(cos.HdrContentLength, strconv.FormatInt(rngs[0].Length, 10))
+	default:
+		var (
+			size  int64
+			ctype string
+		)
+		r, size, ctype = mpByteranges(rngs, fsize, &seqReaderAt{r: csl})
+		whdr.Set(cos.HdrContentType, ctype)
+		whdr.Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+		goi.w.WriteHeader(http.StatusPartialContent)
+	}
+	buf, slab := goi.t.gmm.AllocSize(min(fsize, memsys.DefaultBuf2Size))
+	err = goi.transmit(r, buf, fqn)
+	slab.Free(buf)
+	return 0, err
 }
 
 func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
+	if !goi.dpq.isGFN {
+		r = goi.t.throttleReader(r, goi.lom.Bck(), parseUserRate(goi.dpq.urate))
+	}
 	written, err := cos.CopyBuffer(goi.w, r, buf)
 	if err != nil {
 		if !cos.IsRetriableConnErr(err) || cmn.Rom.FastV(5, cos.SmoduleAIS) {
@@ -1175,6 +1454,9 @@ func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
 			return errSendingResp
 		}
 		goi.lom.SetAtimeUnix(goi.atime)
+		if goi.lom.Bprops().LRU.Policy.CountsHits() {
+			goi.lom.IncHits()
+		}
 		goi.lom.Recache()
 	}
 	//
@@ -1218,10 +1500,9 @@ func (goi *getOI) stats(written int64) {
 	}
 }
 
-// - parse and validate user specified read range (goi.ranges)
-// - set response header accordingly
-func (goi *getOI) rngToHeader(resphdr http.Header, size int64) (hrng *htrange, ecode int, err error) {
-	var ranges []htrange
+// - parse and validate user specified read range(s) (goi.ranges)
+// - set response header accordingly (multiple ranges: see mpByteranges)
+func (goi *getOI) rngToHeader(resphdr http.Header, size int64) (ranges []htrange, ecode int, err error) {
 	ranges, err = parseMultiRange(goi.ranges.Range, size)
 	if err != nil {
 		if cmn.IsErrRangeNotSatisfiable(err) {
@@ -1234,21 +1515,12 @@ func (goi *getOI) rngToHeader(resphdr http.Header, size int64) (hrng *htrange, e
 	if len(ranges) == 0 {
 		return
 	}
-	if len(ranges) > 1 {
-		err = cmn.NewErrUnsupp("multi-range read", goi.lom.Cname())
-		ecode = http.StatusRequestedRangeNotSatisfiable
-		return
-	}
-	if goi.dpq.arch.path != "" {
-		err = cmn.NewErrUnsupp("range-read archived file", goi.dpq.arch.path)
-		ecode = http.StatusRequestedRangeNotSatisfiable
-		return
-	}
 
 	// set response header
-	hrng = &ranges[0]
 	resphdr.Set(cos.HdrAcceptRanges, "bytes")
-	resphdr.Set(cos.HdrContentRange, hrng.contentRange(size))
+	if len(ranges) == 1 {
+		resphdr.Set(cos.HdrContentRange, ranges[0].contentRange(size))
+	}
 	return
 }
 
@@ -1297,7 +1569,12 @@ func (a *apndOI) apnd(buf []byte) (packedHdl string, ecode int, err error) {
 		workFQN = fs.CSM.Gen(a.lom, fs.WorkfileType, fs.WorkfileAppend)
 		a.lom.Lock(false)
 		if a.lom.Load(false /*cache it*/, false /*locked*/) == nil {
-			_, a.hdl.partialCksum, err = cos.CopyFile(a.lom.FQN, workFQN, buf, a.lom.CksumType())
+			if a.lom.IsEncoded() {
+				// appending to plaintext (to be encrypted or compressed again upon flush, as per bucket config)
+				_, a.hdl.partialCksum, err = a.lom.CopyPlain(workFQN, buf, a.lom.CksumType())
+			} else {
+				_, a.hdl.partialCksum, err = cos.CopyFile(a.lom.FQN, workFQN, buf, a.lom.CksumType())
+			}
 			a.lom.Unlock(false)
 			if err != nil {
 				ecode = http.StatusInternalServerError
@@ -1530,6 +1807,7 @@ func (coi *coi) _reader(t *target, dm *bundle.DataMover, lom, dst *core.LOM) (si
 		// preserve src metadata when copying (vs. transforming)
 		dst.CopyVersion(lom)
 		dst.SetCustomMD(lom.GetCustomMD())
+		dst.ClearAtRest() // DP readers provide plaintext
 
 		// [special] when src == dst (`ais cp s3://data s3://data --all`)
 		if backend := lom.Bck().RemoteBck(); backend != nil && backend.Equal(coi.BckTo.Bucket()) {
@@ -1579,6 +1857,9 @@ func (coi *coi) _regular(t *target, lom, dst *core.LOM) (size int64, _ error) {
 		if coi.Finalize {
 			t.putMirror(dst2)
 		}
+		if !lcopy {
+			t.ObjEvent(dst2, cmn.EvObjCreatedCopy)
+		}
 	}
 	if dst2 != nil {
 		core.FreeLOM(dst2)
@@ -1642,6 +1923,16 @@ func (coi *coi) _send(t *target, lom *core.LOM, sargs *sendArgs) (size int64, _
 			lom.Unlock(false)
 			return 0, nil
 		}
+		if lom.IsDeduped() {
+			// dedup chunks are target-local: send content
+			reader, oah, err := lom.NewPlainDeferROC()
+			if err != nil {
+				return 0, err
+			}
+			size = oah.Lsize()
+			sargs.reader, sargs.objAttrs = reader, oah
+			break
+		}
 		reader, err := lom.NewDeferROC()
 		if err != nil {
 			return 0, err
@@ -1739,9 +2030,18 @@ func (a *putA2I) do() (int, error) {
 	if a.filename == "" {
 		return 0, errors.New("archive path is not defined")
 	}
+	// quota: check upfront with the estimated size (compare with poi.fini)
+	size := a.size
+	if !a.put {
+		size += a.lom.Lsize()
+	}
+	if ecode, err := a.t.quota(&a.qd, a.lom, cmn.OwtPut, a.uq, size, true /*locked*/); err != nil {
+		return ecode, err
+	}
 	// standard library does not support appending to tgz, zip, and such;
 	// for TAR there is an optimizing workaround not requiring a full copy
-	if a.mime == archive.ExtTar && !a.put /*append*/ && !a.lom.IsChunked() {
+	if a.mime == archive.ExtTar && !a.put /*append*/ && !a.lom.IsChunked() && !a.lom.IsEncoded() &&
+		sseKeep(a.lom) == nil && cmprKeep(a.lom, 0) == nil && dedupKeep(a.lom, 0) == nil {
 		var (
 			err       error
 			fh        *os.File
@@ -1800,14 +2100,14 @@ cpap: // copy + append
 		aw.Fini()
 	} else {
 		// copy + append
-		lmfh, err = a.lom.Open()
+		lmfh, err = a.lom.OpenPlain()
 		if err != nil {
 			cos.Close(wfh)
 			return http.StatusNotFound, err
 		}
 		cksum.Init(a.lom.CksumType())
 		aw = archive.NewWriter(a.mime, wfh, &cksum, nil)
-		err = aw.Copy(lmfh, a.lom.Lsize())
+		err = aw.Copy(lmfh, a.lom.PlainSize())
 		if err == nil {
 			err = aw.Write(a.filename, oah, a.r)
 		}
@@ -1866,16 +2166,46 @@ func (a *putA2I) finalize(size int64, cksum *cos.Cksum, fqn string) error {
 		debug.AssertNoErr(err)
 		debug.Assertf(finfo.Size() == size, "%d != %d", finfo.Size(), size)
 	})
+	// encrypt, compress, or deduplicate at rest (sets size and checksum)
+	var (
+		err    error
+		atRest bool
+	)
+	if conf := sseKeep(a.lom); conf != nil {
+		fqn, err = a.encrypt(conf, fqn)
+		atRest = true
+	} else {
+		a.lom.ClearAtRest()
+		if conf := cmprKeep(a.lom, size); conf != nil {
+			fqn, err = a.compress(conf, fqn)
+			atRest = true
+		} else if conf := dedupKeep(a.lom, size); conf != nil {
+			fqn, err = a.dedup(conf, fqn)
+			atRest = true
+		}
+	}
+	if err != nil {
+		return err
+	}
 	// done
 	if err := a.lom.RenameFinalize(fqn); err != nil {
 		return err
 	}
-	a.lom.SetSize(size)
-	a.lom.SetCksum(cksum)
+	if !atRest {
+		a.lom.SetSize(size)
+		a.lom.SetCksum(cksum)
+	}
 	a.lom.SetAtimeUnix(a.started)
+	if a.qd.bu != nil {
+		a.qd.setSize(a.lom.Lsize())
+		a.qd.setOwner(a.lom)
+	}
 	if err := a.lom.Persist(); err != nil {
 		return err
 	}
+	if a.qd.bu != nil {
+		a.t.quotas.apply(&a.qd)
+	}
 	if a.lom.ECEnabled() {
 		if err := ec.ECM.EncodeObject(a.lom, nil); err != nil && err != ec.ErrorECDisabled {
 			return err
@@ -5,11 +5,17 @@
 package ais
 
 import (
+	"bytes"
 	"flag"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -265,3 +271,114 @@ func BenchmarkObjGetDiscard(b *testing.B) {
 		})
 	}
 }
+
+func TestMultiRange(t *testing.T) {
+	const (
+		content = "0123456789abcdefghijklmnopqrstuvwxyz"
+		rng     = "bytes=0-3, 30-, -4, 10-12"
+	)
+	size := int64(len(content))
+	ranges, err := parseMultiRange(rng, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, length, ctype := mpByteranges(ranges, size, strings.NewReader(content))
+	body, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(body)) != length {
+		t.Fatalf("content length %d != %d", len(body), length)
+	}
+	_, params, err := mime.ParseMediaType(ctype)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		mr       = multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		expected = []struct{ data, crange string }{
+			{"0123", "bytes 0-3/36"},
+			{"abc", "bytes 10-12/36"},
+			{"uvwxyz", "bytes 30-35/36"}, // (coalesced with the last 4 bytes)
+		}
+	)
+	for _, exp := range expected {
+		part, err := mr.NextPart()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != exp.data || part.Header.Get(cos.HdrContentRange) != exp.crange {
+			t.Errorf("expected %q (%s), got %q (%s)", exp.data, exp.crange, data, part.Header.Get(cos.HdrContentRange))
+		}
+	}
+	if _, err := mr.NextPart(); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestMultiRangeCoalesceAndLimit(t *testing.T) {
+	const size = 100
+	tests := []struct {
+		rng      string
+		expected []htrange
+	}{
+		{"bytes=50-59, 0-9", []htrange{{0, 10}, {50, 10}}},
+		{"bytes=0-9, 10-19, 30-39", []htrange{{0, 20}, {30, 10}}},
+		{"bytes=20-29, 0-24, -10, 95-", []htrange{{0, 30}, {90, 10}}},
+		{"bytes=0-99, 10-20", []htrange{{0, 100}}},
+	}
+	for _, test := range tests {
+		ranges, err := parseMultiRange(test.rng, size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(ranges, test.expected) {
+			t.Errorf("%q: expected %v, got %v", test.rng, test.expected, ranges)
+		}
+	}
+
+	// too many ranges: ignored
+	specs := make([]string, 0, maxMultiRanges+1)
+	for i := range maxMultiRanges + 1 {
+		specs = append(specs, strconv.Itoa(i)+"-"+strconv.Itoa(i))
+	}
+	ranges, err := parseMultiRange(cos.HdrRangeValPrefix+strings.Join(specs, ","), size)
+	if err != nil || ranges != nil {
+		t.Errorf("expected the Range header to be ignored, got %v (%v)", ranges, err)
+	}
+	ranges, err = parseMultiRange(cos.HdrRangeValPrefix+strings.Join(specs[:maxMultiRanges], ","), size)
+	if err != nil || len(ranges) != 1 || ranges[0].Length != maxMultiRanges {
+		t.Errorf("expected a single coalesced range, got %v (%v)", ranges, err)
+	}
+}
+
+func TestMultiRangeSequential(t *testing.T) {
+	const content = "0123456789abcdefghijklmnopqrstuvwxyz"
+	size := int64(len(content))
+	ranges, err := parseMultiRange("bytes=30-, 2-4, 10-12", size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// strings.Reader without ReaderAt (as in: archived file)
+	seq := &seqReaderAt{r: io.LimitReader(strings.NewReader(content), size)}
+	r, length, _ := mpByteranges(ranges, size, seq)
+	body, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(body)) != length {
+		t.Fatalf("content length %d != %d", len(body), length)
+	}
+	for _, s := range []string{"234", "abc", "uvwxyz"} {
+		if !bytes.Contains(body, []byte(s)) {
+			t.Errorf("expected %q in the multipart body", s)
+		}
+	}
+	if _, err := seq.ReadAt(make([]byte, 1), 0); err == nil {
+		t.Error("expected error reading backwards")
+	}
+}
@@ -0,0 +1,336 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+// storage quotas (see cmn/quota.go):
+// - running usage counters are maintained for buckets with quota enabled and, within each bucket,
+//   for object owners (AuthN users with quota); the latter add up to the users' cross-bucket usage;
+// - the counters get seeded from this target's bucket summary (apc.ActSummaryBck) - when the summary
+//   is requested by a user (see prxbsumm.go) or, otherwise, upon the first write that requires it;
+// - hard limits are enforced when writing objects (PUT, copy, promote, download, archive, and ETL);
+//   cold GET, rebalance, and other internal writes are only accounted for;
+// - each target enforces its own share of the cluster-wide limits (see cmn.QuotaUsage.Share).
+
+const quotaSeedIval = 2 * time.Second // polling target-local bucket summary (to seed the counters)
+
+type (
+	bckUsage struct {
+		owners map[string]*cmn.QuotaUsage // by AuthN user (object owner); protected by quotas.mu
+		size   atomic.Int64
+		objs   atomic.Int64
+		warned atomic.Bool // soft limit exceeded (and logged)
+	}
+	quotas struct {
+		bcks    map[uint64]*bckUsage // by bucket ID
+		sid     string               // ID of the last bucket summary used to seed the counters
+		seeding atomic.Bool          // all buckets (users' cross-bucket usage)
+		mu      sync.RWMutex
+	}
+
+	// AuthN user's quota (via proxy's redirect - see p.userQuota)
+	userQuota struct {
+		id     string
+		limits cmn.QuotaUsage
+	}
+
+	// state between poi.quota() and (upon success) quotas.apply()
+	quotaDelta struct {
+		bu    *bckUsage
+		owner string // new owner
+		prev  string // previous owner (when overwriting)
+		psize int64  // previous size
+		size  int64  // new size
+		delta cmn.QuotaUsage
+	}
+)
+
+// "<size>,<objs>,<user-id>"
+func parseUserQuota(s string) *userQuota {
+	parts := strings.SplitN(s, ",", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return nil
+	}
+	size, err1 := strconv.ParseInt(parts[0], 10, 64)
+	objs, err2 := strconv.ParseInt(parts[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return nil
+	}
+	return &userQuota{id: parts[2], limits: cmn.QuotaUsage{Size: size, Objs: objs}}
+}
+
+////////////
+// quotas //
+////////////
+
+// returns bucket usage (nil when not tracked); when `create` and not yet tracked,
+// starts tracking and seeding
+func (t *target) quotaUsage(bck *meta.Bck, create bool) *bckUsage {
+	q := &t.quotas
+	bid := bck.Props.BID
+	q.mu.RLock()
+	bu, ok := q.bcks[bid]
+	untracked := ok && !bck.Props.Quota.Enabled && len(bu.owners) == 0
+	q.mu.RUnlock()
+	if ok {
+		if !create && untracked {
+			q.mu.Lock()
+			delete(q.bcks, bid) // (quota disabled)
+			q.mu.Unlock()
+			return nil
+		}
+		return bu
+	}
+	if !create {
+		return nil
+	}
+
+	q.mu.Lock()
+	if bu, ok = q.bcks[bid]; ok {
+		q.mu.Unlock()
+		return bu
+	}
+	if q.bcks == nil {
+		q.bcks = make(map[uint64]*bckUsage, 4)
+	}
+	bu = &bckUsage{owners: make(map[string]*cmn.QuotaUsage, 4)}
+	q.bcks[bid] = bu
+	q.mu.Unlock()
+
+	t.seedQuota(bck)
+	return bu
+}
+
+// user's usage across all buckets
+func (q *quotas) user(id string) (u cmn.QuotaUsage) {
+	q.mu.RLock()
+	for _, bu := range q.bcks {
+		if ou, ok := bu.owners[id]; ok {
+			u.Size += ou.Size
+			u.Objs += ou.Objs
+		}
+	}
+	q.mu.RUnlock()
+	return u
+}
+
+func (q *quotas) apply(d *quotaDelta) {
+	bu := d.bu
+	bu.size.Add(d.delta.Size)
+	bu.objs.Add(d.delta.Objs)
+	if d.prev == "" && d.owner == "" {
+		return
+	}
+	q.mu.Lock()
+	if ou, ok := bu.owners[d.prev]; ok && d.prev != "" {
+		ou.Size -= d.psize
+		ou.Objs--
+	}
+	if d.owner != "" {
+		ou, ok := bu.owners[d.owner]
+		if !ok {
+			ou = &cmn.QuotaUsage{}
+			bu.owners[d.owner] = ou
+		}
+		ou.Size += d.size
+		ou.Objs++
+	}
+	q.mu.Unlock()
+}
+
+// (deleted object)
+func (q *quotas) del(lom *core.LOM, size int64) {
+	q.mu.RLock()
+	bu, ok := q.bcks[lom.Bprops().BID]
+	q.mu.RUnlock()
+	if !ok {
+		return
+	}
+	d := &quotaDelta{bu: bu, psize: size, delta: cmn.QuotaUsage{Size: -size, Objs: -1}}
+	d.prev, _ = lom.GetCustomKey(cmn.OwnerObjMD)
+	q.apply(d)
+}
+
+// seed (or re-seed) usage counters from a finished bucket summary
+func (q *quotas) seed(xsumm *xs.XactNsumm) {
+	if !xsumm.Full() || xsumm.IsAborted() {
+		return
+	}
+	results, err := xsumm.Result()
+	if err != nil {
+		return
+	}
+	q.mu.Lock()
+	if q.sid == xsumm.ID() {
+		q.mu.Unlock()
+		return
+	}
+	q.sid = xsumm.ID()
+	for _, res := range results {
+		if res.Bck.Props == nil {
+			continue
+		}
+		bid := res.Bck.Props.BID
+		owners := xsumm.Owners(bid)
+		bu, ok := q.bcks[bid]
+		if !ok {
+			if !res.Bck.Props.Quota.Enabled && len(owners) == 0 {
+				continue
+			}
+			if q.bcks == nil {
+				q.bcks = make(map[uint64]*bckUsage, 4)
+			}
+			bu = &bckUsage{}
+			q.bcks[bid] = bu
+		}
+		bu.size.Store(int64(res.TotalSize.PresentObjs))
+		bu.objs.Store(int64(res.ObjCount.Present))
+		bu.owners = make(map[string]*cmn.QuotaUsage, len(owners))
+		for owner, u := range owners {
+			bu.owners[owner] = &u
+		}
+	}
+	q.mu.Unlock()
+}
+
+// run target-local bucket summary and seed the counters upon its completion
+// (all buckets when `bck` is nil)
+func (t *target) seedQuota(bck *meta.Bck) {
+	if bck == nil {
+		bck = meta.NewBck("", "", cmn.NsGlobal)
+	}
+	msg := &apc.BsummCtrlMsg{UUID: cos.GenUUID(), ObjCached: true, BckPresent: true}
+	rns := xreg.RenewBckSummary(bck, msg)
+	if rns.Err != nil {
+		nlog.Warningln(t.String(), "failed to seed quota usage:", rns.Err)
+		return
+	}
+	xsumm := rns.Entry.Get().(*xs.XactNsumm)
+	hk.Reg(apc.ActSummaryBck+"-quota-"+msg.UUID+hk.NameSuffix, func(int64) time.Duration {
+		if !xsumm.Finished() {
+			return quotaSeedIval
+		}
+		t.quotas.seed(xsumm)
+		return hk.UnregInterval
+	}, quotaSeedIval)
+}
+
+//////////////
+// bckUsage //
+//////////////
+
+func (bu *bckUsage) load() cmn.QuotaUsage {
+	return cmn.QuotaUsage{Size: bu.size.Load(), Objs: bu.objs.Load()}
+}
+
+// log once upon exceeding soft limit
+func (bu *bckUsage) soft(bck *meta.Bck, delta, limits cmn.QuotaUsage) {
+	if limits.Size == 0 && limits.Objs == 0 {
+		return
+	}
+	u := bu.load()
+	u.Size += delta.Size
+	u.Objs += delta.Objs
+	if (limits.Size > 0 && u.Size > limits.Size) || (limits.Objs > 0 && u.Objs > limits.Objs) {
+		if bu.warned.CAS(false, true) {
+			nlog.Warningln(bck.Cname(""), "exceeded soft quota limit")
+		}
+	} else {
+		bu.warned.Store(false)
+	}
+}
+
+// check quota before writing `size` bytes, and remember the delta to apply upon success;
+// internal writes (cold GET, rebalance, etc.) are not checked - only accounted for
+func (t *target) quota(d *quotaDelta, lom *core.LOM, owt cmn.OWT, uq *userQuota, size int64, locked bool) (int, error) {
+	var (
+		bck   = lom.Bck()
+		conf  = &bck.Props.Quota
+		check = owt < cmn.OwtRebalance || owt == cmn.OwtNone
+		bu    = t.quotaUsage(bck, check && (conf.Enabled || uq != nil))
+	)
+	*d = quotaDelta{}
+	if bu == nil {
+		return 0, nil
+	}
+	if uq != nil && t.quotas.seeding.CAS(false, true) {
+		go t.seedQuota(nil) // users' usage across all buckets
+	}
+
+	*d = quotaDelta{bu: bu, size: size, delta: cmn.QuotaUsage{Size: size, Objs: 1}}
+	if uq != nil {
+		d.owner = uq.id
+	}
+	cur := core.AllocLOM(lom.ObjName)
+	defer core.FreeLOM(cur)
+	if err := cur.InitBck(lom.Bucket()); err != nil {
+		return 0, err
+	}
+	if err := cur.Load(false /*cache it*/, locked); err == nil {
+		d.psize = cur.Lsize()
+		d.prev, _ = cur.GetCustomKey(cmn.OwnerObjMD)
+		d.delta.Size -= d.psize
+		d.delta.Objs = 0
+	}
+	if !check {
+		return 0, nil
+	}
+
+	ntargets := t.owner.smap.get().CountActiveTs()
+	if conf.Enabled {
+		if err := cmn.CheckQuota(bck.Cname(""), bu.load(), d.delta, conf.Hard().Share(ntargets)); err != nil {
+			return http.StatusForbidden, err
+		}
+		bu.soft(bck, d.delta, conf.Soft().Share(ntargets))
+	}
+	if uq != nil {
+		udelta := cmn.QuotaUsage{Size: size, Objs: 1}
+		if d.prev == uq.id {
+			udelta.Size -= d.psize
+			udelta.Objs = 0
+		}
+		if err := cmn.CheckQuota("user "+uq.id, t.quotas.user(uq.id), udelta, uq.limits.Share(ntargets)); err != nil {
+			return http.StatusForbidden, err
+		}
+	}
+	return 0, nil
+}
+
+////////////////
+// quotaDelta //
+////////////////
+
+// actual size of the new object (vs. estimated when checking)
+func (d *quotaDelta) setSize(size int64) {
+	d.delta.Size += size - d.size
+	d.size = size
+}
+
+// new object's owner (custom metadata)
+func (d *quotaDelta) setOwner(lom *core.LOM) {
+	if d.owner != "" {
+		lom.SetCustomKey(cmn.OwnerObjMD, d.owner)
+	} else {
+		lom.DelCustomKey(cmn.OwnerObjMD)
+	}
+}
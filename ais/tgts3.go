@@ -5,6 +5,7 @@
 package ais
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net/http"
@@ -36,7 +37,13 @@ func (t *target) s3Handler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if l := len(apiItems); (l == 0 && r.Method == http.MethodGet) || l < 2 {
+	if t.corsS3(w, r, apiItems) {
+		return // (preflight)
+	}
+	// (the only bucket-level request: list object versions)
+	l := len(apiItems)
+	listVersions := l == 1 && r.Method == http.MethodGet && r.URL.Query().Has(s3.QparamVersions)
+	if (l == 0 && r.Method == http.MethodGet) || (l < 2 && !listVersions) {
 		err := fmt.Errorf(fmtErrBckObj, r.Method, apiItems)
 		s3.WriteErr(w, r, err, 0)
 		return
@@ -52,9 +59,14 @@ func (t *target) s3Handler(w http.ResponseWriter, r *http.Request) {
 		t.putCopyMpt(w, r, config, apiItems)
 	case http.MethodDelete:
 		q := r.URL.Query()
-		if q.Has(s3.QparamMptUploadID) {
+		switch {
+		case q.Has(s3.QparamMptUploadID):
 			t.abortMpt(w, r, apiItems, q)
-		} else {
+		case q.Has(s3.QparamTagging):
+			t.putObjTaggingS3(w, r, apiItems, nil)
+		case q.Has(s3.QparamVersionID):
+			t.delObjVersionS3(w, r, apiItems, q.Get(s3.QparamVersionID))
+		default:
 			t.delObjS3(w, r, apiItems)
 		}
 	case http.MethodPost:
@@ -90,6 +102,20 @@ func (t *target) putCopyMpt(w http.ResponseWriter, r *http.Request, config *cmn.
 			nlog.Infoln("putMptPart", bck.String(), items, q)
 		}
 		t.putMptPart(w, r, items, q, bck)
+	case q.Has(s3.QparamTagging):
+		b, err := cos.ReadAllN(r.Body, r.ContentLength)
+		if err != nil {
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+		tags, err := s3.DecodeTagging(b)
+		if err != nil {
+			s3.WriteErr(w, r, err, http.StatusBadRequest)
+			return
+		}
+		t.putObjTaggingS3(w, r, items, tags)
+	case q.Has(s3.QparamRetention) || q.Has(s3.QparamLegalHold):
+		t.putObjLockS3(w, r, items, bck, q)
 	case r.Header.Get(cos.S3HdrObjSrc) == "":
 		objName := s3.ObjName(items)
 		lom := core.AllocLOM(objName)
@@ -137,6 +163,26 @@ func (t *target) copyObjS3(w http.ResponseWriter, r *http.Request, config *cmn.C
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	// SSE-C: copying requires the source's key; the copy remains encrypted with the same key
+	srcKey, err := s3.SSECFromHeaders(r.Header, true /*copy source*/)
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if err := lom.CheckSSEC(srcKey); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	dstKey, err := s3.SSECFromHeaders(r.Header, false)
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if !bytes.Equal(srcKey, dstKey) {
+		err := cmn.NewErrNotImpl("copy", "SSE-C encrypted object with a different (or no) customer-provided key")
+		s3.WriteErr(w, r, err, http.StatusNotImplemented)
+		return
+	}
 	// dst
 	bckTo, err, ecode := meta.InitByNameOnly(items[0], t.owner.bmd)
 	if err != nil {
@@ -175,6 +221,7 @@ func (t *target) copyObjS3(w http.ResponseWriter, r *http.Request, config *cmn.C
 	}
 	sgl := t.gmm.NewSGL(0)
 	result.MustMarshal(sgl)
+	s3.SetSSEHeaders(w.Header(), lom.GetCustomMD())
 	w.Header().Set(cos.HdrContentType, cos.ContentXML)
 	sgl.WriteTo2(w)
 	sgl.Free()
@@ -194,6 +241,39 @@ func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck,
 	started := time.Now()
 	lom.SetAtimeUnix(started.UnixNano())
 
+	if s := r.Header.Get(s3.HdrTagging); s != "" {
+		tags, err := cmn.ParseObjTags(s)
+		if err != nil {
+			s3.WriteErr(w, r, s3.NewErrCode("InvalidTag", err.Error()), http.StatusBadRequest)
+			return
+		}
+		lom.SetCustomKey(cmn.TagsObjMD, cmn.ObjTagsToS(tags))
+	}
+	if err := t.objLockFromHeaders(r, lom); err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	sseConf, err := s3.SSEFromHeaders(r.Header, &bck.Props.SSE)
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	ssec, err := s3.SSECFromHeaders(r.Header, false)
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if ssec != nil && sseConf != nil {
+		err := s3.NewErrCode("InvalidArgument", "server-side encryption with customer-provided keys "+
+			"cannot be combined with "+s3.HdrSSE)
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if (sseConf != nil || ssec != nil) && !bck.IsAIS() {
+		s3.WriteErr(w, r, cmn.NewErrNotImpl("encrypt objects in", bck.Provider+" bucket"), http.StatusNotImplemented)
+		return
+	}
+
 	// TODO: dual checksumming, e.g. lom.SetCustom(apc.AWS, ...)
 
 	dpq := dpqAlloc()
@@ -209,15 +289,22 @@ func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck,
 		poi.lom = lom
 		poi.config = config
 		poi.skipVC = cmn.Rom.Features().IsSet(feat.SkipVC) || dpq.skipVC // apc.QparamSkipVC
+		poi.bypassGov = s3.BypassGovernance(r.Header)
+		poi.sse = sseConf
+		poi.ssec = ssec
 		poi.restful = true
 	}
 	ecode, err := poi.do(nil /*response hdr*/, r, dpq)
 	freePOI(poi)
 	if err != nil {
-		t.FSHC(err, lom.Mountpath(), lom.FQN)
+		if !cmn.IsErrObjLocked(err) && !cmn.IsErrPrecondition(err) && !cmn.IsErrQuotaExceeded(err) {
+			t.FSHC(err, lom.Mountpath(), lom.FQN)
+		}
 		s3.WriteErr(w, r, err, ecode)
 	} else {
 		s3.SetEtag(w.Header(), lom)
+		s3.SetVersionHeader(w.Header(), lom)
+		s3.SetSSEHeaders(w.Header(), lom.GetCustomMD())
 	}
 	dpqFree(dpq)
 }
@@ -238,12 +325,29 @@ func (t *target) getObjS3(w http.ResponseWriter, r *http.Request, items []string
 		t.listMptUploads(w, bck, q)
 		return
 	}
+	if len(items) == 1 && q.Has(s3.QparamVersions) {
+		t.listObjVersionsS3(w, r, bck, q)
+		return
+	}
 	if len(items) < 2 {
 		err := fmt.Errorf(fmtErrBckObj, r.Method, items)
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
 	objName := s3.ObjName(items)
+	if q.Has(s3.QparamTagging) {
+		t.getObjTaggingS3(w, r, bck, objName)
+		return
+	}
+	if q.Has(s3.QparamRetention) || q.Has(s3.QparamLegalHold) {
+		t.getObjLockS3(w, r, bck, objName, q)
+		return
+	}
+	if q.Has(s3.QparamVersionID) {
+		if t.getObjVersionS3(w, r, bck, objName, q.Get(s3.QparamVersionID)) {
+			return
+		}
+	}
 	if q.Has(s3.QparamMptPartNo) {
 		if cmn.Rom.FastV(5, cos.SmoduleS3) {
 			nlog.Infoln("getMptPart", bck.String(), objName, q)
@@ -288,6 +392,16 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
+	if q := r.URL.Query(); q.Has(s3.QparamVersionID) {
+		if t.getObjVersionS3(w, r, bck, objName, q.Get(s3.QparamVersionID)) {
+			return
+		}
+	}
+	ssec, err := s3.SSECFromHeaders(r.Header, false)
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
 	lom := core.AllocLOM(objName)
 	defer core.FreeLOM(lom)
 	if err := lom.InitBck(bck.Bucket()); err != nil {
@@ -313,7 +427,25 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 		op  cmn.ObjectProps
 	)
 	if exists {
+		if err := lom.CheckSSEC(ssec); err != nil {
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+		// conditional HEAD
+		if pc := cmn.ParsePreconds(r.Header); pc != nil {
+			if err := evalPrecondRead(pc, lom); err != nil {
+				if isNotModified(err) {
+					writeNotModified(w, lom, true /*S3*/)
+				} else {
+					s3.WriteErr(w, r, err, 0)
+				}
+				return
+			}
+		}
 		op.ObjAttrs = *lom.ObjAttrs()
+		if lom.IsEncoded() {
+			op.ObjAttrs.Size = lom.PlainSize()
+		}
 	} else {
 		// cold HEAD
 		objAttrs, ecode, err := t.HeadCold(lom, r)
@@ -328,6 +460,7 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 	lom.SetCustomMD(custom)
 
 	s3.SetEtag(hdr, lom)
+	s3.SetSSEHeaders(hdr, custom)
 
 	hdr.Set(cos.HdrContentLength, strconv.FormatInt(op.Size, 10))
 	if v, ok := custom[cos.HdrContentType]; ok {
@@ -351,7 +484,13 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 	// see aws.go `_getCustom`
 	if v, ok := custom[cmn.VersionObjMD]; ok {
 		hdr.Set(cos.S3VersionHeader, v)
+	} else if exists {
+		s3.SetVersionHeader(hdr, lom)
 	}
+	if tags := cmn.ObjTags(custom); len(tags) > 0 {
+		hdr.Set(s3.HdrTaggingCount, strconv.Itoa(len(tags)))
+	}
+	s3.SetObjLockHeaders(hdr, custom)
 
 	// TODO: add custom user keys, if any
 }
@@ -370,16 +509,22 @@ func (t *target) delObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	ecode, err = t.DeleteObject(lom, false)
+	ecode, err = t.delObject(lom, false /*evict*/, s3.BypassGovernance(r.Header))
 	if err != nil {
 		name := lom.Cname()
-		if ecode == http.StatusNotFound {
+		switch {
+		case ecode == http.StatusNotFound:
 			s3.WriteErr(w, r, cos.NewErrNotFound(t, name), http.StatusNotFound)
-		} else {
+		case cmn.IsErrObjLocked(err):
+			s3.WriteErr(w, r, err, http.StatusForbidden)
+		default:
 			s3.WriteErr(w, r, fmt.Errorf("error deleting %s: %v", name, err), ecode)
 		}
 		return
 	}
+	if lom.RetainVersions() > 0 {
+		w.Header().Set(s3.HdrDeleteMarker, "true")
+	}
 	// EC cleanup if EC is enabled
 	ec.ECM.CleanupObject(lom)
 }
@@ -410,3 +555,65 @@ func (t *target) postObjS3(w http.ResponseWriter, r *http.Request, items []strin
 		s3.QparamMptUploads, s3.QparamMptUploadID)
 	s3.WriteErr(w, r, err, 0)
 }
+
+// GET /s3/<bucket-name>/<object-name>?tagging
+func (t *target) getObjTaggingS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, objName string) {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) {
+			s3.WriteErr(w, r, cos.NewErrNotFound(t, lom.Cname()), http.StatusNotFound)
+		} else {
+			s3.WriteErr(w, r, err, 0)
+		}
+		return
+	}
+	tagging := s3.NewTagging(cmn.ObjTags(lom.GetCustomMD()))
+	sgl := t.gmm.NewSGL(0)
+	tagging.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>/<object-name>?tagging - replaces existing tags, if any
+// DELETE /s3/<bucket-name>/<object-name>?tagging - (tags == nil)
+func (t *target) putObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string, tags cos.StrKVs) {
+	bck, err, ecode := meta.InitByNameOnly(items[0], t.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	lom := core.AllocLOM(s3.ObjName(items))
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) {
+			s3.WriteErr(w, r, cos.NewErrNotFound(t, lom.Cname()), http.StatusNotFound)
+		} else {
+			s3.WriteErr(w, r, err, 0)
+		}
+		return
+	}
+	if len(tags) == 0 {
+		lom.DelCustomKey(cmn.TagsObjMD)
+	} else {
+		lom.SetCustomKey(cmn.TagsObjMD, cmn.ObjTagsToS(tags))
+	}
+	if err := lom.Persist(); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if tags == nil {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
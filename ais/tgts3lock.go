@@ -0,0 +1,149 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// S3 object lock: per-object retention and legal hold (see cmn/objlock.go)
+
+func errNoObjLock(bck *meta.Bck) error {
+	return s3.NewErrCode("InvalidRequest", bck.Cname("")+": bucket is missing object lock configuration")
+}
+
+// PUT /s3/<bucket-name>/<object-name> with x-amz-object-lock-* headers
+func (*target) objLockFromHeaders(r *http.Request, lom *core.LOM) error {
+	hdr := r.Header
+	if hdr.Get(s3.HdrObjLockMode) == "" && hdr.Get(s3.HdrObjLockRetainUntil) == "" && hdr.Get(s3.HdrObjLockLegalHold) == "" {
+		return nil
+	}
+	if !lom.Bprops().ObjLock.Enabled {
+		return errNoObjLock(lom.Bck())
+	}
+	md := lom.GetCustomMD()
+	if md == nil {
+		md = make(cos.StrKVs, 3)
+	}
+	if err := s3.ObjLockFromHeaders(hdr, md); err != nil {
+		return err
+	}
+	lom.SetCustomMD(md)
+	return nil
+}
+
+// GET /s3/<bucket-name>/<object-name>?retention|legal-hold
+func (t *target) getObjLockS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, objName string, q url.Values) {
+	if !bck.Props.ObjLock.Enabled {
+		s3.WriteErr(w, r, errNoObjLock(bck), http.StatusBadRequest)
+		return
+	}
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) {
+			s3.WriteErr(w, r, cos.NewErrNotFound(t, lom.Cname()), http.StatusNotFound)
+		} else {
+			s3.WriteErr(w, r, err, 0)
+		}
+		return
+	}
+	var (
+		md  = lom.GetCustomMD()
+		sgl = t.gmm.NewSGL(0)
+	)
+	if q.Has(s3.QparamRetention) {
+		ret := cmn.GetObjRetention(md)
+		if !ret.IsSet() {
+			sgl.Free()
+			s3.WriteErr(w, r, s3.NewErrCode("NoSuchObjectLockConfiguration", lom.Cname()+": retention is not set"),
+				http.StatusNotFound)
+			return
+		}
+		s3.NewRetention(&ret).MustMarshal(sgl)
+	} else {
+		s3.NewLegalHold(cmn.ObjLegalHold(md)).MustMarshal(sgl)
+	}
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>/<object-name>?retention|legal-hold
+func (t *target) putObjLockS3(w http.ResponseWriter, r *http.Request, items []string, bck *meta.Bck, q url.Values) {
+	if !bck.Props.ObjLock.Enabled {
+		s3.WriteErr(w, r, errNoObjLock(bck), http.StatusBadRequest)
+		return
+	}
+	body, err := cos.ReadAllN(r.Body, r.ContentLength)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	var (
+		nret cmn.ObjRetention
+		hold bool
+	)
+	if q.Has(s3.QparamRetention) {
+		nret, err = s3.DecodeRetention(body)
+	} else {
+		hold, err = s3.DecodeLegalHold(body)
+	}
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	lom := core.AllocLOM(s3.ObjName(items))
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) {
+			s3.WriteErr(w, r, cos.NewErrNotFound(t, lom.Cname()), http.StatusNotFound)
+		} else {
+			s3.WriteErr(w, r, err, 0)
+		}
+		return
+	}
+	md := lom.GetCustomMD()
+	if md == nil {
+		md = make(cos.StrKVs, 2)
+	}
+	if q.Has(s3.QparamRetention) {
+		ret := cmn.GetObjRetention(md)
+		if err := ret.CheckUpdate(&nret, lom.Cname(), time.Now(), s3.BypassGovernance(r.Header)); err != nil {
+			ecode := http.StatusBadRequest
+			if cmn.IsErrObjLocked(err) {
+				ecode = http.StatusForbidden
+			}
+			s3.WriteErr(w, r, err, ecode)
+			return
+		}
+		nret.ToMD(md)
+	} else {
+		cmn.SetObjLegalHold(md, hold)
+	}
+	lom.SetCustomMD(md)
+	if err := lom.Persist(); err != nil {
+		s3.WriteErr(w, r, err, 0)
+	}
+}
@@ -11,22 +11,18 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"os"
-	"sort"
 	"strconv"
-	"time"
 
 	"github.com/NVIDIA/aistore/ais/backend"
 	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
-	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/cmn/sse"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
-	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/stats"
 )
 
@@ -63,6 +59,15 @@ func (t *target) startMpt(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	ssec, err := s3.SSECFromHeaders(r.Header, false)
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if ssec != nil && !bck.IsAIS() {
+		s3.WriteErr(w, r, cmn.NewErrNotImpl("encrypt objects in", bck.Provider+" bucket"), http.StatusNotImplemented)
+		return
+	}
 	if bck.IsRemoteS3() {
 		uploadID, ecode, err = backend.StartMpt(lom, r, q)
 		if err != nil {
@@ -73,11 +78,14 @@ func (t *target) startMpt(w http.ResponseWriter, r *http.Request, items []string
 		uploadID = cos.GenUUID()
 	}
 
-	s3.InitUpload(uploadID, bck.Name, objName)
+	s3.InitUpload(uploadID, bck.Name, objName, ssec)
 	result := &s3.InitiateMptUploadResult{Bucket: bck.Name, Key: objName, UploadID: uploadID}
 
 	sgl := t.gmm.NewSGL(0)
 	result.MustMarshal(sgl)
+	if ssec != nil {
+		s3.SetSSECHeaders(w.Header(), sse.KeyMD5(ssec))
+	}
 	w.Header().Set(cos.HdrContentType, cos.ContentXML)
 	sgl.WriteTo2(w)
 	sgl.Free()
@@ -101,65 +109,37 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 		s3.WriteErr(w, r, errors.New("empty uploadId"), 0)
 		return
 	}
-	part := q.Get(s3.QparamMptPartNo)
-	if part == "" {
-		s3.WriteErr(w, r, fmt.Errorf("upload %q: missing part number", uploadID), 0)
-		return
-	}
-	partNum, err := s3.ParsePartNum(part)
+	partNum, err := validatePartNum(uploadID, q.Get(s3.QparamMptPartNo))
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	if partNum < 1 || partNum > s3.MaxPartsPerUpload {
-		err := fmt.Errorf("upload %q: invalid part number %d, must be between 1 and %d",
-			uploadID, partNum, s3.MaxPartsPerUpload)
+
+	ssec, err := s3.SSECFromHeaders(r.Header, false)
+	if err == nil {
+		err = s3.CheckUploadSSEC(uploadID, ssec)
+	}
+	if err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
 
-	// 2. init lom, create part file
+	// 2. init lom; write part file
 	objName := s3.ObjName(items)
 	lom := &core.LOM{ObjName: objName}
 	if err := lom.InitBck(bck.Bucket()); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	// workfile name format: <upload-id>.<part-number>.<obj-name>
-	prefix := uploadID + "." + strconv.FormatInt(int64(partNum), 10)
-	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, prefix)
-	partFh, errC := lom.CreatePart(wfqn)
-	if errC != nil {
-		s3.WriteMptErr(w, r, errC, 0, lom, uploadID)
-		return
-	}
-
-	var (
-		etag         string
-		size         int64
-		ecode        int
-		partSHA      = r.Header.Get(cos.S3HdrContentSHA256)
-		checkPartSHA = partSHA != "" && partSHA != cos.S3UnsignedPayload
-		cksumSHA     = &cos.CksumHash{}
-		cksumMD5     = &cos.CksumHash{}
-		remote       = bck.IsRemoteS3()
-	)
-	if checkPartSHA {
-		cksumSHA = cos.NewCksumHash(cos.ChecksumSHA256)
-	}
-	if !remote {
-		cksumMD5 = cos.NewCksumHash(cos.ChecksumMD5)
-	}
-
-	// 3. write
-	mw := multiWriter(cksumMD5.H, cksumSHA.H, partFh)
-
-	if !remote {
-		// write locally
-		buf, slab := t.gmm.Alloc()
-		size, err = io.CopyBuffer(mw, r.Body, buf)
-		slab.Free(buf)
-	} else {
+	debug.Assert(ssec == nil || !bck.IsRemoteS3())
+	cp := func(mw io.Writer) (size int64, etag string, ecode int, err error) {
+		if !bck.IsRemoteS3() {
+			// write locally
+			buf, slab := t.gmm.Alloc()
+			size, err = io.CopyBuffer(mw, r.Body, buf)
+			slab.Free(buf)
+			return size, "", 0, err
+		}
 		// write locally and utilize TeeReader to simultaneously send data to S3
 		tr := io.NopCloser(io.TeeReader(r.Body, mw))
 		size = r.ContentLength
@@ -167,56 +147,28 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 		remoteStart := mono.NanoTime()
 		etag, ecode, err = backend.PutMptPart(lom, tr, r, q, uploadID, size, partNum)
 		remotePutLatency = mono.SinceNano(remoteStart)
+		return size, etag, ecode, err
 	}
-
-	cos.Close(partFh)
+	npart, ecode, err := t.writeMptPart(lom, uploadID, partNum, ssec, r.Header.Get(cos.S3HdrContentSHA256), cp)
 	if err != nil {
-		if nerr := cos.RemoveFile(wfqn); nerr != nil && !os.IsNotExist(nerr) {
-			nlog.Errorf(fmtNested, t, err, "remove", wfqn, nerr)
-		}
 		s3.WriteMptErr(w, r, err, ecode, lom, uploadID)
 		return
 	}
-
-	// 4. finalize the part (expecting the part's remote etag to be md5 checksum)
-	md5 := etag
-	if cksumMD5.H != nil {
-		debug.Assert(etag == "")
-		cksumMD5.Finalize()
-		md5 = cksumMD5.Value()
-	}
-	if checkPartSHA {
-		cksumSHA.Finalize()
-		recvSHA := cos.NewCksum(cos.ChecksumSHA256, partSHA)
-		if !cksumSHA.Equal(recvSHA) {
-			detail := fmt.Sprintf("upload %q, %s, part %d", uploadID, lom, partNum)
-			err = cos.NewErrDataCksum(&cksumSHA.Cksum, recvSHA, detail)
-			s3.WriteMptErr(w, r, err, http.StatusInternalServerError, lom, uploadID)
-			return
-		}
+	w.Header().Set(cos.S3CksumHeader, npart.MD5) // s3cmd checks this one
+	if ssec != nil {
+		s3.SetSSECHeaders(w.Header(), sse.KeyMD5(ssec))
 	}
-	npart := &s3.MptPart{
-		MD5:  md5,
-		FQN:  wfqn,
-		Size: size,
-		Num:  partNum,
-	}
-	if err := s3.AddPart(uploadID, npart); err != nil {
-		s3.WriteMptErr(w, r, err, 0, lom, uploadID)
-		return
-	}
-	w.Header().Set(cos.S3CksumHeader, md5) // s3cmd checks this one
 
 	delta := mono.SinceNano(startTime)
 	t.statsT.AddMany(
-		cos.NamedVal64{Name: stats.PutSize, Value: size},
+		cos.NamedVal64{Name: stats.PutSize, Value: npart.Size},
 		cos.NamedVal64{Name: stats.PutLatency, Value: delta},
 		cos.NamedVal64{Name: stats.PutLatencyTotal, Value: delta},
 	)
 	if remotePutLatency > 0 {
 		backendBck := t.Backend(bck)
 		t.statsT.AddMany(
-			cos.NamedVal64{Name: backendBck.MetricName(stats.PutSize), Value: size},
+			cos.NamedVal64{Name: backendBck.MetricName(stats.PutSize), Value: npart.Size},
 			cos.NamedVal64{Name: backendBck.MetricName(stats.PutLatencyTotal), Value: remotePutLatency},
 			cos.NamedVal64{Name: backendBck.MetricName(stats.PutE2ELatencyTotal), Value: delta},
 		)
@@ -251,23 +203,26 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 		s3.WriteErr(w, r, fmt.Errorf("upload %q: empty list of upload parts", uploadID), 0)
 		return
 	}
+	ssec, err := s3.SSECFromHeaders(r.Header, false)
+	if err == nil {
+		err = s3.CheckUploadSSEC(uploadID, ssec)
+	}
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
 	objName := s3.ObjName(items)
 	lom := &core.LOM{ObjName: objName}
 	if err := lom.InitBck(bck.Bucket()); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	size, errN := s3.ObjSize(uploadID)
-	if errN != nil {
-		s3.WriteMptErr(w, r, errN, 0, lom, uploadID)
-		return
-	}
 
 	// call s3
 	var (
-		etag    string
-		started = time.Now()
-		remote  = bck.IsRemoteS3()
+		etag      string
+		remote    = bck.IsRemoteS3()
+		cksumType = cos.ChecksumMD5
 	)
 	if remote {
 		v, ecode, err := backend.CompleteMpt(lom, r, q, uploadID, body, partList)
@@ -276,109 +231,31 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 			return
 		}
 		etag = v
+		if ty := lom.CksumConf().Type; ty != cos.ChecksumNone {
+			cksumType = ty
+		}
 	}
 
 	// append parts and finalize locally
-	var (
-		mw          io.Writer
-		concatMD5   string // => ETag
-		actualCksum = &cos.CksumHash{}
-	)
-	// .1 sort and check parts
-	sort.Slice(partList.Parts, func(i, j int) bool {
-		return *partList.Parts[i].PartNumber < *partList.Parts[j].PartNumber
-	})
-	nparts, err := s3.CheckParts(uploadID, partList.Parts)
-	if err != nil {
-		s3.WriteMptErr(w, r, err, 0, lom, uploadID)
-		return
-	}
-	// 2. <upload-id>.complete.<obj-name>
-	prefix := uploadID + ".complete"
-	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, prefix)
-	wfh, errC := lom.CreateWork(wfqn)
-	if errC != nil {
-		s3.WriteMptErr(w, r, errC, 0, lom, uploadID)
-		return
-	}
-	if remote && lom.CksumConf().Type != cos.ChecksumNone {
-		actualCksum = cos.NewCksumHash(lom.CksumConf().Type)
+	etagL, ecode, err := t.mptComplete(lom, uploadID, partList.Parts, ssec, cksumType, etag, cmn.OwtNone)
+	if err == nil {
+		etag = etagL
 	} else {
-		actualCksum = cos.NewCksumHash(cos.ChecksumMD5)
-	}
-	mw = multiWriter(actualCksum.H, wfh)
-
-	// .3 write
-	buf, slab := t.gmm.Alloc()
-	concatMD5, written, errA := _appendMpt(nparts, buf, mw)
-	slab.Free(buf)
-
-	if lom.IsFeatureSet(feat.FsyncPUT) {
-		errS := wfh.Sync()
-		debug.AssertNoErr(errS)
-	}
-	cos.Close(wfh)
-
-	if errA == nil && written != size {
-		errA = fmt.Errorf("upload %q %q: expected full size=%d, got %d", uploadID, lom.Cname(), size, written)
-	}
-	if errA != nil {
-		if nerr := cos.RemoveFile(wfqn); nerr != nil && !os.IsNotExist(nerr) {
-			nlog.Errorf(fmtNested, t, err, "remove", wfqn, nerr)
-		}
-		s3.WriteMptErr(w, r, errA, 0, lom, uploadID)
-		return
-	}
-
-	// .4 (s3 client => ais://) compute resulting MD5 and, optionally, ETag
-	if actualCksum.H != nil {
-		actualCksum.Finalize()
-		lom.SetCksum(actualCksum.Cksum.Clone())
-	}
-	if etag == "" {
-		debug.Assert(!remote)
-		debug.Assert(concatMD5 != "")
-		resMD5 := cos.NewCksumHash(cos.ChecksumMD5)
-		_, err = resMD5.H.Write([]byte(concatMD5))
-		debug.AssertNoErr(err)
-		resMD5.Finalize()
-		etag = `"` + resMD5.Value() + cmn.AwsMultipartDelim + strconv.Itoa(len(partList.Parts)) + `"`
-	}
-
-	// .5 finalize
-	lom.SetSize(size)
-	lom.SetCustomKey(cmn.ETag, etag)
-
-	poi := allocPOI()
-	{
-		poi.t = t
-		poi.atime = started.UnixNano()
-		poi.lom = lom
-		poi.workFQN = wfqn
-		poi.owt = cmn.OwtNone
-	}
-	ecode, errF := poi.finalize()
-	freePOI(poi)
-
-	// .6 cleanup parts - unconditionally
-	exists := s3.CleanupUpload(uploadID, lom.FQN, false /*aborted*/)
-	debug.Assert(exists)
-
-	if errF != nil {
 		// NOTE: not failing if remote op. succeeded
 		if !remote {
-			s3.WriteMptErr(w, r, errF, ecode, lom, uploadID)
+			s3.WriteMptErr(w, r, err, ecode, lom, uploadID)
 			return
 		}
 		nlog.Errorf("upload %q: failed to complete %s locally: %v(%d)", uploadID, lom.Cname(), err, ecode)
 	}
 
-	// .7 respond
+	// respond
 	result := &s3.CompleteMptUploadResult{Bucket: bck.Name, Key: objName, ETag: etag}
 	sgl := t.gmm.NewSGL(0)
 	result.MustMarshal(sgl)
 	w.Header().Set(cos.HdrContentType, cos.ContentXML)
 	w.Header().Set(cos.S3CksumHeader, etag)
+	s3.SetSSEHeaders(w.Header(), lom.GetCustomMD())
 	sgl.WriteTo2(w)
 	sgl.Free()
 
@@ -389,31 +266,6 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 	}
 }
 
-func _appendMpt(nparts []*s3.MptPart, buf []byte, mw io.Writer) (concatMD5 string, written int64, err error) {
-	for _, partInfo := range nparts {
-		var (
-			partFh   *os.File
-			partSize int64
-		)
-		concatMD5 += partInfo.MD5
-		if partFh, err = os.Open(partInfo.FQN); err != nil {
-			return "", 0, err
-		}
-		partSize, err = io.CopyBuffer(mw, partFh, buf)
-		cos.Close(partFh)
-		if err != nil {
-			return "", 0, err
-		}
-		written += partSize
-	}
-	return concatMD5, written, nil
-}
-
-// Abort an active multipart upload.
-// Body is empty, only URL query contains uploadID
-// 1. uploadID must exists
-// 2. Remove all temporary files
-// 3. Remove all info from in-memory structs
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_AbortMultipartUpload.html
 func (t *target) abortMpt(w http.ResponseWriter, r *http.Request, items []string, q url.Values) {
 	bck, err, ecode := meta.InitByNameOnly(items[0], t.owner.bmd)
@@ -519,8 +371,18 @@ func (t *target) getMptPart(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 	off, size, status, err := s3.OffsetSorted(lom, partNum)
 	if err != nil {
 		s3.WriteErr(w, r, err, status)
+		return
+	}
+	ssec, err := s3.SSECFromHeaders(r.Header, false)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
 	}
-	fh, err := lom.Open()
+	fh, err := lom.OpenPlainWith(ssec)
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
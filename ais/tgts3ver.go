@@ -0,0 +1,325 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// S3 object versioning: noncurrent versions and delete markers (ais:// buckets only)
+// see also: core/lversion.go
+
+func errVersionsNotSupported(bck *meta.Bck) error {
+	return fmt.Errorf("%s: noncurrent object versions are only supported for ais:// buckets", bck)
+}
+
+// GET /s3/<bucket-name>?versions
+//   - walks object and noncurrent-version directories in sorted order selecting the smallest (at most max-keys+1)
+//     object names that follow the key marker, and skipping subtrees that cannot contain any such names;
+//   - adds versions of the selected names and repeats (starting after the last one) until max-keys is exceeded
+//     or there are no more names.
+func (t *target) listObjVersionsS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, q url.Values) {
+	var (
+		result = s3.NewListVersionsResult(bck.Name, q)
+		smap   = t.owner.smap.get()
+		sel    = &verNames{prefix: result.Prefix, after: result.KeyMarker, incl: true, max: result.MaxKeys + 1}
+	)
+outer:
+	for {
+		if err := sel.walk(bck); err != nil {
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+		for _, objName := range sel.names {
+			if result.Len() > result.MaxKeys {
+				result.IsTruncated = true
+				break outer
+			}
+			t._addVersions(result, bck, objName, smap)
+		}
+		if len(sel.names) < sel.max {
+			break
+		}
+		sel.after, sel.incl = sel.names[len(sel.names)-1], false
+		sel.names = sel.names[:0]
+	}
+	result.Finalize()
+
+	sgl := t.gmm.NewSGL(0)
+	result.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+//////////////
+// verNames //
+//////////////
+
+// bounded selection of the smallest object names (with a given prefix) that follow `after`
+type verNames struct {
+	prefix string
+	after  string
+	root   string   // walk root: bucket's content-type directory
+	ct     string   // content type: fs.ObjectType or fs.VersionType
+	names  []string // selected, sorted
+	max    int
+	incl   bool // include `after` itself
+}
+
+func (sel *verNames) walk(bck *meta.Bck) error {
+	for _, mi := range fs.GetAvail() {
+		for _, ct := range []string{fs.ObjectType, fs.VersionType} {
+			sel.root, sel.ct = mi.MakePathCT(bck.Bucket(), ct)+"/", ct
+			opts := &fs.WalkOpts{
+				Mi:       mi,
+				Bck:      *bck.Bucket(),
+				CTs:      []string{ct},
+				Callback: sel.cb,
+				Sorted:   true,
+			}
+			if err := fs.Walk(opts); err != nil && !cmn.IsErrBucketNought(err) && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (sel *verNames) full() bool   { return len(sel.names) >= sel.max }
+func (sel *verNames) last() string { return sel.names[len(sel.names)-1] }
+
+func (sel *verNames) cb(fqn string, de fs.DirEntry) error {
+	rel := strings.TrimPrefix(fqn, sel.root)
+	if len(rel) == len(fqn) {
+		return nil // (root)
+	}
+	if de.IsDir() {
+		if sel.ct == fs.VersionType {
+			if _, _, ok := fs.ParseVersionName(rel + "/0"); ok {
+				return nil // (versions of a single object)
+			}
+		}
+		if sel.skipDir(rel + "/") {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+	objName := rel
+	if sel.ct == fs.VersionType {
+		var ok bool
+		if objName, _, ok = fs.ParseVersionName(rel); !ok {
+			return nil
+		}
+	}
+	if !sel.want(objName) {
+		if sel.ct == fs.ObjectType && sel.full() && objName > sel.last() {
+			return filepath.SkipDir // (sorted: remaining files in this directory are greater)
+		}
+		return nil
+	}
+	sel.add(objName)
+	return nil
+}
+
+// all names in a given directory start with `dir`
+func (sel *verNames) skipDir(dir string) bool {
+	switch {
+	case !strings.HasPrefix(dir, sel.prefix) && !strings.HasPrefix(sel.prefix, dir):
+		return true
+	case dir < sel.after && !strings.HasPrefix(sel.after, dir):
+		return true
+	default:
+		return sel.full() && dir > sel.last()
+	}
+}
+
+func (sel *verNames) want(objName string) bool {
+	if !strings.HasPrefix(objName, sel.prefix) {
+		return false
+	}
+	if objName < sel.after || (objName == sel.after && !sel.incl) {
+		return false
+	}
+	return !sel.full() || objName < sel.last()
+}
+
+func (sel *verNames) add(objName string) {
+	i, found := slices.BinarySearch(sel.names, objName)
+	if found {
+		return
+	}
+	if sel.full() {
+		sel.names = sel.names[:len(sel.names)-1]
+	}
+	sel.names = slices.Insert(sel.names, i, objName)
+}
+
+// current version (if exists) followed by noncurrent ones, newest first
+func (*target) _addVersions(result *s3.ListVersionsResult, bck *meta.Bck, objName string, smap *smapX) {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return
+	}
+	if _, local, err := lom.HrwTarget(&smap.Smap); err != nil || !local {
+		return
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+
+	isLatest := true
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		_, _, mtime, _ := lom.Fstat(false)
+		cur := &core.ObjVersion{Version: lom.Version(), Cksum: lom.Checksum(), Mtime: mtime, Size: lom.PlainSize()}
+		if result.Want(objName, s3.VersionID(cur.Version)) {
+			result.Add(objName, cur, true)
+		}
+		isLatest = false
+	}
+	vers, err := lom.ListVersions()
+	if err != nil {
+		return
+	}
+	for _, v := range vers {
+		if result.Want(objName, s3.VersionID(v.Version)) {
+			result.Add(objName, v, isLatest && v.DeleteMarker) // (deleted object)
+		}
+		isLatest = false
+	}
+}
+
+// GET|HEAD /s3/<bucket-name>/<object-name>?versionId=<ver>
+// returns false when the requested version is the current one - to be handled by the caller
+func (t *target) getObjVersionS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, objName, ver string) bool {
+	if !bck.IsAIS() {
+		s3.WriteErr(w, r, errVersionsNotSupported(bck), http.StatusNotImplemented)
+		return true
+	}
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return true
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err == nil && s3.VersionID(lom.Version()) == ver {
+		return false
+	}
+	v, err := lom.LoadVersion(s3.AisVersion(ver))
+	if err != nil {
+		if cos.IsNotExist(err, 0) {
+			s3.WriteErr(w, r, s3.NewErrNoSuchVersion(lom.Cname(), ver), http.StatusNotFound)
+		} else {
+			s3.WriteErr(w, r, err, 0)
+		}
+		return true
+	}
+	defer core.FreeLOM(v)
+
+	hdr := w.Header()
+	hdr.Set(cos.S3VersionHeader, ver)
+	if v.IsDeleteMarker() {
+		hdr.Set(s3.HdrDeleteMarker, "true")
+		s3.WriteErr(w, r, s3.NewErrCode("MethodNotAllowed", "the specified method is not allowed against this resource"),
+			http.StatusMethodNotAllowed)
+		return true
+	}
+	ssec, err := s3.SSECFromHeaders(r.Header, false)
+	if err == nil {
+		err = v.CheckSSEC(ssec)
+	}
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return true
+	}
+	_, _, mtime, _ := v.Fstat(false)
+	s3.SetEtag(hdr, v)
+	s3.SetSSEHeaders(hdr, v.GetCustomMD())
+	size := v.PlainSize()
+	hdr.Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+	hdr.Set(cos.S3LastModified, cos.FormatTime(mtime.UTC(), cos.RFC1123GMT))
+	if ctype, ok := v.GetCustomKey(cos.HdrContentType); ok {
+		hdr.Set(cos.HdrContentType, ctype)
+	}
+	if r.Method == http.MethodHead {
+		return true
+	}
+	fh, err := v.OpenPlainWith(ssec)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return true
+	}
+	buf, slab := t.gmm.AllocSize(min(size, memsys.DefaultBuf2Size))
+	_, err = io.CopyBuffer(w, fh, buf)
+	slab.Free(buf)
+	cos.Close(fh)
+	if err != nil {
+		t.statsT.IncErr(stats.ErrGetCount)
+	}
+	return true
+}
+
+// DELETE /s3/<bucket-name>/<object-name>?versionId=<ver>
+func (t *target) delObjVersionS3(w http.ResponseWriter, r *http.Request, items []string, ver string) {
+	bck, err, ecode := meta.InitByNameOnly(items[0], t.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	if !bck.IsAIS() {
+		s3.WriteErr(w, r, errVersionsNotSupported(bck), http.StatusNotImplemented)
+		return
+	}
+	lom := core.AllocLOM(s3.ObjName(items))
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+
+	var isMarker bool
+	if v, err := lom.LoadVersion(s3.AisVersion(ver)); err == nil {
+		isMarker = v.IsDeleteMarker()
+		core.FreeLOM(v)
+	}
+	if err := lom.DelVersion(s3.AisVersion(ver), s3.BypassGovernance(r.Header)); err != nil {
+		switch {
+		case cos.IsNotExist(err, 0):
+			s3.WriteErr(w, r, s3.NewErrNoSuchVersion(lom.Cname(), ver), http.StatusNotFound)
+		case cmn.IsErrObjLocked(err):
+			s3.WriteErr(w, r, err, http.StatusForbidden)
+		default:
+			s3.WriteErr(w, r, err, 0)
+		}
+		return
+	}
+	hdr := w.Header()
+	hdr.Set(cos.S3VersionHeader, ver)
+	if isMarker {
+		hdr.Set(s3.HdrDeleteMarker, "true")
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
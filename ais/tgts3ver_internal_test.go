@@ -0,0 +1,74 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+func TestListVersionNames(tt *testing.T) {
+	fs.CSM.Reg(fs.VersionType, &fs.VersionContentResolver{}, true)
+	bck := meta.NewBck(testBucket, apc.AIS, cmn.NsGlobal)
+	if err := bck.Init(t.owner.bmd); err != nil {
+		tt.Fatal(err)
+	}
+	var (
+		objects  = []string{"a-b", "a.", "a/b", "a/c", "c", "d/e/f"}
+		versions = []string{"a/c", "b/x", "d/e/f"} // "b/x": deleted
+	)
+	create := func(objName string, version bool) {
+		lom := core.AllocLOM(objName)
+		defer core.FreeLOM(lom)
+		if err := lom.InitBck(bck.Bucket()); err != nil {
+			tt.Fatal(err)
+		}
+		fqn := lom.FQN
+		if version {
+			fqn = lom.VersionFQN("1")
+		}
+		if _, err := cos.CreateFile(fqn); err != nil {
+			tt.Fatal(err)
+		}
+	}
+	for _, objName := range objects {
+		create(objName, false)
+	}
+	for _, objName := range versions {
+		create(objName, true)
+	}
+
+	tests := []struct {
+		prefix, after string
+		incl          bool
+		max           int
+		expected      []string
+	}{
+		{"", "", true, 3, []string{"a-b", "a.", "a/b"}},
+		{"", "a-b", false, 3, []string{"a.", "a/b", "a/c"}},
+		{"", "a/c", true, 2, []string{"a/c", "b/x"}},
+		{"", "b/x", false, 10, []string{"c", "d/e/f"}},
+		{"a/", "", true, 10, []string{"a/b", "a/c"}},
+		{"d/", "", true, 10, []string{"d/e/f"}},
+		{"e", "", true, 10, []string{}},
+	}
+	for _, test := range tests {
+		sel := &verNames{prefix: test.prefix, after: test.after, incl: test.incl, max: test.max}
+		if err := sel.walk(bck); err != nil {
+			tt.Fatal(err)
+		}
+		if !slices.Equal(sel.names, test.expected) && (len(sel.names) > 0 || len(test.expected) > 0) {
+			tt.Errorf("prefix %q, after %q (incl %t), max %d: expected %v, got %v",
+				test.prefix, test.after, test.incl, test.max, test.expected, sel.names)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/atomic"
@@ -16,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/nl"
@@ -29,6 +31,12 @@ const (
 	// - compare with cmn/cos/oom
 	// - compare with fs/health/fshc
 	minAutoDetectInterval = 10 * time.Minute
+
+	// how often to execute bucket lifecycle rules (and whether there are any)
+	lcyInterval = time.Hour
+
+	// how often to evict remote objects that outlived their respective `lru.ttl`
+	lruTTLInterval = 30 * time.Minute
 )
 
 var (
@@ -161,3 +169,67 @@ func (t *target) runSpaceCleanup(xargs *xact.ArgsMsg, wg *sync.WaitGroup) fs.Cap
 	})
 	return space.RunCleanup(&ini)
 }
+
+// periodic (housekeeping) callback: LRU policy "ttl" (regardless of used capacity)
+func (t *target) lruTTLHK(int64) time.Duration {
+	if t.regstate.disabled.Load() || !space.HasTTL(&t.owner.bmd.get().BMD) {
+		return lruTTLInterval
+	}
+	go t.runLRU("" /*uuid*/, nil /*wg*/, false)
+	return lruTTLInterval
+}
+
+// periodic (housekeeping) callback
+func (t *target) lcyHK(int64) time.Duration {
+	if t.regstate.disabled.Load() || !space.HasLifecycle(&t.owner.bmd.get().BMD) {
+		return lcyInterval
+	}
+	go t.runLifecycle(&xact.ArgsMsg{}, nil /*wg*/)
+	return lcyInterval
+}
+
+func (t *target) runLifecycle(xargs *xact.ArgsMsg, wg *sync.WaitGroup) {
+	regToIC := xargs.ID != ""
+	if !regToIC {
+		xargs.ID = cos.GenUUID()
+	}
+	rns := xreg.RenewLifecycle(xargs.ID)
+	if rns.Err != nil || rns.IsRunning() {
+		debug.Assert(rns.Err == nil || cmn.IsErrXactUsePrev(rns.Err))
+		if wg != nil {
+			wg.Done()
+		}
+		return
+	}
+	xlcy := rns.Entry.Get()
+	if regToIC && xlcy.ID() == xargs.ID {
+		// pre-existing UUID: notify IC members
+		regMsg := xactRegMsg{UUID: xargs.ID, Kind: apc.ActLifecycle, Srcs: []string{t.SID()}}
+		msg := t.newAmsgActVal(apc.ActRegGlobalXaction, regMsg)
+		t.bcastAsyncIC(msg)
+	}
+	ini := space.IniLcy{
+		Xaction:  xlcy.(*space.XactLcy),
+		Config:   cmn.GCO.Get(),
+		StatsT:   t.statsT,
+		Buckets:  xargs.Buckets,
+		WG:       wg,
+		AbortMpt: abortStaleMpt,
+	}
+	xlcy.AddNotif(&xact.NotifXact{
+		Base: nl.Base{When: core.UponTerm, Dsts: []string{equalIC}, F: t.notifyTerm},
+		Xact: xlcy,
+	})
+	space.RunLifecycle(&ini)
+}
+
+// (compare w/ t.abortMpt that handles S3 API)
+func abortStaleMpt(bck *meta.Bck, now time.Time) (n int) {
+	ids := s3.Stale(bck.Name, &bck.Props.Lifecycle, now)
+	for _, id := range ids {
+		if s3.CleanupUpload(id, "", true /*aborted*/) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,220 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/cmn/sse"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// server-side encryption at rest (see cmn/sse.go and core/lsse.go)
+
+// SSE-C: the key itself comes with the request (poi.ssec)
+var sseCustomer = &cmn.SSEConf{Provider: sse.ProviderCustomer, Enabled: true}
+
+// returns encryption to apply when writing poi.lom, or nil if none
+func (poi *putOI) sseConf() *cmn.SSEConf {
+	lom := poi.lom
+	if lom.IsDeduped() {
+		lom.ClearAtRest() // incoming content is never deduplicated (see tgtdedup.go)
+	}
+	switch poi.owt {
+	case cmn.OwtRebalance, cmn.OwtGetTryLock, cmn.OwtGetLock, cmn.OwtGet, cmn.OwtGetPrefetchLock:
+		return nil // as is
+	case cmn.OwtCopy, cmn.OwtCopySameBucket:
+		if lom.IsEncoded() {
+			return nil // copying encrypted (compressed) content along with its metadata
+		}
+	}
+	lom.ClearAtRest()
+	if poi.ssec != nil {
+		return sseCustomer
+	}
+	if poi.sse != nil {
+		return poi.sse
+	}
+	if conf := &lom.Bprops().SSE; conf.Enabled && lom.Bck().IsAIS() {
+		return conf
+	}
+	return nil
+}
+
+// when appending to an existing shard: keep it encrypted (with the same master key)
+func sseKeep(lom *core.LOM) *cmn.SSEConf {
+	if lom.IsEncrypted() {
+		md := lom.GetCustomMD()
+		return &cmn.SSEConf{Provider: md[cmn.SSEProviderObjMD], KeyID: md[cmn.SSEKeyIDObjMD], Enabled: true}
+	}
+	if conf := &lom.Bprops().SSE; conf.Enabled && lom.Bck().IsAIS() {
+		return conf
+	}
+	return nil
+}
+
+// encrypting variant of poi.write() (see writeAtRest)
+func (poi *putOI) writeEnc(conf *cmn.SSEConf) (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, err error) {
+	var (
+		params  *sse.Params
+		written int64
+		lom     = poi.lom
+	)
+	if conf == sseCustomer {
+		params, err = lom.InitSSEC(poi.ssec)
+	} else {
+		params, err = lom.InitSSE(conf)
+	}
+	if err != nil {
+		return
+	}
+	buf, slab, lmfh, written, err = poi.writeAtRest(func(w io.Writer) (atRestW, error) { return sse.NewWriter(w, params) })
+	if err == nil {
+		lom.SetCustomKey(cmn.SSESizeObjMD, strconv.FormatInt(written, 10))
+	}
+	return
+}
+
+// content at rest (encrypted or compressed) != content as seen by users
+type atRestW interface {
+	io.WriteCloser
+	Size() int64 // at rest
+}
+
+// common part of the encrypting and compressing variants of poi.write():
+// - the store checksum (as per bucket config) is computed over the content at rest, while
+// - the caller-provided checksum (if any) gets validated against plaintext
+// returns the number of plaintext bytes written
+func (poi *putOI) writeAtRest(newW func(io.Writer) (atRestW, error)) (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter,
+	written int64, err error) {
+	var (
+		aw     atRestW
+		store  *cos.CksumHash // at rest
+		compt  *cos.CksumHash // plaintext, to validate poi.cksumToUse
+		lom    = poi.lom
+		ckconf = lom.CksumConf()
+	)
+	if lmfh, err = lom.CreateWork(poi.workFQN); err != nil {
+		return
+	}
+	if poi.size <= 0 {
+		buf, slab = poi.t.gmm.Alloc()
+	} else {
+		buf, slab = poi.t.gmm.AllocSize(poi.size)
+	}
+
+	var w io.Writer = lmfh
+	if ckconf.Type != cos.ChecksumNone {
+		store = cos.NewCksumHash(ckconf.Type)
+		w = cos.NewWriterMulti(store.H, lmfh)
+	}
+	if aw, err = newW(w); err != nil {
+		return
+	}
+	var pw io.Writer = aw
+	if ckconf.Type != cos.ChecksumNone && !poi.skipVC && !poi.cksumToUse.IsEmpty() && poi.validateCksum(ckconf) {
+		compt = cos.NewCksumHash(poi.cksumToUse.Type())
+		pw = cos.NewWriterMulti(compt.H, aw)
+	}
+	if written, err = cos.CopyBuffer(pw, poi.r, buf); err != nil {
+		return
+	}
+	if err = aw.Close(); err != nil {
+		return
+	}
+
+	// validate
+	if compt != nil {
+		compt.Finalize()
+		if !compt.Equal(poi.cksumToUse) {
+			err = cos.NewErrDataCksum(poi.cksumToUse, &compt.Cksum, lom.Cname())
+			poi.t.statsT.AddMany(
+				cos.NamedVal64{Name: stats.ErrCksumCount, Value: 1},
+				cos.NamedVal64{Name: stats.ErrCksumSize, Value: written},
+			)
+			return
+		}
+	}
+
+	// ok
+	if lom.IsFeatureSet(feat.FsyncPUT) {
+		err = lmfh.Sync()
+		debug.AssertNoErr(err)
+	}
+	cos.Close(lmfh)
+	lmfh = nil
+
+	lom.SetSize(aw.Size())
+	if store != nil {
+		store.Finalize()
+		lom.SetCksum(&store.Cksum)
+	} else {
+		lom.SetCksum(cos.NoneCksum)
+	}
+	return
+}
+
+// encrypt plaintext work file that was written by other means (than poi.write) -
+// e.g., multi-object archive and S3 multipart upload
+func (poi *putOI) encWork(conf *cmn.SSEConf) error {
+	return poi.rework(fs.WorkfileEncrypt, "encrypt", func() ([]byte, *memsys.Slab, cos.LomWriter, error) {
+		return poi.writeEnc(conf)
+	})
+}
+
+// rewrite poi.workFQN via the provided `write` that reads from the former and writes
+// to a new work file - the one that poi.workFQN will then refer to
+func (poi *putOI) rework(tag, action string, write func() ([]byte, *memsys.Slab, cos.LomWriter, error)) error {
+	fh, err := os.Open(poi.workFQN)
+	if err != nil {
+		return err
+	}
+	var (
+		plainFQN = poi.workFQN
+		r        = poi.r
+		cksum    = poi.cksumToUse
+	)
+	poi.r, poi.cksumToUse = fh, nil
+	poi.workFQN = fs.CSM.Gen(poi.lom, fs.WorkfileType, tag)
+
+	buf, slab, lmfh, err := write()
+	poi._cleanup(buf, slab, lmfh, err) // closes fh
+
+	newFQN := poi.workFQN
+	poi.r, poi.cksumToUse, poi.workFQN = r, cksum, plainFQN
+	if err != nil {
+		return err
+	}
+	if errV := cos.RemoveFile(plainFQN); errV != nil {
+		nlog.Errorf(fmtNested, poi.t, action, "remove", plainFQN, errV)
+	}
+	poi.workFQN = newFQN
+	return nil
+}
+
+// (putA2I) encrypt resulting shard
+func (a *putA2I) encrypt(conf *cmn.SSEConf, fqn string) (encFQN string, err error) {
+	poi := allocPOI()
+	{
+		poi.t = a.t
+		poi.lom = a.lom
+		poi.workFQN = fqn
+		poi.owt = cmn.OwtArchive
+	}
+	err = poi.encWork(conf)
+	encFQN = poi.workFQN
+	freePOI(poi)
+	return encFQN, err
+}
@@ -894,6 +894,11 @@ func (t *target) beginRm(c *txnSrv) error {
 func (t *target) destroyBucket(c *txnSrv) error {
 	switch c.phase {
 	case apc.ActBegin:
+		if c.msg.Action == apc.ActEvictRemoteBck {
+			if err := t.wb.flushBck(c.bck, c.timeout.host); err != nil {
+				return err
+			}
+		}
 		nlp := newBckNLP(c.bck)
 		if !nlp.TryLock(c.timeout.netw / 2) {
 			return cmn.NewErrBusy("bucket", c.bck.Cname(""))
@@ -0,0 +1,374 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// asynchronous write-back of remote buckets (see apc.WriteBack):
+// - PUT (as well as copy, transform, archive, and promote) into a remote bucket with write-back
+//   data policy completes once the object is stored in-cluster; the object gets marked "dirty"
+//   (cmn.DirtyObjMD) and journaled in the target's kvdb (write-ahead: under object's wlock,
+//   prior to finalizing it);
+// - a small pool of workers uploads dirty objects to remote backend; failed uploads are retried
+//   with exponential backoff - periodically and across restarts;
+// - dirty objects are never evicted by LRU; evicting dirty object (or remote bucket) flushes it first;
+// - the entire journal is flushed when the target is put in maintenance or decommissioned -
+//   first, prior to modifying Smap, when the action fails if any dirty objects remain (unless
+//   apc.ActValRmNode.SkipWriteBack), and then again in the final step;
+// - rebalance migrates dirty objects along with their "dirty" marker, to be journaled by
+//   the receiving target (see poi.fini).
+
+const (
+	wbCollection  = "write-back" // kvdb collection
+	wbQueueSize   = 4096         // in-memory queue
+	wbWorkers     = 4
+	wbBackoff     = 5 * time.Second  // initial backoff (doubles with each failed attempt)
+	wbMaxBackoff  = 10 * time.Minute // max backoff
+	wbRetryIvl    = 30 * time.Second
+	wbFlushPeriod = time.Second // flushing all, or all in a bucket: polling journal
+)
+
+type (
+	// journal entry (kvdb key: object's uname)
+	wbent struct {
+		Dirty  string `json:"dirty"`           // cmn.DirtyObjMD value
+		Queued int64  `json:"queued,string"`   // unix nanoseconds
+		Next   int64  `json:"next,string"`     // next attempt (ditto)
+		Tries  int    `json:"tries,omitempty"` // failed attempts so far
+		Err    string `json:"err,omitempty"`   // most recent failure
+	}
+	wback struct {
+		t        *target
+		db       kvdb.Driver
+		workCh   chan string
+		stopCh   cos.StopCh
+		wg       sync.WaitGroup
+		retrying atomic.Bool
+	}
+)
+
+var errWbDirty = errors.New("object was updated while being written back")
+
+///////////
+// wback //
+///////////
+
+func (wb *wback) init(t *target, db kvdb.Driver) {
+	wb.t = t
+	wb.db = db
+	wb.workCh = make(chan string, wbQueueSize)
+	wb.stopCh.Init()
+	for range wbWorkers {
+		wb.wg.Add(1)
+		go wb.work()
+	}
+	hk.Reg(wbCollection+hk.NameSuffix, wb.housekeep, wbRetryIvl)
+}
+
+func (wb *wback) stop() {
+	if wb.db == nil {
+		return
+	}
+	wb.stopCh.Close()
+	wb.wg.Wait()
+}
+
+// mark dirty (is called prior to finalizing PUT)
+func markDirty(lom *core.LOM) {
+	lom.SetCustomKey(cmn.DirtyObjMD, strconv.FormatInt(time.Now().UnixNano(), 10))
+}
+
+// journal dirty object (write-ahead: is called under wlock prior to finalizing the object);
+// failure to journal fails the PUT - otherwise, the object would remain dirty with
+// nothing to write it back
+// (if the object doesn't get finalized, the entry is going to be removed by wb.upload)
+func (wb *wback) journal(lom *core.LOM) error {
+	if wb.db == nil {
+		return fmt.Errorf("%s: write-back journal is not initialized (%s)", wb.t, lom.Cname())
+	}
+	dirty, _ := lom.GetCustomKey(cmn.DirtyObjMD)
+	ent := &wbent{Dirty: dirty, Queued: time.Now().UnixNano()}
+	if err := wb.db.Set(wbCollection, lom.Uname(), ent); err != nil {
+		return cmn.NewErrFailedTo(wb.t, "journal write-back of", lom.Cname(), err)
+	}
+	return nil
+}
+
+// queue journaled object (is called once the object is finalized)
+func (wb *wback) post(key string) {
+	select {
+	case wb.workCh <- key:
+	default: // queue full (will be retried from the journal)
+	}
+}
+
+func (wb *wback) del(key string) {
+	if err := wb.db.Delete(wbCollection, key); err != nil && !cos.IsNotExist(err, 0) {
+		nlog.Errorln(wb.t.String(), "failed to remove write-back entry:", err)
+	}
+}
+
+func (wb *wback) work() {
+	defer wb.wg.Done()
+	for {
+		select {
+		case key := <-wb.workCh:
+			wb.flush(key, false /*force*/)
+		case <-wb.stopCh.Listen():
+			return
+		}
+	}
+}
+
+// write back one journaled object unless its next attempt is not due yet (and not forced)
+func (wb *wback) flush(key string, force bool) error {
+	ent := &wbent{}
+	if err := wb.db.Get(wbCollection, key, ent); err != nil {
+		return nil // (flushed or removed meanwhile)
+	}
+	if !force && ent.Next > time.Now().UnixNano() {
+		return nil
+	}
+	bck, objName := cmn.ParseUname(key)
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(&bck); err != nil {
+		if cmn.IsErrBucketNought(err) {
+			wb.del(key) // bucket's gone
+			return nil
+		}
+		return err
+	}
+	ecode, err := wb.upload(lom, key)
+	if err == nil || err == errWbDirty {
+		return err
+	}
+
+	// failed: schedule next attempt
+	ent.Tries++
+	ent.Err = err.Error()
+	backoff := min(wbBackoff<<min(ent.Tries-1, 16), wbMaxBackoff)
+	ent.Next = time.Now().Add(backoff).UnixNano()
+	if errV := wb.db.Set(wbCollection, key, ent); errV != nil {
+		nlog.Errorln(wb.t.String(), "failed to update write-back entry:", errV)
+	}
+	nlog.Warningf("%s: failed to write back %s (attempt %d): %v(%d) - retrying in %v",
+		wb.t, lom.Cname(), ent.Tries, err, ecode, backoff)
+	return err
+}
+
+// upload under rlock; then, under wlock, clear the "dirty" marker unless the object got
+// updated in the meantime
+func (wb *wback) upload(lom *core.LOM, key string) (int, error) {
+	lom.Lock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		if cos.IsNotExist(err, 0) || cmn.IsErrObjNought(err) {
+			wb.del(key) // removed meanwhile
+			return 0, nil
+		}
+		return 0, err
+	}
+	dirty, ok := lom.GetCustomKey(cmn.DirtyObjMD)
+	if !ok {
+		lom.Unlock(false)
+		wb.del(key)
+		return 0, nil
+	}
+	ecode, err := wb.t.putBack(lom)
+	lom.Unlock(false)
+	if err != nil {
+		return ecode, err
+	}
+
+	// the uploaded `lom` now has remote metadata (version, ETag, etc.)
+	clean := core.AllocLOM(lom.ObjName)
+	defer core.FreeLOM(clean)
+	if err := clean.InitBck(lom.Bucket()); err != nil {
+		return 0, err
+	}
+	clean.Lock(true)
+	defer clean.Unlock(true)
+	if err := clean.Load(true /*cache it*/, true /*locked*/); err != nil {
+		wb.del(key)
+		return 0, nil
+	}
+	if v, _ := clean.GetCustomKey(cmn.DirtyObjMD); v != dirty {
+		// updated (and journaled again) while being uploaded
+		wb.post(key)
+		return 0, errWbDirty
+	}
+	clean.CopyVersion(lom)
+	for k, v := range lom.GetCustomMD() {
+		clean.SetCustomKey(k, v)
+	}
+	clean.DelCustomKey(cmn.DirtyObjMD)
+	if err := clean.PersistMain(); err != nil {
+		return 0, err
+	}
+	clean.Recache()
+	wb.del(key)
+	return 0, nil
+}
+
+// evict (or remove local copy of) dirty object: flush it first
+// (is called under wlock)
+func (wb *wback) evict(lom *core.LOM) (int, error) {
+	ecode, err := wb.t.putBack(lom)
+	if err == nil {
+		wb.del(lom.Uname())
+	}
+	return ecode, err
+}
+
+func (wb *wback) housekeep(int64) time.Duration {
+	if wb.retrying.CAS(false, true) {
+		go wb.retry()
+	}
+	return wbRetryIvl
+}
+
+// re-post journaled objects that are due (oldest first)
+func (wb *wback) retry() {
+	defer wb.retrying.Store(false)
+	all, err := wb.db.GetAll(wbCollection, "")
+	if err != nil || len(all) == 0 {
+		return
+	}
+	var (
+		now  = time.Now().UnixNano()
+		due  = make([]string, 0, len(all))
+		ents = make(map[string]int64, len(all))
+	)
+	for key, val := range all {
+		ent := &wbent{}
+		if err := jsoniter.UnmarshalFromString(val, ent); err != nil || ent.Next > now {
+			continue
+		}
+		due = append(due, key)
+		ents[key] = ent.Queued
+	}
+	sort.Slice(due, func(i, j int) bool { return ents[due[i]] < ents[due[j]] })
+	for _, key := range due {
+		select {
+		case wb.workCh <- key:
+		case <-wb.stopCh.Listen():
+			return
+		}
+	}
+}
+
+// synchronously flush all journaled objects - or only those in a given bucket -
+// until done or timeout; returns the number of objects remaining dirty
+func (wb *wback) flushAll(bck *cmn.Bck, timeout time.Duration) (int, error) {
+	if wb.db == nil {
+		return 0, nil
+	}
+	var (
+		prefix   string
+		deadline = time.Now().Add(timeout)
+	)
+	if bck != nil {
+		prefix = string(bck.MakeUname(""))
+	}
+	for {
+		keys, err := wb.db.List(wbCollection, prefix)
+		if err != nil {
+			return 0, err
+		}
+		if len(keys) == 0 {
+			return 0, nil
+		}
+		var errs []error
+		for _, key := range keys {
+			if time.Now().After(deadline) {
+				return len(keys), fmt.Errorf("timed out flushing %d dirty object%s", len(keys), cos.Plural(len(keys)))
+			}
+			if err := wb.flush(key, true /*force*/); err != nil && err != errWbDirty {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 {
+			keys, _ = wb.db.List(wbCollection, prefix)
+			return len(keys), fmt.Errorf("failed to flush %d dirty object%s: %w", len(keys), cos.Plural(len(keys)), errs[0])
+		}
+		time.Sleep(wbFlushPeriod)
+	}
+}
+
+// flush remote bucket prior to evicting it
+func (wb *wback) flushBck(bck *meta.Bck, timeout time.Duration) error {
+	if !bck.IsRemote() {
+		return nil
+	}
+	n, err := wb.flushAll(bck.Bucket(), timeout)
+	if err != nil {
+		return fmt.Errorf("%s: cannot evict %s with %d dirty (not yet written back) object%s: %w",
+			wb.t, bck.Cname(""), n, cos.Plural(n), err)
+	}
+	return nil
+}
+
+// flush all upon maintenance and decommission: in the pre-phase (apc.ActFlushWriteBack),
+// fail the action while there are dirty objects remaining; in the final step, proceed
+// anyway (`skip`) - not yet written back data may be lost
+func (wb *wback) flushNode(action string, timeout time.Duration, skip bool) error {
+	n, err := wb.flushAll(nil, timeout)
+	switch {
+	case err == nil:
+		if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+			nlog.Infoln(wb.t.String(), action, "- write-back journal is empty")
+		}
+		return nil
+	case skip:
+		nlog.Errorln(wb.t.String(), action, "- proceeding anyway:", err)
+		return nil
+	default:
+		return fmt.Errorf("%s: cannot %s with %d dirty (not yet written back) object%s: %w",
+			wb.t, action, n, cos.Plural(n), err)
+	}
+}
+
+// upload (rlocked or wlocked) object to its remote backend (compare with poi.putRemote)
+func (t *target) putBack(lom *core.LOM) (int, error) {
+	fh, err := lom.OpenPlain()
+	if err != nil {
+		return 0, err
+	}
+	if lom.IsEncoded() {
+		lom.SetSize(lom.PlainSize())
+	}
+	lom.ObjAttrs().DelStdCustom() // backend.PutObj() will set updated values
+	backend := t.Backend(lom.Bck())
+	ecode, err := backend.PutObj(fh, lom, nil /*origReq*/)
+	if err != nil {
+		return ecode, err
+	}
+	if !lom.Bck().IsRemoteAIS() {
+		lom.SetCustomKey(cmn.SourceObjMD, backend.Provider())
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleAIS) {
+		nlog.Infoln(t.String(), "written back", lom.Cname())
+	}
+	return 0, nil
+}
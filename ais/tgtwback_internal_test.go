@@ -0,0 +1,277 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/readers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type (
+	// remote backend that counts write-back attempts and uploads
+	wbBackend struct {
+		core.Backend // (other methods are not called)
+		err          error
+		calls        atomic.Int32
+		puts         atomic.Int32
+	}
+	// journal that cannot be written
+	wbFailDB struct {
+		kvdb.Driver
+	}
+)
+
+func (*wbBackend) Provider() string { return apc.AWS }
+
+func (bp *wbBackend) PutObj(r io.ReadCloser, lom *core.LOM, _ *http.Request) (int, error) {
+	defer cos.Close(r)
+	bp.calls.Inc()
+	if bp.err != nil {
+		return http.StatusServiceUnavailable, bp.err
+	}
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return 0, err
+	}
+	n := bp.puts.Inc()
+	lom.SetVersion(strconv.Itoa(int(n)))
+	return 0, nil
+}
+
+func (*wbFailDB) Set(string, string, any) error { return errors.New("journal is read-only") }
+
+var _ = Describe("Write-back", func() {
+	const bucketName = "wback"
+
+	var (
+		bck = meta.NewBck(bucketName, apc.AWS, cmn.NsGlobal)
+		bp  *wbBackend
+
+		put = func(objName string) *core.LOM {
+			const size = 4 * cos.KiB
+			lom := core.AllocLOM(objName)
+			Expect(lom.InitBck(bck.Bucket())).NotTo(HaveOccurred())
+			r, _ := readers.NewRand(size, cos.ChecksumNone)
+			params := &core.PutParams{Reader: r, Atime: time.Now(), WorkTag: "wbtest", Size: size, OWT: cmn.OwtPut}
+			err := t.PutObject(lom, params)
+			core.FreePutParams(params)
+			Expect(err).NotTo(HaveOccurred())
+			return lom
+		}
+		entry = func(lom *core.LOM) (*wbent, error) {
+			ent := &wbent{}
+			return ent, t.wb.db.Get(wbCollection, lom.Uname(), ent)
+		}
+		dirty = func(lom *core.LOM) bool {
+			lom.UncacheUnless()
+			Expect(lom.Load(false /*cache it*/, false /*locked*/)).NotTo(HaveOccurred())
+			return lom.IsDirty()
+		}
+		drain = func() {
+			for {
+				select {
+				case <-t.wb.workCh:
+				default:
+					return
+				}
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		// remote bucket with write-back data policy
+		bmd := t.owner.bmd.get()
+		if _, present := bmd.Get(bck); !present {
+			clone := bmd.clone()
+			clone.add(bck, &cmn.Bprops{
+				Cksum:       cmn.CksumConf{Type: cos.ChecksumNone},
+				WritePolicy: cmn.WritePolicyConf{Data: apc.WriteBack, MD: apc.WriteImmediate},
+			})
+			Expect(t.owner.bmd.putPersist(clone, nil)).NotTo(HaveOccurred())
+			Expect(fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)).To(BeEmpty())
+		}
+		// remote backend
+		bp = &wbBackend{}
+		config := cmn.GCO.Get()
+		if config.Backend.Providers == nil {
+			config.Backend.Providers = make(map[string]cmn.Ns, 1)
+		}
+		config.Backend.Providers[apc.AWS] = cmn.NsGlobal
+		if t.backend == nil {
+			t.backend = make(backends, 1)
+		}
+		t.backend[apc.AWS] = bp
+
+		// journal (no workers: flushing synchronously)
+		t.wb.t = t
+		t.wb.db = mock.NewDBDriver()
+		t.wb.workCh = make(chan string, wbQueueSize)
+	})
+
+	AfterEach(func() {
+		delete(cmn.GCO.Get().Backend.Providers, apc.AWS)
+		delete(t.backend, apc.AWS)
+		t.wb.db = nil
+	})
+
+	It("should journal dirty object and remove the entry once written back", func() {
+		lom := put("journal")
+		defer core.FreeLOM(lom)
+		Expect(dirty(lom)).To(BeTrue())
+
+		ent, err := entry(lom)
+		Expect(err).NotTo(HaveOccurred())
+		v, _ := lom.GetCustomKey(cmn.DirtyObjMD)
+		Expect(ent.Dirty).To(Equal(v))
+		Expect(<-t.wb.workCh).To(Equal(lom.Uname()))
+
+		Expect(t.wb.flush(lom.Uname(), false /*force*/)).NotTo(HaveOccurred())
+		Expect(bp.puts.Load()).To(BeEquivalentTo(1))
+		_, err = entry(lom)
+		Expect(err).To(HaveOccurred())
+		Expect(dirty(lom)).To(BeFalse())
+		Expect(lom.Version()).To(Equal("1"))
+	})
+
+	It("should fail PUT that cannot be journaled", func() {
+		t.wb.db = &wbFailDB{mock.NewDBDriver()}
+		lom := core.AllocLOM("nojournal")
+		defer core.FreeLOM(lom)
+		Expect(lom.InitBck(bck.Bucket())).NotTo(HaveOccurred())
+		r, _ := readers.NewRand(cos.KiB, cos.ChecksumNone)
+		params := &core.PutParams{Reader: r, Atime: time.Now(), WorkTag: "wbtest", Size: cos.KiB, OWT: cmn.OwtPut}
+		err := t.PutObject(lom, params)
+		core.FreePutParams(params)
+		Expect(err).To(HaveOccurred())
+
+		// not finalized
+		Expect(lom.Load(false /*cache it*/, false /*locked*/)).To(HaveOccurred())
+		Expect(bp.calls.Load()).To(BeEquivalentTo(0))
+	})
+
+	It("should retry with exponential backoff", func() {
+		lom := put("retry")
+		defer core.FreeLOM(lom)
+		drain()
+		key := lom.Uname()
+
+		// 1st failure
+		bp.err = errors.New("remote is down")
+		started := time.Now()
+		Expect(t.wb.flush(key, false /*force*/)).To(HaveOccurred())
+		ent, err := entry(lom)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ent.Tries).To(Equal(1))
+		Expect(ent.Err).To(ContainSubstring("remote is down"))
+		Expect(ent.Next).To(BeNumerically(">=", started.Add(wbBackoff).UnixNano()))
+
+		// not due yet: neither attempted nor re-posted
+		Expect(t.wb.flush(key, false /*force*/)).NotTo(HaveOccurred())
+		Expect(bp.calls.Load()).To(BeEquivalentTo(1))
+		t.wb.retry()
+		Expect(t.wb.workCh).To(BeEmpty())
+
+		// 2nd (forced) failure doubles the backoff
+		started = time.Now()
+		Expect(t.wb.flush(key, true /*force*/)).To(HaveOccurred())
+		ent, err = entry(lom)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ent.Tries).To(Equal(2))
+		Expect(ent.Next).To(BeNumerically(">=", started.Add(2*wbBackoff).UnixNano()))
+		Expect(dirty(lom)).To(BeTrue())
+
+		// when due, re-posted and written back
+		ent.Next = time.Now().UnixNano()
+		Expect(t.wb.db.Set(wbCollection, key, ent)).NotTo(HaveOccurred())
+		t.wb.retry()
+		Expect(<-t.wb.workCh).To(Equal(key))
+
+		bp.err = nil
+		Expect(t.wb.flush(key, false /*force*/)).NotTo(HaveOccurred())
+		Expect(bp.puts.Load()).To(BeEquivalentTo(1))
+		_, err = entry(lom)
+		Expect(err).To(HaveOccurred())
+		Expect(dirty(lom)).To(BeFalse())
+	})
+
+	It("should write back dirty object prior to evicting it", func() {
+		lom := put("evict")
+		defer core.FreeLOM(lom)
+		drain()
+
+		evict := func() error {
+			lom.Lock(true)
+			defer lom.Unlock(true)
+			_, err, _ := t.delobj(lom, true /*evict*/, false /*bypass*/)
+			return err
+		}
+
+		// cannot write back => cannot evict
+		bp.err = errors.New("remote is down")
+		Expect(evict()).To(HaveOccurred())
+		Expect(dirty(lom)).To(BeTrue())
+		_, err := entry(lom)
+		Expect(err).NotTo(HaveOccurred())
+
+		bp.err = nil
+		Expect(evict()).NotTo(HaveOccurred())
+		Expect(bp.puts.Load()).To(BeEquivalentTo(1))
+		_, err = entry(lom)
+		Expect(err).To(HaveOccurred())
+		lom.UncacheUnless()
+		Expect(lom.Load(false /*cache it*/, false /*locked*/)).To(HaveOccurred())
+	})
+
+	It("should flush all upon maintenance and decommission", func() {
+		const num = 3
+		loms := make([]*core.LOM, 0, num)
+		for i := range num {
+			loms = append(loms, put("node-"+strconv.Itoa(i)))
+		}
+		defer func() {
+			for _, lom := range loms {
+				core.FreeLOM(lom)
+			}
+		}()
+		drain()
+
+		// fail the action while dirty objects remain...
+		bp.err = errors.New("remote is down")
+		err := t.wb.flushNode(apc.ActStartMaintenance, time.Minute, false /*skip*/)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("3 dirty"))
+
+		// ...unless explicitly requested to skip write-back
+		Expect(t.wb.flushNode(apc.ActDecommissionNode, time.Minute, true /*skip*/)).NotTo(HaveOccurred())
+		keys, err := t.wb.db.List(wbCollection, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(HaveLen(num))
+
+		bp.err = nil
+		Expect(t.wb.flushNode(apc.ActDecommissionNode, time.Minute, false /*skip*/)).NotTo(HaveOccurred())
+		Expect(bp.puts.Load()).To(BeEquivalentTo(num))
+		keys, err = t.wb.db.List(wbCollection, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(BeEmpty())
+		for _, lom := range loms {
+			Expect(dirty(lom)).To(BeFalse())
+		}
+	})
+})
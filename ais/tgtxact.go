@@ -236,6 +236,14 @@ func (t *target) xstart(args *xact.ArgsMsg, bck *meta.Bck, msg *apc.ActMsg) (xid
 		}
 		go t.runSpaceCleanup(args, wg)
 		wg.Wait()
+	case apc.ActLifecycle:
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		if len(args.Buckets) == 0 && !args.Bck.IsEmpty() {
+			args.Buckets = []cmn.Bck{args.Bck}
+		}
+		go t.runLifecycle(args, wg)
+		wg.Wait()
 	case apc.ActResilver:
 		if bck != nil {
 			nlog.Errorf(erfmb, args.Kind, bck)
@@ -5,12 +5,18 @@
 package ais
 
 import (
+	"bytes"
+	"cmp"
 	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net"
+	"net/textproto"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -18,6 +24,7 @@ import (
 	"github.com/NVIDIA/aistore/api/env"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -36,6 +43,10 @@ const (
 
 const fmtErrParseIP = "failed to parse local unicast IP address: %s"
 
+// max number of byte ranges in a single (multi-range) read request - the Range header with more
+// ranges is ignored, and the entire content is returned (see parseMultiRange)
+const maxMultiRanges = 64
+
 // Network access of handlers (Public, IntraControl, & IntraData)
 type (
 	netAccess int
@@ -316,13 +327,48 @@ func (r htrange) contentRange(size int64) string {
 	return fmt.Sprintf("%s%d-%d/%d", cos.HdrContentRangeValPrefix, r.Start, r.Start+r.Length-1, size)
 }
 
+// multipart/byteranges body (RFC 7233, Appendix A) that comprises all the requested
+// ranges of the [0, size) content; returns the body reader along with its exact
+// length (to set Content-Length upfront) and the corresponding content type
+func mpByteranges(ranges []htrange, size int64, ra io.ReaderAt) (io.Reader, int64, string) {
+	var (
+		bb     bytes.Buffer
+		mw     = multipart.NewWriter(&bb)
+		rs     = make([]io.Reader, 0, 2*len(ranges)+1)
+		length int64
+	)
+	for i := range ranges {
+		r := &ranges[i]
+		hdr := textproto.MIMEHeader{
+			cos.HdrContentType:  []string{cos.ContentBinary},
+			cos.HdrContentRange: []string{r.contentRange(size)},
+		}
+		_, err := mw.CreatePart(hdr) // (writes boundary and part header into bb)
+		debug.AssertNoErr(err)
+		b := bytes.Clone(bb.Bytes())
+		bb.Reset()
+		rs = append(rs, bytes.NewReader(b), io.NewSectionReader(ra, r.Start, r.Length))
+		length += int64(len(b)) + r.Length
+	}
+	mw.Close() // (closing boundary)
+	rs = append(rs, bytes.NewReader(bytes.Clone(bb.Bytes())))
+	length += int64(bb.Len())
+	return io.MultiReader(rs...), length, cos.ContentMultiByteranges + "; boundary=" + mw.Boundary()
+}
+
 // ParseMultiRange parses a Range Header string as per RFC 7233.
 // ErrNoOverlap is returned if none of the ranges overlap with the [0, size) content.
+// In addition:
+//   - more than maxMultiRanges ranges: the header is ignored (nil ranges, nil error - RFC 7233, Section 3.1);
+//   - multiple ranges are sorted, and those that overlap or are adjacent get coalesced (RFC 7233, Section 4.1).
 func parseMultiRange(s string, size int64) (ranges []htrange, err error) {
 	var noOverlap bool
 	if !strings.HasPrefix(s, cos.HdrRangeValPrefix) {
 		return nil, fmt.Errorf("read range %q is invalid (prefix)", s)
 	}
+	if strings.Count(s, ",") >= maxMultiRanges {
+		return nil, nil
+	}
 	allRanges := strings.Split(s[len(cos.HdrRangeValPrefix):], ",")
 	for _, ra := range allRanges {
 		ra = strings.TrimSpace(ra)
@@ -385,7 +431,52 @@ func parseMultiRange(s string, size int64) (ranges []htrange, err error) {
 	if noOverlap && len(ranges) == 0 {
 		return nil, cmn.NewErrRangeNotSatisfiable(nil, allRanges, size)
 	}
-	return ranges, nil
+	return coalesce(ranges), nil
+}
+
+func coalesce(ranges []htrange) []htrange {
+	if len(ranges) < 2 {
+		return ranges
+	}
+	slices.SortFunc(ranges, func(a, b htrange) int { return cmp.Compare(a.Start, b.Start) })
+	j := 0
+	for i := 1; i < len(ranges); i++ {
+		prev, r := &ranges[j], &ranges[i]
+		if end := prev.Start + prev.Length; r.Start <= end {
+			prev.Length = max(end, r.Start+r.Length) - prev.Start
+			continue
+		}
+		j++
+		ranges[j] = *r
+	}
+	return ranges[:j+1]
+}
+
+// forward-only io.ReaderAt over a sequential reader (e.g., archived file): serves
+// sorted non-overlapping ranges (as in: parseMultiRange => mpByteranges)
+type seqReaderAt struct {
+	r   io.Reader
+	off int64
+}
+
+func (sr *seqReaderAt) ReadAt(b []byte, off int64) (n int, err error) {
+	if off < sr.off {
+		return 0, fmt.Errorf("cannot read at %d: already at %d (sequential reader)", off, sr.off)
+	}
+	if off > sr.off {
+		var skipped int64
+		skipped, err = io.CopyN(io.Discard, sr.r, off-sr.off)
+		sr.off += skipped
+		if err != nil {
+			return 0, err
+		}
+	}
+	n, err = io.ReadFull(sr.r, b)
+	sr.off += int64(n)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
 }
 
 //
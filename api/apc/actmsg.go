@@ -36,6 +36,11 @@ const (
 
 	ActBlobDl = "blob-download"
 
+	// multipart upload (see also: QparamMptUploadID, QparamMptPartNum)
+	ActMptCreate   = "mpt-create"
+	ActMptComplete = "mpt-complete"
+	ActMptAbort    = "mpt-abort"
+
 	ActMakeNCopies = "make-n-copies"
 	ActPutCopies   = "put-copies"
 
@@ -48,6 +53,7 @@ const (
 
 	ActLRU          = "lru"
 	ActStoreCleanup = "cleanup-store"
+	ActLifecycle    = "lifecycle" // execute bucket lifecycle rules (see cmn/lifecycle.go)
 
 	ActEvictRemoteBck = "evict-remote-bck" // evict remote bucket's data
 	ActInvalListCache = "inval-listobj-cache"
@@ -74,6 +80,9 @@ const (
 	ActPrefetchObjects = "prefetch-listrange"
 	ActArchive         = "archive" // see ArchiveMsg
 
+	// multi-object GET (see cmn.GetBatchMsg)
+	ActGetBatch = "get-batch"
+
 	ActAttachRemAis = "attach"
 	ActDetachRemAis = "detach"
 
@@ -109,6 +118,8 @@ const (
 	ActSelfRemove   = "self-initiated-removal" // e.g., when losing last mountpath
 	ActPrimaryForce = "primary-force"          // set primary with force (BEWARE! advanced usage only)
 	ActBumpMetasync = "bump-metasync"          // when executing ActPrimaryForce - the final step
+
+	ActFlushWriteBack = "flush-write-back" // primary => target(s) to be put in maintenance or decommissioned (pre-phase)
 )
 
 const (
@@ -125,6 +136,10 @@ const (
 	ActXactStop  = Stop
 	ActXactStart = Start
 
+	// Actions on scheduled jobs (see cmn/sched.go)
+	ActSchedAdd    = "sched-add"
+	ActSchedRemove = "sched-rm"
+
 	// auxiliary
 	ActTransient = "transient" // transient - in-memory only
 )
@@ -162,6 +177,7 @@ type (
 		RmUserData        bool   `json:"rm_user_data"`        // decommission-only
 		KeepInitialConfig bool   `json:"keep_initial_config"` // ditto (to be able to restart a node from scratch)
 		NoShutdown        bool   `json:"no_shutdown"`
+		SkipWriteBack     bool   `json:"skip_write_back,omitempty"` // proceed even if dirty (not yet written back) objects remain
 	}
 )
 
@@ -26,12 +26,24 @@ type (
 			Max int64 `json:"obj_max_size"`
 		}
 		TotalSize struct {
-			OnDisk      uint64 `json:"size_on_disk,string"`          // sum(dir sizes) aka "apparent size"
-			PresentObjs uint64 `json:"size_all_present_objs,string"` // sum(cached object sizes)
-			RemoteObjs  uint64 `json:"size_all_remote_objs,string"`  // sum(all object sizes in a remote bucket)
-			Disks       uint64 `json:"total_disks_size,string"`
+			OnDisk         uint64 `json:"size_on_disk,string"`             // sum(dir sizes) aka "apparent size"
+			PresentObjs    uint64 `json:"size_all_present_objs,string"`    // sum(cached object sizes)
+			PresentLogical uint64 `json:"size_all_present_logical,string"` // (ditto) uncompressed and decrypted
+			RemoteObjs     uint64 `json:"size_all_remote_objs,string"`     // sum(all object sizes in a remote bucket)
+			Disks          uint64 `json:"total_disks_size,string"`
+			DedupLogical   uint64 `json:"size_dedup_logical,string"` // sum(deduplicated object sizes)
+			DedupChunks    uint64 `json:"size_dedup_chunks,string"`  // sum(dedup chunk sizes) - bucket-wide, no prefix
 		}
 		UsedPct      uint64 `json:"used_pct"`
 		IsBckPresent bool   `json:"is_present"` // in BMD
 	}
 )
+
+// DedupRatio returns the ratio of deduplicated content size to the size of the dedup chunks
+// that store it, or zero if not applicable (see cmn/dedup.go)
+func (bs *BsummResult) DedupRatio() float64 {
+	if bs.TotalSize.DedupChunks == 0 {
+		return 0
+	}
+	return float64(bs.TotalSize.DedupLogical) / float64(bs.TotalSize.DedupChunks)
+}
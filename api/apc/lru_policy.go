@@ -0,0 +1,41 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import "fmt"
+
+// LRU eviction policy (enum and accessors)
+// bucket-configurable with global defaults via cluster config (see cmn.LRUConf and space/lru.go)
+type LRUPolicy string
+
+const (
+	LRUPolicyLRU  = LRUPolicy("lru")  // least recently used (default)
+	LRUPolicyLFU  = LRUPolicy("lfu")  // least frequently used; ties broken by access time
+	LRUPolicyGDSF = LRUPolicy("gdsf") // size-aware GreedyDual variant: large, cold, and rarely accessed objects go first
+	LRUPolicyTTL  = LRUPolicy("ttl")  // remote buckets: evict objects cached longer than `lru.ttl`, then same as "lru"
+
+	LRUPolicyDefault = LRUPolicy("") // same as `LRUPolicyLRU`
+)
+
+var SupportedLRUPolicy = [...]string{string(LRUPolicyLRU), string(LRUPolicyLFU), string(LRUPolicyGDSF), string(LRUPolicyTTL)}
+
+func (p LRUPolicy) IsLRU() bool { return p == LRUPolicyDefault || p == LRUPolicyLRU }
+
+// access counters must be maintained (see core/lom.go)
+func (p LRUPolicy) CountsHits() bool { return p == LRUPolicyLFU || p == LRUPolicyGDSF }
+
+func (p LRUPolicy) String() string {
+	if p == LRUPolicyDefault {
+		return string(LRUPolicyLRU)
+	}
+	return string(p)
+}
+
+func (p LRUPolicy) Validate() (err error) {
+	if p.IsLRU() || p == LRUPolicyLFU || p == LRUPolicyGDSF || p == LRUPolicyTTL {
+		return
+	}
+	return fmt.Errorf("invalid LRU policy %q (expecting one of %v)", p, SupportedLRUPolicy)
+}
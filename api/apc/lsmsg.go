@@ -104,6 +104,7 @@ const (
 	EntryIsArchive  = 1 << (EntryStatusBits + 4)
 	EntryVerChanged = 1 << (EntryStatusBits + 5) // see also: QparamLatestVer, et al.
 	EntryVerRemoved = 1 << (EntryStatusBits + 6) // ditto
+	EntryIsDirty    = 1 << (EntryStatusBits + 7) // not yet written back (see WriteBack)
 )
 
 // ObjEntry.Flags field
@@ -151,6 +152,7 @@ type LsoMsg struct {
 	SID               string      `json:"target"`                // selected target to solely execute backend.list-objects
 	Flags             uint64      `json:"flags,string"`          // enum {LsObjCached, ...} - "LsoMsg flags" above
 	PageSize          int64       `json:"pagesize"`              // max entries returned by list objects call
+	Tags              cos.StrKVs  `json:"tags,omitempty"`        // return only in-cluster objects tagged with all of the tags (logical AND)
 }
 
 ////////////
@@ -0,0 +1,16 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+// multipart upload: ActMptComplete message (ActMsg.Name carries upload ID)
+type (
+	MptPart struct {
+		ETag string `json:"etag,omitempty"` // as returned by PUT(part); when specified, gets validated
+		Num  int32  `json:"num"`            // part number: 1 to 10000
+	}
+	MptCompleteMsg struct {
+		Parts []MptPart `json:"parts"`
+	}
+)
@@ -4,12 +4,16 @@
  */
 package apc
 
+import "github.com/NVIDIA/aistore/cmn/cos"
+
 // (common for all multi-object operations)
 type (
 	// List of object names _or_ a template specifying { optional Prefix, zero or more Ranges }
+	// optionally, filtered by object tags (see cmn/objtags.go)
 	ListRange struct {
-		Template string   `json:"template"`
-		ObjNames []string `json:"objnames"`
+		Template string     `json:"template"`
+		ObjNames []string   `json:"objnames"`
+		Tags     cos.StrKVs `json:"tags,omitempty"` // only in-cluster objects tagged with all of the tags (logical AND)
 	}
 )
 
@@ -34,7 +38,7 @@ type PrefetchMsg struct {
 // at the specified (bucket) destination.
 // See also: api.PutApndArchArgs
 // --------------------  terminology   ---------------------
-// here and elsewhere "archive" is any (.tar, .tgz/.tar.gz, .zip, .tar.lz4) formatted object.
+// here and elsewhere "archive" is any (.tar, .tgz/.tar.gz, .zip, .tar.lz4, .tar.zst) formatted object.
 // [NOTE] see cmn/api for cmn.ArchiveMsg (that also contains ToBck)
 type ArchiveMsg struct {
 	TxnUUID     string `json:"-"`        // internal use
@@ -57,3 +61,13 @@ type TCOMsg struct {
 	NumWorkers      int  `json:"num-workers"` // user-defined num concurrent workers; 0 - number of mountpaths (default); (-1) none
 	ContinueOnError bool `json:"coer"`
 }
+
+// multi-object GET (see cmn.GetBatchMsg): what to do when a requested object,
+// or a file archived in the requested object (shard), does not exist
+const (
+	GbMissingSkip        = "skip"        // omit (default)
+	GbMissingError       = "error"       // fail the entire request
+	GbMissingPlaceholder = "placeholder" // include zero-size entry named GbMissingPrefix + <name>
+)
+
+const GbMissingPrefix = "__404__/"
@@ -15,8 +15,9 @@ const (
 	Azure = "azure"
 	GCP   = "gcp"
 	HT    = "ht"
+	File  = "file" // directory tree on a filesystem shared by all targets (e.g., NFS)
 
-	AllProviders = "ais, aws (s3://), gcp (gs://), azure (az://), ht://" // NOTE: must include all
+	AllProviders = "ais, aws (s3://), gcp (gs://), azure (az://), ht://, file://" // NOTE: must include all
 
 	NsUUIDPrefix = '@' // BEWARE: used by on-disk layout
 	NsNamePrefix = '#' // BEWARE: used by on-disk layout
@@ -34,12 +35,14 @@ const (
 
 const RemAIS = "remais" // to differentiate ais vs ais; also, default (remote ais cluster) alias
 
-var Providers = cos.NewStrSet(AIS, GCP, AWS, Azure, HT)
+var Providers = cos.NewStrSet(AIS, GCP, AWS, Azure, HT, File)
 
 func IsProvider(p string) bool { return Providers.Contains(p) }
 
+// NOTE: includes `file://` - a shared directory tree that, for all intents and purposes
+// (listing, cold GET, write-through PUT, enable/disable), is treated as a cloud
 func IsCloudProvider(p string) bool {
-	return p == AWS || p == GCP || p == Azure
+	return p == AWS || p == GCP || p == Azure || p == File
 }
 
 // NOTE: not to confuse w/ bck.IsRemote() which also includes remote AIS
@@ -90,6 +93,8 @@ func DisplayProvider(p string) string {
 		return "GCP"
 	case HT:
 		return "HTTP(S)"
+	case File:
+		return "File"
 	default:
 		return p
 	}
@@ -62,6 +62,19 @@ const (
 	QparamAppendType   = "append_type"
 	QparamAppendHandle = "append_handle"
 
+	// PUT(multipart upload part) - see ActMptCreate et al.
+	QparamMptUploadID = "mpt_upload_id"
+	QparamMptPartNum  = "mpt_part_num"
+
+	// PUT: AuthN user's cross-bucket quota - internal use (proxy => target redirect)
+	QparamUserQuota = "user_quota"
+
+	// GET and PUT: AuthN user's bandwidth limit - internal use (ditto)
+	QparamUserRate = "user_rate"
+
+	// signature of the user limits above - internal use (ditto); clients cannot specify any of the three
+	QparamUserSig = "user_sig"
+
 	// HTTP bucket support.
 	QparamOrigURL = "original_url"
 
@@ -71,7 +84,7 @@ const (
 	QparamAllLogs = "all"
 
 	// The following 4 (four) QparamArch* parameters are all intended for usage with sharded datasets,
-	// whereby the shards are (.tar, .tgz (or .tar.gz), .zip, and/or .tar.lz4) formatted objects.
+	// whereby the shards are (.tar, .tgz (or .tar.gz), .zip, .tar.lz4, and/or .tar.zst) formatted objects.
 	//
 	// For the most recently updated list of supported serialization formats, please see cmn/archive package.
 	//
@@ -251,6 +264,9 @@ const (
 	WhatQueryXactStats  = "qryxstats"   // stats: all matching xactions
 	WhatAllRunningXacts = "running_all" // e.g. e.g.: put-copies[D-ViE6HEL_j] list[H96Y7bhR2s] ...
 
+	// scheduled jobs
+	WhatSchedules = "schedules"
+
 	// internal
 	WhatSnode    = "snode"
 	WhatICBundle = "ic_bundle"
@@ -32,6 +32,7 @@ const (
 	Clusters  = "clusters" // AuthN
 	Roles     = "roles"    // AuthN
 	IC        = "ic"       // information center
+	GetBatch  = "gb"       // multi-object GET
 
 	// l3 ---
 
@@ -108,6 +109,7 @@ var (
 	URLPathIC       = urlpath(Version, IC)
 	URLPathHealth   = urlpath(Version, Health)
 	URLPathMetasync = urlpath(Version, Metasync)
+	URLPathGB       = urlpath(Version, GetBatch)
 
 	URLPathClu        = urlpath(Version, Cluster)
 	URLPathCluProxy   = urlpath(Version, Cluster, Proxy)
@@ -15,15 +15,20 @@ const (
 	WriteDelayed   = WritePolicy("delayed")   // cache and flush when not accessed for a while (lom_cache_hk.go)
 	WriteNever     = WritePolicy("never")     // transient - in-memory only
 
+	// data only: remote buckets - PUT completes once the object is stored in-cluster;
+	// uploading to remote backend is done asynchronously (see ais/tgtwback.go)
+	WriteBack = WritePolicy("writeback")
+
 	WriteDefault = WritePolicy("") // same as `WriteImmediate` - see IsImmediate() below
 )
 
-var SupportedWritePolicy = [...]string{string(WriteImmediate), string(WriteDelayed), string(WriteNever)}
+var SupportedWritePolicy = [...]string{string(WriteImmediate), string(WriteDelayed), string(WriteNever), string(WriteBack)}
 
 func (wp WritePolicy) IsImmediate() bool { return wp == WriteDefault || wp == WriteImmediate }
+func (wp WritePolicy) IsWriteBack() bool { return wp == WriteBack }
 
 func (wp WritePolicy) Validate() (err error) {
-	if wp.IsImmediate() || wp == WriteDelayed || wp == WriteNever {
+	if wp.IsImmediate() || wp == WriteDelayed || wp == WriteNever || wp == WriteBack {
 		return
 	}
 	return fmt.Errorf("invalid write policy %q (expecting one of %v)", wp, SupportedWritePolicy)
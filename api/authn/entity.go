@@ -9,6 +9,7 @@ import (
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/jsp"
 )
 
@@ -18,9 +19,23 @@ const (
 
 type (
 	User struct {
-		ID       string  `json:"id"`
-		Password string  `json:"pass,omitempty"`
-		Roles    []*Role `json:"roles"`
+		ID        string         `json:"id"`
+		Password  string         `json:"pass,omitempty"`
+		Roles     []*Role        `json:"roles"`
+		Quota     *UserQuota     `json:"quota,omitempty"`
+		RateLimit *UserRateLimit `json:"rate_limit,omitempty"`
+	}
+
+	// optional cross-bucket storage quota (see cmn/quota.go); zero means no limit
+	UserQuota struct {
+		Size cos.SizeIEC `json:"size,omitempty"` // total size of the objects written by the user
+		Objs int64       `json:"objs,omitempty"` // number of objects written by the user
+	}
+
+	// optional rate limits (see cmn/ratelim.go); zero means no limit
+	UserRateLimit struct {
+		Ops int64       `json:"ops,omitempty"` // requests per second
+		Bps cos.SizeIEC `json:"bps,omitempty"` // bytes per second (GET and PUT)
 	}
 
 	CluACL struct {
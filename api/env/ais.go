@@ -34,6 +34,9 @@ var AIS = struct {
 	SkipVerifyCrt string
 	// TLS: server (aistore, AuthN) side (NOTE comment below)
 
+	// server-side encryption: master keys (target only)
+	SSEKeyfile string
+
 	// tests, CI
 	NumTarget string
 	NumProxy  string
@@ -76,6 +79,9 @@ var AIS = struct {
 	// TLS: common
 	SkipVerifyCrt: "AIS_SKIP_VERIFY_CRT", // cluster config: "net.http.skip_verify"
 
+	// pathname of the JSON file containing SSE master keys (see cmn/sse)
+	SSEKeyfile: "AIS_SSE_KEYFILE",
+
 	// variables used in tests and CI
 	NumTarget: "NUM_TARGET",
 	NumProxy:  "NUM_PROXY",
@@ -0,0 +1,37 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// GetBatch reads multiple objects and/or archived files (from one or more buckets) and writes
+// them to `w` as a single archive (default: .tar), in the order specified by `msg.Items`.
+// Returns the number of bytes written.
+// See also:
+// - cmn.GetBatchMsg
+// - apc.GbMissingSkip et al. in re missing objects and archived files
+func GetBatch(bp BaseParams, msg *cmn.GetBatchMsg, w io.Writer) (int64, error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathGB.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	wresp, err := reqParams.doWriter(w)
+	FreeRp(reqParams)
+	if err != nil {
+		return 0, err
+	}
+	return wresp.n, nil
+}
@@ -55,6 +55,8 @@ type (
 		//   For range formatting, see https://www.rfc-editor.org/rfc/rfc7233#section-2.1
 		// E.g. blob download:
 		// * Header.Set(apc.HdrBlobDownload, "true")
+		// E.g. conditional GET (304 Not Modified when the ETag matches):
+		// * Header.Set(cos.HdrIfNoneMatch, etag)
 		Header http.Header
 	}
 
@@ -92,6 +94,11 @@ type (
 		// - we massively write a new content into a bucket, and/or
 		// - we simply don't care.
 		SkipVC bool
+
+		// optional; e.g., conditional PUT:
+		// * Header.Set(cos.HdrIfNoneMatch, "*") - create-only, fail (412) if the object exists
+		// * Header.Set(cos.HdrIfMatch, etag)    - overwrite only the specified version (compare-and-swap)
+		Header http.Header
 	}
 )
 
@@ -132,6 +139,17 @@ type (
 		Object     string
 		Handle     string
 	}
+
+	// multipart upload: PUT(part)
+	UploadPartArgs struct {
+		Reader     cos.ReadOpenCloser
+		BaseParams BaseParams
+		Bck        cmn.Bck
+		Object     string
+		UploadID   string // as returned by api.CreateMptUpload
+		PartNum    int32  // 1 to 10000
+		Size       int64
+	}
 )
 
 // GET(object) =========================================================================================
@@ -282,6 +300,9 @@ func (args *PutArgs) put(reqArgs *cmn.HreqArgs) (*http.Request, error) {
 	if args.Size != 0 {
 		req.ContentLength = int64(args.Size) // as per https://tools.ietf.org/html/rfc7230#section-3.3.2
 	}
+	for k, v := range args.Header {
+		req.Header[k] = v
+	}
 	SetAuxHeaders(req, &args.BaseParams)
 	return req, nil
 }
@@ -445,7 +466,7 @@ func PrefetchObject(bp BaseParams, bck cmn.Bck, objName string) (string, error)
 }
 
 // Archive the content of a reader (`args.Reader` - e.g., an open file). =======================================
-// Destination, depending on the options, can be an existing (.tar, .tgz or .tar.gz, .zip, .tar.lz4)
+// Destination, depending on the options, can be an existing (.tar, .tgz or .tar.gz, .zip, .tar.lz4, .tar.zst)
 // formatted object (aka "shard") or a new one (or, a new version).
 // ---
 // For the updated list of supported archival formats -- aka MIME types -- see cmn/cos/archive.go.
@@ -558,6 +579,88 @@ func FlushObject(args *FlushArgs) error {
 	return err
 }
 
+// Multipart upload ================================================================================
+// Upload a (large) object in parts:
+// - api.CreateMptUpload returns upload ID;
+// - api.UploadPart uploads a given part - in any order and in parallel, via any AIS gateway;
+//   (re)uploading the same part number replaces the previous content;
+// - api.CompleteMptUpload concatenates the specified parts into a resulting object,
+//   while api.AbortMptUpload discards all uploaded parts.
+// The object becomes visible (to clients) and accessible only _after_ the completion.
+
+func CreateMptUpload(bp BaseParams, bck cmn.Bck, objName string) (uploadID string, err error) {
+	err = _mpt(bp, bck, objName, &apc.ActMsg{Action: apc.ActMptCreate}, &uploadID)
+	return uploadID, err
+}
+
+// returns part's ETag (MD5 of the part's content)
+func UploadPart(args *UploadPartArgs) (string /*etag*/, error) {
+	q := make(url.Values, 4)
+	q.Set(apc.QparamMptUploadID, args.UploadID)
+	q.Set(apc.QparamMptPartNum, strconv.FormatInt(int64(args.PartNum), 10))
+	q = args.Bck.AddToQuery(q)
+
+	reqArgs := cmn.AllocHra()
+	{
+		reqArgs.Method = http.MethodPut
+		reqArgs.Base = args.BaseParams.URL
+		reqArgs.Path = apc.URLPathObjects.Join(args.Bck.Name, args.Object)
+		reqArgs.Query = q
+		reqArgs.BodyR = args.Reader
+	}
+	wresp, err := DoWithRetry(args.BaseParams.Client, args.put, reqArgs) //nolint:bodyclose // it's closed inside
+	cmn.FreeHra(reqArgs)
+	if err != nil {
+		return "", err
+	}
+	return wresp.Header.Get(cos.HdrETag), nil
+}
+
+func (args *UploadPartArgs) getBody() (io.ReadCloser, error) { return args.Reader.Open() }
+
+func (args *UploadPartArgs) put(reqArgs *cmn.HreqArgs) (*http.Request, error) {
+	req, err := reqArgs.Req()
+	if err != nil {
+		return nil, newErrCreateHTTPRequest(err)
+	}
+	req.GetBody = args.getBody
+	if args.Size != 0 {
+		req.ContentLength = args.Size
+	}
+	SetAuxHeaders(req, &args.BaseParams)
+	return req, nil
+}
+
+// parts: all parts that make up the object - part numbers and, optionally, ETags
+// (the latter, if specified, get validated)
+func CompleteMptUpload(bp BaseParams, bck cmn.Bck, objName, uploadID string, parts []apc.MptPart) error {
+	msg := &apc.ActMsg{Action: apc.ActMptComplete, Name: uploadID, Value: &apc.MptCompleteMsg{Parts: parts}}
+	return _mpt(bp, bck, objName, msg, nil)
+}
+
+func AbortMptUpload(bp BaseParams, bck cmn.Bck, objName, uploadID string) error {
+	return _mpt(bp, bck, objName, &apc.ActMsg{Action: apc.ActMptAbort, Name: uploadID}, nil)
+}
+
+func _mpt(bp BaseParams, bck cmn.Bck, objName string, msg *apc.ActMsg, out *string) (err error) {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, objName)
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	if out == nil {
+		err = reqParams.DoRequest()
+	} else {
+		_, err = reqParams.doReqStr(out)
+	}
+	FreeRp(reqParams)
+	return err
+}
+
 // Rename(object) ==============================================================================
 // renames object name from `oldName` to `newName`. Works only within a given specified bucket.
 
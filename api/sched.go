@@ -0,0 +1,54 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// AddJobSchedule adds a new scheduled job: cron expression along with the action message
+// of any of the supported jobs (see cmn/sched.go), e.g.:
+// - apc.ActMsg{Action: apc.ActXactStart, Value: &xact.ArgsMsg{Kind: apc.ActLRU}}
+// - apc.ActMsg{Action: apc.ActPrefetchObjects, Value: &apc.PrefetchMsg{...}} (job.Bck required)
+func AddJobSchedule(bp BaseParams, job *cmn.SchedJob) error {
+	return _sched(bp, apc.ActMsg{Action: apc.ActSchedAdd, Value: job})
+}
+
+func RemoveJobSchedule(bp BaseParams, id string) error {
+	return _sched(bp, apc.ActMsg{Action: apc.ActSchedRemove, Name: id})
+}
+
+func _sched(bp BaseParams, msg apc.ActMsg) error {
+	bp.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+// ListJobSchedules returns all scheduled jobs, including their most recent runs
+func ListJobSchedules(bp BaseParams) (jobs []*cmn.SchedJob, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatSchedules}}
+	}
+	_, err = reqParams.DoReqAny(&jobs)
+	FreeRp(reqParams)
+	return jobs, err
+}
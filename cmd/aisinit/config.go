@@ -132,6 +132,7 @@ var (
 		Enabled:         false,
 		DontEvictTime:   cos.Duration(120 * time.Minute),
 		CapacityUpdTime: cos.Duration(10 * time.Minute),
+		Policy:          aisapc.LRUPolicyLRU,
 	}
 
 	defaultMirror = aiscmn.MirrorConf{
@@ -102,6 +102,12 @@ func (m *mgr) updateUser(userID string, updateReq *authn.User) error {
 	if len(updateReq.Roles) != 0 {
 		uInfo.Roles = updateReq.Roles
 	}
+	if updateReq.Quota != nil {
+		uInfo.Quota = updateReq.Quota
+	}
+	if updateReq.RateLimit != nil {
+		uInfo.RateLimit = updateReq.RateLimit
+	}
 	return m.db.Set(usersCollection, userID, uInfo)
 }
 
@@ -397,7 +403,7 @@ func (m *mgr) _token(msg *authn.LoginMsg, uInfo *authn.User, cluACLs []*authn.Cl
 		token, err = tok.AdminJWT(expires, uid, Conf.Secret())
 	} else {
 		m.fixClusterIDs(cluACLs)
-		token, err = tok.JWT(expires, uid, bckACLs, cluACLs, Conf.Secret())
+		token, err = tok.JWT(expires, uid, bckACLs, cluACLs, uInfo.Quota, uInfo.RateLimit, Conf.Secret())
 	}
 	return token, err
 }
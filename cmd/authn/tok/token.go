@@ -20,12 +20,14 @@ import (
 )
 
 type Token struct {
-	UserID      string          `json:"username"`
-	Expires     time.Time       `json:"expires"`
-	Token       string          `json:"token"`
-	ClusterACLs []*authn.CluACL `json:"clusters"`
-	BucketACLs  []*authn.BckACL `json:"buckets,omitempty"`
-	IsAdmin     bool            `json:"admin"`
+	UserID      string               `json:"username"`
+	Expires     time.Time            `json:"expires"`
+	Token       string               `json:"token"`
+	ClusterACLs []*authn.CluACL      `json:"clusters"`
+	BucketACLs  []*authn.BckACL      `json:"buckets,omitempty"`
+	Quota       *authn.UserQuota     `json:"quota,omitempty"`
+	RateLimit   *authn.UserRateLimit `json:"rate_limit,omitempty"`
+	IsAdmin     bool                 `json:"admin"`
 }
 
 var (
@@ -49,13 +51,20 @@ func AdminJWT(expires time.Time, userID, secret string) (string, error) {
 }
 
 func JWT(expires time.Time, userID string, bucketACLs []*authn.BckACL, clusterACLs []*authn.CluACL,
-	secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	quota *authn.UserQuota, rlim *authn.UserRateLimit, secret string) (string, error) {
+	claims := jwt.MapClaims{
 		"expires":  expires,
 		"username": userID,
 		"buckets":  bucketACLs,
 		"clusters": clusterACLs,
-	})
+	}
+	if quota != nil {
+		claims["quota"] = quota
+	}
+	if rlim != nil {
+		claims["rate_limit"] = rlim
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return t.SignedString([]byte(secret))
 }
 
@@ -55,6 +55,7 @@ var (
 		},
 		cmdStartMaint: {
 			noRebalanceFlag,
+			skipWriteBackFlag,
 			yesFlag,
 		},
 		cmdStopMaint: {
@@ -71,6 +72,7 @@ var (
 			noShutdownFlag,
 			rmUserDataFlag,
 			keepInitialConfigFlag,
+			skipWriteBackFlag,
 			yesFlag,
 		},
 		cmdClusterDecommission: {
@@ -121,6 +123,7 @@ var (
 				Action:       detachRemoteAISHandler,
 				BashComplete: suggestRemote,
 			},
+			s3ProfileSub,
 			{
 				Name:  cmdRebalance,
 				Usage: "administratively start and stop global rebalance; show global rebalance",
@@ -412,6 +415,7 @@ func nodeMaintShutDecommHandler(c *cli.Context) error {
 			DaemonID:      node.ID(),
 			SkipRebalance: skipRebalance,
 			NoShutdown:    noShutdown,
+			SkipWriteBack: flagIsSet(c, skipWriteBackFlag),
 		}
 	)
 	if skipRebalance && node.IsTarget() {
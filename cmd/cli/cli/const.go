@@ -70,6 +70,8 @@ const (
 	commandStart     = apc.ActXactStart
 	commandStop      = apc.ActXactStop
 	commandWait      = "wait"
+	commandAdd       = "add"
+	commandSchedule  = "schedule"
 
 	cmdSmap   = apc.WhatSmap
 	cmdBMD    = apc.WhatBMD
@@ -118,6 +120,7 @@ const (
 	cmdCluAttach = "remote-" + cmdAttach
 	cmdCluDetach = "remote-" + cmdDetach
 	cmdCluConfig = "configure"
+	cmdS3Profile = "s3-profile"
 	cmdReset     = "reset"
 
 	// Mountpath commands
@@ -228,7 +231,7 @@ const (
 )
 
 const (
-	archFormats = ".tar, .tgz or .tar.gz, .zip, .tar.lz4" // namely, archive.FileExtensions
+	archFormats = ".tar, .tgz or .tar.gz, .zip, .tar.lz4, .tar.zst" // namely, archive.FileExtensions
 	archExts    = "(" + archFormats + ")"
 )
 
@@ -889,6 +892,12 @@ var (
 		Usage: "when failing to PUT retry the operation up to so many times (with increasing timeout if timed out)",
 	}
 
+	putPartsFlag = cli.IntFlag{
+		Name: "parts",
+		Usage: "PUT a (large) file as a multipart upload with the specified number of parts (up to 10000)\n" +
+			indent4 + "\tthat get uploaded in parallel (see also: '--num-workers', '--retries')",
+	}
+
 	appendConcatFlag = cli.BoolFlag{
 		Name:  "append",
 		Usage: "concatenate files: append a file or multiple files as a new _or_ to an existing object",
@@ -1088,6 +1097,11 @@ var (
 		Name:  "no-shutdown",
 		Usage: "do not shutdown node upon decommissioning it from the cluster",
 	}
+	skipWriteBackFlag = cli.BoolFlag{
+		Name: "skip-write-back",
+		Usage: "proceed even if the node has dirty objects that are not yet written back\n" +
+			indent4 + "\t(remote buckets with write-back data policy; not yet written back data may be lost)",
+	}
 	rmUserDataFlag = cli.BoolFlag{
 		Name:  "rm-user-data",
 		Usage: "remove all user data when decommissioning node from the cluster",
@@ -1103,6 +1117,35 @@ var (
 		Usage: "update config in memory without storing the change(s) on disk",
 	}
 
+	// S3 profiles
+	s3EndpointFlag = cli.StringFlag{
+		Name:  "endpoint",
+		Usage: "S3-compatible endpoint, e.g.: 'https://minio.local:9000'",
+	}
+	s3RegionFlag = cli.StringFlag{
+		Name:  "region",
+		Usage: "S3 region (when not specified, bucket region is determined via 'GetBucketLocation')",
+	}
+	s3CredsFlag = cli.StringFlag{
+		Name: "creds",
+		Usage: "credentials reference (the credentials themselves are never stored in cluster config):\n" +
+			indent4 + "\t - 'env:NAME' - NAME_ACCESS_KEY_ID, NAME_SECRET_ACCESS_KEY, and optional NAME_SESSION_TOKEN in each target's environment;\n" +
+			indent4 + "\t - 'file:/path[#section]' - AWS shared credentials file (present on each target), default section: 'default';\n" +
+			indent4 + "\t - when omitted: default AWS credential chain",
+	}
+	s3PathStyleFlag = cli.BoolFlag{
+		Name:  "path-style",
+		Usage: "use path-style addressing (e.g., 'https://minio.local:9000/BUCKET/OBJECT')",
+	}
+	s3CABundleFlag = cli.StringFlag{
+		Name:  "ca-bundle",
+		Usage: "absolute path (on each target) to PEM-encoded CA certificate(s) to verify the endpoint",
+	}
+	s3SkipVerifyFlag = cli.BoolFlag{
+		Name:  "skip-verify",
+		Usage: "do not verify the endpoint's TLS certificate (insecure)",
+	}
+
 	setNewCustomMDFlag = cli.BoolFlag{
 		Name:  "set-new-custom",
 		Usage: "remove existing custom keys (if any) and store new custom metadata",
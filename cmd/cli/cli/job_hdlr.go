@@ -68,6 +68,7 @@ var (
 		jobStopSub,
 		jobWaitSub,
 		jobRemoveSub,
+		jobScheduleSub,
 		makeAlias(showCmdJob, "", true, commandShow), // alias for `ais show`
 	}
 )
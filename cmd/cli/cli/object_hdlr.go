@@ -132,6 +132,7 @@ var (
 			continueOnErrorFlag,
 			unitsFlag,
 			putRetriesFlag,
+			putPartsFlag,
 			// cksum
 			skipVerCksumFlag,
 			putObjDfltCksumFlag,
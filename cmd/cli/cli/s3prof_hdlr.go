@@ -0,0 +1,207 @@
+// Package cli provides easy-to-use commands to manage, monitor, and utilize AIS clusters.
+// This file handles named S3 profiles (S3-compatible endpoints with independent credentials).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/cli/teb"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/urfave/cli"
+)
+
+const s3ProfileAddUsage = "add (or update) named S3 profile - S3-compatible endpoint with its own credentials, e.g.:\n" +
+	indent1 + "\t- 'cluster s3-profile add minio1 --endpoint https://minio.local:9000 --creds env:MINIO1 --path-style';\n" +
+	indent1 + "\t- 'cluster s3-profile add rgw --endpoint http://rgw:8080 --creds file:/etc/ais/rgw.creds#ais'.\n" +
+	indent1 + "Buckets reference profiles by name, e.g.: 'ais bucket props set s3://abc extra.aws.profile=minio1'"
+
+var (
+	s3ProfileAddFlags = []cli.Flag{
+		s3EndpointFlag,
+		s3RegionFlag,
+		s3CredsFlag,
+		s3PathStyleFlag,
+		s3CABundleFlag,
+		s3SkipVerifyFlag,
+	}
+	s3ProfileLsFlags = []cli.Flag{
+		noHeaderFlag,
+		jsonFlag,
+	}
+
+	s3ProfileSub = cli.Command{
+		Name:  cmdS3Profile,
+		Usage: "manage named S3 profiles: S3-compatible endpoints (MinIO, Ceph RGW, etc.) with independent credentials",
+		Subcommands: []cli.Command{
+			{
+				Name:      commandAdd,
+				Usage:     s3ProfileAddUsage,
+				ArgsUsage: "PROFILE_NAME",
+				Flags:     s3ProfileAddFlags,
+				Action:    s3ProfileAddHandler,
+			},
+			{
+				Name:      commandList,
+				Usage:     "list S3 profiles",
+				ArgsUsage: "[PROFILE_NAME]",
+				Flags:     s3ProfileLsFlags,
+				Action:    s3ProfileListHandler,
+			},
+			{
+				Name:      commandRemove,
+				Usage:     "remove S3 profile (fails if the profile is still referenced by any bucket)",
+				ArgsUsage: "PROFILE_NAME",
+				Action:    s3ProfileRemoveHandler,
+			},
+		},
+	}
+)
+
+func getS3Profiles() (*cmn.ClusterConfig, *cmn.BackendConfAWS, error) {
+	config, err := api.GetClusterConfig(apiBP)
+	if err != nil {
+		return nil, nil, V(err)
+	}
+	v := config.Backend.Get(apc.AWS)
+	if v == nil {
+		return nil, nil, errors.New("AWS (S3) backend is not configured")
+	}
+	awsConf := &cmn.BackendConfAWS{}
+	if err := cos.MorphMarshal(v, awsConf); err != nil {
+		return nil, nil, err
+	}
+	return config, awsConf, nil
+}
+
+func setS3Profiles(config *cmn.ClusterConfig, awsConf *cmn.BackendConfAWS) error {
+	conf := make(map[string]any, len(config.Backend.Conf))
+	for provider, v := range config.Backend.Conf {
+		conf[provider] = v
+	}
+	conf[apc.AWS] = awsConf
+	toUpdate := &cmn.ConfigToSet{Backend: &cmn.BackendConf{Conf: conf}}
+	return V(api.SetClusterConfigUsingMsg(apiBP, toUpdate, false /*transient*/))
+}
+
+func s3ProfileAddHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	name := c.Args().Get(0)
+	prof := &cmn.S3Profile{
+		Endpoint:   parseStrFlag(c, s3EndpointFlag),
+		Region:     parseStrFlag(c, s3RegionFlag),
+		Creds:      parseStrFlag(c, s3CredsFlag),
+		CABundle:   parseStrFlag(c, s3CABundleFlag),
+		PathStyle:  flagIsSet(c, s3PathStyleFlag),
+		SkipVerify: flagIsSet(c, s3SkipVerifyFlag),
+	}
+	if err := prof.Validate(); err != nil {
+		return err
+	}
+	config, awsConf, err := getS3Profiles()
+	if err != nil {
+		return err
+	}
+	if awsConf.Profiles == nil {
+		awsConf.Profiles = make(map[string]*cmn.S3Profile, 1)
+	}
+	_, exists := awsConf.Profiles[name]
+	awsConf.Profiles[name] = prof
+	if err := awsConf.Validate(); err != nil {
+		return err
+	}
+	if err := setS3Profiles(config, awsConf); err != nil {
+		return err
+	}
+	if exists {
+		actionDone(c, fmt.Sprintf("Updated S3 profile %q", name))
+	} else {
+		actionDone(c, fmt.Sprintf("Added S3 profile %q", name))
+	}
+	return nil
+}
+
+func s3ProfileRemoveHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	name := c.Args().Get(0)
+	config, awsConf, err := getS3Profiles()
+	if err != nil {
+		return err
+	}
+	if _, ok := awsConf.Profiles[name]; !ok {
+		return fmt.Errorf("S3 profile %q does not exist", name)
+	}
+	delete(awsConf.Profiles, name)
+	if err := setS3Profiles(config, awsConf); err != nil {
+		return err
+	}
+	actionDone(c, fmt.Sprintf("Removed S3 profile %q", name))
+	return nil
+}
+
+func s3ProfileListHandler(c *cli.Context) error {
+	_, awsConf, err := getS3Profiles()
+	if err != nil {
+		return err
+	}
+	profiles := awsConf.Profiles
+	if name := c.Args().Get(0); name != "" {
+		prof, ok := profiles[name]
+		if !ok {
+			return fmt.Errorf("S3 profile %q does not exist", name)
+		}
+		profiles = map[string]*cmn.S3Profile{name: prof}
+	}
+	if flagIsSet(c, jsonFlag) {
+		return teb.Print(profiles, "", teb.Jopts(true))
+	}
+	if len(profiles) == 0 {
+		fmt.Fprintln(c.App.Writer, "No S3 profiles")
+		return nil
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "NAME\tENDPOINT\tREGION\tCREDENTIALS\tPATH-STYLE\tTLS")
+	}
+	for _, name := range names {
+		var (
+			prof = profiles[name]
+			tls  = teb.NotSetVal
+		)
+		switch {
+		case prof.SkipVerify:
+			tls = "skip-verify"
+		case prof.CABundle != "":
+			tls = prof.CABundle
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\t%s\n", name, _orNotSet(prof.Endpoint), _orNotSet(prof.Region),
+			_orNotSet(prof.Creds), prof.PathStyle, tls)
+	}
+	return tw.Flush()
+}
+
+func _orNotSet(s string) string {
+	if s == "" {
+		return teb.NotSetVal
+	}
+	return s
+}
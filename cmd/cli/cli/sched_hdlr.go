@@ -0,0 +1,241 @@
+// Package cli provides easy-to-use commands to manage, monitor, and utilize AIS clusters.
+// This file handles scheduled (cron-like) jobs.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/cli/teb"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/urfave/cli"
+)
+
+const schedAddUsage = "schedule a job to run periodically, as per cron expression (local time of the primary), e.g.:\n" +
+	indent1 + "\t- 'job schedule add nightly-pf \"30 2 * * *\" prefetch s3://abc --prefix images/'\t- prefetch daily at 2:30am;\n" +
+	indent1 + "\t- 'job schedule add weekly-lru @weekly lru'\t- run LRU eviction (all buckets) every Sunday at midnight;\n" +
+	indent1 + "\t- 'job schedule add cln \"0 */6 * * *\" cleanup'\t- storage cleanup every 6 hours;\n" +
+	indent1 + "\t- 'job schedule add bkp \"0 1 * * sat\" copy ais://abc ais://abc-backup'\t- copy bucket on Saturdays at 1am;\n" +
+	indent1 + "\t- 'job schedule add summ \"@every 12h\" summary ais://abc'\t- bucket summary every 12 hours.\n" +
+	indent1 + "Cron expression: 'minute hour day-of-month month day-of-week' or one of the descriptors:\n" +
+	indent1 + "\t@yearly, @monthly, @weekly, @daily, @hourly, and '@every <duration>'"
+
+const (
+	schedJobArgument = "SCHED_ID CRON JOB_NAME [BUCKET [DST_BUCKET]]"
+
+	// (in addition to startable xactions)
+	schedPrefetch = commandPrefetch
+	schedCopy     = "copy"
+	schedSummary  = cmdSummary
+)
+
+var (
+	schedAddFlags = []cli.Flag{
+		verbObjPrefixFlag,
+		listFlag,
+		templateFlag,
+		latestVerFlag,
+	}
+	schedLsFlags = []cli.Flag{
+		verboseFlag,
+		noHeaderFlag,
+		jsonFlag,
+	}
+
+	jobScheduleSub = cli.Command{
+		Name:  commandSchedule,
+		Usage: "manage scheduled (cron-like) jobs: prefetch, copy bucket, LRU, storage cleanup, bucket summary, and more",
+		Subcommands: []cli.Command{
+			{
+				Name:      commandAdd,
+				Usage:     schedAddUsage,
+				ArgsUsage: schedJobArgument,
+				Flags:     schedAddFlags,
+				Action:    schedAddHandler,
+			},
+			{
+				Name:      commandList,
+				Usage:     "list scheduled jobs along with their most recent runs",
+				ArgsUsage: "[SCHED_ID]",
+				Flags:     schedLsFlags,
+				Action:    schedListHandler,
+			},
+			{
+				Name:      commandRemove,
+				Usage:     "remove scheduled job",
+				ArgsUsage: "SCHED_ID",
+				Action:    schedRemoveHandler,
+			},
+		},
+	}
+)
+
+func schedAddHandler(c *cli.Context) error {
+	if c.NArg() < 3 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	var (
+		job   = &cmn.SchedJob{ID: c.Args().Get(0), Cron: c.Args().Get(1)}
+		jname = c.Args().Get(2)
+		err   error
+	)
+	if c.NArg() > 3 {
+		if job.Bck, err = parseBckURI(c, c.Args().Get(3), false); err != nil {
+			return err
+		}
+	}
+	switch jname {
+	case schedPrefetch:
+		msg := apc.PrefetchMsg{LatestVer: flagIsSet(c, latestVerFlag)}
+		if flagIsSet(c, listFlag) {
+			msg.ObjNames = splitCsv(parseStrFlag(c, listFlag))
+		}
+		msg.Template = parseStrFlag(c, templateFlag)
+		if prefix := parseStrFlag(c, verbObjPrefixFlag); prefix != "" {
+			if msg.Template != "" || msg.IsList() {
+				return incorrectUsageMsg(c, "%s cannot be used together with %s or %s",
+					qflprn(verbObjPrefixFlag), qflprn(listFlag), qflprn(templateFlag))
+			}
+			msg.Template = prefix
+		}
+		job.Msg = apc.ActMsg{Action: apc.ActPrefetchObjects, Value: &msg}
+	case schedCopy, commandCopy:
+		if c.NArg() < 5 {
+			return missingArgumentsError(c, bucketSrcArgument, bucketDstArgument)
+		}
+		if job.BckTo, err = parseBckURI(c, c.Args().Get(4), false); err != nil {
+			return err
+		}
+		msg := apc.TCBMsg{}
+		msg.Prefix = parseStrFlag(c, verbObjPrefixFlag)
+		msg.LatestVer = flagIsSet(c, latestVerFlag)
+		job.Msg = apc.ActMsg{Action: apc.ActCopyBck, Value: &msg}
+	case schedSummary:
+		msg := apc.BsummCtrlMsg{Prefix: parseStrFlag(c, verbObjPrefixFlag), ObjCached: true, BckPresent: true}
+		job.Msg = apc.ActMsg{Action: apc.ActSummaryBck, Value: &msg}
+	default:
+		kind, _ := xact.GetKindName(jname)
+		if kind == "" || !xact.Table[kind].Startable {
+			return fmt.Errorf("cannot schedule %q: expecting one of: %s, %s, %s, or startable job name (%s)",
+				jname, schedPrefetch, schedCopy, schedSummary, strings.Join(xact.ListDisplayNames(true), ", "))
+		}
+		xargs := xact.ArgsMsg{Kind: kind, Bck: job.Bck}
+		if kind == apc.ActLRU && !job.Bck.IsEmpty() {
+			xargs.Bck, xargs.Buckets = cmn.Bck{}, []cmn.Bck{job.Bck}
+		}
+		job.Msg = apc.ActMsg{Action: apc.ActXactStart, Value: &xargs}
+	}
+	if err := api.AddJobSchedule(apiBP, job); err != nil {
+		return V(err)
+	}
+	actionDone(c, fmt.Sprintf("Scheduled %s %q (%s)", jname, job.ID, job.Cron))
+	return nil
+}
+
+func schedRemoveHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	id := c.Args().Get(0)
+	if err := api.RemoveJobSchedule(apiBP, id); err != nil {
+		return V(err)
+	}
+	actionDone(c, fmt.Sprintf("Removed scheduled job %q", id))
+	return nil
+}
+
+func schedListHandler(c *cli.Context) error {
+	jobs, err := api.ListJobSchedules(apiBP)
+	if err != nil {
+		return V(err)
+	}
+	if id := c.Args().Get(0); id != "" {
+		filtered := jobs[:0]
+		for _, job := range jobs {
+			if job.ID == id {
+				filtered = append(filtered, job)
+			}
+		}
+		if len(filtered) == 0 {
+			return fmt.Errorf("scheduled job %q does not exist", id)
+		}
+		jobs = filtered
+	}
+	if flagIsSet(c, jsonFlag) {
+		return teb.Print(jobs, "", teb.Jopts(true))
+	}
+	if len(jobs) == 0 {
+		fmt.Fprintln(c.App.Writer, "No scheduled jobs")
+		return nil
+	}
+
+	verbose := flagIsSet(c, verboseFlag)
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "ID\tCRON\tJOB\tBUCKET\tNEXT RUN\tLAST RUN\tJOB ID\tERROR")
+	}
+	for _, job := range jobs {
+		var (
+			next, _ = job.Next()
+			bck     = teb.NotSetVal
+			runs    = job.Runs
+		)
+		if !job.Bck.IsEmpty() {
+			bck = job.Bck.Cname("")
+			if !job.BckTo.IsEmpty() {
+				bck += " => " + job.BckTo.Cname("")
+			}
+		}
+		if !verbose && len(runs) > 0 {
+			runs = runs[len(runs)-1:]
+		}
+		if len(runs) == 0 {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", job.ID, job.Cron, schedJobName(job), bck,
+				teb.FmtDateTime(next), teb.NotSetVal, teb.NotSetVal, "")
+			continue
+		}
+		// most recent first
+		for i := len(runs) - 1; i >= 0; i-- {
+			run := &runs[i]
+			if i == len(runs)-1 {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t", job.ID, job.Cron, schedJobName(job), bck, teb.FmtDateTime(next))
+			} else {
+				fmt.Fprint(tw, "\t\t\t\t\t")
+			}
+			xid := run.Xid
+			if xid == "" {
+				xid = teb.NotSetVal
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", teb.FmtDateTime(time.Unix(0, run.Started)), xid, run.Err)
+		}
+	}
+	return tw.Flush()
+}
+
+func schedJobName(job *cmn.SchedJob) string {
+	switch job.Msg.Action {
+	case apc.ActPrefetchObjects:
+		return schedPrefetch
+	case apc.ActCopyBck:
+		return schedCopy
+	case apc.ActSummaryBck:
+		return schedSummary
+	default:
+		var xargs xact.ArgsMsg
+		if err := cos.MorphMarshal(job.Msg.Value, &xargs); err == nil {
+			_, xname := xact.GetKindName(xargs.Kind)
+			return xname
+		}
+		return job.Msg.Action
+	}
+}
@@ -523,6 +523,12 @@ func xlistByKindID(c *cli.Context, xargs *xact.ArgsMsg, caption bool, xs xact.Mu
 		} else {
 			err = teb.Print(dts, teb.XactECPutTmpl, opts)
 		}
+	case apc.ActLRU:
+		if hideHeader {
+			err = teb.Print(dts, teb.XactLRUNoHdrTmpl, opts)
+		} else {
+			err = teb.Print(dts, teb.XactLRUTmpl, opts)
+		}
 	default:
 		switch {
 		case fromToBck && hideHeader:
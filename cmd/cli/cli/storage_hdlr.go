@@ -488,8 +488,17 @@ func (ctx *bsummCtx) progress(summaries *cmn.AllBsummResults, done bool) {
 		}
 		if res.Bck.IsAIS() {
 			debug.Assert(res.ObjCount.Remote == 0 && res.ObjCount.Present != 0)
-			s += fmt.Sprintf("(%s, size=%s)", cos.FormatBigNum(int(res.ObjCount.Present)),
+			s += fmt.Sprintf("(%s, size=%s", cos.FormatBigNum(int(res.ObjCount.Present)),
 				teb.FmtSize(int64(res.TotalSize.PresentObjs), ctx.units, 2))
+			// compressed (or encrypted) at rest
+			if logical := res.TotalSize.PresentLogical; logical != 0 && logical != res.TotalSize.PresentObjs {
+				s += ", logical=" + teb.FmtSize(int64(logical), ctx.units, 2)
+			}
+			// deduplicated
+			if ratio := res.DedupRatio(); ratio > 0 {
+				s += fmt.Sprintf(", dedup=%.2fx", ratio)
+			}
+			s += ")"
 			goto emit
 		}
 
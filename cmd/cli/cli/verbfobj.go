@@ -415,6 +415,9 @@ func putRegular(c *cli.Context, bck cmn.Bck, objName, path string, finfo os.File
 		// resulting message printed upon return
 		return nil
 	}
+	if numParts := parseIntFlag(c, putPartsFlag); numParts > 1 && finfo.Size() >= int64(numParts) {
+		return putMpt(c, bck, objName, path, finfo.Size(), numParts)
+	}
 	cksum, err := cksumToCompute(c, bck)
 	if err != nil {
 		return err
@@ -469,6 +472,112 @@ func putRegular(c *cli.Context, bck cmn.Bck, objName, path string, finfo os.File
 	return err
 }
 
+const maxMptParts = 10000 // (compare w/ s3.MaxPartsPerUpload)
+
+// PUT a (large) file as a multipart upload, with `numParts` sections of the file
+// uploaded in parallel (see api.CreateMptUpload et al.)
+func putMpt(c *cli.Context, bck cmn.Bck, objName, path string, size int64, numParts int) error {
+	if numParts > maxMptParts {
+		return fmt.Errorf("%s: number of parts cannot exceed %d", qflprn(putPartsFlag), maxMptParts)
+	}
+	numWorkers, err := parseNumWorkersFlag(c, numPutWorkersFlag)
+	if err != nil {
+		return err
+	}
+	numWorkers = max(min(numWorkers, numParts), 1)
+
+	uploadID, err := api.CreateMptUpload(apiBP, bck, objName)
+	if err != nil {
+		return err
+	}
+	var (
+		progress *mpb.Progress
+		bars     []*mpb.Bar
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		errs     []error
+		parts    = make([]apc.MptPart, numParts)
+		partSize = size / int64(numParts) // the last part takes the remainder
+		iters    = 1 + parseRetriesFlag(c, putRetriesFlag, true /*warn*/)
+		workCh   = make(chan int32, numParts)
+	)
+	if flagIsSet(c, progressFlag) {
+		args := barArgs{barType: sizeArg, barText: objName, total: size}
+		progress, bars = simpleBar(args)
+	}
+	for num := int32(1); num <= int32(numParts); num++ {
+		workCh <- num
+	}
+	close(workCh)
+
+	upload := func(num int32) (string, error) {
+		var (
+			reader cos.ReadOpenCloser
+			offset = int64(num-1) * partSize
+			psize  = partSize
+		)
+		if num == int32(numParts) {
+			psize = size - offset
+		}
+		fh, err := cos.NewFileSectionHandle(path, offset, psize)
+		if err != nil {
+			return "", err
+		}
+		reader = fh
+		if bars != nil {
+			reader = cos.NewCallbackReadOpenCloser(fh, func(n int, _ error) { bars[0].IncrBy(n) })
+		}
+		args := api.UploadPartArgs{
+			BaseParams: apiBP,
+			Bck:        bck,
+			Object:     objName,
+			Reader:     reader,
+			UploadID:   uploadID,
+			PartNum:    num,
+			Size:       psize,
+		}
+		for i := range iters {
+			etag, err := api.UploadPart(&args)
+			if err == nil || i == iters-1 {
+				return etag, err
+			}
+			fmt.Fprintf(c.App.ErrWriter, "[#%d] %s, part %d: %v - retrying...\n", i+1, path, num, stripErr(err))
+			time.Sleep(time.Second)
+			if args.Reader, err = reader.Open(); err != nil {
+				return "", err
+			}
+		}
+		return "", nil
+	}
+	for range numWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for num := range workCh {
+				etag, err := upload(num)
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("part %d: %v", num, err))
+				}
+				parts[num-1] = apc.MptPart{Num: num, ETag: etag}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if progress != nil {
+		progress.Wait()
+	}
+
+	if len(errs) > 0 {
+		if erc := api.AbortMptUpload(apiBP, bck, objName, uploadID); erc != nil {
+			actionWarn(c, fmt.Sprintf("failed to abort upload %q: %v", uploadID, erc))
+		}
+		return errors.Join(errs...)
+	}
+	return api.CompleteMptUpload(apiBP, bck, objName, uploadID, parts)
+}
+
 // PUT and then APPEND fixed-sized chunks using `api.PutObject`, `api.AppendObject` and `api.FlushObject`
 // - currently, is only used to PUT from standard input when we do expect to overwrite existing destination object
 // - APPEND and flush will only be executed with there's a second chunk
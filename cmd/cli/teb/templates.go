@@ -204,6 +204,24 @@ const (
 		"{{FormatEnd $xctn.EndTime}}\t " +
 		"{{FormatXactState $xctn}}\n"
 
+	XactLRUTmpl      = xactLRUStatsHdr + XactLRUNoHdrTmpl
+	XactLRUNoHdrTmpl = "{{range $daemon := . }}" + xactLRUBody + "{{end}}"
+
+	xactLRUStatsHdr  = "NODE\t ID\t OBJECTS\t BYTES\t EXPIRED\t POLICY\t START\t END\t STATE\n"
+	xactLRUBody      = "{{range $key, $xctn := $daemon.XactSnaps}}" + xactLRUStatsBody + "{{end}}"
+	xactLRUStatsBody = "{{ $daemon.DaemonID }}\t " +
+		"{{if $xctn.ID}}{{$xctn.ID}}{{else}}-{{end}}\t " +
+		"{{if (eq $xctn.Stats.Objs 0) }}-{{else}}{{$xctn.Stats.Objs}}{{end}}\t " +
+		"{{if (eq $xctn.Stats.Bytes 0) }}-{{else}}{{FormatBytesSig $xctn.Stats.Bytes 2}}{{end}}\t " +
+
+		"{{ $ext := ExtLRUStats $xctn }}" +
+		"{{if (eq $ext.Expired 0) }}-{{else}}{{$ext.Expired}}{{end}}\t " +
+		"{{if (eq $ext.Policy \"\") }}-{{else}}{{$ext.Policy}}{{end}}\t " +
+
+		"{{FormatStart $xctn.StartTime}}\t " +
+		"{{FormatEnd $xctn.EndTime}}\t " +
+		"{{FormatXactState $xctn}}\n"
+
 	listBucketsSummHdr  = "NAME\t PRESENT\t OBJECTS\t SIZE (apparent, objects, remote)\t USAGE(%)\n"
 	ListBucketsSummBody = "{{range $k, $v := . }}" +
 		"{{FormatBckName $v.Bck}}\t {{FormatBool $v.Info.IsBckPresent}}\t " +
@@ -220,12 +238,13 @@ const (
 	ListBucketsTmplNoSummary = ListBucketsHdrNoSummary + ListBucketsBodyNoSummary
 
 	// Bucket summary templates
-	BucketsSummariesTmpl = "NAME\t OBJECTS (cached, remote)\t OBJECT SIZES (min, avg, max)\t TOTAL OBJECT SIZE (cached, remote)\t USAGE(%)\n" +
+	BucketsSummariesTmpl = "NAME\t OBJECTS (cached, remote)\t OBJECT SIZES (min, avg, max)\t TOTAL OBJECT SIZE (cached, logical, remote)\t DEDUP\t USAGE(%)\n" +
 		BucketsSummariesBody
 	BucketsSummariesBody = "{{range $k, $v := . }}" +
 		"{{FormatBckName $v.Bck}}\t {{$v.ObjCount.Present}} {{$v.ObjCount.Remote}}\t " +
 		"{{FormatMAM $v.ObjSize.Min}} {{FormatMAM $v.ObjSize.Avg}} {{FormatMAM $v.ObjSize.Max}}\t " +
-		"{{FormatBytesUns $v.TotalSize.PresentObjs 2}} {{FormatBytesUns $v.TotalSize.RemoteObjs 2}}\t {{$v.UsedPct}}%\n" +
+		"{{FormatBytesUns $v.TotalSize.PresentObjs 2}} {{FormatBytesUns $v.TotalSize.PresentLogical 2}} " +
+		"{{FormatBytesUns $v.TotalSize.RemoteObjs 2}}\t {{FormatDedupRatio $v.DedupRatio}}\t {{$v.UsedPct}}%\n" +
 		"{{end}}"
 
 	BucketSummaryValidateTmpl = "BUCKET\t OBJECTS\t MISPLACED\t MISSING COPIES\n" + bucketSummaryValidateBody
@@ -389,6 +408,7 @@ var (
 		"FormatBytesSig2":     fmtSize2,
 		"FormatBytesUns":      func(size uint64, digits int) string { return FmtSize(int64(size), cos.UnitsIEC, digits) },
 		"FormatMAM":           func(u int64) string { return fmt.Sprintf("%-10s", FmtSize(u, cos.UnitsIEC, 2)) },
+		"FormatDedupRatio":    fmtDedupRatio,
 		"FormatMilli":         func(dur cos.Duration) string { return fmtMilli(dur, cos.UnitsIEC) },
 		"FormatDuration":      FormatDuration,
 		"FormatStart":         FmtTime,
@@ -419,6 +439,7 @@ var (
 		"JoinListNL":    func(lst []string) string { return fmtStringListGeneric(lst, "\n") },
 		"ExtECGetStats": extECGetStats,
 		"ExtECPutStats": extECPutStats,
+		"ExtLRUStats":   extLRUStats,
 		// StatsAndStatusHelper:
 		// select specific field and make a slice, and then a string out of it
 		"OnlineStatus": func(h StatsAndStatusHelper) string { return toString(h.onlineStatus()) },
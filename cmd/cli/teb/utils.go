@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/ext/dsort"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/space"
 )
 
 // this file: low-level formatting routines and misc.
@@ -46,6 +47,14 @@ func FmtCopies(copies int) string {
 	return strconv.Itoa(copies)
 }
 
+// deduplicated content size vs. size of the dedup chunks, e.g. "2.50x"; zero becomes "-"
+func fmtDedupRatio(ratio float64) string {
+	if ratio == 0 {
+		return NotSetVal
+	}
+	return strconv.FormatFloat(ratio, 'f', 2, 64) + "x"
+}
+
 // FmtEC formats EC data (DataSlices, ParitySlices, IsECCopy) into a
 // readable string for CLI, e.g. "1:2[encoded]"
 func FmtEC(gen int64, data, parity int, isCopy bool) string {
@@ -351,6 +360,14 @@ func extECPutStats(base *core.Snap) *ec.ExtECPutStats {
 	return ecPut
 }
 
+func extLRUStats(base *core.Snap) *space.ExtLRUStats {
+	lru := &space.ExtLRUStats{}
+	if err := cos.MorphMarshal(base.Ext, lru); err != nil {
+		return &space.ExtLRUStats{}
+	}
+	return lru
+}
+
 //
 // time and duration
 //
@@ -39,6 +39,8 @@ const (
 	PropBackendBck         = "backend_bck"
 	PropBackendBckName     = PropBackendBck + ".name"
 	PropBackendBckProvider = PropBackendBck + ".provider"
+
+	MaxHTListDepth = 16 // ExtraPropsHTTP.ListDepth
 )
 
 type (
@@ -57,6 +59,16 @@ type (
 		BID         uint64          `json:"bid,string" list:"omit"`         // unique ID
 		Created     int64           `json:"created,string" list:"readonly"` // creation timestamp
 		Versioning  VersionConf     `json:"versioning"`                     // versioning (see "inherit")
+		Lifecycle   LifecycleConf   `json:"lifecycle"`                      // lifecycle rules (see cmn/lifecycle.go)
+		Policy      PolicyConf      `json:"policy"`                         // bucket policy and ACL (see cmn/policy.go)
+		CORS        CORSConf        `json:"cors"`                           // CORS rules (see cmn/cors.go)
+		ObjLock     ObjLockConf     `json:"object_lock"`                    // object lock (WORM) defaults (see cmn/objlock.go)
+		SSE         SSEConf         `json:"sse"`                            // server-side encryption at rest (see cmn/sse.go)
+		Quota       QuotaConf       `json:"quota"`                          // storage quota (see cmn/quota.go)
+		Compression CompressionConf `json:"compression"`                    // compression at rest (see cmn/compr.go)
+		Dedup       DedupConf       `json:"dedup"`                          // content-defined deduplication (see cmn/dedup.go)
+		RateLimit   RateLimitConf   `json:"rate_limit"`                     // rate limiting (see cmn/ratelim.go)
+		Events      EventsConf      `json:"events"`                         // event notifications (see cmn/events.go)
 	}
 
 	ExtraProps struct {
@@ -82,6 +94,8 @@ type (
 		// - "Overrides the config profile the Session should be created from. If not
 		// set the value of the environment variable will be loaded (AWS_PROFILE,
 		// or AWS_DEFAULT_PROFILE if the Shared Config is enabled)."
+		// - NOTE: a profile named in cluster config (`backend.aws.profiles`) takes precedence,
+		// providing its own endpoint, region, credentials, and TLS options (see cmn.S3Profile)
 		Profile string `json:"profile,omitempty"`
 
 		// Amazon S3: 1000
@@ -100,9 +114,15 @@ type (
 	ExtraPropsHTTP struct {
 		// Original URL prior to hashing.
 		OrigURLBck string `json:"original_url,omitempty" list:"readonly"`
+
+		// List objects by parsing HTTP index pages (Apache and nginx autoindex, HTML or JSON):
+		// max number of directory levels to traverse, including the bucket's own;
+		// zero (default) - no listing (in-cluster objects only)
+		ListDepth int `json:"list_depth,omitempty"`
 	}
 	ExtraPropsHTTPToSet struct {
 		OrigURLBck *string `json:"original_url"`
+		ListDepth  *int    `json:"list_depth"`
 	}
 
 	ExtraPropsHDFS struct {
@@ -127,6 +147,16 @@ type (
 		Features    *feat.Flags           `json:"features,string,omitempty"`
 		WritePolicy *WritePolicyConfToSet `json:"write_policy,omitempty"`
 		Extra       *ExtraToSet           `json:"extra,omitempty"`
+		Lifecycle   *LifecycleConfToSet   `json:"lifecycle,omitempty"`
+		Policy      *PolicyConfToSet      `json:"policy,omitempty"`
+		CORS        *CORSConfToSet        `json:"cors,omitempty"`
+		ObjLock     *ObjLockConfToSet     `json:"object_lock,omitempty"`
+		SSE         *SSEConfToSet         `json:"sse,omitempty"`
+		Quota       *QuotaConfToSet       `json:"quota,omitempty"`
+		Compression *CompressionConfToSet `json:"compression,omitempty"`
+		Dedup       *DedupConfToSet       `json:"dedup,omitempty"`
+		RateLimit   *RateLimitConfToSet   `json:"rate_limit,omitempty"`
+		Events      *EventsConfToSet      `json:"events,omitempty"`
 		Force       bool                  `json:"force,omitempty" copy:"skip" list:"omit"`
 	}
 
@@ -220,7 +250,9 @@ func (bp *Bprops) Validate(targetCnt int) error {
 
 	// run assorted props validators
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	for _, pv := range []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.Lifecycle,
+		&bp.Policy, &bp.CORS, &bp.ObjLock, &bp.SSE, &bp.Quota, &bp.Compression,
+		&bp.Dedup, &bp.RateLimit, &bp.Events} {
 		var err error
 		switch {
 		case pv == &bp.EC:
@@ -285,6 +317,9 @@ func (c *ExtraProps) ValidateAsProps(arg ...any) error {
 	if provider == apc.HT && c.HTTP.OrigURLBck == "" {
 		return errors.New("original bucket URL must be set for a bucket with HTTP provider")
 	}
+	if c.HTTP.ListDepth < 0 || c.HTTP.ListDepth > MaxHTListDepth {
+		return fmt.Errorf("invalid extra.http.list_depth %d (expecting 0 <= depth <= %d)", c.HTTP.ListDepth, MaxHTListDepth)
+	}
 	return nil
 }
 
@@ -326,7 +361,10 @@ func aggr(from, to *BsummResult) {
 	to.ObjCount.Remote += from.ObjCount.Remote
 	to.TotalSize.OnDisk += from.TotalSize.OnDisk
 	to.TotalSize.PresentObjs += from.TotalSize.PresentObjs
+	to.TotalSize.PresentLogical += from.TotalSize.PresentLogical
 	to.TotalSize.RemoteObjs += from.TotalSize.RemoteObjs
+	to.TotalSize.DedupLogical += from.TotalSize.DedupLogical
+	to.TotalSize.DedupChunks += from.TotalSize.DedupChunks
 }
 
 func (s AllBsummResults) Finalize(dsize map[string]uint64, testingEnv bool) {
@@ -359,7 +397,7 @@ type (
 	// ArchiveBckMsg contains parameters to archive mutiple objects from the specified (source) bucket.
 	// Destination bucket may the same as the source or a different one.
 	// --------------------  NOTE on terminology:   ---------------------
-	// "archive" is any (.tar, .tgz/.tar.gz, .zip, .tar.lz4) formatted object often also called "shard"
+	// "archive" is any (.tar, .tgz/.tar.gz, .zip, .tar.lz4, .tar.zst) formatted object often also called "shard"
 	//
 	// See also: apc.PutApndArchArgs
 	ArchiveBckMsg struct {
@@ -12,7 +12,7 @@ import (
 )
 
 // copy `src` => `tw` destination, one file at a time
-// handles .tar, .tar.gz, and .tar.lz4
+// handles .tar, .tar.gz, .tar.lz4, and .tar.zst
 // - open specific arch reader
 // - always close it
 // - `tw` is the writer that can be further used to write (ie., append)
@@ -0,0 +1,226 @@
+// Package archive: write, read, copy, append, list primitives
+// across all supported formats
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/pierrec/lz4/v3"
+)
+
+// Random-access index of a tar-formatted shard (.tar, .tgz, .tar.gz, .tar.lz4, .tar.zst):
+// archived filename => offset and size of its content in the (uncompressed) tar stream.
+// - .tar:  any archived file can be read directly (io.SectionReader), without scanning tar headers;
+// - compressed: the index still serves listing and lookup (including "not found") but reading
+//   requires decompressing the stream up to the offset - gzip, lz4, and zstd are not seekable;
+// - .zip: not needed - zip has its own central directory.
+//
+// Built in a single pass over the shard; the caller is responsible for persistence and
+// invalidation (see core/larchidx.go).
+
+var ErrNotIndexable = errors.New("archive format does not support indexing")
+
+type (
+	IdxEntry struct {
+		Name string `json:"n"`
+		Off  int64  `json:"o"` // offset of the content in the (uncompressed) tar stream
+		Size int64  `json:"s"`
+	}
+	Index struct {
+		Mime    string     `json:"mime"`
+		Src     string     `json:"src"`     // identifies indexed shard (version, size, mtime - caller's choice)
+		Entries []IdxEntry `json:"entries"` // sorted by (normalized) name
+	}
+
+	// counts consumed bytes; when the underlying reader is io.Seeker (plain tar) allows
+	// tar.Reader to skip archived files' content
+	offReader struct {
+		r   io.Reader
+		off int64
+	}
+	offSeeker struct {
+		offReader
+	}
+)
+
+func Indexable(mime string) bool {
+	switch mime {
+	case ExtTar, ExtTgz, ExtTarGz, ExtTarLz4, ExtTarZst:
+		return true
+	default:
+		return false
+	}
+}
+
+// NOTE: `fh` must be positioned at the beginning of the shard
+func BuildIndex(mime string, fh io.Reader) (*Index, error) {
+	var (
+		r   io.Reader
+		gzr *gzip.Reader
+		err error
+	)
+	switch mime {
+	case ExtTar:
+		r = fh
+	case ExtTgz, ExtTarGz:
+		if gzr, err = gzip.NewReader(fh); err != nil {
+			return nil, err
+		}
+		defer gzr.Close()
+		r = gzr
+	case ExtTarLz4:
+		r = lz4.NewReader(fh)
+	case ExtTarZst:
+		zr, err := NewZstdReader(fh)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		return nil, ErrNotIndexable
+	}
+
+	var (
+		idx = &Index{Mime: mime}
+		or  *offReader
+		tr  *tar.Reader
+	)
+	if _, ok := r.(io.Seeker); ok {
+		ose := &offSeeker{offReader{r: r}}
+		or = &ose.offReader
+		tr = tar.NewReader(ose)
+	} else {
+		or = &offReader{r: r}
+		tr = tar.NewReader(or)
+	}
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if hdr.FileInfo().IsDir() {
+			continue
+		}
+		// content of sparse files is not contiguous
+		if hdr.Typeflag == tar.TypeGNUSparse || hdr.PAXRecords["GNU.sparse.map"] != "" ||
+			hdr.PAXRecords["GNU.sparse.major"] != "" {
+			return nil, ErrNotIndexable
+		}
+		idx.Entries = append(idx.Entries, IdxEntry{Name: hdr.Name, Off: or.off, Size: hdr.Size})
+	}
+
+	// sort and dedup: when the same name is archived more than once the first one wins
+	// (consistent with Reader.ReadOne)
+	sort.SliceStable(idx.Entries, func(i, j int) bool {
+		return idxName(idx.Entries[i].Name) < idxName(idx.Entries[j].Name)
+	})
+	if l := len(idx.Entries); l > 1 {
+		k := 1
+		for i := 1; i < l; i++ {
+			if idxName(idx.Entries[i].Name) != idxName(idx.Entries[k-1].Name) {
+				idx.Entries[k] = idx.Entries[i]
+				k++
+			}
+		}
+		idx.Entries = idx.Entries[:k]
+	}
+	return idx, nil
+}
+
+// in re `--absolute-names` (see namesEq)
+func idxName(name string) string { return strings.TrimPrefix(name, "/") }
+
+// returns nil if not found
+func (idx *Index) Find(filename string) *IdxEntry {
+	name := idxName(filename)
+	i := sort.Search(len(idx.Entries), func(i int) bool { return idxName(idx.Entries[i].Name) >= name })
+	if i < len(idx.Entries) && idxName(idx.Entries[i].Name) == name {
+		return &idx.Entries[i]
+	}
+	return nil
+}
+
+// (compare with List)
+func (idx *Index) List() []*Entry {
+	lst := make([]*Entry, len(idx.Entries))
+	for i := range idx.Entries {
+		lst[i] = &Entry{Name: idx.Entries[i].Name, Size: idx.Entries[i].Size}
+	}
+	sort.Slice(lst, func(i, j int) bool { return lst[i].Name < lst[j].Name })
+	return lst
+}
+
+// ReadOne is the indexed counterpart of Reader.ReadOne: returns (nil, nil) if not found;
+// non-nil reader must be closed by the caller.
+// NOTE: `fh` must be positioned at the beginning of the shard
+func (idx *Index) ReadOne(fh cos.LomReader, filename string) (cos.ReadCloseSizer, error) {
+	e := idx.Find(filename)
+	if e == nil {
+		return nil, nil
+	}
+	switch idx.Mime {
+	case ExtTar:
+		return &cslLimited{LimitedReader: io.LimitedReader{R: io.NewSectionReader(fh, e.Off, e.Size), N: e.Size}}, nil
+	case ExtTgz, ExtTarGz:
+		gzr, err := gzip.NewReader(fh)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.CopyN(io.Discard, gzr, e.Off); err != nil {
+			gzr.Close()
+			return nil, err
+		}
+		return &cslClose{gzr: gzr, R: io.LimitReader(gzr, e.Size), N: e.Size}, nil
+	case ExtTarLz4:
+		lzr := lz4.NewReader(fh)
+		if _, err := io.CopyN(io.Discard, lzr, e.Off); err != nil {
+			return nil, err
+		}
+		return &cslLimited{LimitedReader: io.LimitedReader{R: lzr, N: e.Size}}, nil
+	case ExtTarZst:
+		zr, err := NewZstdReader(fh)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.CopyN(io.Discard, zr, e.Off); err != nil {
+			zr.Close()
+			return nil, err
+		}
+		return &cslClose{gzr: zr.IOReadCloser(), R: io.LimitReader(zr, e.Size), N: e.Size}, nil
+	default:
+		debug.Assert(false, idx.Mime)
+		return nil, ErrNotIndexable
+	}
+}
+
+///////////////
+// offReader //
+///////////////
+
+func (or *offReader) Read(b []byte) (n int, err error) {
+	n, err = or.r.Read(b)
+	or.off += int64(n)
+	return n, err
+}
+
+func (ose *offSeeker) Seek(offset int64, whence int) (int64, error) {
+	pos, err := ose.r.(io.Seeker).Seek(offset, whence)
+	if err == nil {
+		ose.off = pos
+	}
+	return pos, err
+}
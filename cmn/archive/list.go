@@ -51,6 +51,8 @@ func List(fqn string) ([]*Entry, error) {
 		}
 	case ExtTarLz4:
 		lst, err = lsLz4(fh)
+	case ExtTarZst:
+		lst, err = lsZstd(fh)
 	default:
 		debug.Assert(false, mime)
 	}
@@ -63,7 +65,7 @@ func List(fqn string) ([]*Entry, error) {
 	return lst, nil
 }
 
-// list: tar, tgz, zip, msgpack
+// list: tar, tgz, zip, tar.lz4, tar.zst
 func lsTar(reader io.Reader) (lst []*Entry, _ error) {
 	tr := tar.NewReader(reader)
 	for {
@@ -113,3 +115,13 @@ func lsLz4(reader io.Reader) ([]*Entry, error) {
 	lzr := lz4.NewReader(reader)
 	return lsTar(lzr)
 }
+
+func lsZstd(reader io.Reader) ([]*Entry, error) {
+	zr, err := NewZstdReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	lst, err := lsTar(zr)
+	zr.Close()
+	return lst, err
+}
@@ -23,12 +23,14 @@ import (
 //   - FileExtensions
 //   - allMagics
 //   - ext/dsort/shard/rw.go
+//   - archFormats in cmd/cli/cli/const.go
 const (
 	ExtTar    = ".tar"
 	ExtTgz    = ".tgz"
 	ExtTarGz  = ".tar.gz"
 	ExtZip    = ".zip"
 	ExtTarLz4 = ".tar.lz4"
+	ExtTarZst = ".tar.zst"
 )
 
 const (
@@ -47,7 +49,7 @@ type detect struct {
 	offset int
 }
 
-var FileExtensions = [...]string{ExtTar, ExtTgz, ExtTarGz, ExtZip, ExtTarLz4}
+var FileExtensions = [...]string{ExtTar, ExtTgz, ExtTarGz, ExtZip, ExtTarLz4, ExtTarZst}
 
 // standard file signatures
 var (
@@ -55,8 +57,9 @@ var (
 	magicGzip = detect{sig: []byte{0x1f, 0x8b}, mime: ExtTarGz}
 	magicZip  = detect{sig: []byte{0x50, 0x4b}, mime: ExtZip}
 	magicLz4  = detect{sig: []byte{0x04, 0x22, 0x4d, 0x18}, mime: ExtTarLz4}
+	magicZstd = detect{sig: []byte{0x28, 0xb5, 0x2f, 0xfd}, mime: ExtTarZst}
 
-	allMagics = []detect{magicTar, magicGzip, magicZip, magicLz4} // NOTE: must contain all
+	allMagics = []detect{magicTar, magicGzip, magicZip, magicLz4, magicZstd} // NOTE: must contain all
 )
 
 // motivation: prevent from creating archives with non-standard extensions
@@ -91,6 +94,8 @@ func normalize(mime string) (string, error) {
 		return ExtTarGz, nil
 	case strings.Contains(mime, ExtTarLz4[1:]): // ditto
 		return ExtTarLz4, nil
+	case strings.Contains(mime, ExtTarZst[1:]), strings.Contains(mime, "zstd"): // ditto; also "application/zstd"
+		return ExtTarZst, nil
 	default:
 		for _, ext := range FileExtensions {
 			if strings.Contains(mime, ext[1:]) {
@@ -128,8 +133,8 @@ func MimeFile(file cos.LomReader, smm *memsys.MMSA, mime, archname string) (m st
 	)
 	m, n, err = _detect(file, archname, m, buf)
 	if n > 0 {
-		fh, ok := file.(*os.File)
-		cos.Assertf(ok, "expecting os.File, got %T", file)
+		fh, ok := file.(io.Seeker) // os.File or (decrypting) sse.Reader
+		cos.Assertf(ok, "expecting io.Seeker, got %T", file)
 		_, errV := fh.Seek(0, io.SeekStart)
 		debug.AssertNoErr(errV)
 		if err == nil {
@@ -184,6 +189,10 @@ func _detect(file cos.LomReader, archname, mime string, buf []byte) (string, int
 		if l := magicLz4.offset + len(magicLz4.sig) + 4; n < l {
 			return "", n, NewErrUnknownFileExt(archname, fmt.Sprintf(fmtErrTooShort, ExtTarGz, l))
 		}
+	case ExtTarZst:
+		if l := magicZstd.offset + len(magicZstd.sig) + 2; n < l {
+			return "", n, NewErrUnknownFileExt(archname, fmt.Sprintf(fmtErrTooShort, ExtTarZst, l))
+		}
 	}
 	for _, magic := range allMagics {
 		if n > magic.offset && bytes.HasPrefix(buf[magic.offset:], magic.sig) {
@@ -17,6 +17,7 @@ import (
 
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pierrec/lz4/v3"
 )
 
@@ -92,6 +93,10 @@ type (
 		tr  tarReader
 		lzr *lz4.Reader
 	}
+	zstdReader struct {
+		tr tarReader
+		zr *zstd.Decoder
+	}
 )
 
 // interface guard
@@ -100,6 +105,7 @@ var (
 	_ Reader = (*tgzReader)(nil)
 	_ Reader = (*zipReader)(nil)
 	_ Reader = (*lz4Reader)(nil)
+	_ Reader = (*zstdReader)(nil)
 )
 
 func NewReader(mime string, fh io.Reader, size ...int64) (ar Reader, err error) {
@@ -113,6 +119,8 @@ func NewReader(mime string, fh io.Reader, size ...int64) (ar Reader, err error)
 		ar = &zipReader{size: size[0]}
 	case ExtTarLz4:
 		ar = &lz4Reader{}
+	case ExtTarZst:
+		ar = &zstdReader{}
 	default:
 		debug.Assert(false, mime)
 	}
@@ -321,6 +329,34 @@ func (lzr *lz4Reader) ReadOne(filename string) (cos.ReadCloseSizer, error) {
 	return lzr.tr.ReadOne(filename)
 }
 
+// zstdReader
+
+func (zsr *zstdReader) init(fh io.Reader) (err error) {
+	zsr.zr, err = NewZstdReader(fh)
+	if err != nil {
+		return
+	}
+	zsr.tr.baseR.init(zsr.zr)
+	zsr.tr.tr = tar.NewReader(zsr.zr)
+	return
+}
+
+func (zsr *zstdReader) ReadUntil(rcb ArchRCB, regex, mmode string) error {
+	err := zsr.tr.ReadUntil(rcb, regex, mmode)
+	zsr.zr.Close()
+	return err
+}
+
+// same as tgzReader: non-nil reader is closed by the caller (which also releases the decoder)
+func (zsr *zstdReader) ReadOne(filename string) (cos.ReadCloseSizer, error) {
+	reader, err := zsr.tr.ReadOne(filename)
+	if err != nil || reader == nil {
+		zsr.zr.Close()
+		return nil, err
+	}
+	return &cslClose{gzr: zsr.zr.IOReadCloser(), R: reader, N: reader.Size()}, nil
+}
+
 //
 // more limited readers
 //
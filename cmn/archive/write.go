@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pierrec/lz4/v3"
 )
 
@@ -28,6 +29,7 @@ type (
 		CB        HeaderCallback
 		TarFormat tar.Format
 		Serialize bool
+		ZstdLevel int // .tar.zst only: standard zstd compression level (1 through 22); zero means default (3)
 	}
 )
 
@@ -67,6 +69,10 @@ type (
 		lzw *lz4.Writer
 		tw  tarWriter
 	}
+	zstdWriter struct {
+		zw *zstd.Encoder
+		tw tarWriter
+	}
 )
 
 // interface guard
@@ -75,6 +81,7 @@ var (
 	_ Writer = (*tgzWriter)(nil)
 	_ Writer = (*zipWriter)(nil)
 	_ Writer = (*lz4Writer)(nil)
+	_ Writer = (*zstdWriter)(nil)
 )
 
 // calls init() -> open(),alloc()
@@ -88,6 +95,8 @@ func NewWriter(mime string, w io.Writer, cksum *cos.CksumHashSize, opts *Opts) (
 		aw = &zipWriter{}
 	case ExtTarLz4:
 		aw = &lz4Writer{}
+	case ExtTarZst:
+		aw = &zstdWriter{}
 	default:
 		debug.Assert(false, mime)
 	}
@@ -263,3 +272,50 @@ func (lzw *lz4Writer) Copy(src io.Reader, _ ...int64) error {
 	lzr := lz4.NewReader(src)
 	return cpTar(lzr, lzw.tw.tw, lzw.tw.buf)
 }
+
+// zstdWriter
+
+// zstd encoder and decoder: single goroutine each (shards are written and read concurrently,
+// many at a time); `level` is the standard zstd compression level, zero means default
+func NewZstdWriter(w io.Writer, level int) (*zstd.Encoder, error) {
+	elvl := zstd.SpeedDefault
+	if level != 0 {
+		elvl = zstd.EncoderLevelFromZstd(level)
+	}
+	return zstd.NewWriter(w, zstd.WithEncoderLevel(elvl), zstd.WithEncoderConcurrency(1))
+}
+
+func NewZstdReader(r io.Reader) (*zstd.Decoder, error) {
+	return zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+}
+
+func (zsw *zstdWriter) init(w io.Writer, cksum *cos.CksumHashSize, opts *Opts) {
+	var level int
+	if opts != nil {
+		level = opts.ZstdLevel
+	}
+	zsw.tw.baseW.init(w, cksum, opts)
+	zw, err := NewZstdWriter(zsw.tw.wmul, level)
+	debug.AssertNoErr(err) // (only options can fail)
+	zsw.zw = zw
+	zsw.tw.tw = tar.NewWriter(zsw.zw)
+}
+
+func (zsw *zstdWriter) Fini() {
+	zsw.tw.Fini()
+	zsw.zw.Close()
+}
+
+func (zsw *zstdWriter) Write(fullname string, oah cos.OAH, reader io.Reader) error {
+	return zsw.tw.Write(fullname, oah, reader)
+}
+
+func (zsw *zstdWriter) Copy(src io.Reader, _ ...int64) error {
+	zr, err := NewZstdReader(src)
+	if err != nil {
+		return err
+	}
+	err = cpTar(zr, zsw.tw.tw, zsw.tw.buf)
+	zr.Close()
+	return err
+}
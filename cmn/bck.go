@@ -366,6 +366,11 @@ func (b *Bck) IsAIS() bool {
 func (b *Bck) IsRemoteAIS() bool { return b.Provider == apc.AIS && b.Ns.IsRemote() }
 func (b *Bck) IsHT() bool        { return b.Provider == apc.HT }
 
+// HTTP(S) bucket that can be listed by parsing index pages (see ExtraPropsHTTP.ListDepth)
+func (b *Bck) IsListableHT() bool {
+	return b.IsHT() && b.Props != nil && b.Props.Extra.HTTP.ListDepth > 0
+}
+
 func (b *Bck) IsRemote() bool {
 	return apc.IsRemoteProvider(b.Provider) || b.IsRemoteAIS() || b.Backend() != nil
 }
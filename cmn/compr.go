@@ -0,0 +1,90 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn/compr"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Compression of object content at rest: lz4 or zstd, in fixed-size independently compressed
+// frames followed by the frame index (see cmn/compr and core/lcmpr.go).
+//
+// Bucket-level `compression` props enable compression of newly written objects, except:
+// - objects smaller than `min_size`;
+// - objects that do not compress well enough - the first chunk saves less than `min_savings` percent;
+// - objects encrypted at rest (see cmn/sse.go) - encryption and compression are mutually exclusive.
+//
+// Compression is transparent to GET (including range reads), HEAD, and list-objects that all
+// report (and return) uncompressed content. Internally, object size and checksum are those of the
+// compressed content - rebalance, mirroring, and erasure coding move compressed bytes as is.
+// Bucket summary reports both: total size at rest and total (logical) size of the objects.
+//
+// Supported for ais:// buckets only.
+
+// custom metadata
+const (
+	CmprAlgoObjMD = "cmpr-algo" // compression algorithm (compr.AlgoLZ4, etc.)
+	CmprSizeObjMD = "cmpr-size" // uncompressed size
+)
+
+const DefaultCmprMinSavings = 10 // percent
+
+type (
+	CompressionConf struct {
+		Algo       string      `json:"algo"`        // compr.AlgoLZ4 (default) or compr.AlgoZstd
+		MinSize    cos.SizeIEC `json:"min_size"`    // smaller objects are stored as is
+		MinSavings int         `json:"min_savings"` // percent; zero means DefaultCmprMinSavings
+		Enabled    bool        `json:"enabled"`
+	}
+	CompressionConfToSet struct {
+		Algo       *string      `json:"algo,omitempty"`
+		MinSize    *cos.SizeIEC `json:"min_size,omitempty"`
+		MinSavings *int         `json:"min_savings,omitempty"`
+		Enabled    *bool        `json:"enabled,omitempty"`
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*CompressionConf)(nil)
+
+func (c *CompressionConf) ValidateAsProps(...any) error {
+	if c.Algo != "" && !compr.IsValidAlgo(c.Algo) {
+		return fmt.Errorf("invalid compression.algo %q (expecting %q or %q)", c.Algo, compr.AlgoLZ4, compr.AlgoZstd)
+	}
+	if c.MinSize < 0 {
+		return errors.New("compression.min_size cannot be negative")
+	}
+	if c.MinSavings < 0 || c.MinSavings >= 100 {
+		return fmt.Errorf("invalid compression.min_savings %d (expecting percentage in the range [0, 100))", c.MinSavings)
+	}
+	return nil
+}
+
+func (c *CompressionConf) AlgoX() string {
+	if c.Algo == "" {
+		return compr.AlgoLZ4
+	}
+	return c.Algo
+}
+
+func (c *CompressionConf) MinSavingsX() int {
+	if c.MinSavings == 0 {
+		return DefaultCmprMinSavings
+	}
+	return c.MinSavings
+}
+
+func IsCmprObjMD(key string) bool { return key == CmprAlgoObjMD || key == CmprSizeObjMD }
+
+// SSE, compression, and dedup metadata: describes content at rest (see core/lsse.go, core/lcmpr.go,
+// and core/ldedup.go)
+func IsAtRestObjMD(key string) bool {
+	return IsSSEObjMD(key) || IsCmprObjMD(key) || key == DedupSizeObjMD
+}
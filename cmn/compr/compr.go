@@ -0,0 +1,458 @@
+// Package compr provides transparent compression of object content at rest
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package compr
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v3"
+)
+
+// Object content is split into fixed-size chunks, each compressed separately (a "frame"),
+// followed by the frame index and a fixed-size trailer:
+//
+//	| frame 0 | frame 1 | ... | frame N-1 | index: N x uint32 | trailer |
+//
+// - index entry is the size of the frame at rest; the high bit marks a frame that is stored
+//   as is (when compression does not help);
+// - trailer: content (uncompressed) size, number of frames, chunk size, algorithm, and magic;
+// - independent frames make it possible to read (and decompress) arbitrary ranges.
+//
+// Writer decides whether to compress at all: content smaller than the configured minimum,
+// or content that does not compress well enough (judging by its first chunk) is written as is.
+
+// supported algorithms
+const (
+	AlgoLZ4  = "lz4"
+	AlgoZstd = "zstd"
+)
+
+const (
+	ChunkSize   = 64 * cos.KiB
+	TrailerSize = 24
+
+	rawBit = 1 << 31
+)
+
+type (
+	codec interface {
+		id() uint32
+		// returns nil if `src` is not compressible
+		compress(dst, src []byte) []byte
+		// `dst` is sized exactly (to fit decompressed `src`)
+		decompress(dst, src []byte) error
+	}
+	lz4c struct {
+		ht []int
+	}
+	zstdc struct{}
+
+	// compressing writer
+	Writer struct {
+		w          io.Writer
+		c          codec
+		buf        []byte // content chunk
+		out        []byte // compressed chunk
+		index      []uint32
+		size       int64 // content bytes
+		written    int64 // bytes at rest
+		minSize    int64
+		minSavings int
+		n          int // content bytes in buf
+		decided    bool
+		raw        bool
+		closed     bool
+	}
+
+	// decompressing reader (io.ReaderAt over content offsets)
+	Reader struct {
+		r     io.ReaderAt
+		c     codec
+		index []uint32
+		offs  []int64 // frame offsets at rest
+		buf   []byte  // frame at rest
+		dbuf  []byte  // decompressed frame (when not stored as is)
+		plain []byte  // content of the frame `cidx`
+		size  int64   // content size
+		chunk int64
+		cidx  int64
+		off   int64 // (Read)
+	}
+)
+
+const (
+	idLZ4 = iota + 1
+	idZstd
+)
+
+var (
+	magic = [4]byte{'a', 'i', 's', 'z'}
+
+	zonce sync.Once
+	zenc  *zstd.Encoder
+	zdec  *zstd.Decoder
+
+	errNegOffset = errors.New("compr: negative offset")
+	errClosed    = errors.New("compr: writer is closed")
+)
+
+// interface guard
+var (
+	_ cos.LomReader  = (*Reader)(nil)
+	_ io.Seeker      = (*Reader)(nil)
+	_ io.WriteCloser = (*Writer)(nil)
+)
+
+func IsValidAlgo(algo string) bool { return algo == AlgoLZ4 || algo == AlgoZstd }
+
+func newCodec(algo string) (codec, error) {
+	switch algo {
+	case AlgoLZ4:
+		return &lz4c{}, nil
+	case AlgoZstd:
+		return zstdc{}, nil
+	default:
+		return nil, fmt.Errorf("compr: invalid algorithm %q (expecting %q or %q)", algo, AlgoLZ4, AlgoZstd)
+	}
+}
+
+func codecByID(id uint32) (codec, error) {
+	switch id {
+	case idLZ4:
+		return &lz4c{}, nil
+	case idZstd:
+		return zstdc{}, nil
+	default:
+		return nil, fmt.Errorf("compr: unknown algorithm ID %d", id)
+	}
+}
+
+//
+// codecs
+//
+
+func (*lz4c) id() uint32 { return idLZ4 }
+
+func (c *lz4c) compress(dst, src []byte) []byte {
+	if c.ht == nil {
+		c.ht = make([]int, 1<<16)
+	}
+	n, err := lz4.CompressBlock(src, dst[:cap(dst)], c.ht)
+	if err != nil || n == 0 || n >= len(src) {
+		return nil
+	}
+	return dst[:n]
+}
+
+func (*lz4c) decompress(dst, src []byte) error {
+	n, err := lz4.UncompressBlock(src, dst)
+	if err == nil && n != len(dst) {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// zstd encoder and decoder are shared: EncodeAll and DecodeAll are safe for concurrent use
+func _zinit() {
+	var err error
+	zenc, err = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithEncoderCRC(false))
+	cos.AssertNoErr(err)
+	zdec, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	cos.AssertNoErr(err)
+}
+
+func (zstdc) id() uint32 { return idZstd }
+
+func (zstdc) compress(dst, src []byte) []byte {
+	zonce.Do(_zinit)
+	out := zenc.EncodeAll(src, dst[:0])
+	if len(out) >= len(src) {
+		return nil
+	}
+	return out
+}
+
+func (zstdc) decompress(dst, src []byte) error {
+	zonce.Do(_zinit)
+	out, err := zdec.DecodeAll(src, dst[:0])
+	if err == nil && len(out) != len(dst) {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+////////////
+// Writer //
+////////////
+
+// NewWriter returns writer that compresses everything written to it and writes the result
+// into `w`, unless:
+//   - content size turns out to be less than `minSize` (only known when it's less than ChunkSize), or
+//   - compressing the first chunk saves less than `minSavings` percent;
+//
+// in both cases the content gets written as is (see Compressed).
+// The caller must Close() it to flush the last frame.
+func NewWriter(w io.Writer, algo string, minSize int64, minSavings int) (*Writer, error) {
+	c, err := newCodec(algo)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{
+		w:          w,
+		c:          c,
+		buf:        make([]byte, ChunkSize),
+		out:        make([]byte, 0, lz4.CompressBlockBound(ChunkSize)),
+		minSize:    minSize,
+		minSavings: minSavings,
+	}, nil
+}
+
+func (cw *Writer) Write(b []byte) (n int, err error) {
+	if cw.closed {
+		return 0, errClosed
+	}
+	if cw.raw {
+		return cw.passthru(b)
+	}
+	for len(b) > 0 {
+		c := copy(cw.buf[cw.n:], b)
+		cw.n += c
+		cw.size += int64(c)
+		n += c
+		b = b[c:]
+		if cw.n < ChunkSize {
+			continue
+		}
+		if err = cw.flush(); err != nil {
+			return n, err
+		}
+		if cw.raw && len(b) > 0 {
+			c, err = cw.passthru(b)
+			return n + c, err
+		}
+	}
+	return n, nil
+}
+
+func (cw *Writer) passthru(b []byte) (int, error) {
+	n, err := cw.w.Write(b)
+	cw.size += int64(n)
+	cw.written += int64(n)
+	return n, err
+}
+
+func (cw *Writer) write(b []byte) error {
+	n, err := cw.w.Write(b)
+	cw.written += int64(n)
+	return err
+}
+
+// compress and write the buffered chunk
+func (cw *Writer) flush() error {
+	src := cw.buf[:cw.n]
+	cw.n = 0
+	out := cw.c.compress(cw.out, src)
+	if !cw.decided {
+		cw.decided = true
+		if out == nil || int64(len(out)) > int64(len(src))*int64(100-cw.minSavings)/100 {
+			cw.raw = true
+			return cw.write(src)
+		}
+	}
+	if out == nil {
+		cw.index = append(cw.index, uint32(len(src))|rawBit)
+		return cw.write(src)
+	}
+	cw.index = append(cw.index, uint32(len(out)))
+	return cw.write(out)
+}
+
+// Close flushes the last frame and writes frame index and trailer (if compressed);
+// it does not close the underlying writer
+func (cw *Writer) Close() error {
+	if cw.closed {
+		return nil
+	}
+	cw.closed = true
+	if !cw.decided && (cw.size == 0 || cw.size < cw.minSize) {
+		cw.decided, cw.raw = true, true
+		return cw.write(cw.buf[:cw.n])
+	}
+	if cw.n > 0 {
+		if err := cw.flush(); err != nil {
+			return err
+		}
+	}
+	if cw.raw {
+		return nil
+	}
+	b := make([]byte, 4*len(cw.index)+TrailerSize)
+	for i, v := range cw.index {
+		binary.LittleEndian.PutUint32(b[4*i:], v)
+	}
+	t := b[4*len(cw.index):]
+	binary.LittleEndian.PutUint64(t, uint64(cw.size))
+	binary.LittleEndian.PutUint32(t[8:], uint32(len(cw.index)))
+	binary.LittleEndian.PutUint32(t[12:], ChunkSize)
+	binary.LittleEndian.PutUint32(t[16:], cw.c.id())
+	copy(t[20:], magic[:])
+	return cw.write(b)
+}
+
+// Compressed returns false if the content was written as is (valid upon Close)
+func (cw *Writer) Compressed() bool { return !cw.raw }
+
+// Size returns the number of bytes written at rest so far
+func (cw *Writer) Size() int64 { return cw.written }
+
+////////////
+// Reader //
+////////////
+
+// NewReader returns reader of content compressed by Writer, where `size` is the size at rest.
+// Closing the reader closes `r` as well (if `r` is an io.Closer).
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	var t [TrailerSize]byte
+	if size < TrailerSize {
+		return nil, fmt.Errorf("compr: invalid size at rest %d", size)
+	}
+	if _, err := r.ReadAt(t[:], size-TrailerSize); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if [4]byte(t[20:]) != magic {
+		return nil, errors.New("compr: invalid trailer (magic)")
+	}
+	var (
+		csize   = int64(binary.LittleEndian.Uint64(t[:]))
+		nframes = int64(binary.LittleEndian.Uint32(t[8:]))
+		chunk   = int64(binary.LittleEndian.Uint32(t[12:]))
+		idxOff  = size - TrailerSize - 4*nframes
+	)
+	c, err := codecByID(binary.LittleEndian.Uint32(t[16:]))
+	if err != nil {
+		return nil, err
+	}
+	if chunk <= 0 || chunk > rawBit || nframes != (csize+chunk-1)/chunk || idxOff < 0 {
+		return nil, fmt.Errorf("compr: invalid trailer (size %d, frames %d, chunk %d)", csize, nframes, chunk)
+	}
+	b := make([]byte, 4*nframes)
+	if _, err := r.ReadAt(b, idxOff); err != nil && err != io.EOF {
+		return nil, err
+	}
+	cr := &Reader{
+		r:     r,
+		c:     c,
+		index: make([]uint32, nframes),
+		offs:  make([]int64, nframes+1),
+		buf:   make([]byte, chunk),
+		size:  csize,
+		chunk: chunk,
+		cidx:  -1,
+	}
+	for i := range cr.index {
+		v := binary.LittleEndian.Uint32(b[4*i:])
+		cr.index[i] = v
+		cr.offs[i+1] = cr.offs[i] + int64(v&^rawBit)
+	}
+	if cr.offs[nframes] != idxOff {
+		return nil, fmt.Errorf("compr: invalid frame index (%d vs %d)", cr.offs[nframes], idxOff)
+	}
+	return cr, nil
+}
+
+func (cr *Reader) Size() int64 { return cr.size }
+
+func (cr *Reader) ReadAt(b []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errNegOffset
+	}
+	for len(b) > 0 {
+		if off >= cr.size {
+			return n, io.EOF
+		}
+		idx := off / cr.chunk
+		if err = cr.load(idx); err != nil {
+			return n, err
+		}
+		c := copy(b, cr.plain[off-idx*cr.chunk:])
+		n += c
+		off += int64(c)
+		b = b[c:]
+	}
+	return n, nil
+}
+
+func (cr *Reader) Read(b []byte) (n int, err error) {
+	n, err = cr.ReadAt(b, cr.off)
+	cr.off += int64(n)
+	return n, err
+}
+
+func (cr *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += cr.off
+	case io.SeekEnd:
+		offset += cr.size
+	default:
+		return 0, fmt.Errorf("compr: invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errNegOffset
+	}
+	cr.off = offset
+	return offset, nil
+}
+
+// read and decompress a given frame (unless already done)
+func (cr *Reader) load(idx int64) error {
+	if idx == cr.cidx {
+		return nil
+	}
+	var (
+		v    = cr.index[idx]
+		flen = int(v &^ rawBit)
+		clen = int(min(cr.chunk, cr.size-idx*cr.chunk))
+	)
+	if flen > len(cr.buf) || (v&rawBit != 0 && flen != clen) {
+		cr.cidx = -1
+		return fmt.Errorf("compr: invalid frame #%d size %d", idx, flen)
+	}
+	n, err := cr.r.ReadAt(cr.buf[:flen], cr.offs[idx])
+	if n < flen {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		cr.cidx = -1
+		return err
+	}
+	if v&rawBit != 0 {
+		cr.plain, cr.cidx = cr.buf[:flen], idx
+		return nil
+	}
+	if cr.dbuf == nil {
+		cr.dbuf = make([]byte, cr.chunk)
+	}
+	if err := cr.c.decompress(cr.dbuf[:clen], cr.buf[:flen]); err != nil {
+		cr.cidx = -1
+		return fmt.Errorf("compr: failed to decompress frame #%d: %w", idx, err)
+	}
+	cr.plain, cr.cidx = cr.dbuf[:clen], idx
+	return nil
+}
+
+func (cr *Reader) Close() (err error) {
+	if c, ok := cr.r.(io.Closer); ok {
+		err = c.Close()
+	}
+	return
+}
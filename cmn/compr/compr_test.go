@@ -0,0 +1,120 @@
+// Package compr provides transparent compression of object content at rest
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package compr_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/compr"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// compressible: repeating (random) words
+func genData(size int) []byte {
+	var (
+		words = make([][]byte, 16)
+		data  = make([]byte, 0, size+64)
+	)
+	for i := range words {
+		words[i] = make([]byte, 4+i)
+		rand.Read(words[i])
+	}
+	for i := 0; len(data) < size; i++ {
+		data = append(data, words[(i*7)%len(words)]...)
+	}
+	return data[:size]
+}
+
+func compress(t *testing.T, algo string, data []byte, minSize int64, minSavings int) ([]byte, bool) {
+	var (
+		out    bytes.Buffer
+		cw, er = compr.NewWriter(&out, algo, minSize, minSavings)
+	)
+	tassert.CheckFatal(t, er)
+	// write in odd-sized pieces
+	for b := data; len(b) > 0; {
+		n := min(len(b), 1000)
+		_, err := cw.Write(b[:n])
+		tassert.CheckFatal(t, err)
+		b = b[n:]
+	}
+	tassert.CheckFatal(t, cw.Close())
+	tassert.Fatalf(t, cw.Size() == int64(out.Len()), "size %d vs %d", cw.Size(), out.Len())
+	return out.Bytes(), cw.Compressed()
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, algo := range []string{compr.AlgoLZ4, compr.AlgoZstd} {
+		for _, size := range []int{1000, compr.ChunkSize - 1, compr.ChunkSize, compr.ChunkSize + 1, 5*compr.ChunkSize + 17} {
+			data := genData(size)
+			ct, compressed := compress(t, algo, data, 0, 10)
+			tassert.Fatalf(t, compressed, "%s, size %d: expected compressed", algo, size)
+			tassert.Errorf(t, len(ct) < size, "%s, size %d: not compressed (%d)", algo, size, len(ct))
+
+			cr, err := compr.NewReader(bytes.NewReader(ct), int64(len(ct)))
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, cr.Size() == int64(size), "%s: size %d vs %d", algo, cr.Size(), size)
+			pt, err := io.ReadAll(cr)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, bytes.Equal(pt, data), "%s, size %d: content mismatch", algo, size)
+
+			// ranges
+			for _, rng := range [][2]int{{0, 1}, {size / 3, size / 2}, {size - 1, size}, {1, size}} {
+				b := make([]byte, rng[1]-rng[0])
+				n, err := cr.ReadAt(b, int64(rng[0]))
+				tassert.Fatalf(t, err == nil || err == io.EOF, "%s, size %d, range %v: %v", algo, size, rng, err)
+				tassert.Fatalf(t, n == len(b) && bytes.Equal(b, data[rng[0]:rng[1]]),
+					"%s, size %d: range %v mismatch", algo, size, rng)
+			}
+		}
+	}
+}
+
+func TestAsIs(t *testing.T) {
+	// smaller than min size
+	data := genData(1000)
+	out, compressed := compress(t, compr.AlgoLZ4, data, 4096, 10)
+	tassert.Errorf(t, !compressed && bytes.Equal(out, data), "expected content written as is (min size)")
+
+	// not compressible
+	data = make([]byte, 3*compr.ChunkSize+5)
+	rand.Read(data)
+	for _, algo := range []string{compr.AlgoLZ4, compr.AlgoZstd} {
+		out, compressed = compress(t, algo, data, 0, 10)
+		tassert.Errorf(t, !compressed && bytes.Equal(out, data), "%s: expected content written as is (random)", algo)
+	}
+
+	// compressible first chunk, random rest: frames stored as is
+	data = append(genData(compr.ChunkSize), data...)
+	out, compressed = compress(t, compr.AlgoZstd, data, 0, 10)
+	tassert.Fatalf(t, compressed, "expected compressed")
+	cr, err := compr.NewReader(bytes.NewReader(out), int64(len(out)))
+	tassert.CheckFatal(t, err)
+	pt, err := io.ReadAll(cr)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(pt, data), "content mismatch")
+}
+
+func TestCorrupted(t *testing.T) {
+	data := genData(3*compr.ChunkSize + 10)
+	ct, _ := compress(t, compr.AlgoLZ4, data, 0, 0)
+
+	// truncated
+	_, err := compr.NewReader(bytes.NewReader(ct[:len(ct)-1]), int64(len(ct)-1))
+	tassert.Errorf(t, err != nil, "expected invalid trailer")
+
+	// damaged frame
+	bad := bytes.Clone(ct)
+	for i := 10; i < 100; i++ {
+		bad[i] ^= 0xff
+	}
+	cr, err := compr.NewReader(bytes.NewReader(bad), int64(len(bad)))
+	tassert.CheckFatal(t, err)
+	pt, err := io.ReadAll(cr)
+	tassert.Errorf(t, err != nil || !bytes.Equal(pt, data), "expected failure to decompress damaged frame")
+}
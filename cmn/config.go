@@ -165,7 +165,29 @@ type (
 		Conf      map[string]any `json:"-"` // backend implementation-dependent (custom marshaling to populate this field)
 		Providers map[string]Ns  `json:"-"` // conditional (build tag) providers set during validation (BackendConf.Validate)
 	}
-	BackendConfAIS map[string][]string // cluster alias -> [urls...]
+	BackendConfAIS  map[string][]string // cluster alias -> [urls...]
+	BackendConfFile struct {
+		Root string `json:"root"` // absolute path to the directory shared by all targets; each subdirectory is a bucket
+	}
+	BackendConfAWS struct {
+		Profiles map[string]*S3Profile `json:"profiles,omitempty"` // profile name -> S3-compatible endpoint
+	}
+	// named S3-compatible (MinIO, Ceph RGW, etc.) endpoint, with its own credentials;
+	// referenced by buckets via `extra.aws.profile`
+	S3Profile struct {
+		Endpoint string `json:"endpoint,omitempty"` // e.g., "https://minio.local:9000"
+		Region   string `json:"region,omitempty"`
+		// credentials reference (never the credentials themselves):
+		// - "env:NAME" - NAME_ACCESS_KEY_ID, NAME_SECRET_ACCESS_KEY, and optional NAME_SESSION_TOKEN
+		//   in the targets' environment;
+		// - "file:/path[#section]" - AWS shared credentials file (default section: "default")
+		//   on all targets;
+		// - empty - the default AWS credential chain
+		Creds      string `json:"credentials,omitempty"`
+		CABundle   string `json:"ca_bundle,omitempty"` // PEM-encoded CA certificate(s) to verify the endpoint
+		PathStyle  bool   `json:"path_style,omitempty"`
+		SkipVerify bool   `json:"skip_verify,omitempty"`
+	}
 
 	MirrorConf struct {
 		Copies  int64 `json:"copies"`       // num copies
@@ -370,13 +392,22 @@ type (
 		// CapacityUpdTimeStr denotes the frequency at which AIStore updates local capacity utilization
 		CapacityUpdTime cos.Duration `json:"capacity_upd_time"`
 
+		// Policy: which objects to evict first (see apc.LRUPolicy; empty means "lru")
+		Policy apc.LRUPolicy `json:"policy"`
+
+		// TTL: with policy "ttl", remote-backed objects that were cached (written locally)
+		// more than TTL ago get evicted regardless of their access times and capacity usage
+		TTL cos.Duration `json:"ttl"`
+
 		// Enabled: LRU will only run when set to true
 		Enabled bool `json:"enabled"`
 	}
 	LRUConfToSet struct {
-		DontEvictTime   *cos.Duration `json:"dont_evict_time,omitempty"`
-		CapacityUpdTime *cos.Duration `json:"capacity_upd_time,omitempty"`
-		Enabled         *bool         `json:"enabled,omitempty"`
+		DontEvictTime   *cos.Duration  `json:"dont_evict_time,omitempty"`
+		CapacityUpdTime *cos.Duration  `json:"capacity_upd_time,omitempty"`
+		Policy          *apc.LRUPolicy `json:"policy,omitempty"`
+		TTL             *cos.Duration  `json:"ttl,omitempty"`
+		Enabled         *bool          `json:"enabled,omitempty"`
 	}
 
 	DiskConf struct {
@@ -465,11 +496,19 @@ type (
 		// - deleting in-cluster object if its remote ("cached") counterpart does not exist
 		// See also: apc.QparamSync, apc.CopyBckMsg
 		Sync bool `json:"synchronize"`
+
+		// Number of noncurrent object versions to retain (ais:// buckets only; see core/lversion.go)
+		// - when overwritten or deleted, the current version of an object becomes noncurrent
+		//   and can still be accessed by its version ID (e.g., S3 `?versionId=`);
+		// - zero (default) means no retention: previous versions get overwritten.
+		// Ignored when versioning is disabled.
+		Retain int `json:"retain"`
 	}
 	VersionConfToSet struct {
 		Enabled         *bool `json:"enabled,omitempty"`
 		ValidateWarmGet *bool `json:"validate_warm_get,omitempty"`
 		Sync            *bool `json:"synchronize,omitempty"`
+		Retain          *int  `json:"retain,omitempty"`
 	}
 
 	NetConf struct {
@@ -667,7 +706,7 @@ type (
 		MD   apc.WritePolicy `json:"md"`
 	}
 	WritePolicyConfToSet struct {
-		Data *apc.WritePolicy `json:"data,omitempty" list:"readonly"` // NOTE: NIY
+		Data *apc.WritePolicy `json:"data,omitempty"`
 		MD   *apc.WritePolicy `json:"md,omitempty"`
 	}
 )
@@ -725,6 +764,7 @@ var (
 
 	_ PropsValidator = (*CksumConf)(nil)
 	_ PropsValidator = (*SpaceConf)(nil)
+	_ PropsValidator = (*LRUConf)(nil)
 	_ PropsValidator = (*MirrorConf)(nil)
 	_ PropsValidator = (*ECConf)(nil)
 	_ PropsValidator = (*WritePolicyConf)(nil)
@@ -927,6 +967,27 @@ func (c *BackendConf) Validate() (err error) {
 				}
 			}
 			c.Conf[provider] = aisConf
+		case apc.File:
+			var fileConf BackendConfFile
+			if err := jsoniter.Unmarshal(b, &fileConf); err != nil {
+				return fmt.Errorf("invalid %q backend specification: %v", provider, err)
+			}
+			if fileConf.Root == "" || !filepath.IsAbs(fileConf.Root) {
+				return fmt.Errorf("invalid %q backend root %q: expecting absolute path", provider, fileConf.Root)
+			}
+			fileConf.Root = filepath.Clean(fileConf.Root)
+			c.Conf[provider] = fileConf
+			c.setProvider(provider)
+		case apc.AWS:
+			var awsConf BackendConfAWS
+			if err := jsoniter.Unmarshal(b, &awsConf); err != nil {
+				return fmt.Errorf("invalid %q backend specification: %v", provider, err)
+			}
+			if err := awsConf.Validate(); err != nil {
+				return err
+			}
+			c.Conf[provider] = awsConf
+			c.setProvider(provider)
 		case "":
 			continue
 		default:
@@ -939,7 +1000,7 @@ func (c *BackendConf) Validate() (err error) {
 func (c *BackendConf) setProvider(provider string) {
 	var ns Ns
 	switch provider {
-	case apc.AWS, apc.Azure, apc.GCP, apc.HT:
+	case apc.AWS, apc.Azure, apc.GCP, apc.HT, apc.File:
 		ns = NsGlobal
 	default:
 		debug.Assert(false, "unknown backend provider "+provider)
@@ -999,6 +1060,80 @@ func (c BackendConfAIS) String() (s string) {
 	return
 }
 
+////////////////////
+// BackendConfAWS //
+////////////////////
+
+const (
+	S3CredsEnv  = "env:"
+	S3CredsFile = "file:"
+)
+
+func (c *BackendConfAWS) Validate() error {
+	for name, prof := range c.Profiles {
+		if err := cos.CheckAlphaPlus(name, "S3 profile name"); err != nil {
+			return err
+		}
+		if prof == nil {
+			return fmt.Errorf("S3 profile %q is empty", name)
+		}
+		if err := prof.Validate(); err != nil {
+			return fmt.Errorf("invalid S3 profile %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+func (c *BackendConfAWS) Profile(name string) *S3Profile {
+	if name == "" {
+		return nil
+	}
+	return c.Profiles[name]
+}
+
+func (p *S3Profile) Validate() error {
+	if p.Endpoint != "" {
+		u, err := url.ParseRequestURI(p.Endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid endpoint: %v", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid endpoint %q: expecting http or https scheme", p.Endpoint)
+		}
+	}
+	switch {
+	case p.Creds == "":
+	case strings.HasPrefix(p.Creds, S3CredsEnv):
+		if name := strings.TrimPrefix(p.Creds, S3CredsEnv); name == "" {
+			return fmt.Errorf("invalid credentials reference %q: missing environment variable prefix", p.Creds)
+		}
+	case strings.HasPrefix(p.Creds, S3CredsFile):
+		if fpath, _ := p.CredsFile(); !filepath.IsAbs(fpath) {
+			return fmt.Errorf("invalid credentials reference %q: expecting absolute path", p.Creds)
+		}
+	default:
+		return fmt.Errorf("invalid credentials reference %q: expecting %q or %q prefix", p.Creds, S3CredsEnv, S3CredsFile)
+	}
+	if p.CABundle != "" && !filepath.IsAbs(p.CABundle) {
+		return fmt.Errorf("invalid CA bundle %q: expecting absolute path", p.CABundle)
+	}
+	return nil
+}
+
+// "file:/path[#section]" => (path, section)
+func (p *S3Profile) CredsFile() (fpath, section string) {
+	fpath, section, _ = strings.Cut(strings.TrimPrefix(p.Creds, S3CredsFile), "#")
+	if section == "" {
+		section = "default"
+	}
+	return fpath, section
+}
+
+// (contains no secrets; used to cache S3 clients)
+func (p *S3Profile) String() string {
+	return fmt.Sprintf("%s|%s|%s|%s|%t|%t", p.Endpoint, p.Region, p.Creds, p.CABundle, p.PathStyle, p.SkipVerify)
+}
+
 //////////////
 // DiskConf //
 //////////////
@@ -1047,14 +1182,28 @@ func (c *LRUConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
 	}
-	return fmt.Sprintf("lru.dont_evict_time=%v, lru.capacity_upd_time=%v", c.DontEvictTime, c.CapacityUpdTime)
+	return fmt.Sprintf("lru.dont_evict_time=%v, lru.capacity_upd_time=%v, lru.policy=%s",
+		c.DontEvictTime, c.CapacityUpdTime, c.Policy)
 }
 
 func (c *LRUConf) Validate() (err error) {
 	if c.CapacityUpdTime.D() < 10*time.Second {
-		err = fmt.Errorf("invalid %s (expecting: lru.capacity_upd_time >= 10s)", c)
+		return fmt.Errorf("invalid %s (expecting: lru.capacity_upd_time >= 10s)", c)
 	}
-	return
+	return c.ValidateAsProps()
+}
+
+func (c *LRUConf) ValidateAsProps(...any) error {
+	if err := c.Policy.Validate(); err != nil {
+		return err
+	}
+	if c.TTL < 0 {
+		return fmt.Errorf("invalid lru.ttl %v (expecting non-negative)", c.TTL)
+	}
+	if c.Policy == apc.LRUPolicyTTL && c.TTL == 0 {
+		return fmt.Errorf("lru.policy %q requires lru.ttl > 0", c.Policy)
+	}
+	return nil
 }
 
 ///////////////
@@ -1097,10 +1246,15 @@ func (c *CksumConf) String() string {
 // VersionConf //
 /////////////////
 
+const MaxRetainVersions = 1000 // max number of noncurrent versions per object
+
 func (c *VersionConf) Validate() error {
 	if !c.Enabled && c.ValidateWarmGet {
 		return errors.New("versioning.validate_warm_get requires versioning to be enabled")
 	}
+	if c.Retain < 0 || c.Retain > MaxRetainVersions {
+		return fmt.Errorf("invalid versioning.retain=%d (expecting range [0, %d])", c.Retain, MaxRetainVersions)
+	}
 	return nil
 }
 
@@ -1115,6 +1269,9 @@ func (c *VersionConf) String() string {
 	} else {
 		text += "no"
 	}
+	if c.Retain > 0 {
+		text += " | Retain noncurrent: " + strconv.Itoa(c.Retain)
+	}
 
 	return text
 }
@@ -1233,10 +1390,12 @@ func (c *ECConf) RequiredRestoreTargets() int {
 func (c *WritePolicyConf) Validate() (err error) {
 	err = c.Data.Validate()
 	if err == nil {
-		if !c.Data.IsImmediate() {
+		if !c.Data.IsImmediate() && !c.Data.IsWriteBack() {
 			return fmt.Errorf("invalid write policy for data: %q not implemented yet", c.Data)
 		}
-		err = c.MD.Validate()
+		if err = c.MD.Validate(); err == nil && c.MD.IsWriteBack() {
+			err = fmt.Errorf("invalid write policy for metadata: %q applies to data only", c.MD)
+		}
 	}
 	return
 }
@@ -0,0 +1,156 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket CORS (cross-origin resource sharing): a list of rules, each specifying allowed
+// origins, methods, and (request) headers. The rules are stored in the BMD as part of bucket
+// props and enforced by AIS proxies and targets serving S3 API (ais/prxs3.go, ais/tgts3.go):
+// - preflight (OPTIONS) requests are matched against the rules;
+// - actual (cross-origin) requests are responded with the Access-Control-* headers of the first
+//   matching rule.
+//
+// Origins and headers may contain at most one '*' wildcard. Header names are case-insensitive.
+//
+// Can be managed via native API (`api.SetBucketProps`) or S3 `PUT|GET|DELETE /s3/<bucket>?cors`.
+
+const (
+	corsMaxRules = 100 // as per S3 spec
+	corsWildcard = "*"
+)
+
+type (
+	CORSConf struct {
+		Rules []CORSRule `json:"rules,omitempty" list:"omit"`
+	}
+	CORSConfToSet struct {
+		Rules *[]CORSRule `json:"rules,omitempty" list:"omit"`
+	}
+	CORSRule struct {
+		ID             string   `json:"id,omitempty"`
+		AllowedOrigins []string `json:"allowed_origins"`
+		AllowedMethods []string `json:"allowed_methods"`
+		AllowedHeaders []string `json:"allowed_headers,omitempty"`
+		ExposeHeaders  []string `json:"expose_headers,omitempty"`
+		MaxAgeSeconds  int      `json:"max_age_seconds,omitempty"`
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*CORSConf)(nil)
+
+var corsMethods = []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodHead}
+
+//////////////
+// CORSConf //
+//////////////
+
+func (c *CORSConf) ValidateAsProps(...any) error {
+	if len(c.Rules) > corsMaxRules {
+		return fmt.Errorf("CORS: too many rules (%d, max %d)", len(c.Rules), corsMaxRules)
+	}
+	for i := range c.Rules {
+		if err := c.Rules[i].validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CORSConf) IsSet() bool { return len(c.Rules) > 0 }
+
+// Match returns the first rule that allows a given origin, method, and request headers (if any).
+func (c *CORSConf) Match(origin, method string, reqHeaders []string) *CORSRule {
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.MatchOrigin(origin) && rule.matchMethod(method) && rule.matchHeaders(reqHeaders) {
+			return rule
+		}
+	}
+	return nil
+}
+
+//////////////
+// CORSRule //
+//////////////
+
+func (rule *CORSRule) validate() error {
+	if len(rule.AllowedOrigins) == 0 {
+		return fmt.Errorf("CORS: rule %q: allowed origins cannot be empty", rule.ID)
+	}
+	if len(rule.AllowedMethods) == 0 {
+		return fmt.Errorf("CORS: rule %q: allowed methods cannot be empty", rule.ID)
+	}
+	if rule.MaxAgeSeconds < 0 {
+		return fmt.Errorf("CORS: rule %q: invalid max age %d", rule.ID, rule.MaxAgeSeconds)
+	}
+	for _, m := range rule.AllowedMethods {
+		if !cos.StringInSlice(m, corsMethods) {
+			return fmt.Errorf("CORS: rule %q: unsupported method %q (expecting one of %v)", rule.ID, m, corsMethods)
+		}
+	}
+	for _, o := range rule.AllowedOrigins {
+		if strings.Count(o, corsWildcard) > 1 {
+			return fmt.Errorf("CORS: rule %q: origin %q can contain at most one wildcard", rule.ID, o)
+		}
+	}
+	for _, h := range rule.AllowedHeaders {
+		if strings.Count(h, corsWildcard) > 1 {
+			return fmt.Errorf("CORS: rule %q: header %q can contain at most one wildcard", rule.ID, h)
+		}
+	}
+	return nil
+}
+
+func (rule *CORSRule) AnyOrigin() bool { return cos.StringInSlice(corsWildcard, rule.AllowedOrigins) }
+
+func (rule *CORSRule) MatchOrigin(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	for _, o := range rule.AllowedOrigins {
+		if corsMatch(o, origin, false) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *CORSRule) matchMethod(method string) bool {
+	return cos.StringInSlice(method, rule.AllowedMethods)
+}
+
+func (rule *CORSRule) matchHeaders(reqHeaders []string) bool {
+outer:
+	for _, h := range reqHeaders {
+		for _, ah := range rule.AllowedHeaders {
+			if corsMatch(ah, h, true) {
+				continue outer
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// pattern with at most one '*'
+func corsMatch(pattern, s string, nocase bool) bool {
+	if nocase {
+		pattern, s = strings.ToLower(pattern), strings.ToLower(s)
+	}
+	prefix, suffix, found := strings.Cut(pattern, corsWildcard)
+	if !found {
+		return pattern == s
+	}
+	return len(s) >= len(prefix)+len(suffix) && strings.HasPrefix(s, prefix) && strings.HasSuffix(s, suffix)
+}
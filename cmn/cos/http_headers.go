@@ -20,6 +20,9 @@ const (
 
 	// not currently used
 	ContentZip = "application/zip"
+
+	// multi-range response (RFC 7233, Appendix A)
+	ContentMultiByteranges = "multipart/byteranges"
 )
 
 // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers
@@ -44,7 +47,26 @@ const (
 	HdrServer    = "Server"
 	HdrETag      = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
 
+	// conditional requests: https://www.rfc-editor.org/rfc/rfc7232
+	HdrIfMatch           = "If-Match"
+	HdrIfNoneMatch       = "If-None-Match"
+	HdrIfModifiedSince   = "If-Modified-Since"
+	HdrIfUnmodifiedSince = "If-Unmodified-Since"
+	HdrLastModified      = "Last-Modified"
+
 	HdrHSTS = "Strict-Transport-Security"
+
+	// CORS: Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS
+	HdrOrigin             = "Origin"
+	HdrVary               = "Vary"
+	HdrACRequestMethod    = "Access-Control-Request-Method"
+	HdrACRequestHeaders   = "Access-Control-Request-Headers"
+	HdrACAllowOrigin      = "Access-Control-Allow-Origin"
+	HdrACAllowMethods     = "Access-Control-Allow-Methods"
+	HdrACAllowHeaders     = "Access-Control-Allow-Headers"
+	HdrACAllowCredentials = "Access-Control-Allow-Credentials"
+	HdrACExposeHeaders    = "Access-Control-Expose-Headers"
+	HdrACMaxAge           = "Access-Control-Max-Age"
 )
 
 //
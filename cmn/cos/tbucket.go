@@ -0,0 +1,86 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// TokenBucket is a textbook token bucket: refills at `rate` tokens per second,
+// up to `burst` tokens
+type TokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   int64 // mono time of the last refill
+	mu     sync.Mutex
+}
+
+func NewTokenBucket(rate, burst float64) *TokenBucket {
+	return &TokenBucket{rate: rate, burst: burst, tokens: burst, last: mono.NanoTime()}
+}
+
+// must be called under lock
+func (tb *TokenBucket) refill(now int64) {
+	if elapsed := now - tb.last; elapsed > 0 {
+		tb.tokens += tb.rate * float64(elapsed) / float64(time.Second)
+		if tb.tokens > tb.burst {
+			tb.tokens = tb.burst
+		}
+	}
+	tb.last = now
+}
+
+// SetRate updates rate and burst (no-op when unchanged)
+func (tb *TokenBucket) SetRate(rate, burst float64) {
+	tb.mu.Lock()
+	if tb.rate != rate || tb.burst != burst {
+		tb.refill(mono.NanoTime())
+		tb.rate, tb.burst = rate, burst
+		if tb.tokens > burst {
+			tb.tokens = burst
+		}
+	}
+	tb.mu.Unlock()
+}
+
+// Allow takes n tokens if available
+func (tb *TokenBucket) Allow(n float64) bool { return tb.allowAt(n, mono.NanoTime()) }
+
+func (tb *TokenBucket) allowAt(n float64, now int64) (ok bool) {
+	tb.mu.Lock()
+	tb.refill(now)
+	if ok = tb.tokens >= n; ok {
+		tb.tokens -= n
+	}
+	tb.mu.Unlock()
+	return ok
+}
+
+// Reserve unconditionally takes n tokens (possibly going into debt) and returns
+// the time the caller must wait for the debt to be repaid
+func (tb *TokenBucket) Reserve(n float64) time.Duration { return tb.reserveAt(n, mono.NanoTime()) }
+
+func (tb *TokenBucket) reserveAt(n float64, now int64) (wait time.Duration) {
+	tb.mu.Lock()
+	tb.refill(now)
+	tb.tokens -= n
+	if tb.tokens < 0 && tb.rate > 0 {
+		wait = time.Duration(-tb.tokens / tb.rate * float64(time.Second))
+	}
+	tb.mu.Unlock()
+	return wait
+}
+
+// Since returns time elapsed since the bucket was last used
+func (tb *TokenBucket) Since() time.Duration {
+	tb.mu.Lock()
+	last := tb.last
+	tb.mu.Unlock()
+	return mono.Since(last)
+}
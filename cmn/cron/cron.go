@@ -0,0 +1,233 @@
+// Package cron parses cron expressions and computes their next activation times
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Standard 5-field syntax: "minute hour day-of-month month day-of-week", where each field is
+// a comma-separated list of values, ranges ("a-b"), and steps ("*/n", "a-b/n", "a/n");
+// months and days of the week can be also specified by their (case-insensitive) 3-letter names;
+// Sunday is either 0 or 7.
+//
+// As in Vixie cron, when both day-of-month and day-of-week are restricted (i.e., not "*")
+// either one matching will do.
+//
+// Also supported are the following descriptors:
+// @yearly (@annually), @monthly, @weekly, @daily (@midnight), @hourly, and @every <duration>
+// (e.g., "@every 90m").
+//
+// All times are local, granularity is one minute (except @every).
+
+const maxYears = 5 // to find the next activation time
+
+type (
+	Expr struct {
+		spec    string
+		minute  uint64
+		hour    uint64
+		dom     uint64
+		month   uint64
+		dow     uint64
+		domStar bool
+		dowStar bool
+		every   time.Duration
+	}
+	bounds struct {
+		names    map[string]int
+		min, max int
+	}
+)
+
+var (
+	minutes = bounds{min: 0, max: 59}
+	hours   = bounds{min: 0, max: 23}
+	doms    = bounds{min: 1, max: 31}
+	months  = bounds{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dows = bounds{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	descriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+const everyPrefix = "@every "
+
+func Parse(spec string) (*Expr, error) {
+	var (
+		s = strings.TrimSpace(spec)
+		e = &Expr{spec: s}
+	)
+	if strings.HasPrefix(s, everyPrefix) {
+		d, err := time.ParseDuration(strings.TrimSpace(s[len(everyPrefix):]))
+		if err != nil {
+			return nil, fmt.Errorf("cron: invalid %q: %v", spec, err)
+		}
+		if d < time.Minute {
+			return nil, fmt.Errorf("cron: invalid %q: interval must be at least one minute", spec)
+		}
+		e.every = d
+		return e, nil
+	}
+	if strings.HasPrefix(s, "@") {
+		expanded, ok := descriptors[strings.ToLower(s)]
+		if !ok {
+			return nil, fmt.Errorf("cron: unknown descriptor %q", spec)
+		}
+		s = expanded
+	}
+	fields := strings.Fields(s)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron: invalid %q: expecting 5 fields, got %d", spec, len(fields))
+	}
+	var err error
+	if e.minute, err = parseField(fields[0], &minutes); err != nil {
+		return nil, fmt.Errorf("cron: invalid minute in %q: %v", spec, err)
+	}
+	if e.hour, err = parseField(fields[1], &hours); err != nil {
+		return nil, fmt.Errorf("cron: invalid hour in %q: %v", spec, err)
+	}
+	if e.dom, err = parseField(fields[2], &doms); err != nil {
+		return nil, fmt.Errorf("cron: invalid day of month in %q: %v", spec, err)
+	}
+	if e.month, err = parseField(fields[3], &months); err != nil {
+		return nil, fmt.Errorf("cron: invalid month in %q: %v", spec, err)
+	}
+	if e.dow, err = parseField(fields[4], &dows); err != nil {
+		return nil, fmt.Errorf("cron: invalid day of week in %q: %v", spec, err)
+	}
+	if e.dow&(1<<7) != 0 {
+		e.dow |= 1 // Sunday
+	}
+	e.domStar = fields[2] == "*" || strings.HasPrefix(fields[2], "*/")
+	e.dowStar = fields[4] == "*" || strings.HasPrefix(fields[4], "*/")
+	return e, nil
+}
+
+func parseField(field string, b *bounds) (bits uint64, _ error) {
+	for _, item := range strings.Split(field, ",") {
+		var (
+			rng, stepStr, hasStep = strings.Cut(item, "/")
+			lo, hi                int
+			step                  = 1
+			err                   error
+		)
+		if hasStep {
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", item)
+			}
+		}
+		switch {
+		case rng == "*":
+			lo, hi = b.min, b.max
+		case strings.Contains(rng, "-"):
+			l, h, _ := strings.Cut(rng, "-")
+			if lo, err = b.value(l); err != nil {
+				return 0, err
+			}
+			if hi, err = b.value(h); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q", item)
+			}
+		default:
+			if lo, err = b.value(rng); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if hasStep {
+				hi = b.max
+			}
+		}
+		for i := lo; i <= hi; i += step {
+			bits |= 1 << i
+		}
+	}
+	if bits == 0 {
+		return 0, errors.New("empty")
+	}
+	return bits, nil
+}
+
+func (b *bounds) value(s string) (int, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, b.min, b.max)
+	}
+	return v, nil
+}
+
+//////////
+// Expr //
+//////////
+
+func (e *Expr) String() string { return e.spec }
+
+// Next returns the earliest activation time strictly after `t`,
+// or zero time if there's none within the next `maxYears`.
+func (e *Expr) Next(t time.Time) time.Time {
+	if e.every > 0 {
+		return t.Add(e.every)
+	}
+	var (
+		loc   = t.Location()
+		limit = t.AddDate(maxYears, 0, 0)
+	)
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	for t.Before(limit) {
+		if e.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !e.dayMatch(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if e.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if e.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (e *Expr) dayMatch(t time.Time) bool {
+	var (
+		domOK = e.dom&(1<<uint(t.Day())) != 0
+		dowOK = e.dow&(1<<uint(t.Weekday())) != 0
+	)
+	if e.domStar || e.dowStar {
+		return domOK && dowOK
+	}
+	return domOK || dowOK
+}
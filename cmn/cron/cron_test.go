@@ -0,0 +1,57 @@
+// Package cron parses cron expressions and computes their next activation times
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cron"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+const layout = "2006-01-02 15:04"
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		spec, from, next string
+	}{
+		{"* * * * *", "2024-03-10 10:15", "2024-03-10 10:16"},
+		{"30 2 * * *", "2024-03-10 10:15", "2024-03-11 02:30"},
+		{"@daily", "2024-12-31 23:59", "2025-01-01 00:00"},
+		{"@hourly", "2024-03-10 10:00", "2024-03-10 11:00"},
+		{"*/15 * * * *", "2024-03-10 10:15", "2024-03-10 10:30"},
+		{"0 9-17/4 * * mon-fri", "2024-03-08 17:00", "2024-03-11 09:00"}, // Fri => Mon
+		{"0 0 * * 7", "2024-03-10 00:00", "2024-03-17 00:00"},            // Sunday as 7
+		{"0 0 29 feb *", "2024-03-01 00:00", "2028-02-29 00:00"},
+		{"0 0 31 * *", "2024-04-01 00:00", "2024-05-31 00:00"},
+		{"0 0 1,15 * sun", "2024-03-02 00:00", "2024-03-03 00:00"}, // either dom or dow
+		{"5,10 4 1 JAN,jul *", "2024-03-02 00:00", "2024-07-01 04:05"},
+		{"@every 90m", "2024-03-10 10:15", "2024-03-10 11:45"},
+	}
+	for _, test := range tests {
+		expr, err := cron.Parse(test.spec)
+		tassert.CheckFatal(t, err)
+		from, _ := time.ParseInLocation(layout, test.from, time.UTC)
+		next := expr.Next(from).Format(layout)
+		tassert.Errorf(t, next == test.next, "%q from %s: expected %s, got %s", test.spec, test.from, test.next, next)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{
+		"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8",
+		"*/0 * * * *", "5-1 * * * *", "* * * foo *", "@often", "@every 10s", "@every xyz",
+	} {
+		_, err := cron.Parse(spec)
+		tassert.Errorf(t, err != nil, "expected error parsing %q", spec)
+	}
+}
+
+func TestNoNext(t *testing.T) {
+	expr, err := cron.Parse("0 0 31 feb *")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, expr.Next(time.Now()).IsZero(), "expected no activation time")
+}
@@ -0,0 +1,66 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/dedup"
+)
+
+// Content-defined deduplication: targets split the content of newly written objects into
+// variable-size chunks (see cmn/dedup) and store each distinct chunk only once per bucket
+// (and target), named by its checksum; the object itself is stored as the list of its chunks
+// (see core/ldedup.go).
+//
+// Deduplication is transparent to GET (including range reads), HEAD, and list-objects.
+// Internally, object size and checksum are those of the chunk list. Chunks are target-local
+// (and bucket-local): rebalance and copying transfer plain content, to be deduplicated again
+// upon arrival at another target, as per destination bucket's config.
+// Chunks that are no longer referenced get removed by storage cleanup (`ais storage cleanup`).
+//
+// Supported for ais:// buckets only; mutually exclusive with erasure coding, compression,
+// and encryption at rest.
+
+// custom metadata
+const (
+	DedupSizeObjMD = "dd-size" // content size
+)
+
+type (
+	DedupConf struct {
+		CksumType string      `json:"checksum"` // chunk checksum: cos.ChecksumSHA256 (default) or cos.ChecksumSHA512
+		MinSize   cos.SizeIEC `json:"min_size"` // smaller objects are stored as is
+		Enabled   bool        `json:"enabled"`
+	}
+	DedupConfToSet struct {
+		CksumType *string      `json:"checksum,omitempty"`
+		MinSize   *cos.SizeIEC `json:"min_size,omitempty"`
+		Enabled   *bool        `json:"enabled,omitempty"`
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*DedupConf)(nil)
+
+func (c *DedupConf) ValidateAsProps(...any) error {
+	if c.CksumType != "" && !dedup.IsValidCksumType(c.CksumType) {
+		return fmt.Errorf("invalid dedup.checksum %q (expecting %q or %q)", c.CksumType, cos.ChecksumSHA256, cos.ChecksumSHA512)
+	}
+	if c.MinSize < 0 {
+		return errors.New("dedup.min_size cannot be negative")
+	}
+	return nil
+}
+
+func (c *DedupConf) CksumTypeX() string {
+	if c.CksumType == "" {
+		return cos.ChecksumSHA256
+	}
+	return c.CksumType
+}
@@ -0,0 +1,401 @@
+// Package dedup provides content-defined chunking and content-addressed storage of object content
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dedup
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/xoshiro256"
+)
+
+// Object content is split into variable-size chunks at content-defined boundaries (gear-based
+// rolling hash), so that identical content produces identical chunks regardless of its offset
+// within the object. Each chunk is stored once - named by its checksum - and the object itself
+// becomes a "recipe": the ordered list of its chunks:
+//
+//	| magic | version | cksum type | digest size | number of chunks | content size | N x (chunk size, digest) |
+//
+// The package is storage-agnostic: chunks are stored and opened via the Store interface.
+
+const (
+	MinChunk = 16 * cos.KiB
+	AvgChunk = 64 * cos.KiB
+	MaxChunk = 256 * cos.KiB
+
+	// cut when the (log2(AvgChunk)) high bits of the rolling hash are all zero
+	// (with the minimum skipped, the expected chunk size is MinChunk + AvgChunk)
+	cutMask = uint64(AvgChunk-1) << (64 - 16)
+
+	recipeVersion = 1
+)
+
+type (
+	// content-addressed chunk storage
+	Store interface {
+		// stores a new chunk or confirms (and keeps alive) an existing one
+		Put(digest string, chunk []byte) error
+		// opens existing chunk for reading
+		Open(digest string) (ChunkReader, error)
+	}
+	ChunkReader interface {
+		io.ReaderAt
+		io.Closer
+	}
+
+	Chunk struct {
+		Digest string // hex-encoded checksum (see cos.Cksum)
+		Off    int64
+		Size   int64
+	}
+	Recipe struct {
+		CksumType string
+		Chunks    []Chunk
+		Size      int64
+	}
+
+	// chunking writer; writes the resulting recipe upon Close
+	Writer struct {
+		w       io.Writer
+		store   Store
+		rcp     Recipe
+		buf     []byte
+		n       int
+		written int64 // recipe size
+		closed  bool
+	}
+
+	// reader of the recipe's content (io.ReaderAt over content offsets)
+	Reader struct {
+		rcp   *Recipe
+		store Store
+		cr    ChunkReader // open chunk `cidx`
+		cidx  int
+		off   int64 // (Read)
+	}
+)
+
+var (
+	magic = [4]byte{'a', 'i', 's', 'd'}
+
+	gear [256]uint64
+
+	errNegOffset = errors.New("dedup: negative offset")
+	errClosed    = errors.New("dedup: writer is closed")
+)
+
+// interface guard
+var (
+	_ cos.LomReader  = (*Reader)(nil)
+	_ io.Seeker      = (*Reader)(nil)
+	_ io.WriteCloser = (*Writer)(nil)
+)
+
+// NOTE: chunk boundaries depend on the gear table that, therefore, must never change
+func init() {
+	for i := range gear {
+		gear[i] = xoshiro256.Hash(uint64(i) + 0x9e3779b97f4a7c15)
+	}
+}
+
+func IsValidCksumType(ty string) bool { return ty == cos.ChecksumSHA256 || ty == cos.ChecksumSHA512 }
+
+// Cut returns the size of the first chunk of `b`, or zero when more content is required
+// to find the boundary (`b` is shorter than MaxChunk); `b` starts at a chunk boundary.
+func Cut(b []byte) int {
+	if len(b) <= MinChunk {
+		return 0
+	}
+	var (
+		h uint64
+		n = min(len(b), MaxChunk)
+	)
+	for i := MinChunk; i < n; i++ {
+		h = (h << 1) + gear[b[i]]
+		if h&cutMask == 0 {
+			return i + 1
+		}
+	}
+	if n == MaxChunk {
+		return MaxChunk
+	}
+	return 0
+}
+
+////////////
+// Writer //
+////////////
+
+func NewWriter(w io.Writer, store Store, cksumType string) (*Writer, error) {
+	if !IsValidCksumType(cksumType) {
+		return nil, fmt.Errorf("dedup: invalid checksum type %q", cksumType)
+	}
+	dw := &Writer{
+		w:     w,
+		store: store,
+		rcp:   Recipe{CksumType: cksumType},
+		buf:   make([]byte, 2*MaxChunk),
+	}
+	return dw, nil
+}
+
+func (dw *Writer) Write(b []byte) (n int, err error) {
+	if dw.closed {
+		return 0, errClosed
+	}
+	for len(b) > 0 {
+		c := copy(dw.buf[dw.n:], b)
+		dw.n += c
+		n += c
+		b = b[c:]
+		if err = dw.flush(false); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// store complete chunks and shift the remainder to the beginning of the buffer
+func (dw *Writer) flush(final bool) error {
+	var off int
+	for {
+		avail := dw.n - off
+		if avail == 0 || (!final && avail < MaxChunk) {
+			break
+		}
+		size := Cut(dw.buf[off:dw.n])
+		if size == 0 {
+			if !final {
+				break
+			}
+			size = avail
+		}
+		if err := dw.put(dw.buf[off : off+size]); err != nil {
+			return err
+		}
+		off += size
+	}
+	if off > 0 {
+		dw.n = copy(dw.buf, dw.buf[off:dw.n])
+	}
+	return nil
+}
+
+func (dw *Writer) put(chunk []byte) error {
+	cksum := cos.NewCksumHash(dw.rcp.CksumType)
+	cksum.H.Write(chunk)
+	cksum.Finalize()
+	digest := cksum.Value()
+	if err := dw.store.Put(digest, chunk); err != nil {
+		return err
+	}
+	size := int64(len(chunk))
+	dw.rcp.Chunks = append(dw.rcp.Chunks, Chunk{Digest: digest, Off: dw.rcp.Size, Size: size})
+	dw.rcp.Size += size
+	return nil
+}
+
+// flush remaining content and write the recipe
+func (dw *Writer) Close() error {
+	if dw.closed {
+		return nil
+	}
+	dw.closed = true
+	if err := dw.flush(true); err != nil {
+		return err
+	}
+	b, err := dw.rcp.Pack()
+	if err != nil {
+		return err
+	}
+	n, err := dw.w.Write(b)
+	dw.written = int64(n)
+	return err
+}
+
+// content size
+func (dw *Writer) ContentSize() int64 { return dw.rcp.Size }
+
+// recipe size (upon Close)
+func (dw *Writer) Size() int64 { return dw.written }
+
+func (dw *Writer) Recipe() *Recipe { return &dw.rcp }
+
+////////////
+// Recipe //
+////////////
+
+func (rcp *Recipe) Pack() ([]byte, error) {
+	var dlen int
+	if len(rcp.Chunks) > 0 {
+		dlen = len(rcp.Chunks[0].Digest) / 2
+	}
+	var (
+		hlen = len(magic) + 3 + len(rcp.CksumType) + 4 + 8
+		b    = make([]byte, 0, hlen+len(rcp.Chunks)*(4+dlen))
+	)
+	b = append(b, magic[:]...)
+	b = append(b, recipeVersion, byte(len(rcp.CksumType)))
+	b = append(b, rcp.CksumType...)
+	b = append(b, byte(dlen))
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(rcp.Chunks)))
+	b = binary.LittleEndian.AppendUint64(b, uint64(rcp.Size))
+	for i := range rcp.Chunks {
+		c := &rcp.Chunks[i]
+		digest, err := hex.DecodeString(c.Digest)
+		if err != nil || len(digest) != dlen {
+			return nil, fmt.Errorf("dedup: invalid chunk digest %q", c.Digest)
+		}
+		b = binary.LittleEndian.AppendUint32(b, uint32(c.Size))
+		b = append(b, digest...)
+	}
+	return b, nil
+}
+
+func Unpack(b []byte) (*Recipe, error) {
+	const minlen = len(magic) + 3 + 4 + 8
+	if len(b) < minlen || [4]byte(b[:4]) != magic {
+		return nil, errors.New("dedup: invalid recipe (magic)")
+	}
+	if b[4] != recipeVersion {
+		return nil, fmt.Errorf("dedup: unsupported recipe version %d", b[4])
+	}
+	tlen := int(b[5])
+	if len(b) < minlen+tlen {
+		return nil, errors.New("dedup: invalid recipe (header)")
+	}
+	var (
+		rcp  = &Recipe{CksumType: string(b[6 : 6+tlen])}
+		p    = b[6+tlen:]
+		dlen = int(p[0])
+		n    = int(binary.LittleEndian.Uint32(p[1:]))
+	)
+	rcp.Size = int64(binary.LittleEndian.Uint64(p[5:]))
+	p = p[13:]
+	if len(p) != n*(4+dlen) {
+		return nil, fmt.Errorf("dedup: invalid recipe (%d chunks, %d bytes)", n, len(p))
+	}
+	rcp.Chunks = make([]Chunk, n)
+	var off int64
+	for i := range rcp.Chunks {
+		c := &rcp.Chunks[i]
+		c.Size = int64(binary.LittleEndian.Uint32(p))
+		c.Digest = hex.EncodeToString(p[4 : 4+dlen])
+		c.Off = off
+		off += c.Size
+		p = p[4+dlen:]
+	}
+	if off != rcp.Size {
+		return nil, fmt.Errorf("dedup: invalid recipe (size %d vs %d)", off, rcp.Size)
+	}
+	return rcp, nil
+}
+
+// ReadRecipe reads and unpacks recipe of a given size
+func ReadRecipe(r io.ReaderAt, size int64) (*Recipe, error) {
+	b := make([]byte, size)
+	if n, err := r.ReadAt(b, 0); int64(n) != size {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return Unpack(b)
+}
+
+////////////
+// Reader //
+////////////
+
+func NewReader(rcp *Recipe, store Store) *Reader {
+	return &Reader{rcp: rcp, store: store, cidx: -1}
+}
+
+func (dr *Reader) Size() int64 { return dr.rcp.Size }
+
+func (dr *Reader) ReadAt(b []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errNegOffset
+	}
+	chunks := dr.rcp.Chunks
+	for len(b) > 0 {
+		if off >= dr.rcp.Size {
+			return n, io.EOF
+		}
+		idx := sort.Search(len(chunks), func(i int) bool { return chunks[i].Off+chunks[i].Size > off })
+		if err = dr.open(idx); err != nil {
+			return n, err
+		}
+		var (
+			c   = &chunks[idx]
+			end = min(int64(len(b)), c.Off+c.Size-off)
+			m   int
+		)
+		m, err = dr.cr.ReadAt(b[:end], off-c.Off)
+		n += m
+		off += int64(m)
+		b = b[m:]
+		if int64(m) < end {
+			if err == nil || err == io.EOF {
+				err = fmt.Errorf("dedup: chunk %s is truncated: %w", c.Digest, io.ErrUnexpectedEOF)
+			}
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (dr *Reader) open(idx int) (err error) {
+	if idx == dr.cidx {
+		return nil
+	}
+	if dr.cr != nil {
+		dr.cr.Close()
+		dr.cr = nil
+	}
+	dr.cidx = -1
+	if dr.cr, err = dr.store.Open(dr.rcp.Chunks[idx].Digest); err != nil {
+		return err
+	}
+	dr.cidx = idx
+	return nil
+}
+
+func (dr *Reader) Read(b []byte) (n int, err error) {
+	n, err = dr.ReadAt(b, dr.off)
+	dr.off += int64(n)
+	return n, err
+}
+
+func (dr *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += dr.off
+	case io.SeekEnd:
+		offset += dr.rcp.Size
+	default:
+		return 0, fmt.Errorf("dedup: invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errNegOffset
+	}
+	dr.off = offset
+	return offset, nil
+}
+
+func (dr *Reader) Close() (err error) {
+	if dr.cr != nil {
+		err = dr.cr.Close()
+		dr.cr = nil
+	}
+	dr.cidx = -1
+	return err
+}
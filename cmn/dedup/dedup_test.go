@@ -0,0 +1,141 @@
+// Package dedup provides content-defined chunking and content-addressed storage of object content
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dedup_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/dedup"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+type (
+	memStore struct {
+		chunks map[string][]byte
+		puts   int
+	}
+	memChunk struct {
+		*bytes.Reader
+	}
+)
+
+func (*memChunk) Close() error { return nil }
+
+func (s *memStore) Put(digest string, chunk []byte) error {
+	s.puts++
+	if _, ok := s.chunks[digest]; !ok {
+		s.chunks[digest] = bytes.Clone(chunk)
+	}
+	return nil
+}
+
+func (s *memStore) Open(digest string) (dedup.ChunkReader, error) {
+	b, ok := s.chunks[digest]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return &memChunk{bytes.NewReader(b)}, nil
+}
+
+func newStore() *memStore { return &memStore{chunks: make(map[string][]byte)} }
+
+func store(t *testing.T, s *memStore, data []byte) *dedup.Recipe {
+	var (
+		out    bytes.Buffer
+		dw, er = dedup.NewWriter(&out, s, cos.ChecksumSHA256)
+	)
+	tassert.CheckFatal(t, er)
+	// write in odd-sized pieces
+	for b := data; len(b) > 0; {
+		n := min(len(b), 10000)
+		_, err := dw.Write(b[:n])
+		tassert.CheckFatal(t, err)
+		b = b[n:]
+	}
+	tassert.CheckFatal(t, dw.Close())
+	tassert.Fatalf(t, dw.Size() == int64(out.Len()), "size %d vs %d", dw.Size(), out.Len())
+
+	rcp, err := dedup.ReadRecipe(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, rcp.Size == int64(len(data)), "content size %d vs %d", rcp.Size, len(data))
+	return rcp
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, size := range []int{0, 1, dedup.MinChunk, dedup.MaxChunk + 1, 3*dedup.MaxChunk + 17} {
+		var (
+			s    = newStore()
+			data = make([]byte, size)
+		)
+		rand.Read(data)
+		rcp := store(t, s, data)
+		for _, c := range rcp.Chunks {
+			tassert.Fatalf(t, c.Size > 0 && c.Size <= dedup.MaxChunk, "invalid chunk size %d", c.Size)
+		}
+
+		dr := dedup.NewReader(rcp, s)
+		b, err := io.ReadAll(dr)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, bytes.Equal(b, data), "size %d: content mismatch", size)
+
+		// ranges, including across chunk boundaries
+		for _, rng := range [][2]int{{0, 1}, {size / 3, size / 2}, {size - 1, size}, {1, size}} {
+			if rng[0] < 0 || rng[1] <= rng[0] || rng[1] > size {
+				continue
+			}
+			b := make([]byte, rng[1]-rng[0])
+			n, err := dr.ReadAt(b, int64(rng[0]))
+			tassert.Fatalf(t, err == nil || err == io.EOF, "size %d, range %v: %v", size, rng, err)
+			tassert.Fatalf(t, n == len(b) && bytes.Equal(b, data[rng[0]:rng[1]]), "size %d: range %v mismatch", size, rng)
+		}
+		dr.Close()
+	}
+}
+
+func TestDedup(t *testing.T) {
+	var (
+		s    = newStore()
+		data = make([]byte, 4*cos.MiB)
+	)
+	rand.Read(data)
+	rcp := store(t, s, data)
+	nchunks := len(s.chunks)
+	tassert.Fatalf(t, nchunks == len(rcp.Chunks) && nchunks > 8, "expected distinct chunks, got %d", nchunks)
+
+	// same content: no new chunks
+	store(t, s, data)
+	tassert.Errorf(t, len(s.chunks) == nchunks, "identical content: %d new chunks", len(s.chunks)-nchunks)
+
+	// shifted content: boundaries resync, only the leading chunk(s) differ
+	shifted := append([]byte("prepended bytes"), data...)
+	store(t, s, shifted)
+	tassert.Errorf(t, len(s.chunks)-nchunks <= 2, "shifted content: %d new chunks (total %d)", len(s.chunks)-nchunks, nchunks)
+}
+
+func TestCorrupted(t *testing.T) {
+	var (
+		s    = newStore()
+		data = make([]byte, dedup.MaxChunk*2)
+	)
+	rand.Read(data)
+	rcp := store(t, s, data)
+	b, err := rcp.Pack()
+	tassert.CheckFatal(t, err)
+
+	_, err = dedup.Unpack(b[:len(b)-1])
+	tassert.Errorf(t, err != nil, "expected invalid recipe (truncated)")
+	_, err = dedup.Unpack(append([]byte{0}, b...))
+	tassert.Errorf(t, err != nil, "expected invalid recipe (magic)")
+
+	// missing chunk
+	delete(s.chunks, rcp.Chunks[len(rcp.Chunks)-1].Digest)
+	_, err = io.ReadAll(dedup.NewReader(rcp, s))
+	tassert.Errorf(t, err != nil, "expected failure to read missing chunk")
+}
@@ -241,6 +241,15 @@ type (
 		ranges []string // RFC 7233
 		size   int64    // [0, size)
 	}
+	ErrObjLocked struct {
+		cname  string
+		reason string
+	}
+	ErrSSECKey struct {
+		cname  string
+		reason string
+		status int
+	}
 )
 
 var (
@@ -969,6 +978,38 @@ func IsErrRangeNotSatisfiable(err error) bool {
 	return ok
 }
 
+// ErrObjLocked (see cmn/objlock.go)
+
+func NewErrObjLocked(cname, reason string) *ErrObjLocked {
+	return &ErrObjLocked{cname, reason}
+}
+
+func (e *ErrObjLocked) Error() string {
+	return fmt.Sprintf("%s is locked (%s)", e.cname, e.reason)
+}
+
+func IsErrObjLocked(err error) bool {
+	_, ok := err.(*ErrObjLocked)
+	return ok
+}
+
+// ErrSSECKey: missing, invalid, or non-matching customer-provided encryption key (see cmn/sse.go)
+
+func NewErrSSECKey(cname, reason string, status int) *ErrSSECKey {
+	return &ErrSSECKey{cname, reason, status}
+}
+
+func (e *ErrSSECKey) Error() string {
+	return fmt.Sprintf("%s: %s", e.cname, e.reason)
+}
+
+func (e *ErrSSECKey) Status() int { return e.status }
+
+func IsErrSSECKey(err error) bool {
+	_, ok := err.(*ErrSSECKey)
+	return ok
+}
+
 //
 // more is-error helpers
 //
@@ -1239,6 +1280,16 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, opts ...int /*[
 			status = http.StatusInsufficientStorage
 		case IsErrRangeNotSatisfiable(err):
 			status = http.StatusRequestedRangeNotSatisfiable
+		case IsErrObjLocked(err):
+			status = http.StatusForbidden
+		case IsErrSSECKey(err):
+			status = err.(*ErrSSECKey).status
+		case IsErrPrecondition(err):
+			status = err.(*ErrPrecondition).status
+		case IsErrQuotaExceeded(err):
+			status = http.StatusForbidden
+		case IsErrRateLimited(err):
+			status = http.StatusTooManyRequests
 		case isErrUnsupp(err), isErrNotImpl(err):
 			status = http.StatusNotImplemented
 		}
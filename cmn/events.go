@@ -0,0 +1,172 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Bucket event notifications: a list of rules, each specifying event types, optional object
+// name prefix and suffix filters, and the destination (webhook) URL. The rules are stored in
+// the BMD as part of bucket props.
+//
+// Targets generate events when objects get created (PUT, copy, promote, download, EC restore)
+// or removed (DELETE, evict, LRU eviction), and deliver them to the respective webhooks
+// (HTTP POST, JSON) with retries; undelivered events are kept in the target's local (persistent)
+// queue and get redelivered later (see ais/tgtevents.go).
+//
+// The message format follows S3 event notifications: {"Records": [{"eventName": ..., "s3": ...}]}.
+//
+// Can be managed via native API (`api.SetBucketProps`) or S3 `PUT|GET /s3/<bucket>?notification`.
+
+// event types
+const (
+	EvObjCreated         = "ObjectCreated:*"
+	EvObjCreatedPut      = "ObjectCreated:Put"
+	EvObjCreatedCopy     = "ObjectCreated:Copy"
+	EvObjCreatedPromote  = "ObjectCreated:Promote"
+	EvObjCreatedDownload = "ObjectCreated:Download"
+	EvObjCreatedRestore  = "ObjectCreated:Restore" // EC restore
+
+	EvObjRemoved       = "ObjectRemoved:*"
+	EvObjRemovedDelete = "ObjectRemoved:Delete"
+	EvObjRemovedEvict  = "ObjectRemoved:Evict" // evict (remote) object, LRU eviction
+
+	evS3Prefix   = "s3:" // (optional)
+	evWildcard   = "*"
+	evMaxRules   = 100
+	EvSource     = "ais:target"
+	EvMsgVersion = "2.1"
+)
+
+var evTypes = []string{EvObjCreated, EvObjCreatedPut, EvObjCreatedCopy, EvObjCreatedPromote, EvObjCreatedDownload,
+	EvObjCreatedRestore, EvObjRemoved, EvObjRemovedDelete, EvObjRemovedEvict}
+
+type (
+	EventsConf struct {
+		Rules []EventRule `json:"rules,omitempty" list:"omit"`
+	}
+	EventsConfToSet struct {
+		Rules *[]EventRule `json:"rules,omitempty" list:"omit"`
+	}
+	EventRule struct {
+		ID     string   `json:"id,omitempty"`
+		URL    string   `json:"url"`    // webhook (http or https)
+		Events []string `json:"events"` // e.g. "ObjectCreated:*", "ObjectRemoved:Delete"
+		Prefix string   `json:"prefix,omitempty"`
+		Suffix string   `json:"suffix,omitempty"`
+	}
+
+	// webhook payload
+	EventMsg struct {
+		Records []EventRecord `json:"Records"`
+	}
+	EventRecord struct {
+		EventVersion string  `json:"eventVersion"`
+		EventSource  string  `json:"eventSource"`
+		EventTime    string  `json:"eventTime"` // RFC 3339 (UTC)
+		EventName    string  `json:"eventName"`
+		Node         string  `json:"aisNode"` // target that generated the event
+		S3           EventS3 `json:"s3"`
+	}
+	EventS3 struct {
+		ConfigurationID string      `json:"configurationId"` // rule ID
+		Bucket          EventBucket `json:"bucket"`
+		Object          EventObject `json:"object"`
+	}
+	EventBucket struct {
+		Name     string `json:"name"`
+		Provider string `json:"provider"`
+		Ns       string `json:"namespace,omitempty"`
+	}
+	EventObject struct {
+		Key       string `json:"key"`
+		ETag      string `json:"eTag,omitempty"`
+		VersionID string `json:"versionId,omitempty"`
+		Sequencer string `json:"sequencer"`
+		Size      int64  `json:"size"`
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*EventsConf)(nil)
+
+////////////////
+// EventsConf //
+////////////////
+
+func (c *EventsConf) ValidateAsProps(...any) error {
+	if len(c.Rules) > evMaxRules {
+		return fmt.Errorf("events: too many rules (%d, max %d)", len(c.Rules), evMaxRules)
+	}
+	for i := range c.Rules {
+		if err := c.Rules[i].validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *EventsConf) IsSet() bool { return len(c.Rules) > 0 }
+
+// Match returns the rules that match a given event and object name
+func (c *EventsConf) Match(event, objName string) (rules []*EventRule) {
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.Match(event, objName) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+///////////////
+// EventRule //
+///////////////
+
+func (rule *EventRule) validate() error {
+	u, err := url.Parse(rule.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("events: rule %q: invalid webhook URL %q (expecting http or https)", rule.ID, rule.URL)
+	}
+	if len(rule.Events) == 0 {
+		return fmt.Errorf("events: rule %q: no event types", rule.ID)
+	}
+	for _, ev := range rule.Events {
+		if !evValid(strings.TrimPrefix(ev, evS3Prefix)) {
+			return fmt.Errorf("events: rule %q: invalid event type %q (expecting one of: %s)",
+				rule.ID, ev, strings.Join(evTypes, ", "))
+		}
+	}
+	return nil
+}
+
+func evValid(ev string) bool {
+	for _, t := range evTypes {
+		if ev == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *EventRule) Match(event, objName string) bool {
+	if !strings.HasPrefix(objName, rule.Prefix) || !strings.HasSuffix(objName, rule.Suffix) {
+		return false
+	}
+	for _, ev := range rule.Events {
+		ev = strings.TrimPrefix(ev, evS3Prefix)
+		if ev == event {
+			return true
+		}
+		if strings.HasSuffix(ev, evWildcard) && strings.HasPrefix(event, ev[:len(ev)-1]) {
+			return true
+		}
+	}
+	return false
+}
@@ -39,6 +39,7 @@ const (
 	S3ReverseProxy            // intra-cluster communications: instead of regular HTTP redirects reverse-proxy S3 API calls to designated targets
 	S3UsePathStyle            // use older path-style addressing (as opposed to virtual-hosted style), e.g., https://s3.amazonaws.com/BUCKET/KEY
 	DontDeleteWhenRebalancing // when objects get rebalanced to their proper destinations, keep the sources - do not delete
+	IndexArchOnPUT            // (*) build random-access index of tar-formatted (.tar, .tgz, .tar.lz4, .tar.zst) shards at PUT time (default: upon first access)
 )
 
 var Cluster = [...]string{
@@ -58,6 +59,7 @@ var Cluster = [...]string{
 	"S3-Reverse-Proxy",
 	"S3-Use-Path-Style", // https://aws.amazon.com/blogs/aws/amazon-s3-path-deprecation-plan-the-rest-of-the-story
 	"Dont-Delete-When-Rebalancing",
+	"Index-Archives-on-PUT",
 	// "none" ====================
 }
 
@@ -68,6 +70,7 @@ var Bucket = [...]string{
 	"Disable-Cold-GET",
 	"Streaming-Cold-GET",
 	"S3-Use-Path-Style", // https://aws.amazon.com/blogs/aws/amazon-s3-path-deprecation-plan-the-rest-of-the-story
+	"Index-Archives-on-PUT",
 	// "none" ====================
 }
 
@@ -32,6 +32,7 @@ const (
 	BmdPrevious = Bmd + ".prev" // bmd previous version
 	Vmd         = ".ais.vmd"    // vmd persistent file basename
 	Emd         = ".ais.emd"    // emd persistent file basename
+	Sched       = ".ais.sched"  // scheduled jobs persistent file basename (proxy)
 
 	// CLI config
 	CliConfig = "cli.json" // see jsp/app.go
@@ -0,0 +1,69 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+)
+
+// multi-object GET: a single (.tar, .tgz, .zip, .tar.lz4, .tar.zst) formatted response
+// that contains requested objects and/or archived files - in the requested order
+type (
+	GetBatchItem struct {
+		Bck      Bck    `json:"bck"`
+		ObjName  string `json:"objname"`
+		ArchPath string `json:"archpath,omitempty"` // filename in the object (shard) - to read it instead of the entire object
+	}
+	GetBatchMsg struct {
+		Items       []GetBatchItem `json:"items"`
+		Mime        string         `json:"mime,omitempty"`         // output format; default: .tar
+		OnMissing   string         `json:"on-missing,omitempty"`   // enum { apc.GbMissingSkip, ... }
+		OnlyObjName bool           `json:"only-objname,omitempty"` // when true, do not include bucket name into the names of archived files
+	}
+)
+
+func (msg *GetBatchMsg) Validate() error {
+	if len(msg.Items) == 0 {
+		return errors.New("get-batch: empty list of objects")
+	}
+	switch msg.OnMissing {
+	case "":
+		msg.OnMissing = apc.GbMissingSkip
+	case apc.GbMissingSkip, apc.GbMissingError, apc.GbMissingPlaceholder:
+	default:
+		return fmt.Errorf("get-batch: invalid on-missing %q (expecting one of: %q, %q, %q)", msg.OnMissing,
+			apc.GbMissingSkip, apc.GbMissingError, apc.GbMissingPlaceholder)
+	}
+	for i := range msg.Items {
+		item := &msg.Items[i]
+		if err := ValidateOname(item.ObjName); err != nil {
+			return err
+		}
+		if item.ArchPath != "" {
+			if err := ValidatePrefix("get-batch: archpath", item.ArchPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// name of the i-th item in the resulting archive: [bucket/]object[/archpath]
+func (msg *GetBatchMsg) NameInArch(i int) string {
+	item := &msg.Items[i]
+	name := item.ObjName
+	if item.ArchPath != "" {
+		name += "/" + strings.TrimPrefix(item.ArchPath, "/")
+	}
+	if msg.OnlyObjName {
+		return name
+	}
+	return item.Bck.Name + "/" + name
+}
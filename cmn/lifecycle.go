@@ -0,0 +1,159 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket lifecycle: a list of (prefix, tags)-filtered rules, each specifying one or more actions
+// to be executed by the target-side lifecycle xaction (apc.ActLifecycle):
+// - expire (i.e., remove) objects that were last written more than `expiration_days` ago;
+// - expire noncurrent (previous) object versions;
+// - abort incomplete multipart uploads initiated more than `abort_mpt_days` ago.
+//
+// The rules are stored in the BMD as part of bucket props and can be managed either via native
+// API (`api.SetBucketProps`) or S3 `PUT|GET|DELETE /s3/<bucket>?lifecycle`.
+//
+// NOTE: when applied to remote buckets, expiration evicts in-cluster copies - remote data stays intact.
+
+const (
+	lcyDay       = 24 * time.Hour
+	lcyMaxRules  = 1000 // as per S3 spec
+	lcyMaxIDSize = 255  // ditto
+)
+
+type (
+	LifecycleConf struct {
+		Rules []LifecycleRule `json:"rules,omitempty" list:"omit"`
+	}
+	LifecycleConfToSet struct {
+		Rules *[]LifecycleRule `json:"rules,omitempty" list:"omit"`
+	}
+	LifecycleRule struct {
+		ID     string     `json:"id"`
+		Prefix string     `json:"prefix,omitempty"`
+		Tags   cos.StrKVs `json:"tags,omitempty"` // all must match (logical AND)
+
+		// actions (in days; zero means "not set")
+		ExpirationDays int `json:"expiration_days,omitempty"`
+		NoncurrentDays int `json:"noncurrent_days,omitempty"`
+		AbortMptDays   int `json:"abort_mpt_days,omitempty"`
+
+		Enabled bool `json:"enabled"`
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*LifecycleConf)(nil)
+
+///////////////////
+// LifecycleConf //
+///////////////////
+
+func (c *LifecycleConf) ValidateAsProps(...any) error {
+	if len(c.Rules) > lcyMaxRules {
+		return fmt.Errorf("lifecycle: too many rules (%d, max %d)", len(c.Rules), lcyMaxRules)
+	}
+	ids := make(cos.StrSet, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if err := rule.validate(); err != nil {
+			return err
+		}
+		if ids.Contains(rule.ID) {
+			return fmt.Errorf("lifecycle: duplicate rule ID %q", rule.ID)
+		}
+		ids.Add(rule.ID)
+	}
+	return nil
+}
+
+// true if there's at least one enabled rule
+func (c *LifecycleConf) IsEnabled() bool {
+	for i := range c.Rules {
+		if c.Rules[i].Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// Expired returns the first enabled rule that expires a given object
+// (`mtime` being the time the object was last written).
+func (c *LifecycleConf) Expired(objName string, md cos.StrKVs, mtime, now time.Time) *LifecycleRule {
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if !rule.Enabled || rule.ExpirationDays == 0 || !rule.Match(objName, md) {
+			continue
+		}
+		if now.Sub(mtime) >= time.Duration(rule.ExpirationDays)*lcyDay {
+			return rule
+		}
+	}
+	return nil
+}
+
+// NoncurrentExpired is the noncurrent-version counterpart of `Expired` whereby `since` is the time
+// the version in question became noncurrent.
+func (c *LifecycleConf) NoncurrentExpired(objName string, since, now time.Time) bool {
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if !rule.Enabled || rule.NoncurrentDays == 0 || !rule.Match(objName, nil) {
+			continue
+		}
+		if now.Sub(since) >= time.Duration(rule.NoncurrentDays)*lcyDay {
+			return true
+		}
+	}
+	return false
+}
+
+// AbortMpt returns true if a multipart upload of a given object initiated at `ctime`
+// must be aborted. Note that S3 does not allow tag-based filtering for this action.
+func (c *LifecycleConf) AbortMpt(objName string, ctime, now time.Time) bool {
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if !rule.Enabled || rule.AbortMptDays == 0 || !strings.HasPrefix(objName, rule.Prefix) {
+			continue
+		}
+		if now.Sub(ctime) >= time.Duration(rule.AbortMptDays)*lcyDay {
+			return true
+		}
+	}
+	return false
+}
+
+///////////////////
+// LifecycleRule //
+///////////////////
+
+func (rule *LifecycleRule) validate() error {
+	switch {
+	case rule.ID == "":
+		return errors.New("lifecycle: rule ID cannot be empty")
+	case len(rule.ID) > lcyMaxIDSize:
+		return fmt.Errorf("lifecycle: rule ID %q is too long (max %d)", rule.ID, lcyMaxIDSize)
+	case rule.ExpirationDays < 0 || rule.NoncurrentDays < 0 || rule.AbortMptDays < 0:
+		return fmt.Errorf("lifecycle: rule %q: number of days must be a positive integer", rule.ID)
+	case rule.ExpirationDays == 0 && rule.NoncurrentDays == 0 && rule.AbortMptDays == 0:
+		return fmt.Errorf("lifecycle: rule %q must specify at least one action", rule.ID)
+	case rule.AbortMptDays > 0 && len(rule.Tags) > 0:
+		return fmt.Errorf("lifecycle: rule %q: aborting incomplete multipart uploads cannot be filtered by tags", rule.ID)
+	}
+	return nil
+}
+
+// Match returns true if the object's name has the rule's prefix, and the object
+// is tagged with all the rule's tags (see `MatchObjTags`).
+func (rule *LifecycleRule) Match(objName string, md cos.StrKVs) bool {
+	return strings.HasPrefix(objName, rule.Prefix) && MatchObjTags(md, rule.Tags)
+}
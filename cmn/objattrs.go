@@ -35,6 +35,14 @@ const (
 
 	OrigURLObjMD = "orig_url"
 
+	// noncurrent object versions (ais:// buckets only; see core/lversion.go)
+	DeleteMarkerObjMD = "delete-marker"
+	NoncurrentObjMD   = "noncurrent-since" // unix nanoseconds
+
+	// remote buckets with write-back data policy (see apc.WriteBack):
+	// not yet uploaded to remote backend (value: unix nanoseconds)
+	DirtyObjMD = "wb-dirty"
+
 	// additional backend
 	LastModified = "LastModified"
 )
@@ -145,6 +153,8 @@ func (oa *ObjAttrs) SetCustomKey(k, v string) {
 	oa.CustomMD[k] = v
 }
 
+func (oa *ObjAttrs) DelCustomKey(k string) { delete(oa.CustomMD, k) }
+
 func (oa *ObjAttrs) DelStdCustom() {
 	for _, key := range stdCustomProps {
 		delete(oa.CustomMD, key)
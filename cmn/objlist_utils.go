@@ -72,6 +72,8 @@ func (be *LsoEnt) SetVerChanged()     { be.Flags |= apc.EntryVerChanged }
 func (be *LsoEnt) IsVerChanged() bool { return be.Flags&apc.EntryVerChanged != 0 }
 func (be *LsoEnt) SetVerRemoved()     { be.Flags |= apc.EntryVerRemoved }
 func (be *LsoEnt) IsVerRemoved() bool { return be.Flags&apc.EntryVerRemoved != 0 }
+func (be *LsoEnt) SetDirty()          { be.Flags |= apc.EntryIsDirty }
+func (be *LsoEnt) IsDirty() bool      { return be.Flags&apc.EntryIsDirty != 0 }
 
 func (be *LsoEnt) IsStatusOK() bool   { return be.Status() == 0 }
 func (be *LsoEnt) Status() uint16     { return be.Flags & apc.EntryStatusMask }
@@ -0,0 +1,187 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Object lock (WORM): objects that cannot be overwritten, deleted, renamed, or evicted
+// - until their respective retention periods expire, and
+// - while under legal hold.
+//
+// Bucket-level `object_lock` props specify the default retention mode and period that get applied
+// to all newly written objects. Once enabled, object lock cannot be disabled; buckets with object lock
+// enabled cannot be destroyed.
+//
+// Per-object retention and legal hold are stored in the object's custom metadata. In governance mode,
+// retention can be shortened or removed by a user with bucket-admin permissions (see `apc.AceBckSetACL`);
+// in compliance mode, it can only be extended.
+//
+// Enforcement: target PUT, DELETE, rename, and evict paths, and space cleanup, LRU, and lifecycle jobs.
+// Can be managed via native API (`api.SetBucketProps`) or S3 `?object-lock`, `?retention`, `?legal-hold`.
+
+const (
+	ObjLockGovernance = "governance"
+	ObjLockCompliance = "compliance"
+)
+
+// custom metadata
+const (
+	RetainModeObjMD  = "retain-mode"
+	RetainUntilObjMD = "retain-until" // unix nanoseconds
+	LegalHoldObjMD   = "legal-hold"   // "on" when set
+	legalHoldOn      = "on"
+)
+
+const objLockMaxDays = 100 * 365
+
+type (
+	ObjLockConf struct {
+		Mode    string `json:"mode"` // enum { ObjLockGovernance, ObjLockCompliance }
+		Days    int    `json:"days"` // default retention period
+		Enabled bool   `json:"enabled"`
+	}
+	ObjLockConfToSet struct {
+		Mode    *string `json:"mode,omitempty"`
+		Days    *int    `json:"days,omitempty"`
+		Enabled *bool   `json:"enabled,omitempty"`
+	}
+
+	// per-object
+	ObjRetention struct {
+		Until time.Time
+		Mode  string
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*ObjLockConf)(nil)
+
+/////////////////
+// ObjLockConf //
+/////////////////
+
+func (c *ObjLockConf) ValidateAsProps(...any) error {
+	if c.Days < 0 || c.Days > objLockMaxDays {
+		return fmt.Errorf("object lock: invalid default retention period (%d days, expecting [0, %d])", c.Days, objLockMaxDays)
+	}
+	if c.Mode != "" {
+		if err := ValidateObjLockMode(c.Mode); err != nil {
+			return err
+		}
+	}
+	if c.Days > 0 && c.Mode == "" {
+		return fmt.Errorf("object lock: default retention period (%d days) requires retention mode", c.Days)
+	}
+	return nil
+}
+
+// DefaultRetention returns retention to apply to a new object written at `now` (zero if none)
+func (c *ObjLockConf) DefaultRetention(now time.Time) (r ObjRetention) {
+	if !c.Enabled || c.Days == 0 || c.Mode == "" {
+		return
+	}
+	r.Mode = c.Mode
+	r.Until = now.Add(time.Duration(c.Days) * 24 * time.Hour)
+	return
+}
+
+func ValidateObjLockMode(mode string) error {
+	if mode != ObjLockGovernance && mode != ObjLockCompliance {
+		return fmt.Errorf("object lock: invalid retention mode %q (expecting %q or %q)", mode, ObjLockGovernance, ObjLockCompliance)
+	}
+	return nil
+}
+
+//
+// per-object retention and legal hold
+//
+
+func GetObjRetention(md cos.StrKVs) (r ObjRetention) {
+	mode, ok := md[RetainModeObjMD]
+	if !ok {
+		return
+	}
+	ns, err := strconv.ParseInt(md[RetainUntilObjMD], 10, 64)
+	if err != nil {
+		return
+	}
+	r.Mode, r.Until = mode, time.Unix(0, ns)
+	return
+}
+
+func (r *ObjRetention) IsSet() bool { return r.Mode != "" }
+
+// returns true if retention is in effect at a given time
+func (r *ObjRetention) Active(now time.Time) bool { return r.IsSet() && now.Before(r.Until) }
+
+func (r *ObjRetention) ToMD(md cos.StrKVs) {
+	if !r.IsSet() {
+		delete(md, RetainModeObjMD)
+		delete(md, RetainUntilObjMD)
+		return
+	}
+	md[RetainModeObjMD] = r.Mode
+	md[RetainUntilObjMD] = strconv.FormatInt(r.Until.UnixNano(), 10)
+}
+
+// CheckUpdate validates changing object's retention from `r` to `nr`
+// (compliance mode: retention can be only extended; governance: requires bypass)
+func (r *ObjRetention) CheckUpdate(nr *ObjRetention, cname string, now time.Time, bypass bool) error {
+	if nr.IsSet() {
+		if err := ValidateObjLockMode(nr.Mode); err != nil {
+			return err
+		}
+		if !nr.Until.After(now) {
+			return fmt.Errorf("object lock: retain-until date %s must be in the future", nr.Until.UTC().Format(time.RFC3339))
+		}
+	}
+	if !r.Active(now) {
+		return nil
+	}
+	extending := nr.IsSet() && !nr.Until.Before(r.Until)
+	switch {
+	case r.Mode == ObjLockCompliance && (!extending || nr.Mode != ObjLockCompliance):
+		return NewErrObjLocked(cname, "compliance-mode retention can only be extended")
+	case r.Mode == ObjLockGovernance && !extending && !bypass:
+		return NewErrObjLocked(cname, "shortening or removing governance-mode retention requires bypass")
+	}
+	return nil
+}
+
+func ObjLegalHold(md cos.StrKVs) bool { return md[LegalHoldObjMD] == legalHoldOn }
+
+func SetObjLegalHold(md cos.StrKVs, on bool) {
+	if on {
+		md[LegalHoldObjMD] = legalHoldOn
+	} else {
+		delete(md, LegalHoldObjMD)
+	}
+}
+
+// CheckObjLock returns ErrObjLocked if an object (given its custom metadata) cannot be
+// modified or deleted at this time. Governance-mode retention can be bypassed.
+func CheckObjLock(md cos.StrKVs, cname string, now time.Time, bypass bool) error {
+	if len(md) == 0 {
+		return nil
+	}
+	if ObjLegalHold(md) {
+		return NewErrObjLocked(cname, "under legal hold")
+	}
+	r := GetObjRetention(md)
+	if !r.Active(now) {
+		return nil
+	}
+	if r.Mode == ObjLockGovernance && bypass {
+		return nil
+	}
+	return NewErrObjLocked(cname, r.Mode+"-mode retention until "+r.Until.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,98 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Object tags: user-defined key/value pairs stored in the object's custom metadata under
+// a single `TagsObjMD` key. The value is URL-encoded and sorted by key, e.g.: "project=x&split=train"
+// (same format as S3 `x-amz-tagging` header).
+//
+// Tags can be used to filter list-objects results (`apc.LsoMsg.Tags`), multi-object operations
+// (`apc.ListRange.Tags`), and bucket lifecycle rules (`LifecycleRule.Tags`).
+
+const TagsObjMD = "tags"
+
+const (
+	MaxObjTags      = 10 // as per S3 spec
+	maxObjTagKeyLen = 128
+	maxObjTagValLen = 256
+)
+
+// parse URL-encoded tags, e.g. "k1=v1&k2=v2"
+func ParseObjTags(s string) (cos.StrKVs, error) {
+	q, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object tags %q: %v", s, err)
+	}
+	tags := make(cos.StrKVs, len(q))
+	for k, vals := range q {
+		if len(vals) > 1 {
+			return nil, fmt.Errorf("invalid object tags %q: duplicate key %q", s, k)
+		}
+		tags[k] = vals[0]
+	}
+	return tags, ValidateObjTags(tags)
+}
+
+func ValidateObjTags(tags cos.StrKVs) error {
+	if len(tags) > MaxObjTags {
+		return fmt.Errorf("too many object tags (%d, max %d)", len(tags), MaxObjTags)
+	}
+	for k, v := range tags {
+		switch {
+		case k == "":
+			return fmt.Errorf("object tag key cannot be empty (value %q)", v)
+		case len(k) > maxObjTagKeyLen:
+			return fmt.Errorf("object tag key %q is too long (max %d)", k, maxObjTagKeyLen)
+		case len(v) > maxObjTagValLen:
+			return fmt.Errorf("object tag %q: value is too long (max %d)", k, maxObjTagValLen)
+		}
+	}
+	return nil
+}
+
+// canonical (sorted by key) URL-encoded representation
+func ObjTagsToS(tags cos.StrKVs) string {
+	q := make(url.Values, len(tags))
+	for k, v := range tags {
+		q.Set(k, v)
+	}
+	return q.Encode()
+}
+
+// returns object tags given its custom metadata (nil if none)
+func ObjTags(md cos.StrKVs) cos.StrKVs {
+	s, ok := md[TagsObjMD]
+	if !ok || s == "" {
+		return nil
+	}
+	tags, err := ParseObjTags(s)
+	if err != nil {
+		return nil
+	}
+	return tags
+}
+
+// MatchObjTags returns true if the object's custom metadata `md` contains all the
+// `want` tags (logical AND); empty `want` matches all.
+func MatchObjTags(md cos.StrKVs, want map[string]string) bool {
+	if len(want) == 0 {
+		return true
+	}
+	tags := ObjTags(md)
+	for k, v := range want {
+		if vv, ok := tags[k]; !ok || vv != v {
+			return false
+		}
+	}
+	return true
+}
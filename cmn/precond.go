@@ -0,0 +1,153 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Conditional requests (RFC 7232): If-Match, If-None-Match, If-Modified-Since, and If-Unmodified-Since
+// - entity tags are compared as opaque (unquoted) strings; an object matches its ETag and its checksum value;
+// - "*" matches any existing object, and so `If-None-Match: *` makes PUT create-only;
+// - dates are compared at one-second granularity (HTTP-date); invalid dates are ignored (RFC 7232, sections 3.3 and 3.4).
+
+type (
+	Preconds struct {
+		IfModifiedSince   time.Time
+		IfUnmodifiedSince time.Time
+		IfMatch           []string // unquoted entity tags, or "*"
+		IfNoneMatch       []string // ditto
+	}
+	// object attributes that preconditions get evaluated against
+	PrecondObj struct {
+		Mtime  time.Time
+		Tags   []string // unquoted
+		Exists bool
+	}
+)
+
+// returns nil when no preconditions are specified
+func ParsePreconds(hdr http.Header) *Preconds {
+	var (
+		pc    Preconds
+		found bool
+	)
+	if v := hdr.Get(cos.HdrIfMatch); v != "" {
+		pc.IfMatch, found = _etags(v), true
+	}
+	if v := hdr.Get(cos.HdrIfNoneMatch); v != "" {
+		pc.IfNoneMatch, found = _etags(v), true
+	}
+	for _, h := range []string{cos.HdrIfModifiedSince, cos.HdrIfUnmodifiedSince} {
+		v := hdr.Get(h)
+		if v == "" {
+			continue
+		}
+		t, err := http.ParseTime(v)
+		if err != nil {
+			continue
+		}
+		if h == cos.HdrIfModifiedSince {
+			pc.IfModifiedSince = t
+		} else {
+			pc.IfUnmodifiedSince = t
+		}
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	return &pc
+}
+
+func _etags(v string) (tags []string) {
+	for _, s := range strings.Split(v, ",") {
+		s = strings.TrimSpace(s)
+		s = strings.TrimPrefix(s, "W/") // weak comparison
+		if s = strings.Trim(s, `"`); s != "" {
+			tags = append(tags, s)
+		}
+	}
+	return tags
+}
+
+func (po *PrecondObj) match(tags []string) bool {
+	if !po.Exists {
+		return false
+	}
+	for _, tag := range tags {
+		if tag == "*" {
+			return true
+		}
+		for _, t := range po.Tags {
+			if t != "" && t == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Eval returns http.StatusNotModified (GET and HEAD only), http.StatusPreconditionFailed,
+// or zero if the request is to proceed; the order of evaluation follows RFC 7232, section 6
+func (pc *Preconds) Eval(po *PrecondObj, read bool) int {
+	mtime := po.Mtime.Truncate(time.Second)
+	switch {
+	case len(pc.IfMatch) > 0:
+		if !po.match(pc.IfMatch) {
+			return http.StatusPreconditionFailed
+		}
+	case !pc.IfUnmodifiedSince.IsZero() && po.Exists:
+		if mtime.After(pc.IfUnmodifiedSince) {
+			return http.StatusPreconditionFailed
+		}
+	}
+	switch {
+	case len(pc.IfNoneMatch) > 0:
+		if po.match(pc.IfNoneMatch) {
+			if read {
+				return http.StatusNotModified
+			}
+			return http.StatusPreconditionFailed
+		}
+	case !pc.IfModifiedSince.IsZero() && po.Exists && read:
+		if !mtime.After(pc.IfModifiedSince) {
+			return http.StatusNotModified
+		}
+	}
+	return 0
+}
+
+//
+// ErrPrecondition
+//
+
+type ErrPrecondition struct {
+	cname  string
+	status int // http.StatusNotModified or http.StatusPreconditionFailed
+}
+
+func NewErrPrecondition(cname string, status int) *ErrPrecondition {
+	return &ErrPrecondition{cname, status}
+}
+
+func (e *ErrPrecondition) Error() string {
+	if e.status == http.StatusNotModified {
+		return e.cname + ": not modified"
+	}
+	return e.cname + ": precondition failed"
+}
+
+func (e *ErrPrecondition) Status() int { return e.status }
+
+func IsErrPrecondition(err error) bool {
+	_, ok := err.(*ErrPrecondition)
+	return ok
+}
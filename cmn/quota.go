@@ -0,0 +1,137 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Storage quotas: total size (bytes) and number of objects
+// - per bucket: `quota` bucket props;
+// - per AuthN user, across all buckets: optional user's quota that AuthN puts into the user's token.
+//
+// Exceeding a soft limit gets logged; writes that would exceed a hard limit fail with ErrQuotaExceeded.
+// Zero limit means no limit.
+//
+// Targets maintain running usage counters that get seeded from bucket summaries (see `apc.ActSummaryBck`).
+// Each target enforces its share (1/N, where N is the number of active targets) of the cluster-wide
+// limits - the limits are, therefore, approximate.
+//
+// Enforcement: PUT, copy, promote, download, archive, and ETL; objects written by users with quota
+// carry their owner's ID in the object's custom metadata.
+
+// custom metadata
+const OwnerObjMD = "owner" // AuthN user (with quota) that wrote the object
+
+type (
+	QuotaConf struct {
+		SoftSize cos.SizeIEC `json:"soft_size"` // total size that, once exceeded, gets logged
+		HardSize cos.SizeIEC `json:"hard_size"` // total size that cannot be exceeded
+		SoftObjs int64       `json:"soft_objs"` // number of objects that, once exceeded, gets logged
+		HardObjs int64       `json:"hard_objs"` // number of objects that cannot be exceeded
+		Enabled  bool        `json:"enabled"`
+	}
+	QuotaConfToSet struct {
+		SoftSize *cos.SizeIEC `json:"soft_size,omitempty"`
+		HardSize *cos.SizeIEC `json:"hard_size,omitempty"`
+		SoftObjs *int64       `json:"soft_objs,omitempty"`
+		HardObjs *int64       `json:"hard_objs,omitempty"`
+		Enabled  *bool        `json:"enabled,omitempty"`
+	}
+
+	// usage, limits, and deltas
+	QuotaUsage struct {
+		Size int64
+		Objs int64
+	}
+
+	ErrQuotaExceeded struct {
+		what  string // bucket or user
+		limit string
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*QuotaConf)(nil)
+
+///////////////
+// QuotaConf //
+///////////////
+
+func (c *QuotaConf) ValidateAsProps(...any) error {
+	if c.SoftSize < 0 || c.HardSize < 0 || c.SoftObjs < 0 || c.HardObjs < 0 {
+		return errors.New("quota: limits cannot be negative")
+	}
+	if c.HardSize > 0 && c.SoftSize > c.HardSize {
+		return fmt.Errorf("quota: soft size limit (%s) exceeds hard limit (%s)", c.SoftSize, c.HardSize)
+	}
+	if c.HardObjs > 0 && c.SoftObjs > c.HardObjs {
+		return fmt.Errorf("quota: soft limit on the number of objects (%d) exceeds hard limit (%d)", c.SoftObjs, c.HardObjs)
+	}
+	return nil
+}
+
+func (c *QuotaConf) Hard() QuotaUsage { return QuotaUsage{Size: int64(c.HardSize), Objs: c.HardObjs} }
+func (c *QuotaConf) Soft() QuotaUsage { return QuotaUsage{Size: int64(c.SoftSize), Objs: c.SoftObjs} }
+
+////////////////
+// QuotaUsage //
+////////////////
+
+// per-target share of cluster-wide limits (rounded up)
+func (q QuotaUsage) Share(ntargets int) QuotaUsage {
+	if ntargets <= 1 {
+		return q
+	}
+	n := int64(ntargets)
+	if q.Size > 0 {
+		q.Size = (q.Size + n - 1) / n
+	}
+	if q.Objs > 0 {
+		q.Objs = (q.Objs + n - 1) / n
+	}
+	return q
+}
+
+// Exceeds returns true if usage incremented by delta exceeds (non-zero) limits;
+// decrements never exceed
+func (q QuotaUsage) Exceeds(delta, limits QuotaUsage) bool {
+	if limits.Size > 0 && delta.Size > 0 && q.Size+delta.Size > limits.Size {
+		return true
+	}
+	return limits.Objs > 0 && delta.Objs > 0 && q.Objs+delta.Objs > limits.Objs
+}
+
+// CheckQuota returns ErrQuotaExceeded if writing delta would exceed hard limits
+func CheckQuota(what string, usage, delta, hard QuotaUsage) error {
+	if !usage.Exceeds(delta, hard) {
+		return nil
+	}
+	var limit string
+	if hard.Size > 0 && usage.Size+delta.Size > hard.Size {
+		limit = "size " + cos.ToSizeIEC(hard.Size, 2)
+	} else {
+		limit = strconv.FormatInt(hard.Objs, 10) + " objects"
+	}
+	return &ErrQuotaExceeded{what, limit}
+}
+
+//
+// ErrQuotaExceeded
+//
+
+func (e *ErrQuotaExceeded) Error() string {
+	return e.what + ": quota exceeded (hard limit: " + e.limit + ")"
+}
+
+func IsErrQuotaExceeded(err error) bool {
+	_, ok := err.(*ErrQuotaExceeded)
+	return ok
+}
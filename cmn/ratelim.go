@@ -0,0 +1,81 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Rate limiting: requests per second and bytes per second
+// - per bucket: `rate_limit` bucket props;
+// - per AuthN user: optional user's limits that AuthN puts into the user's token.
+//
+// Requests per second are enforced by proxies: each proxy admits its share (1/N, where N is the
+// number of active proxies) of the bucket's (or user's) rate; the per-client (IP address) rate,
+// on the other hand, is enforced by each proxy as is. Rejected requests fail with ErrRateLimited
+// (HTTP 429 Too Many Requests; S3 error code "SlowDown").
+//
+// Bytes per second (bandwidth) are enforced by targets - each target its share of the bucket's
+// (or user's) bandwidth - by throttling (delaying) GET and PUT data transfers.
+//
+// All limits are implemented as token buckets that allow bursts of up to one second's worth.
+// Zero limit means no limit.
+
+type (
+	RateLimitConf struct {
+		Ops       int64       `json:"ops"`        // requests per second (cluster-wide)
+		ClientOps int64       `json:"client_ops"` // requests per second from a given client (IP address)
+		Bps       cos.SizeIEC `json:"bps"`        // bytes per second (cluster-wide)
+		Enabled   bool        `json:"enabled"`
+	}
+	RateLimitConfToSet struct {
+		Ops       *int64       `json:"ops,omitempty"`
+		ClientOps *int64       `json:"client_ops,omitempty"`
+		Bps       *cos.SizeIEC `json:"bps,omitempty"`
+		Enabled   *bool        `json:"enabled,omitempty"`
+	}
+
+	ErrRateLimited struct {
+		what string // bucket, user, or client
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*RateLimitConf)(nil)
+
+func (c *RateLimitConf) ValidateAsProps(...any) error {
+	if c.Ops < 0 || c.ClientOps < 0 || c.Bps < 0 {
+		return errors.New("rate limit: limits cannot be negative")
+	}
+	return nil
+}
+
+// per-node share of cluster-wide rate (rounded up)
+func RateShare(rate int64, nnodes int) int64 {
+	if nnodes <= 1 || rate <= 0 {
+		return rate
+	}
+	n := int64(nnodes)
+	return (rate + n - 1) / n
+}
+
+//
+// ErrRateLimited
+//
+
+func NewErrRateLimited(what string) *ErrRateLimited { return &ErrRateLimited{what} }
+
+func (e *ErrRateLimited) Error() string {
+	return e.what + ": " + http.StatusText(http.StatusTooManyRequests) + " (rate limit exceeded)"
+}
+
+func IsErrRateLimited(err error) bool {
+	_, ok := err.(*ErrRateLimited)
+	return ok
+}
@@ -0,0 +1,163 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/cron"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+)
+
+// Scheduled jobs: cron-like schedules (see cmn/cron) of the supported subset of
+// on-demand xactions, each specified by its original action message:
+// - apc.ActXactStart:       LRU, storage cleanup, and other startable xactions (api.StartXaction);
+// - apc.ActSummaryBck:      bucket summary (api.GetBucketSummary);
+// - apc.ActCopyBck:         copy bucket (api.CopyBucket; requires destination bucket);
+// - apc.ActPrefetchObjects: prefetch remote objects (api.Prefetch).
+//
+// Schedules are stored in SchedMD - replicated (proxies only), versioned, and persistent
+// cluster-level metadata; the primary fires them and records the most recent runs.
+
+const (
+	SchedMaxRuns = 10  // history of the most recent runs (per scheduled job)
+	SchedMaxJobs = 256 // cluster-wide
+)
+
+type (
+	SchedJob struct {
+		ID      string     `json:"id"`
+		Cron    string     `json:"cron"` // e.g. "30 2 * * *" or "@daily"
+		Msg     apc.ActMsg `json:"msg"`
+		Bck     Bck        `json:"bck"`
+		BckTo   Bck        `json:"bck_to"`         // apc.ActCopyBck only
+		Created int64      `json:"created,string"` // unix nano
+		Runs    []SchedRun `json:"runs,omitempty"` // most recent last
+	}
+	SchedRun struct {
+		Xid     string `json:"xid,omitempty"`
+		Err     string `json:"err,omitempty"`
+		Started int64  `json:"started,string"` // unix nano
+	}
+	SchedJobs map[string]*SchedJob
+
+	SchedMD struct {
+		Jobs    SchedJobs `json:"jobs"`
+		Version int64     `json:"version,string"`
+	}
+)
+
+var schedJspOpts = jsp.CCSign(MetaverSched)
+
+// interface guard
+var _ jsp.Opts = (*SchedMD)(nil)
+
+//////////////
+// SchedJob //
+//////////////
+
+func (job *SchedJob) Validate() error {
+	if job.ID == "" {
+		return errors.New("scheduled job ID is missing")
+	}
+	if err := cos.CheckAlphaPlus(job.ID, "scheduled job ID"); err != nil {
+		return err
+	}
+	if _, err := cron.Parse(job.Cron); err != nil {
+		return err
+	}
+	switch job.Msg.Action {
+	case apc.ActXactStart, apc.ActSummaryBck, apc.ActPrefetchObjects:
+	case apc.ActCopyBck:
+		if job.BckTo.IsEmpty() {
+			return fmt.Errorf("scheduled job %q: %s requires destination bucket", job.ID, job.Msg.Action)
+		}
+		if err := job.BckTo.Validate(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("scheduled job %q: action %q cannot be scheduled", job.ID, job.Msg.Action)
+	}
+	if job.Msg.Action != apc.ActXactStart && job.Bck.IsEmpty() {
+		return fmt.Errorf("scheduled job %q: %s requires bucket", job.ID, job.Msg.Action)
+	}
+	if !job.Bck.IsEmpty() {
+		if err := job.Bck.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// the next activation time after the last run (or, if none, after creation)
+func (job *SchedJob) Next() (time.Time, error) {
+	expr, err := cron.Parse(job.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	from := job.Created
+	if l := len(job.Runs); l > 0 {
+		from = job.Runs[l-1].Started
+	}
+	return expr.Next(time.Unix(0, from)), nil
+}
+
+func (job *SchedJob) LastRun() *SchedRun {
+	if l := len(job.Runs); l > 0 {
+		return &job.Runs[l-1]
+	}
+	return nil
+}
+
+func (job *SchedJob) AddRun(run SchedRun) {
+	if len(job.Runs) >= SchedMaxRuns {
+		job.Runs = append(job.Runs[:0:0], job.Runs[len(job.Runs)-SchedMaxRuns+1:]...)
+	}
+	job.Runs = append(job.Runs, run)
+}
+
+///////////////
+// SchedJobs //
+///////////////
+
+// sorted by ID
+func (jobs SchedJobs) Sorted() []*SchedJob {
+	out := make([]*SchedJob, 0, len(jobs))
+	for _, job := range jobs {
+		out = append(out, job)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+/////////////
+// SchedMD //
+/////////////
+
+func (*SchedMD) JspOpts() jsp.Options { return schedJspOpts }
+
+func (smd *SchedMD) Add(job *SchedJob) error {
+	if _, ok := smd.Jobs[job.ID]; ok {
+		return fmt.Errorf("scheduled job %q already exists", job.ID)
+	}
+	if len(smd.Jobs) >= SchedMaxJobs {
+		return errors.New("too many scheduled jobs")
+	}
+	smd.Jobs[job.ID] = job
+	return nil
+}
+
+func (smd *SchedMD) String() string {
+	if smd == nil {
+		return "SchedMD <nil>"
+	}
+	return fmt.Sprintf("SchedMD v%d(%d)", smd.Version, len(smd.Jobs))
+}
@@ -0,0 +1,71 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn/sse"
+)
+
+// Server-side encryption (SSE) of object content at rest: AES-256-GCM with per-object data keys
+// wrapped by master keys from a pluggable key provider (see cmn/sse and core/lsse.go).
+//
+// Bucket-level `sse` props enable encryption of all newly written objects; in addition, S3 clients
+// can request encryption of a given object via `x-amz-server-side-encryption` header.
+// Encryption is transparent to GET (including range reads), HEAD, and list-objects that all
+// report (and return) plaintext. Internally, object size and checksum are those of the encrypted
+// content - rebalance, mirroring, and erasure coding move encrypted bytes as is.
+//
+// With SSE-C (`x-amz-server-side-encryption-customer-*` headers), S3 clients provide the key
+// with each request: the key is used as the object's data key and is never stored - only its MD5,
+// to validate the key that must accompany every subsequent GET, HEAD, and copy request.
+//
+// Supported for ais:// buckets only.
+
+// custom metadata
+const (
+	SSEKeyObjMD      = "sse-key"      // wrapped data key (base64)
+	SSEKeyIDObjMD    = "sse-kid"      // ID of the master key used to wrap the data key
+	SSEProviderObjMD = "sse-provider" // key provider
+	SSENonceObjMD    = "sse-nonce"    // base nonce (base64)
+	SSESizeObjMD     = "sse-size"     // plaintext size
+	SSECKeyMD5ObjMD  = "sse-cmd5"     // MD5 of the customer-provided key (SSE-C; base64)
+)
+
+type (
+	SSEConf struct {
+		Provider string `json:"provider"` // key provider (empty: sse.DefaultProvider)
+		KeyID    string `json:"key_id"`   // master key ID (empty: provider's default)
+		Enabled  bool   `json:"enabled"`
+	}
+	SSEConfToSet struct {
+		Provider *string `json:"provider,omitempty"`
+		KeyID    *string `json:"key_id,omitempty"`
+		Enabled  *bool   `json:"enabled,omitempty"`
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*SSEConf)(nil)
+
+func (c *SSEConf) ValidateAsProps(...any) error {
+	if !c.Enabled || c.Provider == "" {
+		return nil
+	}
+	if _, err := sse.GetProvider(c.Provider); err != nil {
+		return fmt.Errorf("invalid sse.provider: %w", err)
+	}
+	return nil
+}
+
+func IsSSEObjMD(key string) bool {
+	switch key {
+	case SSEKeyObjMD, SSEKeyIDObjMD, SSEProviderObjMD, SSENonceObjMD, SSESizeObjMD, SSECKeyMD5ObjMD:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,297 @@
+// Package sse provides server-side encryption (SSE) of object content at rest
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/env"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Key providers wrap (encrypt) and unwrap per-object data keys with master keys
+// that never leave the provider. Built-in:
+// - "keyfile": master keys loaded from a local JSON file (see env.AIS.SSEKeyfile)
+// Plus:
+// - NewKMSProvider: adapter for external key management services (implementing KMS interface)
+//   that caches unwrapped data keys for a limited time (to save a KMS round trip per read)
+
+const (
+	ProviderKeyfile = "keyfile"
+
+	// SSE-C: data key is provided by the client with every request and is never stored
+	// (only its MD5 - to validate subsequent requests); not a registered provider
+	ProviderCustomer = "customer"
+
+	DefaultProvider = ProviderKeyfile
+)
+
+const (
+	DefaultKMSKeyTTL = 10 * time.Minute
+	kmsMaxCached     = 64 * 1024 // unwrapped data keys
+)
+
+type (
+	KeyProvider interface {
+		Name() string
+		// WrapKey encrypts data key with a given master key (empty `kid`: provider's default)
+		// and returns the wrapped key along with the ID of the master key that was used
+		WrapKey(kid string, dk []byte) (wrapped []byte, usedKID string, err error)
+		UnwrapKey(kid string, wrapped []byte) (dk []byte, err error)
+	}
+
+	// KMS is a minimal client interface to external key management services
+	KMS interface {
+		DefaultKeyID() string
+		Encrypt(kid string, plaintext []byte) ([]byte, error)
+		Decrypt(kid string, ciphertext []byte) ([]byte, error)
+	}
+
+	// local keyfile (JSON)
+	Keyfile struct {
+		Keys    map[string]string `json:"keys"`    // master key ID => base64-encoded 256-bit key
+		Default string            `json:"default"` // default master key ID
+	}
+
+	keyfileProvider struct {
+		keys map[string]cipher.AEAD
+		fqn  string
+		dflt string
+		mu   sync.Mutex
+	}
+	kmsProvider struct {
+		client KMS
+		cache  map[string]kmsCached // kid + wrapped key => data key
+		name   string
+		ttl    time.Duration
+		mu     sync.Mutex
+	}
+	kmsCached struct {
+		dk  []byte
+		exp int64 // mono time
+	}
+)
+
+// interface guard
+var (
+	_ KeyProvider = (*keyfileProvider)(nil)
+	_ KeyProvider = (*kmsProvider)(nil)
+)
+
+var providers = struct {
+	m  map[string]KeyProvider
+	mu sync.RWMutex
+}{
+	m: map[string]KeyProvider{
+		ProviderKeyfile: &keyfileProvider{}, // loads lazily from env.AIS.SSEKeyfile
+	},
+}
+
+// Register adds (or replaces) named key provider
+func Register(p KeyProvider) {
+	providers.mu.Lock()
+	providers.m[p.Name()] = p
+	providers.mu.Unlock()
+}
+
+// GetProvider returns named key provider (empty name: DefaultProvider)
+func GetProvider(name string) (KeyProvider, error) {
+	if name == "" {
+		name = DefaultProvider
+	}
+	providers.mu.RLock()
+	p, ok := providers.m[name]
+	providers.mu.RUnlock()
+	if !ok {
+		if name == ProviderCustomer {
+			return nil, errors.New("sse: content is encrypted with a customer-provided key")
+		}
+		return nil, fmt.Errorf("sse: unknown key provider %q", name)
+	}
+	return p, nil
+}
+
+/////////////
+// keyfile //
+/////////////
+
+// LoadKeyfile returns key provider with master keys loaded from a given JSON file
+func LoadKeyfile(fqn string) (KeyProvider, error) {
+	p := &keyfileProvider{fqn: fqn}
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (*keyfileProvider) Name() string { return ProviderKeyfile }
+
+func (p *keyfileProvider) load() error {
+	if p.fqn == "" {
+		if p.fqn = os.Getenv(env.AIS.SSEKeyfile); p.fqn == "" {
+			return fmt.Errorf("sse: %s provider: master keyfile is not configured (see %s)", ProviderKeyfile, env.AIS.SSEKeyfile)
+		}
+	}
+	var kf Keyfile
+	if _, err := jsp.Load(p.fqn, &kf, jsp.Plain()); err != nil {
+		return fmt.Errorf("sse: failed to load master keys from %q: %w", p.fqn, err)
+	}
+	if len(kf.Keys) == 0 {
+		return fmt.Errorf("sse: no master keys in %q", p.fqn)
+	}
+	keys := make(map[string]cipher.AEAD, len(kf.Keys))
+	for kid, s := range kf.Keys {
+		key, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return fmt.Errorf("sse: master key %q: %w", kid, err)
+		}
+		if len(key) != KeySize {
+			return fmt.Errorf("sse: master key %q: invalid length %d (expecting %d)", kid, len(key), KeySize)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return err
+		}
+		if keys[kid], err = cipher.NewGCM(block); err != nil {
+			return err
+		}
+	}
+	if kf.Default == "" && len(kf.Keys) == 1 {
+		for kid := range kf.Keys {
+			kf.Default = kid
+		}
+	}
+	if _, ok := keys[kf.Default]; !ok {
+		return fmt.Errorf("sse: default master key %q not found in %q", kf.Default, p.fqn)
+	}
+	p.keys, p.dflt = keys, kf.Default
+	return nil
+}
+
+func (p *keyfileProvider) key(kid string) (cipher.AEAD, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keys == nil {
+		if err := p.load(); err != nil {
+			return nil, "", err
+		}
+	}
+	if kid == "" {
+		kid = p.dflt
+	}
+	aead, ok := p.keys[kid]
+	if !ok {
+		return nil, "", fmt.Errorf("sse: master key %q not found", kid)
+	}
+	return aead, kid, nil
+}
+
+// wrapped key: | nonce | sealed data key | (with master key ID as AAD)
+func (p *keyfileProvider) WrapKey(kid string, dk []byte) ([]byte, string, error) {
+	aead, kid, err := p.key(kid)
+	if err != nil {
+		return nil, "", err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(dk)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, "", err
+	}
+	return aead.Seal(nonce, nonce, dk, []byte(kid)), kid, nil
+}
+
+func (p *keyfileProvider) UnwrapKey(kid string, wrapped []byte) ([]byte, error) {
+	aead, kid, err := p.key(kid)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize()+aead.Overhead() {
+		return nil, errors.New("sse: invalid wrapped key")
+	}
+	ns := aead.NonceSize()
+	dk, err := aead.Open(nil, wrapped[:ns], wrapped[ns:], []byte(kid))
+	if err != nil {
+		return nil, fmt.Errorf("sse: failed to unwrap data key (master key %q): %w", kid, err)
+	}
+	return dk, nil
+}
+
+/////////
+// KMS //
+/////////
+
+// NewKMSProvider returns named key provider backed by external KMS
+// (the caller then registers it - see Register);
+// `ttl` - for how long to keep unwrapped data keys (0: DefaultKMSKeyTTL, negative: don't cache)
+func NewKMSProvider(name string, client KMS, ttl time.Duration) KeyProvider {
+	if ttl == 0 {
+		ttl = DefaultKMSKeyTTL
+	}
+	p := &kmsProvider{name: name, client: client, ttl: ttl}
+	if ttl > 0 {
+		p.cache = make(map[string]kmsCached, 64)
+	}
+	return p
+}
+
+func (p *kmsProvider) Name() string { return p.name }
+
+func (p *kmsProvider) WrapKey(kid string, dk []byte) ([]byte, string, error) {
+	if kid == "" {
+		kid = p.client.DefaultKeyID()
+	}
+	wrapped, err := p.client.Encrypt(kid, dk)
+	return wrapped, kid, err
+}
+
+func (p *kmsProvider) UnwrapKey(kid string, wrapped []byte) ([]byte, error) {
+	if p.cache == nil {
+		return p.client.Decrypt(kid, wrapped)
+	}
+	var (
+		key = kid + "\x00" + string(wrapped)
+		now = mono.NanoTime()
+	)
+	p.mu.Lock()
+	c, ok := p.cache[key]
+	p.mu.Unlock()
+	if ok && now < c.exp {
+		return c.dk, nil
+	}
+	dk, err := p.client.Decrypt(kid, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	if len(p.cache) >= kmsMaxCached {
+		p._evict(now)
+	}
+	p.cache[key] = kmsCached{dk: dk, exp: now + p.ttl.Nanoseconds()}
+	p.mu.Unlock()
+	return dk, nil
+}
+
+// remove expired entries and, if need be, arbitrary others (down to half the capacity)
+func (p *kmsProvider) _evict(now int64) {
+	for key, c := range p.cache {
+		if now >= c.exp {
+			delete(p.cache, key)
+		}
+	}
+	for key := range p.cache {
+		if len(p.cache) < kmsMaxCached/2 {
+			break
+		}
+		delete(p.cache, key)
+	}
+}
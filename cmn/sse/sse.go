@@ -0,0 +1,337 @@
+// Package sse provides server-side encryption (SSE) of object content at rest
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5" //nolint:gosec // G501 as per S3 SSE-C
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Object content is encrypted with AES-256-GCM in fixed-size chunks, each sealed separately:
+//
+//	| ciphertext(chunk 0) | tag | ciphertext(chunk 1) | tag | ... | ciphertext(last chunk) | tag |
+//
+// - every object gets its own random data key and base nonce;
+// - the data key is wrapped (encrypted) with a master key from a pluggable KeyProvider
+//   (see provider.go) and stored, along with the base nonce, in object's metadata (see core/lsse.go);
+// - chunk nonce = base nonce XOR chunk index; additional authenticated data (AAD) marks
+//   the last chunk, to detect truncation;
+// - empty object is a single (empty) last chunk;
+// - fixed-size chunks make it possible to read and decrypt arbitrary ranges.
+
+const (
+	ChunkSize = 64 * cos.KiB
+	TagSize   = 16
+	KeySize   = 32 // AES-256
+	NonceSize = 12
+
+	chunkPhys = ChunkSize + TagSize
+)
+
+type (
+	// per-object data key and base nonce
+	Params struct {
+		Key   []byte
+		Nonce []byte
+	}
+
+	// encrypting writer
+	Writer struct {
+		w       io.Writer
+		aead    cipher.AEAD
+		params  *Params
+		nonce   []byte
+		buf     []byte // plaintext chunk, with room for the tag
+		n       int    // plaintext bytes in buf
+		idx     int64  // chunk index
+		written int64  // ciphertext bytes
+		closed  bool
+	}
+
+	// decrypting reader (io.ReaderAt over plaintext offsets)
+	Reader struct {
+		r       io.ReaderAt
+		aead    cipher.AEAD
+		params  *Params
+		nonce   []byte
+		buf     []byte // physical chunk
+		plain   []byte // decrypted chunk `cidx`
+		size    int64  // plaintext size
+		nchunks int64
+		cidx    int64
+		off     int64 // (Read)
+	}
+)
+
+var (
+	aadNext = []byte{0}
+	aadLast = []byte{1}
+
+	errNegOffset = errors.New("sse: negative offset")
+	errClosed    = errors.New("sse: writer is closed")
+)
+
+// interface guard
+var (
+	_ cos.LomReader  = (*Reader)(nil)
+	_ io.Seeker      = (*Reader)(nil)
+	_ io.WriteCloser = (*Writer)(nil)
+)
+
+// NumChunks returns the number of encrypted chunks given plaintext size
+func NumChunks(size int64) int64 {
+	if size <= 0 {
+		return 1
+	}
+	return (size + ChunkSize - 1) / ChunkSize
+}
+
+// PhysSize returns the size of encrypted content given plaintext size
+func PhysSize(size int64) int64 { return size + NumChunks(size)*TagSize }
+
+////////////
+// Params //
+////////////
+
+// NewParams generates new random data key and base nonce
+func NewParams() (*Params, error) {
+	p := &Params{Key: make([]byte, KeySize), Nonce: make([]byte, NonceSize)}
+	if _, err := rand.Read(p.Key); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(p.Nonce); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NewCustomerParams returns params with a customer-provided key (SSE-C) and a new random nonce
+func NewCustomerParams(key []byte) (*Params, error) {
+	p := &Params{Key: key, Nonce: make([]byte, NonceSize)}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("sse: invalid customer key length %d (expecting %d)", len(key), KeySize)
+	}
+	if _, err := rand.Read(p.Nonce); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// KeyMD5 returns base64-encoded MD5 of the key (SSE-C)
+func KeyMD5(key []byte) string {
+	sum := md5.Sum(key) //nolint:gosec // G401 (S3 SSE-C)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func (p *Params) validate() error {
+	if len(p.Key) != KeySize {
+		return fmt.Errorf("sse: invalid data key length %d (expecting %d)", len(p.Key), KeySize)
+	}
+	if len(p.Nonce) != NonceSize {
+		return fmt.Errorf("sse: invalid nonce length %d (expecting %d)", len(p.Nonce), NonceSize)
+	}
+	return nil
+}
+
+func (p *Params) aead() (cipher.AEAD, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(p.Key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunk nonce = base nonce XOR (big-endian) chunk index
+func (p *Params) chunkNonce(idx int64, out []byte) {
+	copy(out, p.Nonce)
+	for i := range 8 {
+		out[NonceSize-1-i] ^= byte(idx >> (8 * i))
+	}
+}
+
+////////////
+// Writer //
+////////////
+
+// NewWriter returns writer that encrypts everything written to it and writes
+// the result into `w`. The caller must Close() it to seal the last chunk.
+func NewWriter(w io.Writer, p *Params) (*Writer, error) {
+	aead, err := p.aead()
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{
+		w:      w,
+		aead:   aead,
+		params: p,
+		nonce:  make([]byte, NonceSize),
+		buf:    make([]byte, chunkPhys),
+	}, nil
+}
+
+func (ew *Writer) Write(b []byte) (n int, err error) {
+	if ew.closed {
+		return 0, errClosed
+	}
+	for len(b) > 0 {
+		// seal full chunk only when there's more to write
+		// (so that the last one is always sealed by Close)
+		if ew.n == ChunkSize {
+			if err = ew.seal(false); err != nil {
+				return n, err
+			}
+		}
+		c := copy(ew.buf[ew.n:ChunkSize], b)
+		ew.n += c
+		n += c
+		b = b[c:]
+	}
+	return n, nil
+}
+
+func (ew *Writer) seal(last bool) error {
+	aad := aadNext
+	if last {
+		aad = aadLast
+	}
+	ew.params.chunkNonce(ew.idx, ew.nonce)
+	out := ew.aead.Seal(ew.buf[:0], ew.nonce, ew.buf[:ew.n], aad)
+	n, err := ew.w.Write(out)
+	ew.written += int64(n)
+	ew.n = 0
+	ew.idx++
+	return err
+}
+
+// Close seals the last (possibly, empty) chunk; it does not close the underlying writer
+func (ew *Writer) Close() error {
+	if ew.closed {
+		return nil
+	}
+	ew.closed = true
+	return ew.seal(true)
+}
+
+// Size returns the number of encrypted bytes written so far
+func (ew *Writer) Size() int64 { return ew.written }
+
+////////////
+// Reader //
+////////////
+
+// NewReader returns reader that decrypts content encrypted by Writer;
+// `size` is the size of the original (plaintext) content.
+// Closing the reader closes `r` as well (if `r` is an io.Closer).
+func NewReader(r io.ReaderAt, p *Params, size int64) (*Reader, error) {
+	aead, err := p.aead()
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{
+		r:       r,
+		aead:    aead,
+		params:  p,
+		nonce:   make([]byte, NonceSize),
+		buf:     make([]byte, chunkPhys),
+		size:    size,
+		nchunks: NumChunks(size),
+		cidx:    -1,
+	}, nil
+}
+
+func (dr *Reader) Size() int64 { return dr.size }
+
+func (dr *Reader) ReadAt(b []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errNegOffset
+	}
+	for len(b) > 0 {
+		if off >= dr.size {
+			return n, io.EOF
+		}
+		idx := off / ChunkSize
+		if err = dr.load(idx); err != nil {
+			return n, err
+		}
+		c := copy(b, dr.plain[off-idx*ChunkSize:])
+		n += c
+		off += int64(c)
+		b = b[c:]
+	}
+	return n, nil
+}
+
+func (dr *Reader) Read(b []byte) (n int, err error) {
+	n, err = dr.ReadAt(b, dr.off)
+	dr.off += int64(n)
+	return n, err
+}
+
+func (dr *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += dr.off
+	case io.SeekEnd:
+		offset += dr.size
+	default:
+		return 0, fmt.Errorf("sse: invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errNegOffset
+	}
+	dr.off = offset
+	return offset, nil
+}
+
+// read and decrypt a given chunk (unless already done)
+func (dr *Reader) load(idx int64) error {
+	if idx == dr.cidx {
+		return nil
+	}
+	var (
+		last = idx == dr.nchunks-1
+		plen = chunkPhys
+		aad  = aadNext
+	)
+	if last {
+		plen = int(dr.size-idx*ChunkSize) + TagSize
+		aad = aadLast
+	}
+	n, err := dr.r.ReadAt(dr.buf[:plen], idx*chunkPhys)
+	if n < plen {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		dr.cidx = -1
+		return err
+	}
+	dr.params.chunkNonce(idx, dr.nonce)
+	plain, err := dr.aead.Open(dr.buf[:0], dr.nonce, dr.buf[:plen], aad)
+	if err != nil {
+		dr.cidx = -1
+		return fmt.Errorf("sse: failed to decrypt chunk #%d: %w", idx, err)
+	}
+	dr.plain, dr.cidx = plain, idx
+	return nil
+}
+
+func (dr *Reader) Close() (err error) {
+	if c, ok := dr.r.(io.Closer); ok {
+		err = c.Close()
+	}
+	return
+}
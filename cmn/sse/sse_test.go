@@ -0,0 +1,181 @@
+// Package sse provides server-side encryption (SSE) of object content at rest
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/sse"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func encrypt(t *testing.T, p *sse.Params, data []byte) []byte {
+	var (
+		out    bytes.Buffer
+		ew, er = sse.NewWriter(&out, p)
+	)
+	tassert.CheckFatal(t, er)
+	// write in odd-sized pieces
+	for b := data; len(b) > 0; {
+		n := min(len(b), 1000)
+		_, err := ew.Write(b[:n])
+		tassert.CheckFatal(t, err)
+		b = b[n:]
+	}
+	tassert.CheckFatal(t, ew.Close())
+	tassert.Fatalf(t, ew.Size() == int64(out.Len()), "size %d vs %d", ew.Size(), out.Len())
+	tassert.Fatalf(t, int64(out.Len()) == sse.PhysSize(int64(len(data))), "phys size %d vs %d",
+		out.Len(), sse.PhysSize(int64(len(data))))
+	return out.Bytes()
+}
+
+func TestRoundTrip(t *testing.T) {
+	p, err := sse.NewParams()
+	tassert.CheckFatal(t, err)
+	for _, size := range []int{0, 1, 100, sse.ChunkSize - 1, sse.ChunkSize, sse.ChunkSize + 1, 3*sse.ChunkSize + 17} {
+		data := make([]byte, size)
+		rand.Read(data)
+		ct := encrypt(t, p, data)
+
+		dr, err := sse.NewReader(bytes.NewReader(ct), p, int64(size))
+		tassert.CheckFatal(t, err)
+		pt, err := io.ReadAll(dr)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, bytes.Equal(pt, data), "size %d: plaintext mismatch", size)
+
+		// ranges
+		if size < 3 {
+			continue
+		}
+		for _, rng := range [][2]int{{0, 1}, {size / 3, size / 2}, {size - 1, size}, {1, size}} {
+			b := make([]byte, rng[1]-rng[0])
+			n, err := dr.ReadAt(b, int64(rng[0]))
+			tassert.Fatalf(t, err == nil || err == io.EOF, "size %d, range %v: %v", size, rng, err)
+			tassert.Fatalf(t, n == len(b) && bytes.Equal(b, data[rng[0]:rng[1]]), "size %d: range %v mismatch", size, rng)
+		}
+	}
+}
+
+func TestTamperAndTruncate(t *testing.T) {
+	p, err := sse.NewParams()
+	tassert.CheckFatal(t, err)
+	data := make([]byte, 2*sse.ChunkSize+10)
+	rand.Read(data)
+	ct := encrypt(t, p, data)
+
+	// flip a bit
+	bad := bytes.Clone(ct)
+	bad[sse.ChunkSize+5] ^= 1
+	dr, _ := sse.NewReader(bytes.NewReader(bad), p, int64(len(data)))
+	_, err = io.ReadAll(dr)
+	tassert.Errorf(t, err != nil, "expected authentication failure")
+
+	// drop the last chunk and pretend the object is shorter
+	short := ct[:2*(sse.ChunkSize+sse.TagSize)]
+	dr, _ = sse.NewReader(bytes.NewReader(short), p, 2*sse.ChunkSize)
+	_, err = io.ReadAll(dr)
+	tassert.Errorf(t, err != nil, "expected truncation to be detected")
+
+	// wrong key
+	p2, _ := sse.NewParams()
+	dr, _ = sse.NewReader(bytes.NewReader(ct), p2, int64(len(data)))
+	_, err = io.ReadAll(dr)
+	tassert.Errorf(t, err != nil, "expected failure to decrypt with a wrong key")
+}
+
+func TestKeyfile(t *testing.T) {
+	keys := map[string]string{}
+	for _, kid := range []string{"k1", "k2"} {
+		key := make([]byte, sse.KeySize)
+		rand.Read(key)
+		keys[kid] = base64.StdEncoding.EncodeToString(key)
+	}
+	b, err := json.Marshal(&sse.Keyfile{Keys: keys, Default: "k2"})
+	tassert.CheckFatal(t, err)
+	fqn := filepath.Join(t.TempDir(), "keys.json")
+	tassert.CheckFatal(t, os.WriteFile(fqn, b, 0o600))
+
+	kp, err := sse.LoadKeyfile(fqn)
+	tassert.CheckFatal(t, err)
+	p, _ := sse.NewParams()
+
+	wrapped, kid, err := kp.WrapKey("", p.Key)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, kid == "k2", "expected default master key, got %q", kid)
+	dk, err := kp.UnwrapKey(kid, wrapped)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(dk, p.Key), "unwrapped key mismatch")
+
+	_, err = kp.UnwrapKey("k1", wrapped)
+	tassert.Errorf(t, err != nil, "expected failure to unwrap with a different master key")
+	_, _, err = kp.WrapKey("k3", p.Key)
+	tassert.Errorf(t, err != nil, "expected unknown master key")
+}
+
+// (XOR with a per-master-key byte) counting decryptions
+type testKMS struct {
+	decrypts int
+}
+
+func (*testKMS) DefaultKeyID() string { return "m1" }
+
+func (*testKMS) Encrypt(kid string, b []byte) ([]byte, error) { return xorKey(kid, b), nil }
+
+func (k *testKMS) Decrypt(kid string, b []byte) ([]byte, error) {
+	k.decrypts++
+	return xorKey(kid, b), nil
+}
+
+func xorKey(kid string, b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[i] = b[i] ^ kid[len(kid)-1]
+	}
+	return out
+}
+
+func TestKMSCache(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		ttl      time.Duration
+		decrypts int
+	}{
+		{"cached", 0 /*default*/, 2},
+		{"expired", time.Millisecond, 4},
+		{"no-cache", -1, 4},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				kms = &testKMS{}
+				kp  = sse.NewKMSProvider("test-kms", kms, test.ttl)
+			)
+			p1, _ := sse.NewParams()
+			p2, _ := sse.NewParams()
+			w1, kid, err := kp.WrapKey("", p1.Key)
+			tassert.CheckFatal(t, err)
+			w2, _, err := kp.WrapKey(kid, p2.Key)
+			tassert.CheckFatal(t, err)
+
+			for range 2 {
+				dk, err := kp.UnwrapKey(kid, w1)
+				tassert.CheckFatal(t, err)
+				tassert.Errorf(t, bytes.Equal(dk, p1.Key), "unwrapped key mismatch")
+				dk, err = kp.UnwrapKey(kid, w2)
+				tassert.CheckFatal(t, err)
+				tassert.Errorf(t, bytes.Equal(dk, p2.Key), "unwrapped key mismatch")
+				time.Sleep(2 * time.Millisecond)
+			}
+			tassert.Errorf(t, kms.decrypts == test.decrypts, "expected %d KMS round trips, got %d", test.decrypts, kms.decrypts)
+		})
+	}
+}
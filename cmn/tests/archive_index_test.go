@@ -0,0 +1,83 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestArchIndex(t *testing.T) {
+	const numFiles = 50
+	var (
+		dir   = t.TempDir()
+		files = make(map[string][]byte, numFiles)
+	)
+	for i := range numFiles {
+		name := fmt.Sprintf("dir%d/file-%03d.txt", i%3, i)
+		files[name] = bytes.Repeat([]byte{byte('a' + i%26)}, 100+i*1000)
+	}
+	for _, mime := range []string{archive.ExtTar, archive.ExtTgz, archive.ExtTarLz4, archive.ExtTarZst} {
+		t.Run(mime, func(t *testing.T) {
+			fqn := filepath.Join(dir, "shard"+mime)
+			wfh, err := cos.CreateFile(fqn)
+			tassert.CheckFatal(t, err)
+			aw := archive.NewWriter(mime, wfh, nil, nil)
+			for name, data := range files {
+				err = aw.Write(name, cos.SimpleOAH{Size: int64(len(data))}, bytes.NewReader(data))
+				tassert.CheckFatal(t, err)
+			}
+			aw.Fini()
+			tassert.CheckFatal(t, wfh.Close())
+
+			fh, err := os.Open(fqn)
+			tassert.CheckFatal(t, err)
+			defer fh.Close()
+			idx, err := archive.BuildIndex(mime, fh)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, len(idx.Entries) == numFiles, "expected %d entries, got %d", numFiles, len(idx.Entries))
+
+			// listing
+			lst, err := archive.List(fqn)
+			tassert.CheckFatal(t, err)
+			ilst := idx.List()
+			tassert.Fatalf(t, len(lst) == len(ilst), "list: %d vs %d", len(lst), len(ilst))
+			for i := range lst {
+				tassert.Errorf(t, *lst[i] == *ilst[i], "list: %+v vs %+v", lst[i], ilst[i])
+			}
+
+			// reading
+			for name, data := range files {
+				if name[len(name)-5] == '7' {
+					name = "/" + name // (--absolute-names)
+				}
+				_, err = fh.Seek(0, io.SeekStart)
+				tassert.CheckFatal(t, err)
+				csl, err := idx.ReadOne(fh, name)
+				tassert.CheckFatal(t, err)
+				tassert.Fatalf(t, csl != nil, "%s not found", name)
+				tassert.Errorf(t, csl.Size() == int64(len(data)), "%s: size %d vs %d", name, csl.Size(), len(data))
+				b, err := io.ReadAll(csl)
+				tassert.CheckFatal(t, err)
+				csl.Close()
+				tassert.Errorf(t, bytes.Equal(b, data), "%s: content mismatch", strings.TrimPrefix(name, "/"))
+			}
+			csl, err := idx.ReadOne(fh, "dir0/nonexistent.txt")
+			tassert.Errorf(t, csl == nil && err == nil, "expecting (nil, nil) for nonexistent file, got (%v, %v)", csl, err)
+		})
+	}
+
+	_, err := archive.BuildIndex(archive.ExtZip, bytes.NewReader(nil))
+	tassert.Errorf(t, err == archive.ErrNotIndexable, "expecting zip to be not indexable, got %v", err)
+}
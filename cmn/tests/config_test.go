@@ -72,3 +72,95 @@ func TestValidateMpath(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateWritePolicy(t *testing.T) {
+	tests := []struct {
+		conf cmn.WritePolicyConf
+		ok   bool
+	}{
+		{cmn.WritePolicyConf{}, true},
+		{cmn.WritePolicyConf{Data: apc.WriteBack, MD: apc.WriteImmediate}, true},
+		{cmn.WritePolicyConf{Data: apc.WriteBack, MD: apc.WriteDelayed}, true},
+		{cmn.WritePolicyConf{Data: apc.WriteDelayed}, false},
+		{cmn.WritePolicyConf{MD: apc.WriteBack}, false},
+		{cmn.WritePolicyConf{Data: "xyz"}, false},
+	}
+	for _, test := range tests {
+		err := test.conf.Validate()
+		tassert.Errorf(t, (err == nil) == test.ok, "%+v: expected ok=%t, got err=%v", test.conf, test.ok, err)
+	}
+}
+
+func TestValidateBackendFile(t *testing.T) {
+	tests := []struct {
+		conf any
+		ok   bool
+	}{
+		{map[string]any{"root": "/mnt/nfs/datasets/"}, true},
+		{map[string]any{"root": "mnt/nfs"}, false},
+		{map[string]any{"root": ""}, false},
+		{map[string]any{}, false},
+	}
+	for _, test := range tests {
+		c := cmn.BackendConf{Conf: map[string]any{apc.File: test.conf}}
+		err := c.Validate()
+		tassert.Errorf(t, (err == nil) == test.ok, "%+v: expected ok=%t, got err=%v", test.conf, test.ok, err)
+		if err != nil {
+			continue
+		}
+		conf, ok := c.Get(apc.File).(cmn.BackendConfFile)
+		tassert.Fatalf(t, ok, "expected %T, got %T", cmn.BackendConfFile{}, c.Get(apc.File))
+		tassert.Errorf(t, conf.Root == "/mnt/nfs/datasets", "expected clean root, got %q", conf.Root)
+		_, ok = c.Providers[apc.File]
+		tassert.Errorf(t, ok, "expected %q provider", apc.File)
+	}
+}
+
+func TestValidateS3Profiles(t *testing.T) {
+	tests := []struct {
+		prof cmn.S3Profile
+		ok   bool
+	}{
+		{cmn.S3Profile{}, true},
+		{cmn.S3Profile{Endpoint: "https://minio.local:9000", Creds: "env:MINIO1", PathStyle: true}, true},
+		{cmn.S3Profile{Endpoint: "http://rgw:8080", Creds: "file:/etc/ais/rgw.creds#ais", CABundle: "/etc/ssl/ca.pem"}, true},
+		{cmn.S3Profile{Endpoint: "minio.local:9000"}, false},
+		{cmn.S3Profile{Endpoint: "ftp://minio.local"}, false},
+		{cmn.S3Profile{Creds: "env:"}, false},
+		{cmn.S3Profile{Creds: "file:rgw.creds"}, false},
+		{cmn.S3Profile{Creds: "AKIAXXXXXXXX"}, false},
+		{cmn.S3Profile{CABundle: "ca.pem"}, false},
+	}
+	for _, test := range tests {
+		c := cmn.BackendConf{Conf: map[string]any{
+			apc.AWS: map[string]any{"profiles": map[string]any{"p1": test.prof}},
+		}}
+		err := c.Validate()
+		tassert.Errorf(t, (err == nil) == test.ok, "%+v: expected ok=%t, got err=%v", test.prof, test.ok, err)
+		if err != nil {
+			continue
+		}
+		awsConf, ok := c.Get(apc.AWS).(cmn.BackendConfAWS)
+		tassert.Fatalf(t, ok, "expected %T, got %T", cmn.BackendConfAWS{}, c.Get(apc.AWS))
+		prof := awsConf.Profile("p1")
+		tassert.Fatalf(t, prof != nil && *prof == test.prof, "expected %+v, got %+v", test.prof, prof)
+	}
+
+	prof := &cmn.S3Profile{Creds: "file:/etc/ais/rgw.creds"}
+	fpath, section := prof.CredsFile()
+	tassert.Errorf(t, fpath == "/etc/ais/rgw.creds" && section == "default", "got (%q, %q)", fpath, section)
+
+	// empty aws backend config remains valid
+	c := cmn.BackendConf{Conf: map[string]any{apc.AWS: map[string]any{}}}
+	tassert.CheckFatal(t, c.Validate())
+	_, ok := c.Providers[apc.AWS]
+	tassert.Errorf(t, ok, "expected %q provider", apc.AWS)
+}
+
+func TestValidateHTListDepth(t *testing.T) {
+	for depth, ok := range map[int]bool{0: true, 3: true, cmn.MaxHTListDepth: true, -1: false, cmn.MaxHTListDepth + 1: false} {
+		extra := cmn.ExtraProps{HTTP: cmn.ExtraPropsHTTP{OrigURLBck: "https://example.com/data/", ListDepth: depth}}
+		err := extra.ValidateAsProps(apc.HT)
+		tassert.Errorf(t, (err == nil) == ok, "list_depth %d: expected ok=%t, got err=%v", depth, ok, err)
+	}
+}
@@ -0,0 +1,36 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestGetBatchMsg(t *testing.T) {
+	bck := cmn.Bck{Name: "data", Provider: apc.AIS}
+	msg := &cmn.GetBatchMsg{}
+	tassert.Errorf(t, msg.Validate() != nil, "expecting error (empty list)")
+
+	msg.Items = []cmn.GetBatchItem{
+		{Bck: bck, ObjName: "a/b.jpg"},
+		{Bck: bck, ObjName: "shard.tar", ArchPath: "/x/y.cls"},
+	}
+	tassert.CheckFatal(t, msg.Validate())
+	tassert.Errorf(t, msg.OnMissing == apc.GbMissingSkip, "expecting default %q, got %q", apc.GbMissingSkip, msg.OnMissing)
+	tassert.Errorf(t, msg.NameInArch(0) == "data/a/b.jpg", "got %q", msg.NameInArch(0))
+	tassert.Errorf(t, msg.NameInArch(1) == "data/shard.tar/x/y.cls", "got %q", msg.NameInArch(1))
+	msg.OnlyObjName = true
+	tassert.Errorf(t, msg.NameInArch(1) == "shard.tar/x/y.cls", "got %q", msg.NameInArch(1))
+
+	msg.OnMissing = "ignore"
+	tassert.Errorf(t, msg.Validate() != nil, "expecting error (invalid on-missing)")
+	msg.OnMissing = apc.GbMissingPlaceholder
+	msg.Items = append(msg.Items, cmn.GetBatchItem{Bck: bck, ObjName: "../etc/passwd"})
+	tassert.Errorf(t, msg.Validate() != nil, "expecting error (invalid object name)")
+}
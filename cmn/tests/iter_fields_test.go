@@ -80,6 +80,7 @@ var _ = Describe("IterFields", func() {
 					"versioning.enabled":           false,
 					"versioning.validate_warm_get": false,
 					"versioning.synchronize":       false,
+					"versioning.retain":            0,
 
 					"checksum.type":              cos.ChecksumXXHash,
 					"checksum.validate_warm_get": false,
@@ -90,6 +91,8 @@ var _ = Describe("IterFields", func() {
 					"lru.enabled":           false,
 					"lru.dont_evict_time":   cos.Duration(0),
 					"lru.capacity_upd_time": cos.Duration(0),
+					"lru.policy":            apc.LRUPolicy(""),
+					"lru.ttl":               cos.Duration(0),
 
 					"extra.aws.cloud_region": "us-central",
 					"extra.aws.endpoint":     "",
@@ -102,6 +105,29 @@ var _ = Describe("IterFields", func() {
 
 					"write_policy.data": apc.WritePolicy(""),
 					"write_policy.md":   apc.WritePolicy(""),
+
+					"object_lock.mode":        "",
+					"object_lock.days":        0,
+					"object_lock.enabled":     false,
+					"sse.provider":            "",
+					"sse.key_id":              "",
+					"sse.enabled":             false,
+					"quota.soft_size":         cos.SizeIEC(0),
+					"quota.hard_size":         cos.SizeIEC(0),
+					"quota.soft_objs":         int64(0),
+					"quota.hard_objs":         int64(0),
+					"quota.enabled":           false,
+					"compression.algo":        "",
+					"compression.min_size":    cos.SizeIEC(0),
+					"compression.min_savings": 0,
+					"compression.enabled":     false,
+					"dedup.checksum":          "",
+					"dedup.min_size":          cos.SizeIEC(0),
+					"dedup.enabled":           false,
+					"rate_limit.ops":          int64(0),
+					"rate_limit.client_ops":   int64(0),
+					"rate_limit.bps":          cos.SizeIEC(0),
+					"rate_limit.enabled":      false,
 				},
 			),
 			Entry("list BpropsToSet fields",
@@ -139,6 +165,7 @@ var _ = Describe("IterFields", func() {
 					"versioning.enabled":           (*bool)(nil),
 					"versioning.validate_warm_get": (*bool)(nil),
 					"versioning.synchronize":       (*bool)(nil),
+					"versioning.retain":            (*int)(nil),
 
 					"checksum.type":              apc.Ptr(cos.ChecksumXXHash),
 					"checksum.validate_warm_get": (*bool)(nil),
@@ -149,6 +176,8 @@ var _ = Describe("IterFields", func() {
 					"lru.enabled":           (*bool)(nil),
 					"lru.dont_evict_time":   (*cos.Duration)(nil),
 					"lru.capacity_upd_time": (*cos.Duration)(nil),
+					"lru.policy":            (*apc.LRUPolicy)(nil),
+					"lru.ttl":               (*cos.Duration)(nil),
 
 					"access":   apc.Ptr[apc.AccessAttrs](1024),
 					"features": apc.Ptr[feat.Flags](1024),
@@ -156,12 +185,36 @@ var _ = Describe("IterFields", func() {
 					"write_policy.data": (*apc.WritePolicy)(nil),
 					"write_policy.md":   apc.Ptr(apc.WriteDelayed),
 
+					"object_lock.mode":        (*string)(nil),
+					"object_lock.days":        (*int)(nil),
+					"object_lock.enabled":     (*bool)(nil),
+					"sse.provider":            (*string)(nil),
+					"sse.key_id":              (*string)(nil),
+					"sse.enabled":             (*bool)(nil),
+					"quota.soft_size":         (*cos.SizeIEC)(nil),
+					"quota.hard_size":         (*cos.SizeIEC)(nil),
+					"quota.soft_objs":         (*int64)(nil),
+					"quota.hard_objs":         (*int64)(nil),
+					"quota.enabled":           (*bool)(nil),
+					"compression.algo":        (*string)(nil),
+					"compression.min_size":    (*cos.SizeIEC)(nil),
+					"compression.min_savings": (*int)(nil),
+					"compression.enabled":     (*bool)(nil),
+					"dedup.checksum":          (*string)(nil),
+					"dedup.min_size":          (*cos.SizeIEC)(nil),
+					"dedup.enabled":           (*bool)(nil),
+					"rate_limit.ops":          (*int64)(nil),
+					"rate_limit.client_ops":   (*int64)(nil),
+					"rate_limit.bps":          (*cos.SizeIEC)(nil),
+					"rate_limit.enabled":      (*bool)(nil),
+
 					"extra.hdfs.ref_directory": (*string)(nil),
 					"extra.aws.cloud_region":   (*string)(nil),
 					"extra.aws.endpoint":       (*string)(nil),
 					"extra.aws.profile":        (*string)(nil),
 					"extra.aws.max_pagesize":   (*int64)(nil),
 					"extra.http.original_url":  (*string)(nil),
+					"extra.http.list_depth":    (*int)(nil),
 				},
 			),
 			Entry("check for omit tag",
@@ -0,0 +1,76 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestPreconds(t *testing.T) {
+	var (
+		mtime   = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		before  = mtime.Add(-time.Hour).Format(http.TimeFormat)
+		after   = mtime.Add(time.Hour).Format(http.TimeFormat)
+		same    = mtime.Format(http.TimeFormat)
+		obj     = &cmn.PrecondObj{Mtime: mtime.Add(300 * time.Millisecond), Tags: []string{"abc", "cksum"}, Exists: true}
+		missing = &cmn.PrecondObj{}
+	)
+	tests := []struct {
+		name   string
+		hdr    map[string]string
+		obj    *cmn.PrecondObj
+		read   bool
+		status int
+	}{
+		{"if-match", map[string]string{cos.HdrIfMatch: `"abc"`}, obj, true, 0},
+		{"if-match-cksum", map[string]string{cos.HdrIfMatch: `"xyz", "cksum"`}, obj, false, 0},
+		{"if-match-fail", map[string]string{cos.HdrIfMatch: `"xyz"`}, obj, true, http.StatusPreconditionFailed},
+		{"if-match-any", map[string]string{cos.HdrIfMatch: "*"}, obj, false, 0},
+		{"if-match-missing", map[string]string{cos.HdrIfMatch: "*"}, missing, false, http.StatusPreconditionFailed},
+		{"if-none-match-get", map[string]string{cos.HdrIfNoneMatch: `W/"abc"`}, obj, true, http.StatusNotModified},
+		{"if-none-match-put", map[string]string{cos.HdrIfNoneMatch: `"abc"`}, obj, false, http.StatusPreconditionFailed},
+		{"create-only", map[string]string{cos.HdrIfNoneMatch: "*"}, obj, false, http.StatusPreconditionFailed},
+		{"create-only-ok", map[string]string{cos.HdrIfNoneMatch: "*"}, missing, false, 0},
+		{"if-modified-since", map[string]string{cos.HdrIfModifiedSince: before}, obj, true, 0},
+		{"not-modified", map[string]string{cos.HdrIfModifiedSince: same}, obj, true, http.StatusNotModified},
+		{"not-modified-put", map[string]string{cos.HdrIfModifiedSince: after}, obj, false, 0},
+		{"if-unmodified-since", map[string]string{cos.HdrIfUnmodifiedSince: before}, obj, false, http.StatusPreconditionFailed},
+		{"if-unmodified-since-ok", map[string]string{cos.HdrIfUnmodifiedSince: same}, obj, true, 0},
+		// If-None-Match takes precedence over If-Modified-Since
+		{"precedence", map[string]string{cos.HdrIfNoneMatch: `"xyz"`, cos.HdrIfModifiedSince: after}, obj, true, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hdr := http.Header{}
+			for k, v := range test.hdr {
+				hdr.Set(k, v)
+			}
+			pc := cmn.ParsePreconds(hdr)
+			tassert.Fatalf(t, pc != nil, "expecting preconditions")
+			status := pc.Eval(test.obj, test.read)
+			tassert.Errorf(t, status == test.status, "expected %d, got %d", test.status, status)
+		})
+	}
+
+	pc := cmn.ParsePreconds(http.Header{})
+	tassert.Errorf(t, pc == nil, "expecting no preconditions")
+
+	// invalid dates are ignored (RFC 7232, sections 3.3 and 3.4)
+	pc = cmn.ParsePreconds(http.Header{cos.HdrIfModifiedSince: []string{"yesterday"}})
+	tassert.Errorf(t, pc == nil, "expecting invalid If-Modified-Since to be ignored")
+	hdr := http.Header{}
+	hdr.Set(cos.HdrIfUnmodifiedSince, "not a date")
+	hdr.Set(cos.HdrIfNoneMatch, `"xyz"`)
+	pc = cmn.ParsePreconds(hdr)
+	tassert.Fatalf(t, pc != nil && pc.IfUnmodifiedSince.IsZero(), "expecting invalid If-Unmodified-Since to be ignored")
+	status := pc.Eval(obj, true)
+	tassert.Errorf(t, status == 0, "expected 0, got %d", status)
+}
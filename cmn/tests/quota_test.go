@@ -0,0 +1,71 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestQuota(t *testing.T) {
+	hard := cmn.QuotaUsage{Size: 100 * cos.KiB, Objs: 10}
+	tests := []struct {
+		name     string
+		usage    cmn.QuotaUsage
+		delta    cmn.QuotaUsage
+		exceeded bool
+	}{
+		{"empty", cmn.QuotaUsage{}, cmn.QuotaUsage{Size: cos.KiB, Objs: 1}, false},
+		{"at-limit", cmn.QuotaUsage{Size: 99 * cos.KiB, Objs: 9}, cmn.QuotaUsage{Size: cos.KiB, Objs: 1}, false},
+		{"size", cmn.QuotaUsage{Size: 99 * cos.KiB, Objs: 1}, cmn.QuotaUsage{Size: cos.KiB + 1, Objs: 1}, true},
+		{"objs", cmn.QuotaUsage{Size: cos.KiB, Objs: 10}, cmn.QuotaUsage{Size: 1, Objs: 1}, true},
+		// overwriting with a smaller object always succeeds
+		{"shrink", cmn.QuotaUsage{Size: 200 * cos.KiB, Objs: 20}, cmn.QuotaUsage{Size: -cos.KiB}, false},
+		{"overwrite", cmn.QuotaUsage{Size: 100 * cos.KiB, Objs: 20}, cmn.QuotaUsage{Size: 1}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := cmn.CheckQuota("ais://abc", test.usage, test.delta, hard)
+			tassert.Errorf(t, (err != nil) == test.exceeded, "expected exceeded=%t, got %v", test.exceeded, err)
+			if err != nil {
+				tassert.Errorf(t, cmn.IsErrQuotaExceeded(err), "unexpected error type %T", err)
+			}
+		})
+	}
+
+	// no limits
+	err := cmn.CheckQuota("ais://abc", cmn.QuotaUsage{Size: cos.TiB, Objs: 1e9}, cmn.QuotaUsage{Size: cos.GiB, Objs: 1},
+		cmn.QuotaUsage{})
+	tassert.CheckError(t, err)
+
+	// per-target share
+	share := hard.Share(3)
+	tassert.Errorf(t, share.Size == 34134 && share.Objs == 4, "unexpected share %+v", share)
+	share = cmn.QuotaUsage{Objs: 10}.Share(4)
+	tassert.Errorf(t, share.Size == 0 && share.Objs == 3, "unexpected share %+v", share)
+}
+
+func TestQuotaConf(t *testing.T) {
+	valid := []cmn.QuotaConf{
+		{},
+		{HardSize: cos.SizeIEC(cos.GiB), Enabled: true},
+		{SoftSize: cos.SizeIEC(cos.MiB), HardSize: cos.SizeIEC(cos.GiB), SoftObjs: 10, HardObjs: 100},
+		{SoftObjs: 10}, // soft only
+	}
+	for _, c := range valid {
+		tassert.CheckError(t, c.ValidateAsProps())
+	}
+	invalid := []cmn.QuotaConf{
+		{HardSize: -1},
+		{SoftSize: cos.SizeIEC(cos.GiB), HardSize: cos.SizeIEC(cos.MiB)},
+		{SoftObjs: 100, HardObjs: 10},
+	}
+	for _, c := range invalid {
+		tassert.Errorf(t, c.ValidateAsProps() != nil, "expecting %+v to fail validation", c)
+	}
+}
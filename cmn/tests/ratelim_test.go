@@ -0,0 +1,59 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	tb := cos.NewTokenBucket(10, 10)
+	for i := range 10 {
+		tassert.Fatalf(t, tb.Allow(1), "burst: expecting request #%d to be allowed", i)
+	}
+	tassert.Errorf(t, !tb.Allow(1), "expecting request to be rejected upon exhausting the burst")
+
+	// reserve: debt to be repaid at 1000 tokens per second
+	tb = cos.NewTokenBucket(1000, 1000)
+	wait := tb.Reserve(1000)
+	tassert.Errorf(t, wait == 0, "expecting no wait, got %v", wait)
+	wait = tb.Reserve(500)
+	tassert.Errorf(t, wait > 400*time.Millisecond && wait <= 500*time.Millisecond, "unexpected wait %v", wait)
+	tassert.Errorf(t, !tb.Allow(1), "expecting rejection while in debt")
+
+	// rate change
+	tb.SetRate(1e9, 1e9)
+	time.Sleep(time.Millisecond)
+	tassert.Errorf(t, tb.Allow(1), "expecting request to be allowed at the new rate")
+}
+
+func TestRateLimitConf(t *testing.T) {
+	valid := []cmn.RateLimitConf{
+		{},
+		{Ops: 100, Enabled: true},
+		{Ops: 100, ClientOps: 10, Bps: cos.SizeIEC(cos.MiB), Enabled: true},
+	}
+	for _, c := range valid {
+		tassert.CheckError(t, c.ValidateAsProps())
+	}
+	invalid := []cmn.RateLimitConf{
+		{Ops: -1},
+		{ClientOps: -1},
+		{Bps: -1},
+	}
+	for _, c := range invalid {
+		tassert.Errorf(t, c.ValidateAsProps() != nil, "expecting %+v to fail validation", c)
+	}
+
+	// per-node share (rounded up)
+	tassert.Errorf(t, cmn.RateShare(100, 3) == 34, "unexpected share %d", cmn.RateShare(100, 3))
+	tassert.Errorf(t, cmn.RateShare(100, 1) == 100, "unexpected share %d", cmn.RateShare(100, 1))
+	tassert.Errorf(t, cmn.RateShare(0, 4) == 0, "unexpected share %d", cmn.RateShare(0, 4))
+}
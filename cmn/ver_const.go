@@ -38,9 +38,11 @@ const (
 	MetaverRMD   = 1 // Rebalance MD (jsp)
 	MetaverVMD   = 2 // Volume MD (jsp)
 	MetaverEtlMD = 1 // ETL MD (jsp)
+	MetaverSched = 1 // scheduled jobs (jsp)
 
-	MetaverLOM   = 1 // LOM
-	MetaverChunk = 2 // LOM chunk
+	MetaverLOM     = 2 // LOM (v2: access counter; v1 is still written when there's none - see core/lom_xattr.go)
+	MetaverChunk   = 2 // LOM chunk
+	MetaverArchIdx = 1 // archive (shard) index (jsp)
 
 	MetaverConfig      = 4 // Global Configuration (jsp)
 	MetaverAuthNConfig = 1 // Authn config (jsp) // ditto
@@ -0,0 +1,172 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Random-access shard index (see cmn/archive/index.go):
+// - stored as fs.ArchIdxType content on the same mountpath as the shard itself;
+// - built upon first access to an archived file (or listing), or at PUT time (feat.IndexArchOnPUT);
+// - identified by the shard's size and mtime, both of which change with every new version
+//   of the object - a stale index gets rebuilt on the fly;
+// - not moved (rebalance, resilver) and not built for encrypted objects.
+
+func (lom *LOM) ArchIdxFQN() string { return fs.CSM.Gen(lom, fs.ArchIdxType, "") }
+
+// identifies the shard that's open for reading
+func archIdxSrc(fh cos.LomReader) (string, error) {
+	f, ok := fh.(*os.File)
+	if !ok {
+		return "", archive.ErrNotIndexable
+	}
+	finfo, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(finfo.Size(), 10) + "-" + strconv.FormatInt(finfo.ModTime().UnixNano(), 10), nil
+}
+
+// ArchIndex loads the shard's index or, if not present or stale, builds and stores a new one.
+// Returns (nil, nil) when indexing is not supported (e.g., .zip). Upon return, the file handle is
+// positioned at the beginning.
+// - `fh` is an open (plain) shard;
+// - no locking is required: the index is tied to the open file (see archIdxSrc).
+func (lom *LOM) ArchIndex(fh cos.LomReader, mime string) (*archive.Index, error) {
+	if !archive.Indexable(mime) || lom.IsEncoded() {
+		return nil, nil
+	}
+	src, err := archIdxSrc(fh)
+	if err != nil {
+		if err == archive.ErrNotIndexable {
+			err = nil
+		}
+		return nil, err
+	}
+	var (
+		fqn = lom.ArchIdxFQN()
+		idx = &archive.Index{}
+	)
+	if _, err := jsp.Load(fqn, idx, jsp.CCSign(cmn.MetaverArchIdx)); err == nil {
+		if idx.Src == src && idx.Mime == mime {
+			return idx, nil
+		}
+	} else if !os.IsNotExist(err) {
+		nlog.Warningln("failed to load", lom.Cname(), "index:", err, "- rebuilding")
+	}
+
+	idx, err = archive.BuildIndex(mime, fh)
+	if _, errS := fh.(io.Seeker).Seek(0, io.SeekStart); err == nil {
+		err = errS
+	}
+	if err != nil {
+		if err == archive.ErrNotIndexable {
+			err = nil
+		}
+		return nil, err
+	}
+	idx.Src = src
+	if err := jsp.Save(fqn, idx, jsp.CCSign(cmn.MetaverArchIdx), nil); err != nil {
+		nlog.Errorln("failed to store", lom.Cname(), "index:", err)
+	}
+	return idx, nil
+}
+
+// IndexArch builds and stores the shard's index, if supported (see ArchIndex)
+func (lom *LOM) IndexArch() error {
+	mime, err := archive.Mime("", lom.ObjName)
+	if err != nil || !archive.Indexable(mime) {
+		return nil
+	}
+	fh, err := lom.Open()
+	if err != nil {
+		return err
+	}
+	_, err = lom.ArchIndex(fh, mime)
+	cos.Close(fh)
+	return err
+}
+
+// ListArch lists archived files, using the shard's index when possible
+// (compare with archive.List)
+func (lom *LOM) ListArch() ([]*archive.Entry, error) {
+	mime, err := archive.Mime("", lom.ObjName)
+	if err != nil {
+		return nil, err
+	}
+	if lom.IsEncoded() {
+		return lom.lsPlain(mime)
+	}
+	if !archive.Indexable(mime) {
+		return archive.List(lom.FQN)
+	}
+	fh, err := lom.Open()
+	if err != nil {
+		return nil, err
+	}
+	idx, err := lom.ArchIndex(fh, mime)
+	cos.Close(fh)
+	if err != nil {
+		return nil, err
+	}
+	if idx == nil {
+		return archive.List(lom.FQN)
+	}
+	return idx.List(), nil
+}
+
+// encrypted, compressed, or deduplicated shard: list its plain content
+// (not indexed - see ArchIndex)
+func (lom *LOM) lsPlain(mime string) ([]*archive.Entry, error) {
+	fh, err := lom.OpenPlain()
+	if err != nil {
+		return nil, err
+	}
+	defer cos.Close(fh)
+	ar, err := archive.NewReader(mime, fh, lom.PlainSize())
+	if err != nil {
+		return nil, err
+	}
+	rcb := &lsArchRCB{}
+	if err := ar.ReadUntil(rcb, "", ""); err != nil {
+		return nil, err
+	}
+	// (same as archive.List)
+	sort.Slice(rcb.lst, func(i, j int) bool { return rcb.lst[i].Name < rcb.lst[j].Name })
+	return rcb.lst, nil
+}
+
+type lsArchRCB struct {
+	lst []*archive.Entry
+}
+
+func (rcb *lsArchRCB) Call(filename string, reader cos.ReadCloseSizer, hdr any) (bool, error) {
+	if th, ok := hdr.(*tar.Header); !ok || !th.FileInfo().IsDir() {
+		rcb.lst = append(rcb.lst, &archive.Entry{Name: filename, Size: reader.Size()})
+	}
+	return false, reader.Close()
+}
+
+// (when removing the object)
+func (lom *LOM) rmArchIdx() {
+	if mime, err := archive.Mime("", lom.ObjName); err != nil || !archive.Indexable(mime) {
+		return
+	}
+	if err := cos.RemoveFile(lom.ArchIdxFQN()); err != nil {
+		nlog.Warningln(err)
+	}
+}
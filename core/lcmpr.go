@@ -0,0 +1,61 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/compr"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Compression at rest (see cmn/compr.go):
+// - same as with SSE (see lsse.go), lom.Lsize() and lom.Checksum() describe the bytes on disk,
+//   while the uncompressed size and the algorithm are stored in custom metadata;
+// - objects stored as is (too small, not compressible) carry no compression metadata;
+// - readers that need uncompressed content use OpenPlain (or NewPlainReader) - the same
+//   code paths that decrypt.
+
+func (lom *LOM) IsCompressed() bool {
+	_, ok := lom.GetCustomKey(cmn.CmprAlgoObjMD)
+	return ok
+}
+
+// IsEncoded returns true if the object's content at rest is not what users see
+// (i.e., is encrypted, compressed, or deduplicated)
+func (lom *LOM) IsEncoded() bool { return lom.IsEncrypted() || lom.IsCompressed() || lom.IsDeduped() }
+
+// NewPlainReader wraps the (open) object's file handle to read decrypted, decompressed, or
+// deduplicated content (`ckey`: customer-provided key, if any); closes the handle on error
+func (lom *LOM) NewPlainReader(fh cos.LomReader, ckey []byte) (cos.LomReader, error) {
+	switch {
+	case lom.IsEncrypted():
+		return lom.NewDecryptor(fh, ckey)
+	case lom.IsDeduped():
+		return lom.NewDedupReader(fh, ckey)
+	}
+	return lom.NewDecompressor(fh, ckey)
+}
+
+func (lom *LOM) NewDecompressor(fh cos.LomReader, ckey []byte) (cos.LomReader, error) {
+	if ckey != nil {
+		cos.Close(fh)
+		return nil, lom.CheckSSEC(ckey)
+	}
+	r, err := compr.NewReader(fh, lom.Lsize())
+	if err != nil {
+		cos.Close(fh)
+		return nil, cmn.NewErrFailedTo(T, "decompress", lom.Cname(), err)
+	}
+	return r, nil
+}
+
+// SetCompressed stores compression metadata (to be persisted with the object)
+func (lom *LOM) SetCompressed(algo string, size int64) {
+	lom.ClearAtRest()
+	lom.SetCustomKey(cmn.CmprAlgoObjMD, algo)
+	lom.SetCustomKey(cmn.CmprSizeObjMD, strconv.FormatInt(size, 10))
+}
@@ -295,14 +295,29 @@ func (lom *LOM) copy2fqn(dst *LOM, buf []byte) (err error) {
 			dst.md.copies[fqn] = mpi
 		}
 	}
+	var plain bool
 	if !dst.Bck().Equal(lom.Bck(), true /*same ID*/, true /*same backend*/) {
 		// The copy will be in a new bucket - completely separate object. Hence, we have to set initial version.
 		dst.SetVersion(lomInitialVersion)
+		// dedup chunks belong to the source bucket
+		plain = lom.IsDeduped()
 	}
 
 	workFQN := fs.CSM.Gen(dst, fs.WorkfileType, fs.WorkfileCopy)
-	_, dstCksum, err = cos.CopyFile(lom.FQN, workFQN, buf, cksumType)
-	if err != nil {
+	if plain {
+		var size int64
+		if size, dstCksum, err = lom.CopyPlain(workFQN, buf, cksumType); err != nil {
+			return
+		}
+		dst.ClearAtRest()
+		dst.SetSize(size)
+		if dstCksum != nil {
+			dst.SetCksum(dstCksum.Clone())
+		} else {
+			dst.SetCksum(cos.NoneCksum)
+		}
+		cksumType = cos.ChecksumNone // (not comparable with the source)
+	} else if _, dstCksum, err = cos.CopyFile(lom.FQN, workFQN, buf, cksumType); err != nil {
 		return
 	}
 
@@ -0,0 +1,290 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/dedup"
+	"github.com/NVIDIA/aistore/fs"
+
+	"github.com/OneOfOne/xxhash"
+)
+
+// Content-defined deduplication (see cmn/dedup.go):
+// - chunks are stored as fs.DedupType content of the bucket, named by their checksums and
+//   placed on mountpaths by HRW (falling back to searching all mountpaths, e.g., after
+//   a new mountpath gets attached);
+// - the object file contains the chunk list ("recipe"); lom.Lsize() and lom.Checksum()
+//   describe the recipe, while the content size is stored in custom metadata;
+// - readers that need the content use OpenPlain (or NewPlainReader) - the same code paths that
+//   decrypt and decompress;
+// - references are the recipes themselves (including noncurrent versions): there are no
+//   per-chunk reference counts (in LOM metadata or elsewhere) that would have to be updated
+//   atomically with every PUT, overwrite, delete, and versioning change - instead, storage
+//   cleanup marks (collects) references and sweeps unreferenced chunks (see space/dedup.go);
+// - to that end, storing an existing chunk (again) updates its mtime, under the chunk's name
+//   lock that is also taken by the sweep (see RemoveDedupChunk);
+// - chunks are target-local (rebalance migrates deduplicated objects as plain content) -
+//   resilver moves them to their HRW mountpaths (see MoveDedupChunk).
+
+// (moving chunk: copy and rename - see MoveDedupChunk)
+const dedupOpenRetry = 10 * time.Millisecond
+
+type dedupStore struct {
+	bck cmn.Bck
+}
+
+// interface guard
+var _ dedup.Store = (*dedupStore)(nil)
+
+func (lom *LOM) IsDeduped() bool {
+	_, ok := lom.GetCustomKey(cmn.DedupSizeObjMD)
+	return ok
+}
+
+func (lom *LOM) SetDeduped(size int64) {
+	lom.ClearAtRest()
+	lom.SetCustomKey(cmn.DedupSizeObjMD, strconv.FormatInt(size, 10))
+}
+
+// NewDedupWriter returns writer that stores content chunks and writes the resulting recipe to `w`
+func (lom *LOM) NewDedupWriter(w io.Writer, conf *cmn.DedupConf) (*dedup.Writer, error) {
+	return dedup.NewWriter(w, &dedupStore{bck: *lom.Bucket()}, conf.CksumTypeX())
+}
+
+// NewDedupReader reads the recipe from the (open) object's file handle and returns reader
+// of the object's content; always closes the handle
+func (lom *LOM) NewDedupReader(fh cos.LomReader, ckey []byte) (cos.LomReader, error) {
+	if ckey != nil {
+		cos.Close(fh)
+		return nil, lom.CheckSSEC(ckey)
+	}
+	rcp, err := dedup.ReadRecipe(fh, lom.Lsize())
+	cos.Close(fh)
+	if err != nil {
+		return nil, cmn.NewErrFailedTo(T, "read recipe", lom.Cname(), err)
+	}
+	return dedup.NewReader(rcp, &dedupStore{bck: *lom.Bucket()}), nil
+}
+
+// DedupRefs adds to `refs` the chunks referenced by the deduplicated object or its noncurrent
+// version stored at `fqn` (see fs.VersionType); no-op otherwise
+func DedupRefs(bck *cmn.Bck, fqn string, refs map[string]struct{}) error {
+	lom := AllocLOM("")
+	defer FreeLOM(lom)
+	if err := lom.InitBck(bck); err != nil {
+		return err
+	}
+	lom.FQN = fqn
+	if err := lom.fromFS(); err != nil {
+		if os.IsNotExist(err) || cmn.IsErrLmetaNotFound(err) {
+			return nil // removed or being written
+		}
+		return err
+	}
+	if !lom.IsDeduped() {
+		return nil
+	}
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return err
+	}
+	rcp, err := dedup.ReadRecipe(fh, lom.Lsize())
+	fh.Close()
+	if err != nil {
+		return cmn.NewErrFailedTo(T, "read recipe", fqn, err)
+	}
+	for i := range rcp.Chunks {
+		refs[rcp.Chunks[i].Digest] = struct{}{}
+	}
+	return nil
+}
+
+// chunk's name relative to the bucket's fs.DedupType directory
+// (the base name is the chunk's digest - see space/dedup.go)
+func DedupChunkName(digest string) string { return digest[:2] + "/" + digest }
+
+// DedupChunksSize returns the total size of the bucket's dedup chunks stored on this target
+func DedupChunksSize(bck *cmn.Bck) (size int64, err error) {
+	avail := fs.GetAvail()
+	for _, mi := range avail {
+		opts := &fs.WalkOpts{
+			Mi:  mi,
+			Bck: *bck,
+			CTs: []string{fs.DedupType},
+			Callback: func(fqn string, de fs.DirEntry) error {
+				if de.IsDir() {
+					return nil
+				}
+				if finfo, err := os.Stat(fqn); err == nil {
+					size += finfo.Size()
+				}
+				return nil
+			},
+		}
+		if err = fs.Walk(opts); err != nil {
+			return size, err
+		}
+	}
+	return size, nil
+}
+
+// RemoveDedupChunk removes unreferenced chunk unless the latter was stored (again) since
+// the time it was determined to be unreferenced - i.e., unless `isOld` returns false;
+// returns the size of the removed chunk or -1 if not removed
+func RemoveDedupChunk(bck *cmn.Bck, fqn string, isOld func(os.FileInfo) bool) (int64, error) {
+	nlc, uname := dedupLocker(bck, filepath.Base(fqn))
+	nlc.Lock(uname, true)
+	defer nlc.Unlock(uname, true)
+
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return -1, err
+	}
+	if !isOld(finfo) {
+		return -1, nil
+	}
+	if err := cos.RemoveFile(fqn); err != nil {
+		return -1, err
+	}
+	return finfo.Size(), nil
+}
+
+func dedupLocker(bck *cmn.Bck, digest string) (*nlc, string) {
+	var (
+		uname = bck.MakeUname(digest)
+		hash  = xxhash.Checksum64S(uname, cos.MLCG32)
+	)
+	return &g.dlocker[hash&cos.MultiHashMapMask], cos.UnsafeS(uname)
+}
+
+// MoveDedupChunk moves a given chunk to its HRW mountpath (resilver); returns the size
+// of the moved chunk or -1 if there was nothing to do
+func MoveDedupChunk(bck *cmn.Bck, fqn string, buf []byte) (int64, error) {
+	digest := filepath.Base(fqn)
+	mi, _, err := fs.Hrw(cos.UnsafeB(digest))
+	if err != nil {
+		return -1, err
+	}
+	name := DedupChunkName(digest)
+	dst := mi.MakePathFQN(bck, fs.DedupType, name)
+	if dst == fqn {
+		return -1, nil
+	}
+	var size int64
+	if cos.Stat(dst) != nil {
+		var (
+			tag     = fs.CSM.Resolver(fs.WorkfileType).GenUniqueFQN(name, fs.WorkfileDedup)
+			workFQN = mi.MakePathFQN(bck, fs.WorkfileType, tag)
+		)
+		size, _, err = cos.CopyFile(fqn, workFQN, buf, cos.ChecksumNone)
+		if err == nil {
+			err = cos.Rename(workFQN, dst)
+		}
+		if err != nil {
+			cos.RemoveFile(workFQN)
+			if os.IsNotExist(err) {
+				return -1, nil // removed in the meantime (storage cleanup)
+			}
+			return -1, err
+		}
+	}
+	return size, cos.RemoveFile(fqn)
+}
+
+////////////////
+// dedupStore //
+////////////////
+
+// returns existing chunk's location or, if not found, where it should be
+func (s *dedupStore) locate(digest string) (mi *fs.Mountpath, fqn string, exists bool, err error) {
+	name := DedupChunkName(digest)
+	if mi, _, err = fs.Hrw(cos.UnsafeB(digest)); err != nil {
+		return nil, "", false, err
+	}
+	if fqn = mi.MakePathFQN(&s.bck, fs.DedupType, name); cos.Stat(fqn) == nil {
+		return mi, fqn, true, nil
+	}
+	avail := fs.GetAvail()
+	for _, other := range avail {
+		if other == mi {
+			continue
+		}
+		if ofqn := other.MakePathFQN(&s.bck, fs.DedupType, name); cos.Stat(ofqn) == nil {
+			return other, ofqn, true, nil
+		}
+	}
+	return mi, fqn, false, nil
+}
+
+// (shared chunk lock: concurrent PUTs of the same content are fine, storage cleanup is not)
+func (s *dedupStore) Put(digest string, chunk []byte) error {
+	nlc, uname := dedupLocker(&s.bck, digest)
+	nlc.Lock(uname, false)
+	defer nlc.Unlock(uname, false)
+
+	mi, fqn, exists, err := s.locate(digest)
+	if err != nil {
+		return err
+	}
+	if exists {
+		now := time.Now()
+		if err := os.Chtimes(fqn, now, now); err == nil || !os.IsNotExist(err) {
+			return err
+		}
+		// (removed in the meantime)
+	}
+	var (
+		tag     = fs.CSM.Resolver(fs.WorkfileType).GenUniqueFQN(DedupChunkName(digest), fs.WorkfileDedup)
+		workFQN = mi.MakePathFQN(&s.bck, fs.WorkfileType, tag)
+	)
+	fh, err := cos.CreateFile(workFQN)
+	if err != nil {
+		return err
+	}
+	_, err = fh.Write(chunk)
+	if erc := fh.Close(); err == nil {
+		err = erc
+	}
+	if err == nil {
+		err = cos.Rename(workFQN, fqn)
+	}
+	if err != nil {
+		cos.RemoveFile(workFQN)
+	}
+	return err
+}
+
+// (retrying once in case the chunk is being moved - see MoveDedupChunk)
+func (s *dedupStore) Open(digest string) (dedup.ChunkReader, error) {
+	for retry := false; ; retry = true {
+		_, fqn, exists, err := s.locate(digest)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			fh, err := os.Open(fqn)
+			if err == nil {
+				return fh, nil
+			}
+			if !os.IsNotExist(err) || retry {
+				return nil, err
+			}
+		} else if retry {
+			return nil, cos.NewErrNotFound(T, s.bck.Cname("")+" dedup chunk "+digest)
+		}
+		time.Sleep(dedupOpenRetry)
+	}
+}
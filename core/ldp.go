@@ -80,6 +80,9 @@ func (*LDP) Reader(lom *LOM, latestVer, sync bool) (cos.ReadOpenCloser, cos.OAH,
 			}
 		}
 
+		if lom.IsEncoded() {
+			return lom.NewPlainDeferROC() // (ditto) decrypting or decompressing
+		}
 		roc, err := lom.NewDeferROC() // keeping lock, reading local
 		return roc, lom, err
 	}
@@ -125,6 +128,10 @@ func (lom *LOM) CheckRemoteMD(locked, sync bool, origReq *http.Request) (res CRM
 		// that doesn't provide any versioning metadata
 		return CRMD{Eq: true}
 	}
+	if lom.IsDirty() {
+		// not yet written back - in-cluster content is the latest
+		return CRMD{Eq: true}
+	}
 
 	oa, ecode, err := T.HeadCold(lom, origReq)
 	if err == nil {
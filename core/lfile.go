@@ -119,6 +119,7 @@ func (lom *LOM) RemoveObj(force ...bool) (err error) {
 	})
 	lom.Uncache()
 	err = lom.RemoveMain()
+	lom.rmArchIdx()
 	for copyFQN := range lom.md.copies {
 		if erc := cos.RemoveFile(copyFQN); erc != nil && !os.IsNotExist(erc) && err == nil {
 			err = erc
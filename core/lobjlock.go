@@ -0,0 +1,61 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Object lock (WORM) - see cmn/objlock.go
+
+// CheckLock returns cmn.ErrObjLocked if the (loaded) object is under legal hold
+// or active retention; governance-mode retention can be bypassed
+func (lom *LOM) CheckLock(bypass bool) error {
+	return cmn.CheckObjLock(lom.GetCustomMD(), lom.Cname(), time.Now(), bypass)
+}
+
+// (space cleanup, LRU, and lifecycle: never bypass)
+func (lom *LOM) IsObjLocked() bool { return lom.CheckLock(false) != nil }
+
+// CheckLockCurrent checks the object's current on-disk version, if exists,
+// while the `lom` itself may already carry new (to be written) metadata
+// - caller must hold the write lock.
+func (lom *LOM) CheckLockCurrent(bypass bool) error {
+	if !lom.Bprops().ObjLock.Enabled {
+		return nil
+	}
+	cur := AllocLOM(lom.ObjName)
+	defer FreeLOM(cur)
+	if err := cur.InitBck(lom.Bucket()); err != nil {
+		return err
+	}
+	if err := cur.fromFS(); err != nil {
+		if cos.IsNotExist(err, 0) {
+			return nil
+		}
+		return err
+	}
+	return cur.CheckLock(bypass)
+}
+
+// SetDefaultRetention applies bucket's default retention to a new object
+// unless the latter has its own
+func (lom *LOM) SetDefaultRetention() {
+	conf := &lom.Bprops().ObjLock
+	if !conf.Enabled {
+		return
+	}
+	if _, ok := lom.GetCustomKey(cmn.RetainModeObjMD); ok {
+		return
+	}
+	if r := conf.DefaultRetention(time.Now()); r.IsSet() {
+		lom.SetCustomKey(cmn.RetainModeObjMD, r.Mode)
+		lom.SetCustomKey(cmn.RetainUntilObjMD, strconv.FormatInt(r.Until.UnixNano(), 10))
+	}
+}
@@ -48,12 +48,13 @@ const (
 )
 
 type (
-	lmeta struct { // sizeof = 72
+	lmeta struct { // sizeof = 80
 		copies fs.MPI
 		uname  *string
 		cmn.ObjAttrs
 		atimefs uint64 // (high bit `lomDirtyMask` | int64: atime)
 		lid     lomBID
+		hits    int64 // access counter (see IncHits)
 	}
 	LOM struct {
 		mi      *fs.Mountpath
@@ -72,6 +73,7 @@ type (
 		pmm      *memsys.MMSA
 		smm      *memsys.MMSA
 		locker   nameLocker
+		dlocker  nameLocker // dedup chunks (see ldedup.go)
 		lchk     lchk
 		maxLmeta atomic.Int64
 	}
@@ -103,6 +105,7 @@ func Tinit(t Target, tstats cos.StatsUpdater, config *cmn.Config, runHK bool) {
 	{
 		g.maxLmeta.Store(xattrMaxSize)
 		g.locker = newNameLocker()
+		g.dlocker = newNameLocker()
 		g.tstats = tstats
 		g.pmm = t.PageMM()
 		g.smm = t.ByteMM()
@@ -178,6 +181,15 @@ func (lom *LOM) Atime() time.Time      { return time.Unix(0, lom.md.Atime) }
 func (lom *LOM) AtimeUnix() int64      { return lom.md.Atime }
 func (lom *LOM) SetAtimeUnix(tu int64) { lom.md.Atime = tu }
 
+// access counter: maintained only for buckets with LFU-like eviction policies (see apc.LRUPolicy);
+// persistence is lazy - via dirty metadata that lcache flushes when not accessed for a while
+func (lom *LOM) Hits() int64 { return lom.md.hits }
+
+func (lom *LOM) IncHits() {
+	lom.md.hits++
+	lom.md.makeDirty()
+}
+
 func (lom *LOM) bid() uint64             { return lom.md.lid.bid() }
 func (lom *LOM) setbid(bpropsBID uint64) { lom.md.lid = lom.md.lid.setbid(bpropsBID) }
 
@@ -187,6 +199,7 @@ func (lom *LOM) SetCustomMD(md cos.StrKVs) { lom.md.SetCustomMD(md) }
 
 func (lom *LOM) GetCustomKey(key string) (string, bool) { return lom.md.GetCustomKey(key) }
 func (lom *LOM) SetCustomKey(key, value string)         { lom.md.SetCustomKey(key, value) }
+func (lom *LOM) DelCustomKey(key string)                { lom.md.DelCustomKey(key) }
 
 // subj to resilvering
 func (lom *LOM) IsHRW() bool {
@@ -235,6 +248,18 @@ func (lom *LOM) WritePolicy() (p apc.WritePolicy) {
 	return
 }
 
+// remote bucket with write-back data policy (see ais/tgtwback.go)
+func (lom *LOM) IsWriteBack() bool {
+	bprops := lom.Bprops()
+	return bprops != nil && bprops.WritePolicy.Data.IsWriteBack() && lom.Bck().IsRemote()
+}
+
+// not yet written back
+func (lom *LOM) IsDirty() bool {
+	_, ok := lom.GetCustomKey(cmn.DirtyObjMD)
+	return ok
+}
+
 func (lom *LOM) loaded() bool { return lom.md.lid != 0 }
 
 func (lom *LOM) HrwTarget(smap *meta.Smap) (tsi *meta.Snode, local bool, err error) {
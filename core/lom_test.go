@@ -6,17 +6,22 @@
 package core_test
 
 import (
+	"bytes"
 	cryptorand "crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/sse"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/core/mock"
@@ -33,6 +38,7 @@ var _ = Describe("LOM", func() {
 		bucketLocalA = "LOM_TEST_Local_A"
 		bucketLocalB = "LOM_TEST_Local_B"
 		bucketLocalC = "LOM_TEST_Local_C"
+		bucketLocalV = "LOM_TEST_Local_V"
 
 		bucketCloudA = "LOM_TEST_Cloud_A"
 		bucketCloudB = "LOM_TEST_Cloud_B"
@@ -43,6 +49,7 @@ var _ = Describe("LOM", func() {
 	var (
 		localBckA = cmn.Bck{Name: bucketLocalA, Provider: apc.AIS, Ns: cmn.NsGlobal}
 		localBckB = cmn.Bck{Name: bucketLocalB, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		localBckV = cmn.Bck{Name: bucketLocalV, Provider: apc.AIS, Ns: cmn.NsGlobal}
 		cloudBckA = cmn.Bck{Name: bucketCloudA, Provider: apc.AWS, Ns: cmn.NsGlobal}
 	)
 
@@ -71,6 +78,9 @@ var _ = Describe("LOM", func() {
 
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	fs.CSM.Reg(fs.VersionType, &fs.VersionContentResolver{}, true)
+	fs.CSM.Reg(fs.ArchIdxType, &fs.ArchIdxContentResolver{}, true)
+	fs.CSM.Reg(fs.DedupType, &fs.DedupContentResolver{}, true)
 
 	bmd := mock.NewBaseBownerMock(
 		meta.NewBck(
@@ -94,6 +104,14 @@ var _ = Describe("LOM", func() {
 		meta.NewBck(bucketCloudA, apc.AWS, cmn.NsGlobal, &cmn.Bprops{BID: 5}),
 		meta.NewBck(bucketCloudB, apc.AWS, cmn.NsGlobal, &cmn.Bprops{BID: 6}),
 		meta.NewBck(sameBucketName, apc.AWS, cmn.NsGlobal, &cmn.Bprops{BID: 7}),
+		meta.NewBck(
+			bucketLocalV, apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{
+				Cksum:      cmn.CksumConf{Type: cos.ChecksumNone},
+				Versioning: cmn.VersionConf{Enabled: true, Retain: 2},
+				BID:        8,
+			},
+		),
 	)
 
 	BeforeEach(func() {
@@ -995,6 +1013,255 @@ var _ = Describe("LOM", func() {
 		})
 	})
 
+	Describe("noncurrent versions", func() {
+		It("should keep, list, trim, and delete noncurrent versions", func() {
+			lom := &core.LOM{ObjName: "vdir/test-obj.ext"}
+			Expect(lom.InitBck(&localBckV)).NotTo(HaveOccurred())
+			Expect(lom.RetainVersions()).To(Equal(2))
+			lom.Lock(true)
+			defer lom.Unlock(true)
+
+			// overwrite
+			for i := 1; i <= 4; i++ {
+				next, err := lom.ArchiveVersion()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(next).To(Equal(strconv.Itoa(i)))
+				createTestFile(lom.FQN, i)
+				lom.SetSize(int64(i))
+				lom.SetVersion(next)
+				Expect(persist(lom)).NotTo(HaveOccurred())
+				lom.TrimVersions(lom.RetainVersions())
+			}
+			vers, err := lom.ListVersions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vers).To(HaveLen(2))
+			Expect(vers[0].Version).To(Equal("3"))
+			Expect(vers[0].Size).To(BeEquivalentTo(3))
+			Expect(vers[1].Version).To(Equal("2"))
+
+			v, err := lom.LoadVersion("2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v.Lsize()).To(BeEquivalentTo(2))
+			Expect(v.NoncurrentSince()).NotTo(BeZero())
+			core.FreeLOM(v)
+			_, err = lom.LoadVersion("1")
+			Expect(cos.IsNotExist(err, 0)).To(BeTrue())
+
+			// delete
+			Expect(lom.DelCurrent(lom.RetainVersions())).NotTo(HaveOccurred())
+			Expect(lom.Load(false, true)).To(HaveOccurred())
+			vers, err = lom.ListVersions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vers).To(HaveLen(2))
+			Expect(vers[0].Version).To(Equal("5"))
+			Expect(vers[0].DeleteMarker).To(BeTrue())
+			Expect(vers[1].Version).To(Equal("4"))
+
+			// removing delete marker restores the latest version
+			Expect(lom.DelVersion("5", false)).NotTo(HaveOccurred())
+			Expect(lom.Load(false, true)).NotTo(HaveOccurred())
+			Expect(lom.Version()).To(Equal("4"))
+			Expect(lom.Lsize()).To(BeEquivalentTo(4))
+
+			Expect(lom.DelVersion("4", false)).NotTo(HaveOccurred())
+			Expect(lom.Load(false, true)).To(HaveOccurred())
+			Expect(lom.DelVersion("4", false)).To(HaveOccurred())
+		})
+
+		It("should store migrated and move misplaced noncurrent versions", func() {
+			const size = 7
+			lom := &core.LOM{ObjName: "vdir/test-obj-mv.ext"}
+			Expect(lom.InitBck(&localBckV)).NotTo(HaveOccurred())
+			lom.Lock(true)
+			defer lom.Unlock(true)
+
+			// rebalance
+			oa := &cmn.ObjAttrs{Size: size, Cksum: cos.NoneCksum}
+			oa.SetVersion("7")
+			oa.SetCustomKey(cmn.NoncurrentObjMD, "1")
+			Expect(lom.PutVersion(oa, bytes.NewReader(make([]byte, size)), nil)).NotTo(HaveOccurred())
+			Expect(lom.PutVersion(oa, bytes.NewReader(nil), nil)).NotTo(HaveOccurred()) // (exists)
+			oa.SetVersion("x")
+			Expect(lom.PutVersion(oa, bytes.NewReader(nil), nil)).To(HaveOccurred())
+
+			v, err := lom.LoadVersion("7")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v.Lsize()).To(BeEquivalentTo(size))
+			Expect(v.NoncurrentSince()).To(Equal(time.Unix(0, 1)))
+			core.FreeLOM(v)
+
+			// resilver
+			var other *fs.Mountpath
+			for _, mi := range mis {
+				if mi.Path != lom.Mountpath().Path {
+					other = mi
+					break
+				}
+			}
+			vfqn := lom.VersionFQN("7")
+			srcFQN := other.Path + strings.TrimPrefix(vfqn, lom.Mountpath().Path)
+			Expect(cos.CreateDir(filepath.Dir(srcFQN))).NotTo(HaveOccurred())
+			Expect(os.Rename(vfqn, srcFQN)).NotTo(HaveOccurred())
+			_, err = lom.LoadVersion("7")
+			Expect(cos.IsNotExist(err, 0)).To(BeTrue())
+
+			Expect(lom.MoveVersion(srcFQN, "7", nil)).NotTo(HaveOccurred())
+			Expect(srcFQN).NotTo(BeAnExistingFile())
+			v, err = lom.LoadVersion("7")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v.Lsize()).To(BeEquivalentTo(size))
+			core.FreeLOM(v)
+			Expect(lom.DelVersion("7", false)).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("dedup chunks", func() {
+		It("should move chunk to its HRW mountpath", func() {
+			const digest = "0123456789abcdef"
+			hmi, _, err := fs.Hrw([]byte(digest))
+			Expect(err).NotTo(HaveOccurred())
+			var other *fs.Mountpath
+			for _, mi := range mis {
+				if mi.Path != hmi.Path {
+					other = mi
+					break
+				}
+			}
+			var (
+				name = core.DedupChunkName(digest)
+				src  = other.MakePathFQN(&localBckA, fs.DedupType, name)
+				dst  = hmi.MakePathFQN(&localBckA, fs.DedupType, name)
+			)
+			createTestFile(src, 5)
+
+			size, err := core.MoveDedupChunk(&localBckA, src, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(BeEquivalentTo(5))
+			Expect(src).NotTo(BeAnExistingFile())
+			Expect(dst).To(BeARegularFile())
+
+			// nothing to do
+			size, err = core.MoveDedupChunk(&localBckA, dst, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(BeEquivalentTo(-1))
+			Expect(dst).To(BeARegularFile())
+			Expect(os.Remove(dst)).NotTo(HaveOccurred())
+		})
+
+		It("should not remove chunk stored again since the scan", func() {
+			const digest = "fedcba9876543210"
+			hmi, _, err := fs.Hrw([]byte(digest))
+			Expect(err).NotTo(HaveOccurred())
+			var (
+				fqn   = hmi.MakePathFQN(&localBckA, fs.DedupType, core.DedupChunkName(digest))
+				scan  = time.Now()
+				isOld = func(finfo os.FileInfo) bool { return !finfo.ModTime().After(scan) }
+			)
+			createTestFile(fqn, 5)
+			old := scan.Add(-time.Hour)
+			Expect(os.Chtimes(fqn, old, old)).NotTo(HaveOccurred())
+
+			// PUT of the same content refreshes the chunk
+			now := scan.Add(time.Second)
+			Expect(os.Chtimes(fqn, now, now)).NotTo(HaveOccurred())
+			size, err := core.RemoveDedupChunk(&localBckA, fqn, isOld)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(BeEquivalentTo(-1))
+			Expect(fqn).To(BeARegularFile())
+
+			Expect(os.Chtimes(fqn, old, old)).NotTo(HaveOccurred())
+			size, err = core.RemoveDedupChunk(&localBckA, fqn, isOld)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(BeEquivalentTo(5))
+			Expect(fqn).NotTo(BeAnExistingFile())
+
+			// removed in the meantime
+			size, err = core.RemoveDedupChunk(&localBckA, fqn, isOld)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(BeEquivalentTo(-1))
+		})
+	})
+
+	Describe("archive index", func() {
+		It("should build, reuse, invalidate, and remove shard index", func() {
+			lom := &core.LOM{ObjName: "adir/test-shard.tar"}
+			Expect(lom.InitBck(&localBckA)).NotTo(HaveOccurred())
+			lom.Lock(true)
+			defer lom.Unlock(true)
+
+			for _, n := range []int{3, 5} {
+				createTestShard(lom.FQN, n)
+				mtime := time.Now().Add(time.Duration(n) * time.Second)
+				Expect(os.Chtimes(lom.FQN, mtime, mtime)).NotTo(HaveOccurred())
+				lom.SetSize(int64(n))
+				Expect(persist(lom)).NotTo(HaveOccurred())
+
+				lst, err := lom.ListArch()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lst).To(HaveLen(n))
+				Expect(lst[0].Name).To(Equal("file-0.txt"))
+				Expect(lom.ArchIdxFQN()).To(BeARegularFile())
+
+				// ditto, via stored index
+				lst, err = lom.ListArch()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lst).To(HaveLen(n))
+			}
+
+			Expect(lom.RemoveObj()).NotTo(HaveOccurred())
+			Expect(lom.ArchIdxFQN()).NotTo(BeAnExistingFile())
+		})
+
+		It("should list encrypted shard", func() {
+			const n = 4
+			key := make([]byte, sse.KeySize)
+			_, err := cryptorand.Read(key)
+			Expect(err).NotTo(HaveOccurred())
+			dir := GinkgoT().TempDir()
+			keyfile := filepath.Join(dir, "sse-keyfile.json")
+			kf := &sse.Keyfile{Keys: map[string]string{"k1": base64.StdEncoding.EncodeToString(key)}}
+			Expect(os.WriteFile(keyfile, cos.MustMarshal(kf), cos.PermRWR)).NotTo(HaveOccurred())
+			kp, err := sse.LoadKeyfile(keyfile)
+			Expect(err).NotTo(HaveOccurred())
+			sse.Register(kp)
+
+			// plain shard => encrypted object
+			plain := filepath.Join(dir, "plain-shard.tar")
+			createTestShard(plain, n)
+			lom := &core.LOM{ObjName: "adir/enc-shard.tar"}
+			Expect(lom.InitBck(&localBckA)).NotTo(HaveOccurred())
+			lom.Lock(true)
+			defer lom.Unlock(true)
+
+			params, err := lom.InitSSE(&cmn.SSEConf{Provider: sse.ProviderKeyfile})
+			Expect(err).NotTo(HaveOccurred())
+			src, err := os.ReadFile(plain)
+			Expect(err).NotTo(HaveOccurred())
+			fh, err := cos.CreateFile(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			ew, err := sse.NewWriter(fh, params)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = ew.Write(src)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ew.Close()).NotTo(HaveOccurred())
+			Expect(fh.Close()).NotTo(HaveOccurred())
+			lom.SetCustomKey(cmn.SSESizeObjMD, strconv.Itoa(len(src)))
+			lom.SetSize(ew.Size())
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			Expect(lom.IsEncrypted()).To(BeTrue())
+
+			lst, err := lom.ListArch()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lst).To(HaveLen(n))
+			for i, e := range lst {
+				Expect(e.Name).To(Equal("file-" + strconv.Itoa(i) + ".txt"))
+				Expect(e.Size).To(BeEquivalentTo(1))
+			}
+			Expect(lom.ArchIdxFQN()).NotTo(BeAnExistingFile())
+			Expect(lom.RemoveObj()).NotTo(HaveOccurred())
+		})
+	})
+
 	Describe("local and cloud bucket with the same name", func() {
 		It("should have different fqn", func() {
 			testObject := "foldr/test-obj.ext"
@@ -1076,6 +1343,20 @@ func createTestFile(fqn string, size int) {
 	}
 }
 
+func createTestShard(fqn string, numFiles int) {
+	_ = os.Remove(fqn)
+	fh, err := cos.CreateFile(fqn)
+	Expect(err).ShouldNot(HaveOccurred())
+	aw := archive.NewWriter(archive.ExtTar, fh, nil, nil)
+	for i := range numFiles {
+		data := []byte(strconv.Itoa(i))
+		err = aw.Write("file-"+strconv.Itoa(i)+".txt", cos.SimpleOAH{Size: int64(len(data))}, bytes.NewReader(data))
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+	aw.Fini()
+	Expect(fh.Close()).ShouldNot(HaveOccurred())
+}
+
 func getTestFileHash(fqn string) (hash string) {
 	reader, _ := os.Open(fqn)
 	_, cksum, err := cos.CopyAndChecksum(io.Discard, reader, nil, cos.ChecksumXXHash)
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -39,6 +40,13 @@ import (
 // * metadata - the rest of the layout. The content of the metadata can vary depending
 //   on the version of the layout.
 
+// LOM metadata layout versions:
+// - v1: all of the below except access counter (packedHits);
+// - v2 (cmn.MetaverLOM): v1 + access counter (see apc.LRUPolicy).
+// Both are supported; v1 is still written when there are no hits to store
+// (and can be read by older targets).
+const lmetaV1 = 1
+
 // the one and only currently supported checksum type == xxhash;
 // adding more checksums will likely require a new cmn.MetaverLOM version
 const mdCksumTyXXHash = 1
@@ -75,6 +83,7 @@ const (
 	packedCustom
 	packedNum
 	packedChunk
+	packedHits
 )
 
 // packing format: separators
@@ -303,8 +312,9 @@ func (md *lmeta) unpack(buf []byte) error {
 	if len(buf) < prefLen {
 		return fmt.Errorf("%s: too short (%d)", badLmeta, len(buf))
 	}
-	if buf[0] != cmn.MetaverLOM {
-		return fmt.Errorf("%s: unknown version %d", badLmeta, buf[0])
+	metaver := buf[0]
+	if metaver != cmn.MetaverLOM && metaver != lmetaV1 {
+		return fmt.Errorf("%s: unknown version %d", badLmeta, metaver)
 	}
 	if buf[1] != mdCksumTyXXHash {
 		return fmt.Errorf("%s: unknown checksum %d", badLmeta, buf[1])
@@ -389,6 +399,15 @@ func (md *lmeta) unpack(buf []byte) error {
 				custom[entries[i]] = entries[i+1]
 			}
 			md.SetCustomMD(custom)
+		case packedHits:
+			if metaver == lmetaV1 {
+				return errors.New(badLmeta + " #5.3")
+			}
+			hits, err := strconv.ParseInt(string(record[cos.SizeofI16:]), 10, 64)
+			if err != nil {
+				return errors.New(badLmeta + " #5.2")
+			}
+			md.hits = hits
 		default:
 			return errors.New(badLmeta + " #6")
 		}
@@ -436,8 +455,17 @@ func (md *lmeta) pack(mdSize int64) (buf []byte) {
 		buf = _packCustom(buf, custom)
 	}
 
+	// access counter
+	if md.hits > 0 {
+		buf = g.smm.Append(buf, recordSepa)
+		buf = _packRecord(buf, packedHits, strconv.FormatInt(md.hits, 10), false)
+	}
+
 	// checksum, prepend, and return
-	buf[0] = cmn.MetaverLOM
+	buf[0] = lmetaV1
+	if md.hits > 0 {
+		buf[0] = cmn.MetaverLOM
+	}
 	buf[1] = mdCksumTyXXHash
 	mdCksumValue := xxhash.Checksum64S(buf[prefLen:], cos.MLCG32)
 	binary.BigEndian.PutUint64(buf[2:], mdCksumValue)
@@ -116,6 +116,8 @@ var _ = Describe("LOM Xattributes", func() {
 					cmn.ETag:        "etag",
 					cmn.CRC32CObjMD: "crc32",
 				})
+				lom.IncHits()
+				lom.IncHits()
 				Expect(lom.AddCopy(fqns[0], copyMpathInfo)).NotTo(HaveOccurred())
 				Expect(lom.AddCopy(fqns[1], copyMpathInfo)).NotTo(HaveOccurred())
 				Expect(persist(lom)).NotTo(HaveOccurred())
@@ -123,6 +125,7 @@ var _ = Describe("LOM Xattributes", func() {
 				b, err := fs.GetXattr(localFQN, core.XattrLOM)
 				Expect(b).ToNot(BeEmpty())
 				Expect(err).NotTo(HaveOccurred())
+				Expect(b[0]).To(BeEquivalentTo(cmn.MetaverLOM)) // (access counter)
 
 				hrwLom := &core.LOM{ObjName: testObjectName}
 				Expect(hrwLom.InitBck(&localBck)).NotTo(HaveOccurred())
@@ -137,6 +140,7 @@ var _ = Describe("LOM Xattributes", func() {
 				Expect(lom.GetCopies()).To(BeEquivalentTo(newLom.GetCopies()))
 				Expect(lom.GetCustomMD()).To(HaveLen(3))
 				Expect(lom.GetCustomMD()).To(BeEquivalentTo(newLom.GetCustomMD()))
+				Expect(newLom.Hits()).To(BeEquivalentTo(2))
 			})
 
 			It("should _not_ save meta to disk", func() {
@@ -322,6 +326,13 @@ var _ = Describe("LOM Xattributes", func() {
 					Expect(err).To(MatchError("bad lmeta: unknown checksum 0"))
 				})
 
+				It("should write v1 layout unless there's access counter to store", func() {
+					b, err := fs.GetXattr(localFQN, core.XattrLOM)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(b[0]).To(BeEquivalentTo(1))
+					Expect(lom.LoadMetaFromFS()).NotTo(HaveOccurred())
+				})
+
 				It("should fail when metadata version is invalid", func() {
 					b, err := fs.GetXattr(localFQN, core.XattrLOM)
 					Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,316 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/compr"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/dedup"
+	"github.com/NVIDIA/aistore/cmn/sse"
+)
+
+// Server-side encryption at rest (see cmn/sse.go):
+// - lom.Lsize() and lom.Checksum() describe encrypted content - the bytes on disk;
+// - plaintext size and wrapped data key are stored in custom metadata;
+// - readers that need plaintext (GET, archive, ETL, dsort) use OpenPlain (or NewDecryptor);
+// - SSE-C objects (customer-provided keys) can only be decrypted with the key that comes with
+//   the (S3) request - see OpenPlainWith and CheckSSEC;
+// - rebalance, mirroring, EC, and copying (as is) move encrypted content along with its metadata;
+// - data keys unwrapped by external KMS are cached (see sse.NewKMSProvider).
+
+func (lom *LOM) IsEncrypted() bool {
+	_, ok := lom.GetCustomKey(cmn.SSENonceObjMD)
+	return ok
+}
+
+// PlainSize returns the size of the object's content as seen by users
+// (decrypted, decompressed, or reassembled from deduplicated chunks - see lcmpr.go and ldedup.go)
+func (lom *LOM) PlainSize() int64 {
+	for _, key := range []string{cmn.SSESizeObjMD, cmn.CmprSizeObjMD, cmn.DedupSizeObjMD} {
+		if s, ok := lom.GetCustomKey(key); ok {
+			if size, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return size
+			}
+		}
+	}
+	return lom.Lsize()
+}
+
+// ClearAtRest removes SSE, compression, and dedup metadata (if any) that does not describe the content
+// about to be written, e.g. metadata of the previous version. Never modifies the map in place
+// (as it may be shared with the cached metadata of the current version).
+func (lom *LOM) ClearAtRest() {
+	var (
+		md = lom.GetCustomMD()
+		n  int
+	)
+	for k := range md {
+		if cmn.IsAtRestObjMD(k) {
+			n++
+		}
+	}
+	if n == 0 {
+		return
+	}
+	nmd := make(cos.StrKVs, len(md)-n)
+	for k, v := range md {
+		if !cmn.IsAtRestObjMD(k) {
+			nmd[k] = v
+		}
+	}
+	lom.SetCustomMD(nmd)
+}
+
+// InitSSE generates a new data key and nonce, wraps the key with the configured master key,
+// and stores the result in the object's custom metadata (to be persisted with the object)
+func (lom *LOM) InitSSE(conf *cmn.SSEConf) (*sse.Params, error) {
+	kp, err := sse.GetProvider(conf.Provider)
+	if err != nil {
+		return nil, err
+	}
+	params, err := sse.NewParams()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, kid, err := kp.WrapKey(conf.KeyID, params.Key)
+	if err != nil {
+		return nil, err
+	}
+	lom.ClearAtRest()
+	lom.SetCustomKey(cmn.SSEKeyObjMD, base64.StdEncoding.EncodeToString(wrapped))
+	lom.SetCustomKey(cmn.SSEKeyIDObjMD, kid)
+	lom.SetCustomKey(cmn.SSEProviderObjMD, kp.Name())
+	lom.SetCustomKey(cmn.SSENonceObjMD, base64.StdEncoding.EncodeToString(params.Nonce))
+	return params, nil
+}
+
+// InitSSEC is the SSE-C variant of InitSSE: customer-provided key is used as the data key
+// as is; only its MD5 gets stored
+func (lom *LOM) InitSSEC(ckey []byte) (*sse.Params, error) {
+	params, err := sse.NewCustomerParams(ckey)
+	if err != nil {
+		return nil, err
+	}
+	lom.ClearAtRest()
+	lom.SetCustomKey(cmn.SSECKeyMD5ObjMD, sse.KeyMD5(ckey))
+	lom.SetCustomKey(cmn.SSEProviderObjMD, sse.ProviderCustomer)
+	lom.SetCustomKey(cmn.SSENonceObjMD, base64.StdEncoding.EncodeToString(params.Nonce))
+	return params, nil
+}
+
+// CheckSSEC validates customer-provided key (nil if not provided) against the object:
+// SSE-C object requires the matching key, other objects require none
+func (lom *LOM) CheckSSEC(ckey []byte) error {
+	md5, ok := lom.GetCustomKey(cmn.SSECKeyMD5ObjMD)
+	switch {
+	case ok && ckey == nil:
+		return cmn.NewErrSSECKey(lom.Cname(), "object is encrypted with a customer-provided key that must be specified",
+			http.StatusBadRequest)
+	case ok && sse.KeyMD5(ckey) != md5:
+		return cmn.NewErrSSECKey(lom.Cname(), "customer-provided key does not match", http.StatusForbidden)
+	case !ok && ckey != nil:
+		return cmn.NewErrSSECKey(lom.Cname(), "object is not encrypted with a customer-provided key",
+			http.StatusBadRequest)
+	}
+	return nil
+}
+
+// SSEParams returns the (unwrapped or customer-provided) data key and nonce of an encrypted object
+func (lom *LOM) SSEParams(ckey []byte) (*sse.Params, error) {
+	var (
+		md       = lom.GetCustomMD()
+		provider = md[cmn.SSEProviderObjMD]
+	)
+	nonce, err := base64.StdEncoding.DecodeString(md[cmn.SSENonceObjMD])
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid %s: %w", lom.Cname(), cmn.SSENonceObjMD, err)
+	}
+	if provider == sse.ProviderCustomer {
+		if err := lom.CheckSSEC(ckey); err != nil {
+			return nil, err
+		}
+		return &sse.Params{Key: ckey, Nonce: nonce}, nil
+	}
+	kp, err := sse.GetProvider(provider)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", lom.Cname(), err)
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(md[cmn.SSEKeyObjMD])
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid %s: %w", lom.Cname(), cmn.SSEKeyObjMD, err)
+	}
+	dk, err := kp.UnwrapKey(md[cmn.SSEKeyIDObjMD], wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", lom.Cname(), err)
+	}
+	return &sse.Params{Key: dk, Nonce: nonce}, nil
+}
+
+// NewDecryptor wraps the (open) object's file handle to read plaintext
+// (`ckey`: customer-provided key, if any); closes the handle on error
+func (lom *LOM) NewDecryptor(fh cos.LomReader, ckey []byte) (cos.LomReader, error) {
+	params, err := lom.SSEParams(ckey)
+	if err != nil {
+		cos.Close(fh)
+		return nil, err
+	}
+	r, err := sse.NewReader(fh, params, lom.PlainSize())
+	if err != nil {
+		cos.Close(fh)
+		return nil, err
+	}
+	return r, nil
+}
+
+// OpenPlain opens the object for reading its (decrypted or decompressed, if need be) content
+// (compare with lom.Open)
+func (lom *LOM) OpenPlain() (cos.LomReader, error) { return lom.OpenPlainWith(nil) }
+
+// (ditto) with customer-provided key
+func (lom *LOM) OpenPlainWith(ckey []byte) (cos.LomReader, error) {
+	if ckey != nil {
+		if err := lom.CheckSSEC(ckey); err != nil {
+			return nil, err
+		}
+	}
+	fh, err := lom.Open()
+	if err != nil || !lom.IsEncoded() {
+		return fh, err
+	}
+	return lom.NewPlainReader(fh, ckey)
+}
+
+// CopyPlain copies the object's (decrypted or decompressed) content to a new file `dst`
+// (compare with cos.CopyFile)
+func (lom *LOM) CopyPlain(dst string, buf []byte, cksumType string) (written int64, cksum *cos.CksumHash, err error) {
+	var (
+		src cos.LomReader
+		dfh *os.File
+	)
+	if src, err = lom.OpenPlain(); err != nil {
+		return 0, nil, err
+	}
+	if dfh, err = lom._cf(dst); err != nil {
+		cos.Close(src)
+		return 0, nil, err
+	}
+	written, cksum, err = cos.CopyAndChecksum(dfh, src, buf, cksumType)
+	cos.Close(src)
+	if erc := dfh.Close(); erc != nil && err == nil {
+		err = erc
+	}
+	if err != nil {
+		cos.RemoveFile(dst)
+	}
+	return written, cksum, err
+}
+
+//
+// plaintext data provider (see LDP.Reader)
+//
+
+type plainROC struct {
+	cos.LomReader
+	params *sse.Params // when encrypted
+	dd     *dedupStore // when deduplicated (compressed otherwise)
+	fqn    string
+	size   int64 // plaintext size
+	lsize  int64 // size at rest
+	lif    LIF
+	unlock bool
+}
+
+// NewPlainDeferROC is the plaintext variant of NewDeferROC that also returns plaintext attributes;
+// is called under rlock; unlocks on fail and otherwise keeps the lock until Close
+func (lom *LOM) NewPlainDeferROC() (cos.ReadOpenCloser, cos.OAH, error) {
+	r, err := lom.openPlainROC()
+	if err != nil {
+		lom.Unlock(false)
+		return nil, nil, err
+	}
+	r.lif, r.unlock = lom.LIF(), true
+	return r, lom.PlainAttrs(), nil
+}
+
+func (lom *LOM) openPlainROC() (*plainROC, error) {
+	var (
+		params *sse.Params
+		dd     *dedupStore
+		err    error
+	)
+	switch {
+	case lom.IsEncrypted():
+		if params, err = lom.SSEParams(nil); err != nil {
+			return nil, err
+		}
+	case lom.IsDeduped():
+		dd = &dedupStore{bck: *lom.Bucket()}
+	}
+	r, err := _openPlain(lom.FQN, params, dd, lom.PlainSize(), lom.Lsize())
+	if err != nil {
+		return nil, cmn.NewErrFailedTo(T, "open", lom.Cname(), err)
+	}
+	return r, nil
+}
+
+// PlainAttrs returns the object's attributes as seen by users: plaintext size,
+// no checksum, and no SSE, compression, or dedup metadata
+func (lom *LOM) PlainAttrs() *cmn.ObjAttrs {
+	oa := &cmn.ObjAttrs{}
+	oa.CopyFrom(lom, true /*skip cksum*/)
+	oa.Cksum = cos.NoneCksum
+	oa.Size = lom.PlainSize()
+	for k := range oa.CustomMD {
+		if cmn.IsAtRestObjMD(k) {
+			oa.DelCustomKey(k)
+		}
+	}
+	return oa
+}
+
+func _openPlain(fqn string, params *sse.Params, dd *dedupStore, size, lsize int64) (*plainROC, error) {
+	var r cos.LomReader
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case params != nil:
+		r, err = sse.NewReader(fh, params, size)
+	case dd != nil:
+		var rcp *dedup.Recipe
+		if rcp, err = dedup.ReadRecipe(fh, lsize); err == nil {
+			fh.Close()
+			r = dedup.NewReader(rcp, dd)
+		}
+	default:
+		r, err = compr.NewReader(fh, lsize)
+	}
+	if err != nil {
+		fh.Close()
+		return nil, err
+	}
+	return &plainROC{LomReader: r, params: params, dd: dd, fqn: fqn, size: size, lsize: lsize}, nil
+}
+
+func (r *plainROC) Open() (cos.ReadOpenCloser, error) {
+	return _openPlain(r.fqn, r.params, r.dd, r.size, r.lsize)
+}
+
+func (r *plainROC) Close() (err error) {
+	err = r.LomReader.Close()
+	if r.unlock {
+		r.lif.Unlock(false)
+		r.unlock = false
+	}
+	return err
+}
@@ -0,0 +1,440 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Noncurrent object versions (ais:// buckets with versioning enabled and `versioning.retain` > 0):
+// - when an object is overwritten or deleted, its current version is moved (renamed) into the
+//   fs.VersionType content directory on the same mountpath; the object's metadata (xattr) moves
+//   along with it;
+// - deleting an object creates a delete marker: an empty "version" with `cmn.DeleteMarkerObjMD` set;
+// - version IDs are numeric and monotonic (compare with IncVersion);
+// - at most `retain` noncurrent versions are kept - the oldest get removed first;
+// - noncurrent versions are neither mirrored nor erasure coded;
+// - noncurrent versions always reside on the object's HRW mountpath and target: resilver moves
+//   them (MoveVersion) and global rebalance migrates them (PutVersion) along with the object.
+
+type ObjVersion struct {
+	Version      string
+	Cksum        *cos.Cksum
+	Mtime        time.Time
+	Size         int64
+	DeleteMarker bool
+}
+
+// returns the configured number of noncurrent versions to retain (0 - none)
+func (lom *LOM) RetainVersions() int {
+	if !lom.Bck().IsAIS() {
+		return 0
+	}
+	if vconf := lom.VersionConf(); vconf.Enabled {
+		return vconf.Retain
+	}
+	return 0
+}
+
+func (lom *LOM) VersionFQN(ver string) string { return fs.CSM.Gen(lom, fs.VersionType, ver) }
+
+func (lom *LOM) IsDeleteMarker() bool {
+	v, ok := lom.GetCustomKey(cmn.DeleteMarkerObjMD)
+	return ok && v == "true"
+}
+
+// NoncurrentSince returns the time when a given (loaded) noncurrent version became noncurrent
+func (lom *LOM) NoncurrentSince() time.Time {
+	if s, ok := lom.GetCustomKey(cmn.NoncurrentObjMD); ok {
+		if ns, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return time.Unix(0, ns)
+		}
+	}
+	_, _, mtime, _ := lom.Fstat(false)
+	return mtime
+}
+
+// (compare with Load)
+func (lom *LOM) fromFS() error {
+	if err := lom.FromFS(); err != nil {
+		return err
+	}
+	lom.setbid(lom.Bprops().BID)
+	return nil
+}
+
+// noncurrent version IDs, newest first
+func (lom *LOM) versionIDs() ([]int64, error) {
+	dents, err := os.ReadDir(filepath.Dir(lom.VersionFQN("0")))
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return nil, err
+	}
+	ids := make([]int64, 0, len(dents))
+	for _, de := range dents {
+		if de.IsDir() {
+			continue
+		}
+		if id, err := strconv.ParseInt(de.Name(), 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b int64) int { return cmp.Compare(b, a) })
+	return ids, nil
+}
+
+// LoadVersion returns read-only LOM of a given noncurrent version; the caller must FreeLOM
+// (never cache or persist it)
+func (lom *LOM) LoadVersion(ver string) (*LOM, error) {
+	if _, err := strconv.ParseInt(ver, 10, 64); err != nil {
+		return nil, cos.NewErrNotFound(T, lom.Cname()+" version "+ver)
+	}
+	v := AllocLOM(lom.ObjName)
+	if err := v.InitBck(lom.Bucket()); err != nil {
+		FreeLOM(v)
+		return nil, err
+	}
+	v.FQN = lom.VersionFQN(ver)
+	if err := v.fromFS(); err != nil {
+		FreeLOM(v)
+		if os.IsNotExist(err) {
+			err = cos.NewErrNotFound(T, lom.Cname()+" version "+ver)
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+// ListVersions returns all noncurrent versions, newest first
+func (lom *LOM) ListVersions() ([]*ObjVersion, error) {
+	ids, err := lom.versionIDs()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	vers := make([]*ObjVersion, 0, len(ids))
+	for _, id := range ids {
+		v, err := lom.LoadVersion(strconv.FormatInt(id, 10))
+		if err != nil {
+			nlog.Warningln(err)
+			continue
+		}
+		_, _, mtime, _ := v.Fstat(false)
+		vers = append(vers, &ObjVersion{
+			Version:      v.Version(),
+			Cksum:        v.Checksum(),
+			Mtime:        mtime,
+			Size:         v.PlainSize(),
+			DeleteMarker: v.IsDeleteMarker(),
+		})
+		FreeLOM(v)
+	}
+	return vers, nil
+}
+
+// ArchiveVersion moves the current version of the object (if exists) to noncurrent
+// and returns the next version to use.
+// - caller must hold the write lock;
+// - in-memory metadata of the `lom` itself is not used and remains intact.
+func (lom *LOM) ArchiveVersion() (next string, err error) {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	ids, err := lom.versionIDs()
+	if err != nil {
+		return "", err
+	}
+	var latest int64
+	if len(ids) > 0 {
+		latest = ids[0]
+	}
+
+	cur := AllocLOM(lom.ObjName)
+	defer FreeLOM(cur)
+	if err := cur.InitBck(lom.Bucket()); err != nil {
+		return "", err
+	}
+	cur.Uncache()
+	if err := cur.fromFS(); err != nil {
+		if cos.IsNotExist(err, 0) {
+			return strconv.FormatInt(latest+1, 10), nil
+		}
+		return "", err
+	}
+	id, errV := strconv.ParseInt(cur.Version(), 10, 64)
+	if errV != nil || id <= latest {
+		id = latest + 1
+	}
+	ver := strconv.FormatInt(id, 10)
+	vfqn := lom.VersionFQN(ver)
+	if err := cur.mvVersion(cur.FQN, vfqn, nil); err != nil {
+		return "", err
+	}
+	for copyFQN := range cur.md.copies {
+		if copyFQN == cur.FQN {
+			continue
+		}
+		if err := cos.RemoveFile(copyFQN); err != nil {
+			nlog.Errorln(err)
+		}
+	}
+	cur.md.copies = nil
+	cur.SetVersion(ver)
+	cur.SetCustomKey(cmn.NoncurrentObjMD, strconv.FormatInt(time.Now().UnixNano(), 10))
+	buf := cur.pack()
+	err = fs.SetXattr(vfqn, XattrLOM, buf)
+	g.smm.Free(buf)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(id+1, 10), nil
+}
+
+// PutDeleteMarker creates delete marker with a given version (see ArchiveVersion)
+func (lom *LOM) PutDeleteMarker(ver string) error {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	m := AllocLOM(lom.ObjName)
+	defer FreeLOM(m)
+	if err := m.InitBck(lom.Bucket()); err != nil {
+		return err
+	}
+	m.FQN = lom.VersionFQN(ver)
+	fh, err := m._cf(m.FQN)
+	if err != nil {
+		return err
+	}
+	cos.Close(fh)
+	m.SetSize(0)
+	m.SetCksum(cos.NoneCksum)
+	m.SetVersion(ver)
+	m.SetCustomKey(cmn.DeleteMarkerObjMD, "true")
+	m.SetCustomKey(cmn.NoncurrentObjMD, strconv.FormatInt(time.Now().UnixNano(), 10))
+	buf := m.pack()
+	err = fs.SetXattr(m.FQN, XattrLOM, buf)
+	g.smm.Free(buf)
+	if err != nil {
+		cos.RemoveFile(m.FQN)
+	}
+	return err
+}
+
+// DelCurrent "deletes" the object by moving its current version to noncurrent
+// and adding a delete marker on top (compare with RemoveObj)
+// - caller must hold the write lock.
+func (lom *LOM) DelCurrent(retain int) error {
+	next, err := lom.ArchiveVersion()
+	if err != nil {
+		return err
+	}
+	lom.Uncache()
+	lom.md.lid = 0
+	if err := lom.PutDeleteMarker(next); err != nil {
+		return err
+	}
+	lom.TrimVersions(retain)
+	return nil
+}
+
+// TrimVersions removes the oldest noncurrent versions in excess of `retain`
+// (skipping locked ones, if any - see cmn/objlock.go)
+func (lom *LOM) TrimVersions(retain int) (n int) {
+	ids, err := lom.versionIDs()
+	if err != nil {
+		nlog.Errorln(lom.Cname(), err)
+		return 0
+	}
+	objLock := lom.Bprops().ObjLock.Enabled
+	for i := retain; i < len(ids); i++ {
+		ver := strconv.FormatInt(ids[i], 10)
+		if objLock {
+			if v, err := lom.LoadVersion(ver); err == nil {
+				locked := v.IsObjLocked()
+				FreeLOM(v)
+				if locked {
+					continue
+				}
+			}
+		}
+		if err := cos.RemoveFile(lom.VersionFQN(ver)); err != nil {
+			nlog.Errorln(err)
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// DelVersion permanently deletes a given version, current or noncurrent.
+// If there's no current version afterwards, the latest noncurrent one becomes current
+// unless it is a delete marker.
+// Locked versions (see cmn/objlock.go) cannot be deleted unless governance-mode retention is bypassed.
+// - caller must hold the write lock.
+func (lom *LOM) DelVersion(ver string, bypass bool) error {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	cur := AllocLOM(lom.ObjName)
+	defer FreeLOM(cur)
+	if err := cur.InitBck(lom.Bucket()); err != nil {
+		return err
+	}
+	cur.Uncache()
+	errCur := cur.fromFS()
+	switch {
+	case errCur == nil && cur.Version() == ver:
+		if err := cur.CheckLock(bypass); err != nil {
+			return err
+		}
+		if err := cur.RemoveObj(); err != nil {
+			return err
+		}
+	default:
+		v, err := lom.LoadVersion(ver)
+		if err != nil {
+			return err
+		}
+		if err = v.CheckLock(bypass); err == nil {
+			err = cos.RemoveFile(v.FQN)
+		}
+		FreeLOM(v)
+		if err != nil {
+			return err
+		}
+		if errCur == nil {
+			return nil
+		}
+	}
+	return lom.restoreLatest(cur)
+}
+
+func (lom *LOM) restoreLatest(cur *LOM) error {
+	ids, err := lom.versionIDs()
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+	v, err := lom.LoadVersion(strconv.FormatInt(ids[0], 10))
+	if err != nil {
+		return err
+	}
+	defer FreeLOM(v)
+	if v.IsDeleteMarker() {
+		return nil
+	}
+	if err := cur.mvVersion(v.FQN, cur.FQN, nil); err != nil {
+		return err
+	}
+	cur.md = v.md
+	cur.md.copies = nil
+	cur.setbid(cur.Bprops().BID)
+	cur.DelCustomKey(cmn.NoncurrentObjMD)
+	return cur.PersistMain()
+}
+
+// MoveVersion moves a given noncurrent version from another (non-HRW) mountpath (resilver)
+// - caller must hold the write lock.
+func (lom *LOM) MoveVersion(srcFQN, ver string, buf []byte) error {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	vfqn := lom.VersionFQN(ver)
+	if srcFQN == vfqn {
+		return nil
+	}
+	if err := cos.Stat(vfqn); err == nil {
+		// same version ID, same content
+		return cos.RemoveFile(srcFQN)
+	}
+	return lom.mvVersion(srcFQN, vfqn, buf)
+}
+
+// PutVersion stores noncurrent version received from another target (rebalance);
+// the version ID is `oa.Version()`, and an existing version with the same ID is kept as is.
+// - caller must hold the write lock.
+func (lom *LOM) PutVersion(oa *cmn.ObjAttrs, r io.Reader, buf []byte) error {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	ver := oa.Version()
+	if _, err := strconv.ParseInt(ver, 10, 64); err != nil {
+		return fmt.Errorf("%s: invalid noncurrent version %q", lom.Cname(), ver)
+	}
+	vfqn := lom.VersionFQN(ver)
+	if err := cos.Stat(vfqn); err == nil {
+		return nil
+	}
+	v := AllocLOM(lom.ObjName)
+	defer FreeLOM(v)
+	if err := v.InitBck(lom.Bucket()); err != nil {
+		return err
+	}
+	if buf == nil {
+		b, slab := g.pmm.Alloc()
+		defer slab.Free(b)
+		buf = b
+	}
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileVersion)
+	if _, err := cos.SaveReader(workFQN, r, buf, cos.ChecksumNone, oa.Size); err != nil {
+		cos.RemoveFile(workFQN)
+		return err
+	}
+	v.CopyAttrs(oa, false /*skip cksum*/)
+	v.setbid(v.Bprops().BID)
+	if err := v.setXattrRename(workFQN, vfqn); err != nil {
+		cos.RemoveFile(workFQN)
+		return err
+	}
+	return nil
+}
+
+// renames noncurrent (or restored) version along with its metadata; across filesystems
+// (mountpaths), copies the content and the metadata, and removes the source
+func (lom *LOM) mvVersion(src, dst string, buf []byte) error {
+	err := cos.Rename(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	v := AllocLOM(lom.ObjName)
+	defer FreeLOM(v)
+	if err := v.InitBck(lom.Bucket()); err != nil {
+		return err
+	}
+	v.FQN = src
+	if err := v.fromFS(); err != nil {
+		return err
+	}
+	if buf == nil {
+		b, slab := g.pmm.Alloc()
+		defer slab.Free(b)
+		buf = b
+	}
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileVersion)
+	if _, _, err := cos.CopyFile(src, workFQN, buf, cos.ChecksumNone); err != nil {
+		cos.RemoveFile(workFQN)
+		return err
+	}
+	if err := v.setXattrRename(workFQN, dst); err != nil {
+		cos.RemoveFile(workFQN)
+		return err
+	}
+	return cos.RemoveFile(src)
+}
+
+func (lom *LOM) setXattrRename(workFQN, dst string) error {
+	md := lom.pack()
+	err := fs.SetXattr(workFQN, XattrLOM, md)
+	g.smm.Free(md)
+	if err != nil {
+		return err
+	}
+	return cos.Rename(workFQN, dst)
+}
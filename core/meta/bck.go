@@ -49,6 +49,7 @@ func (b *Bck) Bucket() *cmn.Bck { return (*cmn.Bck)(b) }
 func (b *Bck) IsAIS() bool                  { return (*cmn.Bck)(b).IsAIS() }
 func (b *Bck) HasProvider() bool            { return (*cmn.Bck)(b).HasProvider() }
 func (b *Bck) IsHT() bool                   { return (*cmn.Bck)(b).IsHT() }
+func (b *Bck) IsListableHT() bool           { return (*cmn.Bck)(b).IsListableHT() }
 func (b *Bck) IsCloud() bool                { return (*cmn.Bck)(b).IsCloud() }
 func (b *Bck) IsRemote() bool               { return (*cmn.Bck)(b).IsRemote() }
 func (b *Bck) IsRemoteAIS() bool            { return (*cmn.Bck)(b).IsRemoteAIS() }
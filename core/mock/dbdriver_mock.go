@@ -85,7 +85,7 @@ func (bd *DBDriver) List(collection, pattern string) ([]string, error) {
 		if strings.HasPrefix(k, filter) {
 			_, key := kvdb.ParsePath(k)
 			if key != "" {
-				keys = append(keys, k)
+				keys = append(keys, key)
 			}
 		}
 	}
@@ -100,8 +100,8 @@ func (bd *DBDriver) DeleteCollection(collection string) error {
 	if err != nil || len(keys) == 0 {
 		return err
 	}
-	for _, k := range keys {
-		delete(bd.values, k)
+	for _, key := range keys {
+		delete(bd.values, bd.makePath(collection, key))
 	}
 	return nil
 }
@@ -107,6 +107,9 @@ type (
 		EvictObject(lom *LOM) (ecode int, err error)
 		DeleteObject(lom *LOM, evict bool) (ecode int, err error)
 
+		// bucket event notifications (see cmn/events.go)
+		ObjEvent(lom *LOM, event string)
+
 		GetCold(ctx context.Context, lom *LOM, owt cmn.OWT) (ecode int, err error)
 
 		HeadCold(lom *LOM, origReq *http.Request) (objAttrs *cmn.ObjAttrs, ecode int, err error)
@@ -61,6 +61,8 @@ cat > $AIS_CONF_FILE <<EOL
 	"lru": {
 		"dont_evict_time":   "120m",
 		"capacity_upd_time": "10m",
+		"policy":            "lru",
+		"ttl":               "0s",
 		"enabled":           true
 	},
 	"disk":{
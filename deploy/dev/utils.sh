@@ -38,7 +38,7 @@ run_cmd() {
 # 3. when adding/deleting backends, update the 3 (three) functions that follow below:
 
 set_env_backends() {
-  known_backends=( aws gcp azure ht )
+  known_backends=( aws gcp azure ht file )
   if [[ ! -z $TAGS ]]; then
     ## environment var TAGS may contain any/all build tags, including backends
     for b in "${known_backends[@]}"; do
@@ -64,6 +64,7 @@ set_env_backends() {
         azure) ;;
         gcp)   ;;
         ht)    ;;
+        file)  ;;
 	*) echo "fatal: unknown backend '$b' in 'AIS_BACKEND_PROVIDERS=${AIS_BACKEND_PROVIDERS}'"; exit 1;;
       esac
     done
@@ -117,6 +118,7 @@ make_backend_conf() {
       azure) backend_conf+=('"azure": {}') ;;
       gcp)   backend_conf+=('"gcp":   {}') ;;
       ht)    backend_conf+=('"ht":    {}') ;;
+      file)  backend_conf+=("\"file\":  {\"root\": \"${AIS_FILE_BACKEND_ROOT:-/tmp/ais-file}\"}") ;;
     esac
   done
   echo {$(IFS=$','; echo "${backend_conf[*]}")}
@@ -141,7 +141,9 @@ func (c *getJogger) ec(req *request) {
 	}
 	if err == nil {
 		c.parent.stats.updateObjTime(time.Since(req.putTime))
-		err = ctx.lom.Persist()
+		if err = ctx.lom.Persist(); err == nil {
+			core.T.ObjEvent(ctx.lom, cmn.EvObjCreatedRestore)
+		}
 	}
 	c.freeCtx(ctx)
 	c.finalizeReq(req, err)
@@ -246,6 +248,9 @@ func (c *getJogger) restoreReplicaFromMem(ctx *restoreCtx) error {
 	}
 
 	ctx.lom.SetSize(writer.Size())
+	if len(ctx.meta.CustomMD) > 0 {
+		ctx.lom.SetCustomMD(ctx.meta.CustomMD) // e.g., encrypted at rest
+	}
 	args := &WriteArgs{
 		Reader:     memsys.NewReader(writer),
 		MD:         ctx.meta.NewPack(),
@@ -612,6 +617,9 @@ func (c *getJogger) restoreMainObj(ctx *restoreCtx) ([]*slice, error) {
 		ctx.lom.SetVersion(version)
 	}
 	ctx.lom.SetSize(ctx.meta.Size)
+	if len(ctx.meta.CustomMD) > 0 {
+		ctx.lom.SetCustomMD(ctx.meta.CustomMD) // (ditto)
+	}
 	mainMeta := *ctx.meta
 	mainMeta.SliceID = 0
 	args := &WriteArgs{
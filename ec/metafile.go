@@ -16,7 +16,11 @@ import (
 	"github.com/OneOfOne/xxhash"
 )
 
-const MDVersionLast = 1 // current version of metadata
+// metadata format versions
+const (
+	mdVersionV1   = 1
+	MDVersionLast = 2 // current version of metadata (v2: object's custom metadata)
+)
 
 // Metadata - EC information stored in metafiles for every encoded object
 type Metadata struct {
@@ -28,6 +32,7 @@ type Metadata struct {
 	CksumValue  string           `json:"slice_cksum"`   // slice checksum of the slice if EC is used
 	FullReplica string           `json:"replica_node"`  // daemon ID where full(main) replica is
 	Daemons     cos.MapStrUint16 `json:"nodes"`         // Locations of all slices: DaemonID <-> SliceID
+	CustomMD    cos.StrKVs       `json:"custom_md"`     // object's custom metadata (e.g., to decrypt restored content)
 	Data        int              `json:"data_slices"`   // the number of data slices
 	Parity      int              `json:"parity_slices"` // the number of parity slices
 	SliceID     int              `json:"slice_id"`      // 0 for full replica, 1 to N for slices
@@ -125,10 +130,14 @@ func (md *Metadata) Unpack(unpacker *cos.ByteUnpack) (err error) {
 	}
 	switch md.MDVersion {
 	case MDVersionLast:
-		err = md.unpackLastVersion(unpacker)
+		if err = md.unpackV1(unpacker); err == nil {
+			err = md.unpackCustom(unpacker)
+		}
+	case mdVersionV1:
+		err = md.unpackV1(unpacker)
 	default:
-		err = fmt.Errorf("unsupported metadata format version %d. Only %d supported",
-			md.MDVersion, MDVersionLast)
+		err = fmt.Errorf("unsupported metadata format version %d. Only %d and %d supported",
+			md.MDVersion, mdVersionV1, MDVersionLast)
 	}
 	if err != nil {
 		return
@@ -145,7 +154,7 @@ func (md *Metadata) Unpack(unpacker *cos.ByteUnpack) (err error) {
 	return err
 }
 
-func (md *Metadata) unpackLastVersion(unpacker *cos.ByteUnpack) (err error) {
+func (md *Metadata) unpackV1(unpacker *cos.ByteUnpack) (err error) {
 	var i16 uint16
 	if md.Generation, err = unpacker.ReadInt64(); err != nil {
 		return
@@ -187,8 +196,28 @@ func (md *Metadata) unpackLastVersion(unpacker *cos.ByteUnpack) (err error) {
 	return
 }
 
+func (md *Metadata) unpackCustom(unpacker *cos.ByteUnpack) error {
+	n, err := unpacker.ReadUint16()
+	if err != nil || n == 0 {
+		return err
+	}
+	md.CustomMD = make(cos.StrKVs, n)
+	for range n {
+		var k, v string
+		if k, err = unpacker.ReadString(); err != nil {
+			return err
+		}
+		if v, err = unpacker.ReadString(); err != nil {
+			return err
+		}
+		md.CustomMD[k] = v
+	}
+	return nil
+}
+
+// always packs the current version
 func (md *Metadata) Pack(packer *cos.BytePack) {
-	packer.WriteUint32(md.MDVersion)
+	packer.WriteUint32(MDVersionLast)
 	packer.WriteInt64(md.Generation)
 	packer.WriteInt64(md.Size)
 	packer.WriteUint16(uint16(md.Data))
@@ -201,6 +230,11 @@ func (md *Metadata) Pack(packer *cos.BytePack) {
 	packer.WriteString(md.CksumType)
 	packer.WriteString(md.CksumValue)
 	packer.WriteMapStrUint16(md.Daemons)
+	packer.WriteUint16(uint16(len(md.CustomMD)))
+	for k, v := range md.CustomMD {
+		packer.WriteString(k)
+		packer.WriteString(v)
+	}
 	h := xxhash.Checksum64S(packer.Bytes(), cos.MLCG32)
 	packer.WriteUint64(h)
 }
@@ -210,8 +244,12 @@ func (md *Metadata) PackedSize() int {
 	for k := range md.Daemons {
 		daemonListSz += cos.PackedStrLen(k) + cos.SizeofI16
 	}
+	customSz := cos.SizeofI16
+	for k, v := range md.CustomMD {
+		customSz += cos.PackedStrLen(k) + cos.PackedStrLen(v)
+	}
 	return cos.SizeofI32 + cos.SizeofI64*2 + cos.SizeofI16*3 + 1 /*isCopy*/ +
 		cos.PackedStrLen(md.ObjCksum) + cos.PackedStrLen(md.ObjVersion) +
 		cos.PackedStrLen(md.CksumType) + cos.PackedStrLen(md.CksumValue) +
-		cos.PackedStrLen(md.FullReplica) + daemonListSz + cos.SizeofI64 /*md cksum*/
+		cos.PackedStrLen(md.FullReplica) + daemonListSz + customSz + cos.SizeofI64 /*md cksum*/
 }
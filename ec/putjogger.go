@@ -247,6 +247,7 @@ func (c *putJogger) encode(req *request, lom *core.LOM) error {
 		CksumType:   cksumType,
 		FullReplica: core.T.SID(),
 		Daemons:     make(cos.MapStrUint16, reqTargets),
+		CustomMD:    lom.GetCustomMD(),
 	}
 
 	c.parent.LomAdd(lom)
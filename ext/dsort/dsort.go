@@ -370,15 +370,29 @@ func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 			goto exit
 		}
 
-		file, err := cos.NewFileHandle(lom.FQN)
-		if err != nil {
-			return err
+		var (
+			file  cos.ReadOpenCloser
+			attrs = cmn.ObjAttrs{Size: lom.Lsize(), Cksum: lom.Checksum()}
+		)
+		if lom.IsEncoded() {
+			// sending plaintext (to be encrypted, or not, by the receiver)
+			r, err := lom.OpenPlain()
+			if err != nil {
+				return err
+			}
+			file, attrs = cos.NopOpener(r), cmn.ObjAttrs{Size: lom.PlainSize(), Cksum: cos.NoneCksum}
+		} else {
+			fh, err := cos.NewFileHandle(lom.FQN)
+			if err != nil {
+				return err
+			}
+			file = fh
 		}
 
 		o := transport.AllocSend()
 		o.Hdr = transport.ObjHdr{
 			ObjName:  shardName,
-			ObjAttrs: cmn.ObjAttrs{Size: lom.Lsize(), Cksum: lom.Checksum()},
+			ObjAttrs: attrs,
 		}
 		o.Hdr.Bck.Copy(lom.Bucket())
 
@@ -1000,20 +1014,24 @@ func (es *extractShard) _do(lom *core.LOM) error {
 	}
 
 	lom.Lock(false)
-	fh, err := lom.Open()
+	if lom.IsEncoded() {
+		m.recm.MarkEncoded(lom.ObjName)
+	}
+	fh, err := lom.OpenPlain()
 	if err != nil {
 		phaseInfo.adjuster.releaseSema(lom.Mountpath())
 		lom.Unlock(false)
 		return errors.Errorf("unable to open %s: %v", lom.Cname(), err)
 	}
 
-	expectedExtractedSize := uint64(float64(lom.Lsize()) / m.compressionRatio())
+	size := lom.PlainSize()
+	expectedExtractedSize := uint64(float64(size) / m.compressionRatio())
 	toDisk := m.dsorter.preShardExtraction(expectedExtractedSize)
 
 	extractedSize, extractedCount, err := shardRW.Extract(lom, fh, m.recm, toDisk)
 	cos.Close(fh)
 
-	m.addSizes(lom.Lsize(), extractedSize) // update compression rate
+	m.addSizes(size, extractedSize) // update compression rate
 
 	phaseInfo.adjuster.releaseSema(lom.Mountpath())
 	lom.Unlock(false)
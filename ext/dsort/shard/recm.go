@@ -68,6 +68,7 @@ type (
 		keyExtractor    KeyExtractor
 		contents        *sync.Map
 		extractionPaths *sync.Map // Keys correspond to all paths to record contents on disk.
+		encoded         *sync.Map // names of encrypted or compressed (at rest) shards - see MarkEncoded
 
 		enqueued struct {
 			mu      sync.Mutex
@@ -89,9 +90,23 @@ func NewRecordManager(bck cmn.Bck, extractCreator RW, keyExtractor KeyExtractor,
 		keyExtractor:        keyExtractor,
 		contents:            &sync.Map{},
 		extractionPaths:     &sync.Map{},
+		encoded:             &sync.Map{},
 	}
 }
 
+// MarkEncoded is called prior to extracting a shard that is encrypted or compressed at rest:
+// offset-based records (see OffsetStoreType) would refer to the shard's content on disk
+// and are therefore not used
+func (recm *RecordManager) MarkEncoded(shardName string) { recm.encoded.Store(shardName, struct{}{}) }
+
+func (recm *RecordManager) supportsOffset(shardName string) bool {
+	if !recm.extractCreator.SupportsOffset() {
+		return false
+	}
+	_, encoded := recm.encoded.Load(shardName)
+	return !encoded
+}
+
 func (recm *RecordManager) RecordWithBuffer(args *extractRecordArgs) (size int64, err error) {
 	var (
 		storeType        string
@@ -139,7 +154,7 @@ func (recm *RecordManager) RecordWithBuffer(args *extractRecordArgs) (size int64
 			return size, errors.WithStack(err)
 		}
 		recm.contents.Store(fullContentPath, sgl)
-	case args.extractMethod.Has(ExtractToDisk) && recm.extractCreator.SupportsOffset():
+	case args.extractMethod.Has(ExtractToDisk) && recm.supportsOffset(args.shardName):
 		mdSize, size = recm.extractCreator.MetadataSize(), r.Size()
 		storeType = OffsetStoreType
 		contentPath, _ = recm.encodeRecordName(storeType, args.shardName, args.recordName)
@@ -305,9 +320,12 @@ func (recm *RecordManager) FreeMem(fullContentPath, newStoreType string, value a
 
 	debug.Assert(obj.StoreType == SGLStoreType, obj.StoreType+" vs "+SGLStoreType) // only SGLs are supported
 
+	shardName, _ := parseRecordUname(record.Name)
+	if newStoreType == OffsetStoreType && !recm.supportsOffset(shardName) {
+		newStoreType = DiskStoreType
+	}
 	switch newStoreType {
 	case OffsetStoreType:
-		shardName, _ := parseRecordUname(record.Name)
 		obj.ContentPath = shardName
 		obj.MetadataSize = recm.extractCreator.MetadataSize()
 	case DiskStoreType:
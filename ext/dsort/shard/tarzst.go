@@ -0,0 +1,59 @@
+// Package shard provides Extract(shard), Create(shard), and associated methods
+// across all suppported archival formats (see cmn/archive/mime.go)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package shard
+
+import (
+	"archive/tar"
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+)
+
+type tzstRW struct {
+	ext string
+}
+
+// interface guard
+var _ RW = (*tzstRW)(nil)
+
+func NewTarzstRW() RW { return &tzstRW{ext: archive.ExtTarZst} }
+
+func (*tzstRW) IsCompressed() bool   { return true }
+func (*tzstRW) SupportsOffset() bool { return true }
+func (*tzstRW) MetadataSize() int64  { return archive.TarBlockSize } // size of tar header with padding
+
+// Extract reads the tarball f and extracts its metadata.
+func (trw *tzstRW) Extract(lom *core.LOM, r cos.ReadReaderAt, extractor RecordExtractor, toDisk bool) (int64, int, error) {
+	ar, err := archive.NewReader(trw.ext, r)
+	if err != nil {
+		return 0, 0, err
+	}
+	c := &rcbCtx{parent: trw, extractor: extractor, shardName: lom.ObjName, toDisk: toDisk, fromTar: true}
+	err = c.extract(lom, ar)
+
+	return c.extractedSize, c.extractedCount, err
+}
+
+// create local shard based on Shard
+func (*tzstRW) Create(s *Shard, tarball io.Writer, loader ContentLoader) (written int64, err error) {
+	zw, err := archive.NewZstdWriter(tarball, 0 /*default level*/)
+	if err != nil {
+		return 0, err
+	}
+	var (
+		tw       = tar.NewWriter(zw)
+		rdReader = newTarRecordDataReader()
+	)
+	written, err = writeCompressedTar(s, tw, zw, loader, rdReader)
+
+	// note the order of closing: tw, zw, and eventually tarball (by the caller)
+	rdReader.free()
+	cos.Close(tw)
+	cos.Close(zw)
+	return written, err
+}
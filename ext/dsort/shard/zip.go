@@ -57,7 +57,7 @@ func (*zipRW) MetadataSize() int64  { return 0 } // zip does not have header siz
 
 // Extract reads the tarball f and extracts its metadata.
 func (zrw *zipRW) Extract(lom *core.LOM, r cos.ReadReaderAt, extractor RecordExtractor, toDisk bool) (int64, int, error) {
-	ar, err := archive.NewReader(zrw.ext, r, lom.Lsize())
+	ar, err := archive.NewReader(zrw.ext, r, lom.PlainSize())
 	if err != nil {
 		return 0, 0, err
 	}
@@ -234,7 +234,7 @@ func (pc *pushComm) do(lom *core.LOM, timeout time.Duration) (_ cos.ReadCloseSiz
 	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
 		return nil, 0, err
 	}
-	size := lom.Lsize()
+	size := lom.PlainSize()
 
 	switch pc.boot.msg.ArgTypeX {
 	case ArgTypeDefault, ArgTypeURL:
@@ -244,12 +244,15 @@ func (pc *pushComm) do(lom *core.LOM, timeout time.Duration) (_ cos.ReadCloseSiz
 		debug.Assert(lom.Bck().Ns.IsGlobal(), lom.Bck().Cname(""), " - bucket with namespace")
 		u = pc.boot.uri + "/" + lom.Bck().Name + "/" + lom.ObjName
 
-		fh, err := cos.NewFileHandle(lom.FQN)
+		fh, err := lom.OpenPlain()
 		if err != nil {
 			return nil, 0, err
 		}
 		body = fh
 	case ArgTypeFQN:
+		if lom.IsEncoded() {
+			return nil, 0, fmt.Errorf("%s: cannot transform encrypted or compressed %s via %q", pc, lom.Cname(), ArgTypeFQN)
+		}
 		body = http.NoBody
 		u = cos.JoinPath(pc.boot.uri, url.PathEscape(lom.FQN)) // compare w/ rc.redirectURL()
 	default:
@@ -465,7 +468,7 @@ func lomLoad(lom *core.LOM) (size int64, err error) {
 			err = nil // NOTE: size == 0
 		}
 	} else {
-		size = lom.Lsize()
+		size = lom.PlainSize()
 	}
 	return size, err
 }
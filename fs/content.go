@@ -38,8 +38,14 @@ const (
 	WorkfileType = "wk"
 	ECSliceType  = "ec"
 	ECMetaType   = "mt"
+	VersionType  = "vr" // noncurrent object versions (see core/lversion.go)
+	ArchIdxType  = "ai" // random-access index of a shard (see core/larchidx.go)
+	DedupType    = "dd" // deduplicated content chunks (see core/ldedup.go)
 )
 
+// noncurrent version `ver` of the object `name` is stored as "<name>~v/<ver>"
+const verDirSuffix = "~v"
+
 type (
 	ContentResolver interface {
 		// When set to true, services like rebalance have permission to move
@@ -177,6 +183,9 @@ type (
 	WorkfileContentResolver struct{}
 	ECSliceContentResolver  struct{}
 	ECMetaContentResolver   struct{}
+	VersionContentResolver  struct{}
+	ArchIdxContentResolver  struct{}
+	DedupContentResolver    struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -251,3 +260,51 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+// noncurrent versions follow the object (see core/lversion.go)
+func (*VersionContentResolver) PermToMove() bool    { return true }
+func (*VersionContentResolver) PermToEvict() bool   { return false }
+func (*VersionContentResolver) PermToProcess() bool { return false }
+
+func (*VersionContentResolver) GenUniqueFQN(base, ver string) string {
+	return base + verDirSuffix + string(filepath.Separator) + ver
+}
+
+// (the base is the version itself)
+func (*VersionContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+// ParseVersionName returns object name and version given the (parsed) name of its noncurrent version
+// (compare with VersionContentResolver.GenUniqueFQN)
+func ParseVersionName(name string) (objName, ver string, ok bool) {
+	dir, ver := filepath.Split(name)
+	if ver == "" || !strings.HasSuffix(dir, verDirSuffix+string(filepath.Separator)) {
+		return "", "", false
+	}
+	objName = dir[:len(dir)-len(verDirSuffix)-1]
+	return objName, ver, objName != ""
+}
+
+// shard indexes are rebuilt on demand: not moved and safe to evict
+func (*ArchIdxContentResolver) PermToMove() bool    { return false }
+func (*ArchIdxContentResolver) PermToEvict() bool   { return true }
+func (*ArchIdxContentResolver) PermToProcess() bool { return false }
+
+func (*ArchIdxContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*ArchIdxContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+// dedup chunks are target-local: resilvered (but not rebalanced) and removed only by storage cleanup
+// (when no longer referenced)
+func (*DedupContentResolver) PermToMove() bool    { return true }
+func (*DedupContentResolver) PermToEvict() bool   { return false }
+func (*DedupContentResolver) PermToProcess() bool { return false }
+
+func (*DedupContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*DedupContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
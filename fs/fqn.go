@@ -35,6 +35,10 @@ const (
 	WorkfileAppend       = "append"         // APPEND to object (as file)
 	WorkfileAppendToArch = "append-to-arch" // APPEND to existing archive
 	WorkfileCreateArch   = "create-arch"    // CREATE multi-object archive
+	WorkfileEncrypt      = "encrypt"        // encrypt object content at rest (see cmn/sse.go)
+	WorkfileCompress     = "compress"       // compress object content at rest (see cmn/compr.go)
+	WorkfileDedup        = "dedup"          // deduplicate object content (see cmn/dedup.go)
+	WorkfileVersion      = "version"        // move or migrate noncurrent version (see core/lversion.go)
 )
 
 type ParsedFQN struct {
@@ -21,6 +21,7 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/OneOfOne/xxhash"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -364,9 +365,9 @@ func (j *jogger) visitFQN(fqn string, buf []byte) error {
 		return err
 	}
 
-	if j.opts.SkipGloballyMisplaced {
+	if j.opts.SkipGloballyMisplaced && ct.ContentType() != fs.DedupType { // (dedup chunks are target-local)
 		smap := core.T.Sowner().Get()
-		tsi, err := smap.HrwHash2T(ct.Digest())
+		tsi, err := smap.HrwHash2T(ownerDigest(ct))
 		if err != nil {
 			return err
 		}
@@ -392,6 +393,16 @@ func (j *jogger) visitFQN(fqn string, buf []byte) error {
 	return nil
 }
 
+// noncurrent versions are placed along with their objects (see core/lversion.go)
+func ownerDigest(ct *core.CT) uint64 {
+	if ct.ContentType() == fs.VersionType {
+		if objName, _, ok := fs.ParseVersionName(ct.ObjectName()); ok {
+			return xxhash.Checksum64S(ct.Bck().MakeUname(objName), cos.MLCG32)
+		}
+	}
+	return ct.Digest()
+}
+
 func (j *jogger) visitObj(lom *core.LOM, buf []byte) (err error) {
 	switch j.opts.DoLoad {
 	case noLoad:
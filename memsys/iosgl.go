@@ -229,6 +229,12 @@ func (z *SGL) Read(b []byte) (n int, err error) {
 	return
 }
 
+// (io.ReaderAt; does not move read offset)
+func (z *SGL) ReadAt(b []byte, off int64) (n int, err error) {
+	n, _, err = z._readAt(b, off)
+	return
+}
+
 func (z *SGL) ReadByte() (byte, error) {
 	var (
 		b           [1]byte
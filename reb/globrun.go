@@ -623,9 +623,9 @@ func (reb *Reb) retransmit(rargs *rebArgs, xreb *xs.Rebalance) (cnt int) {
 				continue
 			}
 			// retransmit
-			roc, err := _getReader(lom)
+			roc, oah, err := _getReader(lom)
 			if err == nil {
-				err = rj.doSend(lom, tsi, roc)
+				err = rj.doSend(lom, oah, tsi, roc)
 			}
 			if err == nil {
 				if cmn.Rom.FastV(4, cos.SmoduleReb) {
@@ -732,7 +732,13 @@ func (rj *rebJogger) jog(mi *fs.Mountpath) {
 
 func (rj *rebJogger) walkBck(bck *meta.Bck) bool {
 	rj.opts.Bck.Copy(bck.Bucket())
+	rj.opts.CTs, rj.opts.Callback = []string{fs.ObjectType}, rj.visitObj
 	err := fs.Walk(&rj.opts)
+	if err == nil && bck.IsAIS() {
+		// noncurrent versions (including EC buckets - versions are not erasure coded)
+		rj.opts.CTs, rj.opts.Callback = []string{fs.VersionType}, rj.visitVersion
+		err = fs.Walk(&rj.opts)
+	}
 	if err == nil {
 		return rj.xreb.IsAborted()
 	}
@@ -809,14 +815,14 @@ func (rj *rebJogger) _lwalk(lom *core.LOM, fqn string) error {
 		return cmn.ErrSkip
 	}
 	// prepare to send: rlock, load, new roc
-	var roc cos.ReadOpenCloser
-	if roc, err = _getReader(lom); err != nil {
+	roc, oah, err := _getReader(lom)
+	if err != nil {
 		return err
 	}
 
 	// transmit (unlock via transport completion => roc.Close)
 	rj.m.addLomAck(lom)
-	if err := rj.doSend(lom, tsi, roc); err != nil {
+	if err := rj.doSend(lom, oah, tsi, roc); err != nil {
 		rj.m.cleanupLomAck(lom)
 		return err
 	}
@@ -825,7 +831,8 @@ func (rj *rebJogger) _lwalk(lom *core.LOM, fqn string) error {
 }
 
 // takes rlock and keeps it _iff_ successful
-func _getReader(lom *core.LOM) (roc cos.ReadOpenCloser, err error) {
+// (deduplicated objects are sent as plain content - dedup chunks are target-local)
+func _getReader(lom *core.LOM) (roc cos.ReadOpenCloser, oah cos.OAH, err error) {
 	lom.Lock(false)
 	if err = lom.Load(false /*cache it*/, true /*locked*/); err != nil {
 		lom.Unlock(false)
@@ -843,20 +850,111 @@ func _getReader(lom *core.LOM) (roc cos.ReadOpenCloser, err error) {
 		}
 	}
 	debug.Assert(lom.Checksum() != nil, lom.String())
-	return lom.NewDeferROC()
+	if lom.IsDeduped() {
+		return lom.NewPlainDeferROC()
+	}
+	roc, err = lom.NewDeferROC()
+	return roc, lom, err
 }
 
-func (rj *rebJogger) doSend(lom *core.LOM, tsi *meta.Snode, roc cos.ReadOpenCloser) error {
+func (rj *rebJogger) doSend(lom *core.LOM, oah cos.OAH, tsi *meta.Snode, roc cos.ReadOpenCloser) error {
 	var (
 		ack    = regularAck{rebID: rj.m.RebID(), daemonID: core.T.SID()}
 		o      = transport.AllocSend()
-		opaque = ack.NewPack()
+		opaque = ack.NewPack(rebMsgRegular)
 	)
 	debug.Assert(ack.rebID != 0)
 	o.Hdr.Bck.Copy(lom.Bucket())
 	o.Hdr.ObjName = lom.ObjName
 	o.Hdr.Opaque = opaque
-	o.Hdr.ObjAttrs.CopyFrom(lom.ObjAttrs(), false /*skip cksum*/)
+	o.Hdr.ObjAttrs.CopyFrom(oah, false /*skip cksum*/)
 	o.Callback, o.CmplArg = rj.objSentCallback, lom
 	return rj.m.dm.Send(o, roc, tsi)
 }
+
+//
+// noncurrent versions: migrate along with the object (see core/lversion.go)
+//
+
+func (rj *rebJogger) visitVersion(fqn string, de fs.DirEntry) error {
+	if err := rj.xreb.AbortErr(); err != nil {
+		nlog.Infoln(rj.xreb.Name(), "rj-walk-visit aborted", err)
+		return err
+	}
+	if de.IsDir() {
+		return nil
+	}
+	var parsed fs.ParsedFQN
+	if err := parsed.Init(fqn); err != nil {
+		return nil
+	}
+	objName, ver, ok := fs.ParseVersionName(parsed.ObjName)
+	if !ok {
+		return nil
+	}
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(&parsed.Bck); err != nil {
+		if cmn.IsErrBucketLevel(err) {
+			return err
+		}
+		return nil
+	}
+	tsi, err := rj.smap.HrwHash2T(lom.Digest())
+	if err != nil {
+		return err
+	}
+	if tsi.ID() == core.T.SID() {
+		return nil
+	}
+	return rj.sendVersion(lom, ver, tsi)
+}
+
+// rlock the object and keep it locked until the version is sent (unlock via roc.Close)
+func (rj *rebJogger) sendVersion(lom *core.LOM, ver string, tsi *meta.Snode) error {
+	lom.Lock(false)
+	v, err := lom.LoadVersion(ver)
+	if err != nil {
+		lom.Unlock(false)
+		if cos.IsNotExist(err, 0) {
+			err = nil
+		}
+		return err
+	}
+	defer core.FreeLOM(v)
+
+	var (
+		roc cos.ReadOpenCloser
+		oah cos.OAH = v
+	)
+	if v.IsDeduped() {
+		roc, oah, err = v.NewPlainDeferROC()
+	} else {
+		roc, err = v.NewDeferROC()
+	}
+	if err != nil {
+		return err
+	}
+	var (
+		ack = regularAck{rebID: rj.m.RebID(), daemonID: core.T.SID()}
+		o   = transport.AllocSend()
+	)
+	o.Hdr.Bck.Copy(lom.Bucket())
+	o.Hdr.ObjName = lom.ObjName
+	o.Hdr.Opaque = ack.NewPack(rebMsgVersion)
+	o.Hdr.ObjAttrs.CopyFrom(oah, false /*skip cksum*/)
+	o.Hdr.ObjAttrs.SetVersion(ver)
+	o.Callback = rj.verSentCallback
+	return rj.m.dm.Send(o, roc, tsi)
+}
+
+func (rj *rebJogger) verSentCallback(hdr *transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
+	if err == nil {
+		rj.xreb.OutObjsAdd(1, hdr.ObjAttrs.Size)
+		return
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleReb) || !cos.IsRetriableConnErr(err) {
+		nlog.Errorf("%s: %s failed to send %s version %s: %v", core.T, rj.xreb.Name(), hdr.Cname(),
+			hdr.ObjAttrs.Version(), err)
+	}
+}
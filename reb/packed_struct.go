@@ -16,6 +16,7 @@ const (
 	rebMsgRegular   = iota // regular rebalance: acknowledge/Object
 	rebMsgEC               // EC rebalance: acknowledge/CT/Namespace
 	rebMsgStageNtfn        // stage notification (of target transitioning to the next stage)
+	rebMsgVersion          // regular rebalance: acknowledge/noncurrent object version
 )
 const rebMsgKindSize = 1
 const (
@@ -68,10 +69,10 @@ func (rack *regularAck) Pack(packer *cos.BytePack) {
 	packer.WriteString(rack.daemonID)
 }
 
-func (rack *regularAck) NewPack() []byte { // TODO: consider adding as another cos.Packer interface
+func (rack *regularAck) NewPack(kind byte) []byte { // TODO: consider adding as another cos.Packer interface
 	l := rebMsgKindSize + rack.PackedSize()
 	packer := cos.NewPacker(nil, l)
-	packer.WriteByte(rebMsgRegular)
+	packer.WriteByte(kind)
 	packer.WriteAny(rack)
 	return packer.Bytes()
 }
@@ -53,9 +53,13 @@ func (reb *Reb) recvObj(hdr *transport.ObjHdr, objReader io.Reader, err error) e
 		nlog.Errorf("g[%d]: failed to recv recv-obj action (regular or EC): %v", reb.RebID(), err)
 		return reb._recvErr(err)
 	}
-	if act == rebMsgRegular {
+	switch act {
+	case rebMsgRegular:
 		err := reb.recvObjRegular(hdr, smap, unpacker, objReader)
 		return reb._recvErr(err)
+	case rebMsgVersion:
+		err := reb.recvVersion(hdr, smap, unpacker, objReader)
+		return reb._recvErr(err)
 	}
 	debug.Assertf(act == rebMsgEC, "act=%d", act)
 	err = reb.recvECData(hdr, unpacker, objReader)
@@ -81,6 +85,9 @@ func (reb *Reb) recvAck(hdr *transport.ObjHdr, _ io.Reader, err error) error {
 	case act == rebMsgRegular:
 		err := reb.recvRegularAck(hdr, unpacker)
 		return reb._recvErr(err)
+	case act == rebMsgVersion:
+		err := reb.recvVersionAck(hdr, unpacker)
+		return reb._recvErr(err)
 	default:
 		err := fmt.Errorf("g[%d]: invalid ACK message type '%d' (expecting '%d')", reb.RebID(), act, rebMsgRegular)
 		return reb._recvErr(err)
@@ -200,9 +207,13 @@ func (reb *Reb) recvObjRegular(hdr *transport.ObjHdr, smap *meta.Smap, unpacker
 		nlog.Errorln(err)
 		return err
 	}
+	return reb.ack(hdr, tsi, rebMsgRegular)
+}
+
+func (reb *Reb) ack(hdr *transport.ObjHdr, tsi *meta.Snode, kind byte) error {
 	if stage := reb.stages.stage.Load(); stage < rebStageFinStreams && stage != rebStageInactive {
 		ack := &regularAck{rebID: reb.RebID(), daemonID: core.T.SID()}
-		hdr.Opaque = ack.NewPack()
+		hdr.Opaque = ack.NewPack(kind)
 		hdr.ObjAttrs.Size = 0
 		if err := reb.dm.ACK(hdr, nil, tsi); err != nil {
 			nlog.Errorln(err)
@@ -241,6 +252,76 @@ func (reb *Reb) recvRegularAck(hdr *transport.ObjHdr, unpacker *cos.ByteUnpack)
 	return nil
 }
 
+//
+// noncurrent versions (see core/lversion.go)
+//
+
+func (reb *Reb) recvVersion(hdr *transport.ObjHdr, smap *meta.Smap, unpacker *cos.ByteUnpack, objReader io.Reader) error {
+	ack := &regularAck{}
+	if err := unpacker.ReadAny(ack); err != nil {
+		nlog.Errorf("g[%d]: failed to parse ACK: %v", reb.RebID(), err)
+		return err
+	}
+	if ack.rebID != reb.RebID() {
+		nlog.Warningln("received", hdr.Cname(), "version", hdr.ObjAttrs.Version(), reb.warnID(ack.rebID, ack.daemonID))
+		return nil
+	}
+	xreb := reb.xctn()
+	if xreb.IsAborted() {
+		return nil
+	}
+	lom := core.AllocLOM(hdr.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(&hdr.Bck); err != nil {
+		nlog.Errorln(err)
+		return nil
+	}
+	lom.Lock(true)
+	err := lom.PutVersion(&hdr.ObjAttrs, objReader, nil)
+	if err == nil {
+		lom.TrimVersions(lom.RetainVersions())
+	}
+	lom.Unlock(true)
+	if err != nil {
+		nlog.Errorln(err)
+		return err
+	}
+	xreb.InObjsAdd(1, hdr.ObjAttrs.Size)
+
+	tsi := smap.GetTarget(ack.daemonID)
+	if tsi == nil {
+		err := fmt.Errorf("g[%d]: %s is not in the %s", reb.RebID(), meta.Tname(ack.daemonID), smap)
+		nlog.Errorln(err)
+		return err
+	}
+	return reb.ack(hdr, tsi, rebMsgVersion)
+}
+
+// remove migrated version; without ACK the version stays and gets migrated by the next rebalance
+func (reb *Reb) recvVersionAck(hdr *transport.ObjHdr, unpacker *cos.ByteUnpack) error {
+	ack := &regularAck{}
+	if err := unpacker.ReadAny(ack); err != nil {
+		return fmt.Errorf("g[%d]: failed to unpack version ACK: %v", reb.RebID(), err)
+	}
+	if ack.rebID != reb.rebID.Load() {
+		nlog.Warningln("ACK from", ack.daemonID, "[", reb.warnID(ack.rebID, ack.daemonID), "]")
+		return nil
+	}
+	lom := core.AllocLOM(hdr.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(&hdr.Bck); err != nil {
+		nlog.Errorln(err)
+		return nil
+	}
+	lom.Lock(true)
+	err := cos.RemoveFile(lom.VersionFQN(hdr.ObjAttrs.Version()))
+	lom.Unlock(true)
+	if err != nil {
+		nlog.Errorln(err)
+	}
+	return nil
+}
+
 //
 // EC receive
 //
@@ -103,7 +103,7 @@ func (res *Res) RunResilver(args Args, tstats cos.StatsUpdater) {
 		jctx      = &joggerCtx{xres: xres, config: config}
 
 		opts = &mpather.JgroupOpts{
-			CTs:                   []string{fs.ObjectType, fs.ECSliceType},
+			CTs:                   []string{fs.ObjectType, fs.ECSliceType, fs.VersionType, fs.DedupType},
 			VisitObj:              jctx.visitObj,
 			VisitCT:               jctx.visitCT,
 			Slab:                  slab,
@@ -375,6 +375,14 @@ func (*joggerCtx) fixHrw(lom *core.LOM, mi *fs.Mountpath, buf []byte) (hlom *cor
 }
 
 func (jg *joggerCtx) visitCT(ct *core.CT, buf []byte) (err error) {
+	switch ct.ContentType() {
+	case fs.VersionType:
+		jg._mvVersion(ct, buf)
+		return nil
+	case fs.DedupType:
+		jg._mvChunk(ct, buf)
+		return nil
+	}
 	debug.Assert(ct.ContentType() == fs.ECSliceType)
 	if !ct.Bck().Props.EC.Enabled {
 		// Since `%ec` directory is inside a bucket, it is safe to skip
@@ -384,3 +392,60 @@ func (jg *joggerCtx) visitCT(ct *core.CT, buf []byte) (err error) {
 	jg._mvSlice(ct, buf)
 	return nil
 }
+
+// Moves noncurrent version to the (HRW) mountpath of its object (see core/lversion.go)
+func (jg *joggerCtx) _mvVersion(ct *core.CT, buf []byte) {
+	objName, ver, ok := fs.ParseVersionName(ct.ObjectName())
+	if !ok {
+		return
+	}
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(ct.Bucket()); err != nil {
+		jg.xres.AddErr(err)
+		return
+	}
+	if lom.Mountpath().Path == ct.Mountpath().Path {
+		return
+	}
+	if !lom.TryLock(true) { // NOTE: skipping busy
+		time.Sleep(time.Second >> 1)
+		if !lom.TryLock(true) {
+			return
+		}
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleReb) {
+		nlog.Infof("%s: moving %s version %s %q -> %q", core.T, lom, ver, ct.Mountpath(), lom.Mountpath())
+	}
+	finfo, err := os.Stat(ct.FQN())
+	if err == nil {
+		err = lom.MoveVersion(ct.FQN(), ver, buf)
+	}
+	lom.Unlock(true)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return // (removed in the meantime)
+		}
+		if cos.IsErrOOS(err) {
+			err = cmn.NewErrAborted(jg.xres.Name(), "", err)
+		}
+		jg.xres.AddErr(fmt.Errorf("%s: failed to move %s version %s: %w", jg.xres.Name(), lom, ver, err), 0)
+		return
+	}
+	jg.xres.ObjsAdd(1, finfo.Size())
+}
+
+// Moves dedup chunk to its HRW mountpath (see core/ldedup.go)
+func (jg *joggerCtx) _mvChunk(ct *core.CT, buf []byte) {
+	size, err := core.MoveDedupChunk(ct.Bucket(), ct.FQN(), buf)
+	if err != nil {
+		if cos.IsErrOOS(err) {
+			err = cmn.NewErrAborted(jg.xres.Name(), "", err)
+		}
+		jg.xres.AddErr(fmt.Errorf("%s: failed to move %s dedup chunk: %w", jg.xres.Name(), ct.Cname(), err), 0)
+		return
+	}
+	if size >= 0 {
+		jg.xres.ObjsAdd(1, size)
+	}
+}
@@ -174,6 +174,17 @@ func RunCleanup(ini *IniCln) fs.CapStatus {
 		j.stop()
 	}
 
+	// unreferenced dedup chunks (see dedup.go)
+	bcks := ini.Args.Buckets
+	if len(bcks) == 0 {
+		provider := apc.AIS
+		core.T.Bowner().Get().Range(&provider, nil, func(bck *meta.Bck) bool {
+			bcks = append(bcks, *bck.Bucket())
+			return false
+		})
+	}
+	parent.gcDedup(bcks, config, now)
+
 	var err, errCap error
 	parent.cs.c, err, errCap = fs.CapRefresh(config, nil /*tcdf*/)
 	if err != nil {
@@ -368,7 +379,7 @@ func (j *clnJ) jogBck() (size int64, err error) {
 	opts := &fs.WalkOpts{
 		Mi:       j.mi,
 		Bck:      j.bck,
-		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType},
+		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType, fs.ArchIdxType},
 		Callback: j.walk,
 		Sorted:   false,
 	}
@@ -430,6 +441,17 @@ func (j *clnJ) visitCT(parsedFQN *fs.ParsedFQN, fqn string) {
 			return
 		}
 		j.oldWork = append(j.oldWork, fqn)
+	case fs.ArchIdxType:
+		// shard indexes: remove those without the shard (see core/larchidx.go)
+		ct, err := core.NewCTFromFQN(fqn, core.T.Bowner())
+		if err != nil {
+			j.oldWork = append(j.oldWork, fqn)
+			return
+		}
+		objCT := ct.Clone(fs.ObjectType)
+		if cos.Stat(objCT.FQN()) != nil {
+			j.oldWork = append(j.oldWork, fqn)
+		}
 	default:
 		debug.Assert(false, "Unsupported content type: ", parsedFQN.ContentType)
 	}
@@ -490,7 +512,7 @@ func (j *clnJ) visitObj(fqn string, lom *core.LOM) {
 		if lom.HasCopies() {
 			j.rmExtraCopies(lom)
 		}
-		if lom.Lsize() == 0 {
+		if lom.Lsize() == 0 && !lom.IsObjLocked() {
 			if j.ini.Args.Flags&xact.XrmZeroSize == xact.XrmZeroSize {
 				// remove in place
 				if ecode, err := core.T.DeleteObject(lom, false /*evict*/); err != nil {
@@ -0,0 +1,155 @@
+// Package space provides storage cleanup and eviction functionality (the latter based on the
+// least recently used cache replacement). It also serves as a built-in garbage-collection
+// mechanism for orphaned workfiles.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package space
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Dedup chunks (see cmn/dedup.go and core/ldedup.go) are shared by objects across all
+// mountpaths of a given target, which is why garbage collection runs per bucket (rather than
+// per mountpath) once all cleanup joggers are done:
+// 1. collect unreferenced candidates: chunks not updated for at least `lru.dont_evict_time`;
+// 2. collect references: chunk lists of all objects and their noncurrent versions;
+// 3. remove unreferenced candidates, unless updated in the meantime (stored again by a PUT
+//    that has not finished yet) - under the chunk's name lock (see core.RemoveDedupChunk)
+//    to serialize with PUTs that would otherwise refresh (and reuse) a chunk being removed.
+// Failure to read any of the chunk lists skips the bucket altogether.
+// This is mark-and-sweep rather than reference counting in LOM metadata: the recipes
+// are the references, and so there's nothing to keep consistent (or repair) upon PUT,
+// overwrite, delete, versioning, rebalance, and crashes in the middle of any of those.
+
+type dedupGC struct {
+	p         *clnP
+	bck       cmn.Bck
+	avail     fs.MPI
+	now       int64
+	dontEvict int64
+	// runtime
+	candidates []string // chunk FQNs
+}
+
+func (p *clnP) gcDedup(bcks []cmn.Bck, config *cmn.Config, now int64) {
+	var (
+		fevicted, bevicted int64
+		xcln               = p.ini.Xaction
+		avail              = fs.GetAvail()
+	)
+	for i := range bcks {
+		if !bcks[i].IsAIS() {
+			continue
+		}
+		if xcln.IsAborted() {
+			break
+		}
+		gc := &dedupGC{p: p, bck: bcks[i], avail: avail, now: now, dontEvict: int64(config.LRU.DontEvictTime)}
+		n, size, err := gc.run()
+		if err != nil {
+			xcln.AddErr(err)
+			nlog.Errorln(xcln.Name(), "dedup gc", gc.bck.Cname(""), "err:", err)
+		}
+		fevicted += n
+		bevicted += size
+	}
+	if fevicted > 0 {
+		nlog.Infoln(xcln.Name(), "dedup gc: removed", fevicted, "unreferenced chunks, size", cos.ToSizeIEC(bevicted, 1))
+	}
+	p.ini.StatsT.Add(stats.CleanupStoreSize, bevicted)
+	p.ini.StatsT.Add(stats.CleanupStoreCount, fevicted)
+	xcln.ObjsAdd(int(fevicted), bevicted)
+}
+
+func (gc *dedupGC) run() (n, size int64, err error) {
+	// 1. candidates
+	if err = gc.walk(fs.DedupType, gc.visitChunk); err != nil || len(gc.candidates) == 0 {
+		return 0, 0, err
+	}
+
+	// 2. references
+	var (
+		refs = make(map[string]struct{}, len(gc.candidates))
+		cb   = func(fqn string, _ os.FileInfo) error { return core.DedupRefs(&gc.bck, fqn, refs) }
+	)
+	if err = gc.walk(fs.ObjectType, cb); err != nil {
+		return 0, 0, err
+	}
+	if err = gc.walk(fs.VersionType, cb); err != nil {
+		return 0, 0, err
+	}
+
+	// 3. remove
+	for _, fqn := range gc.candidates {
+		if _, ok := refs[filepath.Base(fqn)]; ok {
+			continue
+		}
+		csize, err := core.RemoveDedupChunk(&gc.bck, fqn, gc.isOld)
+		if err != nil {
+			nlog.Errorln(gc.p.ini.Xaction.Name(), "failed to rm dedup chunk", fqn, "err:", err)
+			continue
+		}
+		if csize < 0 {
+			continue
+		}
+		n++
+		size += csize
+		if cmn.Rom.FastV(4, cos.SmoduleSpace) {
+			nlog.Infoln(gc.p.ini.Xaction.Name(), "rm unreferenced dedup chunk", fqn)
+		}
+	}
+	return n, size, nil
+}
+
+func (gc *dedupGC) visitChunk(fqn string, finfo os.FileInfo) error {
+	if gc.isOld(finfo) {
+		gc.candidates = append(gc.candidates, fqn)
+	}
+	return nil
+}
+
+func (gc *dedupGC) isOld(finfo os.FileInfo) bool {
+	return finfo.ModTime().UnixNano()+gc.dontEvict <= gc.now
+}
+
+// walk a given content type on all mountpaths
+func (gc *dedupGC) walk(ct string, cb func(fqn string, finfo os.FileInfo) error) error {
+	xcln := gc.p.ini.Xaction
+	for _, mi := range gc.avail {
+		opts := &fs.WalkOpts{
+			Mi:  mi,
+			Bck: gc.bck,
+			CTs: []string{ct},
+			Callback: func(fqn string, de fs.DirEntry) error {
+				if de.IsDir() {
+					return nil
+				}
+				if xcln.IsAborted() {
+					return cmn.NewErrAborted(xcln.Name(), "", nil)
+				}
+				finfo, err := os.Stat(fqn)
+				if err != nil {
+					if os.IsNotExist(err) {
+						return nil
+					}
+					return err
+				}
+				return cb(fqn, finfo)
+			},
+		}
+		if err := fs.Walk(opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,372 @@
+// Package space provides storage cleanup and eviction functionality (the latter based on the
+// least recently used cache replacement). It also serves as a built-in garbage-collection
+// mechanism for orphaned workfiles.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package space
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Lifecycle xaction executes bucket lifecycle rules (cmn.LifecycleConf) on a given target:
+// - walks all buckets that have (enabled) lifecycle rules - one jogger per mountpath;
+// - removes objects that expired as per the respective rules;
+// - aborts stale multipart uploads (via IniLcy.AbortMpt callback).
+//
+// The xaction runs periodically (see ais/tgtspace.go) and can be also started via `api.StartXaction`.
+
+type (
+	IniLcy struct {
+		Xaction *XactLcy
+		Config  *cmn.Config
+		StatsT  stats.Tracker
+		Buckets []cmn.Bck // optional: limit to these buckets
+		WG      *sync.WaitGroup
+		// abort incomplete multipart uploads as per bucket's lifecycle; return the number of aborted
+		AbortMpt func(bck *meta.Bck, now time.Time) int
+	}
+	XactLcy struct {
+		xact.Base
+	}
+)
+
+// private
+type (
+	// parent (contains mpath joggers)
+	lcyP struct {
+		now  time.Time
+		ini  IniLcy
+		bcks []*meta.Bck
+		wg   sync.WaitGroup
+	}
+	// lcyJ represents a single lifecycle /jogger/ that traverses a single given mountpath
+	lcyJ struct {
+		p   *lcyP
+		mi  *fs.Mountpath
+		bck *meta.Bck
+		// runtime
+		nexp, sexp int64
+		nvisited   int64
+	}
+	lcyFactory struct {
+		xreg.RenewBase
+		xctn *XactLcy
+	}
+)
+
+// interface guard
+var (
+	_ xreg.Renewable = (*lcyFactory)(nil)
+	_ core.Xact      = (*XactLcy)(nil)
+)
+
+////////////////
+// lcyFactory //
+////////////////
+
+func (*lcyFactory) New(args xreg.Args, _ *meta.Bck) xreg.Renewable {
+	return &lcyFactory{RenewBase: xreg.RenewBase{Args: args}}
+}
+
+func (p *lcyFactory) Start() error {
+	p.xctn = &XactLcy{}
+	p.xctn.InitBase(p.UUID(), apc.ActLifecycle, nil)
+	return nil
+}
+
+func (*lcyFactory) Kind() string     { return apc.ActLifecycle }
+func (p *lcyFactory) Get() core.Xact { return p.xctn }
+
+func (*lcyFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (wpr xreg.WPR, err error) {
+	return xreg.WprUse, cmn.NewErrXactUsePrev(prevEntry.Get().String())
+}
+
+func (*XactLcy) Run(*sync.WaitGroup) { debug.Assert(false) }
+
+func (r *XactLcy) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+// HasLifecycle returns true if at least one bucket in the cluster has enabled lifecycle rules.
+func HasLifecycle(bmd *meta.BMD) (yes bool) {
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		yes = bck.Props.Lifecycle.IsEnabled()
+		return yes
+	})
+	return
+}
+
+func RunLifecycle(ini *IniLcy) {
+	var (
+		xlcy   = ini.Xaction
+		avail  = fs.GetAvail()
+		parent = &lcyP{ini: *ini, now: time.Now()}
+	)
+	defer func() {
+		if ini.WG != nil {
+			ini.WG.Done()
+		}
+	}()
+	if len(avail) == 0 {
+		xlcy.AddErr(cmn.ErrNoMountpaths, 0)
+		xlcy.Finish()
+		return
+	}
+	parent.bcks = lcyBuckets(core.T.Bowner().Get(), ini.Buckets)
+
+	nlog.Infoln(xlcy.Name(), "started: num buckets", len(parent.bcks))
+	if ini.WG != nil {
+		ini.WG.Done()
+		ini.WG = nil
+	}
+
+	// stale multipart uploads first
+	if ini.AbortMpt != nil {
+		for _, bck := range parent.bcks {
+			if n := ini.AbortMpt(bck, parent.now); n > 0 {
+				nlog.Infoln(xlcy.Name(), bck.Cname(""), "aborted incomplete multipart uploads:", n)
+			}
+		}
+	}
+
+	// expire objects
+	joggers := make([]*lcyJ, 0, len(avail))
+	for _, mi := range avail {
+		j := &lcyJ{p: parent, mi: mi}
+		joggers = append(joggers, j)
+		parent.wg.Add(1)
+		go j.run()
+	}
+	parent.wg.Wait()
+
+	var nexp, sexp int64
+	for _, j := range joggers {
+		nexp += j.nexp
+		sexp += j.sexp
+	}
+	if nexp > 0 {
+		ini.StatsT.Add(stats.LcyExpireCount, nexp)
+		ini.StatsT.Add(stats.LcyExpireSize, sexp)
+	}
+	xlcy.Finish()
+	nlog.Infoln(xlcy.Name(), "finished: expired", nexp, "objects, total size", cos.ToSizeIEC(sexp, 2))
+}
+
+// buckets that have enabled lifecycle rules, optionally limited to the specified ones
+func lcyBuckets(bmd *meta.BMD, only []cmn.Bck) (bcks []*meta.Bck) {
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		if !bck.Props.Lifecycle.IsEnabled() {
+			return false
+		}
+		if len(only) > 0 {
+			var found bool
+			for i := range only {
+				if only[i].Equal(bck.Bucket()) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		bcks = append(bcks, bck)
+		return false
+	})
+	return
+}
+
+//////////
+// lcyJ //
+//////////
+
+func (j *lcyJ) String() string {
+	return fmt.Sprintf("%s: jog-%s", j.p.ini.Xaction, j.mi)
+}
+
+func (j *lcyJ) run() {
+	defer j.p.wg.Done()
+	for _, bck := range j.p.bcks {
+		j.bck = bck
+		if err := bck.Allow(apc.AceObjDELETE); err != nil {
+			nlog.Warningln(j.String()+":", "skipping", bck.Cname(""), "[", err, "]")
+			continue
+		}
+		opts := &fs.WalkOpts{
+			Mi:       j.mi,
+			Bck:      *bck.Bucket(),
+			CTs:      []string{fs.ObjectType},
+			Callback: j.walk,
+			Sorted:   false,
+		}
+		if err := j._walk(opts); err != nil {
+			return
+		}
+		// noncurrent versions (see core/lversion.go)
+		if bck.IsAIS() && bck.Props.Versioning.Enabled {
+			opts.CTs = []string{fs.VersionType}
+			opts.Callback = j.walkVer
+			if err := j._walk(opts); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// returns error only when aborted
+func (j *lcyJ) _walk(opts *fs.WalkOpts) error {
+	err := fs.Walk(opts)
+	if err == nil {
+		return nil
+	}
+	if cmn.IsErrAborted(err) {
+		return err
+	}
+	if !cmn.IsErrBucketNought(err) && !cmn.IsErrObjNought(err) {
+		j.p.ini.Xaction.AddErr(err)
+		nlog.Errorln(j.String()+":", j.bck.Cname(""), err)
+	}
+	return nil
+}
+
+func (j *lcyJ) walk(fqn string, de fs.DirEntry) error {
+	if de.IsDir() {
+		return nil
+	}
+	if err := j.yieldTerm(); err != nil {
+		return err
+	}
+	lom := core.AllocLOM("")
+	if err := lom.InitFQN(fqn, j.bck.Bucket()); err == nil {
+		j.visit(lom)
+	}
+	core.FreeLOM(lom)
+	return nil
+}
+
+func (j *lcyJ) visit(lom *core.LOM) {
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return
+	}
+	if lom.IsCopy() {
+		return
+	}
+	_, _, mtime, err := lom.Fstat(false /*get atime*/)
+	if err != nil {
+		return
+	}
+	rule := j.bck.Props.Lifecycle.Expired(lom.ObjName, lom.GetCustomMD(), mtime, j.p.now)
+	if rule == nil {
+		return
+	}
+	if lom.RetainVersions() == 0 && lom.IsObjLocked() {
+		return // (when retaining noncurrent versions, expiration adds delete marker)
+	}
+	size := lom.Lsize()
+	lom.Lock(true)
+	if retain := lom.RetainVersions(); retain > 0 && lom.IsHRW() {
+		err = lom.DelCurrent(retain) // becomes noncurrent
+	} else {
+		err = lom.RemoveObj()
+	}
+	lom.Unlock(true)
+	if err != nil {
+		nlog.Errorf("%s: failed to expire %s (rule %q): %v", j, lom, rule.ID, err)
+		return
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleSpace) {
+		nlog.Infof("%s: expired %s (rule %q), size=%d", j, lom, rule.ID, size)
+	}
+	j.nexp++
+	j.sexp += size
+	j.p.ini.Xaction.ObjsAdd(1, size)
+}
+
+func (j *lcyJ) walkVer(fqn string, de fs.DirEntry) error {
+	if de.IsDir() {
+		return nil
+	}
+	if err := j.yieldTerm(); err != nil {
+		return err
+	}
+	var parsed fs.ParsedFQN
+	if err := parsed.Init(fqn); err != nil {
+		return nil
+	}
+	objName, ver, ok := fs.ParseVersionName(parsed.ObjName)
+	if !ok {
+		return nil
+	}
+	lom := core.AllocLOM(objName)
+	if err := lom.InitBck(j.bck.Bucket()); err == nil {
+		j.visitVer(lom, ver)
+	}
+	core.FreeLOM(lom)
+	return nil
+}
+
+// expire noncurrent version (including delete markers)
+func (j *lcyJ) visitVer(lom *core.LOM, ver string) {
+	v, err := lom.LoadVersion(ver)
+	if err != nil {
+		return
+	}
+	var (
+		size    = v.Lsize()
+		fqn     = v.FQN
+		expired = j.bck.Props.Lifecycle.NoncurrentExpired(lom.ObjName, v.NoncurrentSince(), j.p.now) && !v.IsObjLocked()
+	)
+	core.FreeLOM(v)
+	if !expired {
+		return
+	}
+	lom.Lock(true)
+	err = cos.RemoveFile(fqn)
+	lom.Unlock(true)
+	if err != nil {
+		nlog.Errorf("%s: failed to expire %s version %s: %v", j, lom, ver, err)
+		return
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleSpace) {
+		nlog.Infof("%s: expired %s version %s, size=%d", j, lom, ver, size)
+	}
+	j.nexp++
+	j.sexp += size
+	j.p.ini.Xaction.ObjsAdd(1, size)
+}
+
+func (j *lcyJ) yieldTerm() error {
+	xlcy := j.p.ini.Xaction
+	select {
+	case errCause := <-xlcy.ChanAbort():
+		return cmn.NewErrAborted(xlcy.Name(), "", errCause)
+	default:
+	}
+	j.nvisited++
+	if fs.IsThrottle(j.nvisited) {
+		if pct, _, _ := fs.ThrottlePct(); pct > fs.MaxThrottlePct {
+			time.Sleep(fs.Throttle10ms)
+		}
+	}
+	return nil
+}
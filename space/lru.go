@@ -10,11 +10,13 @@ import (
 	"container/heap"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
@@ -31,7 +33,9 @@ import (
 // config.Space.HighWM (section "space" in the cluster config).
 //
 // When and if exceeded, AIS target will start gradually evicting objects from its
-// stable storage: oldest first access-time wise.
+// stable storage: by default, oldest first access-time wise. Other (per-bucket)
+// eviction policies include LFU, size-aware GreedyDual, and TTL - see apc.LRUPolicy
+// and `evictKey` below.
 //
 // LRU is implemented as eXtended Action (xaction, see xact/README.md) that gets
 // triggered when/if a used local capacity exceeds high watermark (config.Space.HighWM). LRU then
@@ -60,20 +64,32 @@ type (
 		Force               bool // Ignore LRU prop when set to be true.
 	}
 	XactLRU struct {
+		policies map[string]apc.LRUPolicy // bucket => eviction policy
 		xact.Base
+		expired atomic.Int64 // evicted upon TTL expiration
+		mu      sync.Mutex
+	}
+	ExtLRUStats struct {
+		Policies map[string]apc.LRUPolicy `json:"lru.policies,omitempty"`
+		Expired  int64                    `json:"lru.ttl.expired.n,string"`
 	}
 )
 
 // private
 type (
-	// minHeap keeps fileInfo sorted by access time with oldest on top of the heap.
-	minHeap []*core.LOM
+	// minHeap keeps objects sorted by eviction priority with the first-to-evict on top of the heap
+	minHeap  []heapItem
+	heapItem struct {
+		lom *core.LOM
+		key float64 // policy-specific (see evictKey), ties broken by atime
+	}
 
 	// parent (contains mpath joggers)
 	lruP struct {
 		wg      sync.WaitGroup
 		joggers map[string]*lruJ
 		ini     IniLRU
+		ttl     bool // see HasTTL
 	}
 
 	// lruJ represents a single LRU context and a single /jogger/
@@ -81,10 +97,13 @@ type (
 	lruJ struct {
 		// runtime
 		curSize   int64
-		totalSize int64 // difference between lowWM size and used size
-		newest    int64
+		totalSize int64    // difference between lowWM size and used size
+		newest    heapItem // the last to evict (of all objects in the heap)
 		heap      *minHeap
+		expired   []*core.LOM // policy "ttl"
 		bck       cmn.Bck
+		policy    apc.LRUPolicy
+		ttl       int64
 		now       int64
 		// init-time
 		p       *lruP
@@ -96,6 +115,7 @@ type (
 		// runtime
 		throttle    bool
 		allowDelObj bool
+		ttlOnly     bool // capacity-wise, nothing to do
 	}
 	lruFactory struct {
 		xreg.RenewBase
@@ -138,7 +158,7 @@ func RunLRU(ini *IniLRU) {
 		avail   = fs.GetAvail()
 		num     = len(avail)
 		joggers = make(map[string]*lruJ, num)
-		parent  = &lruP{joggers: joggers, ini: *ini}
+		parent  = &lruP{joggers: joggers, ini: *ini, ttl: HasTTL(core.T.Bowner().Get())}
 	)
 	defer func() {
 		if ini.WG != nil {
@@ -190,10 +210,58 @@ func (r *XactLRU) Snap() (snap *core.Snap) {
 	snap = &core.Snap{}
 	r.ToSnap(snap)
 
+	ext := &ExtLRUStats{Expired: r.expired.Load()}
+	r.mu.Lock()
+	if len(r.policies) > 0 {
+		ext.Policies = make(map[string]apc.LRUPolicy, len(r.policies))
+		for bname, policy := range r.policies {
+			ext.Policies[bname] = policy
+		}
+	}
+	r.mu.Unlock()
+	snap.Ext = ext
+
 	snap.IdleX = r.IsIdle()
 	return
 }
 
+func (r *XactLRU) addPolicy(bck *cmn.Bck, policy apc.LRUPolicy) {
+	r.mu.Lock()
+	if r.policies == nil {
+		r.policies = make(map[string]apc.LRUPolicy, 4)
+	}
+	r.policies[bck.Cname("")] = apc.LRUPolicy(policy.String())
+	r.mu.Unlock()
+}
+
+// HasTTL returns true if at least one (remote) bucket in the cluster has LRU policy "ttl".
+func HasTTL(bmd *meta.BMD) (yes bool) {
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		yes = bck.Props.LRU.Enabled && bck.Props.LRU.Policy == apc.LRUPolicyTTL && bck.IsRemote()
+		return yes
+	})
+	return
+}
+
+/////////////////
+// ExtLRUStats //
+/////////////////
+
+// Policy returns distinct eviction policies of the buckets visited by the xaction
+func (ext *ExtLRUStats) Policy() string {
+	if len(ext.Policies) == 0 {
+		return ""
+	}
+	names := make([]string, 0, 4)
+	for _, policy := range ext.Policies {
+		if !cos.StringInSlice(string(policy), names) {
+			names = append(names, string(policy))
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
 //////////////////////
 // mountpath jogger //
 //////////////////////
@@ -212,8 +280,12 @@ func (j *lruJ) run(providers []string) {
 		goto ex
 	}
 	if j.totalSize < minEvictThresh {
-		nlog.Infof("%s: used cap below threshold, nothing to do", j)
-		return
+		if !j.p.ttl {
+			nlog.Infof("%s: used cap below threshold, nothing to do", j)
+			return
+		}
+		// still, remove objects that outlived their TTL
+		j.ttlOnly, j.totalSize = true, 0
 	}
 	if len(j.ini.Buckets) != 0 {
 		nlog.Infof("%s: freeing-up %s", j, cos.ToSizeIEC(j.totalSize, 2))
@@ -264,10 +336,16 @@ func (j *lruJ) jogBcks(bcks []cmn.Bck, force bool) (err error) {
 			continue
 		}
 		j.allowDelObj = j.allowDelObj || force
+		if j.ttlOnly && j.ttl == 0 {
+			continue
+		}
+		if j.allowDelObj {
+			j.ini.Xaction.addPolicy(&bck, j.policy)
+		}
 		if size, err = j.jogBck(); err != nil {
 			return
 		}
-		if size < cos.KiB {
+		if size < cos.KiB || j.ttlOnly {
 			continue
 		}
 		// recompute size-to-evict
@@ -275,7 +353,11 @@ func (j *lruJ) jogBcks(bcks []cmn.Bck, force bool) (err error) {
 			return
 		}
 		if j.totalSize < cos.KiB {
-			return
+			if !j.p.ttl {
+				return
+			}
+			// keep going but only to expire objects
+			j.ttlOnly, j.totalSize = true, 0
 		}
 	}
 	return
@@ -286,6 +368,8 @@ func (j *lruJ) jogBck() (size int64, err error) {
 	h := (*j.heap)[:0]
 	j.heap = &h
 	heap.Init(j.heap)
+	j.expired = j.expired[:0]
+	j.curSize, j.newest = 0, heapItem{}
 
 	// 2. collect
 	opts := &fs.WalkOpts{
@@ -321,25 +405,56 @@ func (j *lruJ) _visit(lom *core.LOM) (pushed bool) {
 	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
 		return
 	}
-	if lom.AtimeUnix()+int64(j.config.LRU.DontEvictTime) > j.now {
+	if lom.HasCopies() && lom.IsCopy() {
 		return
 	}
-	if lom.HasCopies() && lom.IsCopy() {
+	if lom.IsObjLocked() {
 		return
 	}
+	if lom.IsDirty() {
+		return // not yet written back to remote backend
+	}
+	// absolute TTL (regardless of access time and used capacity)
+	if j.ttl > 0 {
+		if _, _, mtime, err := lom.Fstat(false /*get-atime*/); err == nil && mtime.UnixNano()+j.ttl < j.now {
+			j.expired = append(j.expired, lom)
+			return true
+		}
+	}
+	if j.ttlOnly || lom.AtimeUnix()+int64(j.config.LRU.DontEvictTime) > j.now {
+		return
+	}
+	item := heapItem{lom: lom, key: j.evictKey(lom)}
 	// do nothing if the heap's curSize >= totalSize and
-	// the file is more recent then the the heap's newest.
-	if j.curSize >= j.totalSize && lom.AtimeUnix() > j.newest {
+	// the object is less evictable than the heap's newest
+	if j.curSize >= j.totalSize && j.newest.lom != nil && j.newest.less(&item) {
 		return
 	}
-	heap.Push(j.heap, lom)
+	heap.Push(j.heap, item)
 	j.curSize += lom.Lsize()
-	if lom.AtimeUnix() > j.newest {
-		j.newest = lom.AtimeUnix()
+	if j.newest.lom == nil || j.newest.less(&item) {
+		j.newest = item
 	}
 	return true
 }
 
+// eviction priority: the lower the sooner (see also heapItem.less)
+func (j *lruJ) evictKey(lom *core.LOM) float64 {
+	switch j.policy {
+	case apc.LRUPolicyLFU:
+		return float64(lom.Hits())
+	case apc.LRUPolicyGDSF:
+		// "value density": frequency / (size * age) - large and cold objects go first
+		var (
+			size = max(float64(lom.Lsize())/cos.KiB, 1)
+			age  = max(float64(j.now-lom.AtimeUnix())/float64(time.Second), 1)
+		)
+		return float64(lom.Hits()+1) / (size * age)
+	default:
+		return 0 // least recently used
+	}
+}
+
 func (j *lruJ) walk(fqn string, de fs.DirEntry) error {
 	var parsed fs.ParsedFQN
 	if de.IsDir() {
@@ -366,9 +481,28 @@ func (j *lruJ) evict() (size int64, err error) {
 		xlru               = j.ini.Xaction
 	)
 
+	// expired first
+	for i, lom := range j.expired {
+		j.expired[i] = nil
+		if !j.evictObj(lom) {
+			core.FreeLOM(lom)
+			continue
+		}
+		objSize := lom.Lsize(true /*not loaded*/)
+		core.FreeLOM(lom)
+		bevicted += objSize
+		size += objSize
+		fevicted++
+		xlru.expired.Inc()
+		if capCheck, err = j.postRemove(capCheck, objSize); err != nil {
+			return
+		}
+	}
+	j.expired = j.expired[:0]
+
 	// evict(sic!) and house-keep
 	for h.Len() > 0 && j.totalSize > 0 {
-		lom := heap.Pop(h).(*core.LOM)
+		lom := heap.Pop(h).(heapItem).lom
 		if !j.evictObj(lom) {
 			core.FreeLOM(lom)
 			continue
@@ -432,6 +566,10 @@ func (j *lruJ) _throttle(usedPct int64) (err error) {
 // remove local copies that "belong" to different LRU joggers (space accounting may be temporarily not precise)
 func (j *lruJ) evictObj(lom *core.LOM) bool {
 	lom.Lock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil && lom.IsDirty() {
+		lom.Unlock(true) // updated meanwhile and not yet written back
+		return false
+	}
 	err := lom.RemoveObj()
 	lom.Unlock(true)
 	if err != nil {
@@ -441,6 +579,7 @@ func (j *lruJ) evictObj(lom *core.LOM) bool {
 	if cmn.Rom.FastV(5, cos.SmoduleSpace) {
 		nlog.Infof("%s: evicted %s, size=%d", j, lom, lom.Lsize(true /*not loaded*/))
 	}
+	core.T.ObjEvent(lom, cmn.EvObjRemovedEvict)
 	return true
 }
 
@@ -507,6 +646,10 @@ func (j *lruJ) allow() (ok bool, err error) {
 		return
 	}
 	ok = b.Props.LRU.Enabled && b.Allow(apc.AceObjDELETE) == nil
+	j.policy, j.ttl = b.Props.LRU.Policy, 0
+	if j.policy == apc.LRUPolicyTTL && b.IsRemote() {
+		j.ttl = int64(b.Props.LRU.TTL)
+	}
 	return
 }
 
@@ -515,13 +658,21 @@ func (j *lruJ) allow() (ok bool, err error) {
 //////////////
 
 func (h minHeap) Len() int           { return len(h) }
-func (h minHeap) Less(i, j int) bool { return h[i].Atime().Before(h[j].Atime()) }
+func (h minHeap) Less(i, j int) bool { return h[i].less(&h[j]) }
 func (h minHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
-func (h *minHeap) Push(x any)        { *h = append(*h, x.(*core.LOM)) }
+func (h *minHeap) Push(x any)        { *h = append(*h, x.(heapItem)) }
 func (h *minHeap) Pop() any {
 	old := *h
 	n := len(old)
 	fi := old[n-1]
+	old[n-1] = heapItem{}
 	*h = old[0 : n-1]
 	return fi
 }
+
+func (item *heapItem) less(other *heapItem) bool {
+	if item.key != other.key {
+		return item.key < other.key
+	}
+	return item.lom.AtimeUnix() < other.lom.AtimeUnix()
+}
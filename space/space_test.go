@@ -38,6 +38,7 @@ const (
 	basePath             = "/tmp/space-tests"
 	bucketName           = "space-bck"
 	bucketNameAnother    = bucketName + "-another"
+	bucketNameLFU        = bucketName + "-lfu"
 )
 
 type fileMetadata struct {
@@ -62,6 +63,7 @@ var _ = Describe("space evict/cleanup tests", func() {
 		var (
 			filesPath  string
 			fpAnother  string
+			fpLFU      string
 			bckAnother cmn.Bck
 		)
 
@@ -74,8 +76,10 @@ var _ = Describe("space evict/cleanup tests", func() {
 			bckAnother = cmn.Bck{Name: bucketNameAnother, Provider: apc.AIS, Ns: cmn.NsGlobal}
 			filesPath = avail[basePath].MakePathCT(&bck, fs.ObjectType)
 			fpAnother = avail[basePath].MakePathCT(&bckAnother, fs.ObjectType)
+			fpLFU = avail[basePath].MakePathCT(&cmn.Bck{Name: bucketNameLFU, Provider: apc.AIS, Ns: cmn.NsGlobal}, fs.ObjectType)
 			cos.CreateDir(filesPath)
 			cos.CreateDir(fpAnother)
+			cos.CreateDir(fpLFU)
 		})
 
 		AfterEach(func() {
@@ -135,6 +139,37 @@ var _ = Describe("space evict/cleanup tests", func() {
 				}
 			})
 
+			It("should evict the least frequently used files [lfu]", func() {
+				const numberOfFiles = 6
+
+				ini.GetFSStats = getMockGetFSStats(numberOfFiles)
+
+				hot := []fileMetadata{
+					{getRandomFileName(3), fileSize},
+					{getRandomFileName(4), fileSize},
+					{getRandomFileName(5), fileSize},
+				}
+				saveRandomFilesWithMetadata(fpLFU, hot)
+				for i, file := range hot {
+					hitFile(path.Join(fpLFU, file.name), i+1)
+				}
+				time.Sleep(1 * time.Second)
+				saveRandomFiles(fpLFU, 3)
+
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(fpLFU)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(3))
+
+				hotNames := namesFromFilesMetadatas(hot)
+				for _, name := range files {
+					Expect(cos.StringInSlice(name.Name(), hotNames)).To(BeTrue())
+				}
+				ext := ini.Xaction.Snap().Ext.(*space.ExtLRUStats)
+				Expect(ext.Policy()).To(ContainSubstring(string(apc.LRUPolicyLFU)))
+			})
+
 			It("should evict files of different sizes", func() {
 				const totalSize = 32 * cos.MiB
 				if testing.Short() {
@@ -327,6 +362,15 @@ func newTargetLRUMock() *mock.TargetMock {
 					BID:    0xa7b8c1d2,
 				},
 			),
+			meta.NewBck(
+				bucketNameLFU, apc.AIS, cmn.NsGlobal,
+				&cmn.Bprops{
+					Cksum:  cmn.CksumConf{Type: cos.ChecksumNone},
+					LRU:    cmn.LRUConf{Enabled: true, Policy: apc.LRUPolicyLFU},
+					Access: apc.AccessAll,
+					BID:    0xb1c2d3e4,
+				},
+			),
 			meta.NewBck(
 				bucketNameAnother, apc.AIS, cmn.NsGlobal,
 				&cmn.Bprops{
@@ -401,6 +445,17 @@ func saveRandomFile(filename string, size int64) {
 	Expect(lom.Persist()).NotTo(HaveOccurred())
 }
 
+func hitFile(filename string, hits int) {
+	lom := &core.LOM{}
+	err := lom.InitFQN(filename, nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+	for range hits {
+		lom.IncHits()
+	}
+	Expect(lom.Persist()).NotTo(HaveOccurred())
+}
+
 func saveRandomFilesWithMetadata(filesPath string, files []fileMetadata) {
 	for _, file := range files {
 		saveRandomFile(path.Join(filesPath, file.name), file.size)
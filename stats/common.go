@@ -95,6 +95,10 @@ const (
 	ErrDownloadCount  = errPrefix + "dl.n"
 	ErrPutMirrorCount = errPrefix + "put.mirror.n"
 
+	// rate limiting (see cmn/ratelim.go)
+	ThrottleCount        = "throttle.n"        // requests rejected with 429 (proxy)
+	ThrottleLatencyTotal = "throttle.ns.total" // GET and PUT bandwidth throttling delays (target)
+
 	// KindLatency
 	// latency stats have numSamples used to compute average latency
 	GetLatency         = "get.ns"
@@ -256,6 +260,18 @@ func (r *runner) regCommon(snode *meta.Snode) {
 		},
 	)
 
+	// rate limiting
+	r.reg(snode, ThrottleCount, KindCounter,
+		&Extra{
+			Help: "number of requests rejected (HTTP 429) for exceeding rate limits",
+		},
+	)
+	r.reg(snode, ThrottleLatencyTotal, KindTotal,
+		&Extra{
+			Help: "total cumulative time (nanoseconds) GET and PUT data transfers were delayed to comply with bandwidth limits",
+		},
+	)
+
 	// basic latencies
 	r.reg(snode, GetLatency, KindLatency,
 		&Extra{
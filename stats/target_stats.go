@@ -43,6 +43,9 @@ const (
 	CleanupStoreCount = "cleanup.store.n"
 	CleanupStoreSize  = "cleanup.store.size"
 
+	LcyExpireCount = "lcy.expire.n"
+	LcyExpireSize  = "lcy.expire.size"
+
 	VerChangeCount = "ver.change.n"
 	VerChangeSize  = "ver.change.size"
 
@@ -267,6 +270,17 @@ func (r *Trunner) RegMetrics(snode *meta.Snode) {
 		},
 	)
 
+	r.reg(snode, LcyExpireCount, KindCounter,
+		&Extra{
+			Help: "bucket lifecycle: number of expired objects",
+		},
+	)
+	r.reg(snode, LcyExpireSize, KindSize,
+		&Extra{
+			Help: "bucket lifecycle: total cumulative size (bytes) of expired objects",
+		},
+	)
+
 	// out-of-band (x 3)
 	r.reg(snode, VerChangeCount, KindCounter,
 		&Extra{
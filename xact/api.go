@@ -124,9 +124,13 @@ var Table = map[string]Descriptor{
 
 	apc.ActETLInline: {Scope: ScopeG, Startable: false, AbortRebRes: true},
 
+	// multi-object GET (objects and archived files from one or more buckets)
+	apc.ActGetBatch: {Scope: ScopeG, Access: apc.AceGET, Startable: false, Idles: true, AbortRebRes: true},
+
 	// (one bucket) | (all buckets)
 	apc.ActLRU:          {DisplayName: "lru-eviction", Scope: ScopeGB, Startable: true},
 	apc.ActStoreCleanup: {DisplayName: "cleanup", Scope: ScopeGB, Startable: true},
+	apc.ActLifecycle:    {DisplayName: "lifecycle", Scope: ScopeGB, Startable: true, RefreshCap: true},
 	apc.ActSummaryBck: {
 		DisplayName: "summary",
 		Scope:       ScopeGB,
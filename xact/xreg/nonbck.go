@@ -6,6 +6,7 @@ package xreg
 
 import (
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -44,6 +45,11 @@ func RenewStoreCleanup(id string) RenewRes {
 	return dreg.renew(e, nil)
 }
 
+func RenewLifecycle(id string) RenewRes {
+	e := dreg.nonbckXacts[apc.ActLifecycle].New(Args{UUID: id}, nil)
+	return dreg.renew(e, nil)
+}
+
 func RenewDownloader(xid string, bck *meta.Bck) RenewRes {
 	e := dreg.nonbckXacts[apc.ActDownload].New(Args{UUID: xid, Custom: bck}, nil)
 	return dreg.renew(e, nil)
@@ -54,6 +60,11 @@ func RenewETL(msg any, xid string) RenewRes {
 	return dreg.renew(e, nil)
 }
 
+func RenewGetBatch(xid string, msg *cmn.GetBatchMsg) RenewRes {
+	e := dreg.nonbckXacts[apc.ActGetBatch].New(Args{UUID: xid, Custom: msg}, nil)
+	return dreg.renewByID(e, nil)
+}
+
 func RenewBckSummary(bck *meta.Bck, msg *apc.BsummCtrlMsg) RenewRes {
 	e := dreg.nonbckXacts[apc.ActSummaryBck].New(Args{UUID: msg.UUID, Custom: msg}, bck)
 	return dreg.renew(e, bck)
@@ -211,7 +211,7 @@ func (r *XactArch) Begin(msg *cmn.ArchiveBckMsg, archlom *core.LOM) (err error)
 
 		// append case (above)
 		if lmfh != nil {
-			err = wi.writer.Copy(lmfh, wi.archlom.Lsize())
+			err = wi.writer.Copy(lmfh, wi.archlom.PlainSize())
 			if err != nil {
 				wi.writer.Fini()
 				wi.cleanup()
@@ -313,6 +313,9 @@ func (r *XactArch) doSend(lom *core.LOM, wi *archwi, fh cos.ReadOpenCloser) {
 		hdr.Bck = wi.msg.ToBck
 		hdr.ObjName = lom.ObjName
 		hdr.ObjAttrs.CopyFrom(lom.ObjAttrs(), false /*skip cksum*/)
+		if lom.IsEncoded() {
+			hdr.ObjAttrs.Size, hdr.ObjAttrs.Cksum = lom.PlainSize(), cos.NoneCksum // sending plaintext
+		}
 		hdr.Opaque = []byte(wi.msg.TxnUUID)
 	}
 	// o.Callback nil on purpose (lom is freed by the iterator)
@@ -510,7 +513,7 @@ func (j *jogger) run() {
 
 func (wi *archwi) beginAppend() (lmfh cos.LomReader, err error) {
 	msg := wi.msg
-	if msg.Mime == archive.ExtTar {
+	if msg.Mime == archive.ExtTar && !wi.archlom.IsEncoded() {
 		err = wi.openTarForAppend()
 		if err == nil /*can append*/ || err != archive.ErrTarIsEmpty /*fail XactArch.Begin*/ {
 			return nil, err
@@ -520,7 +523,7 @@ func (wi *archwi) beginAppend() (lmfh cos.LomReader, err error) {
 	// <extra copy>
 	// prep to copy `lmfh` --> `wi.fh` with subsequent APPEND-ing
 	// msg.Mime has been already validated (see ais/* for apc.ActArchive)
-	lmfh, err = wi.archlom.Open()
+	lmfh, err = wi.archlom.OpenPlain()
 	if err != nil {
 		return nil, err
 	}
@@ -579,7 +582,19 @@ func (wi *archwi) do(lom *core.LOM, lrit *lrit) {
 		}
 	}
 
-	fh, err := cos.NewFileHandle(lom.FQN)
+	var (
+		fh  cos.ReadOpenCloser
+		oah cos.OAH = lom
+		err error
+	)
+	if lom.IsEncoded() {
+		var r cos.LomReader
+		if r, err = lom.OpenPlain(); err == nil {
+			fh, oah = cos.NopOpener(r), &cos.SimpleOAH{Size: lom.PlainSize(), Atime: lom.AtimeUnix()}
+		}
+	} else {
+		fh, err = cos.NewFileHandle(lom.FQN)
+	}
 	if err != nil {
 		wi.r.AddErr(err, 5, cos.SmoduleXs)
 		return
@@ -595,7 +610,7 @@ func (wi *archwi) do(lom *core.LOM, lrit *lrit) {
 		wi.r.Abort(err)
 		return
 	}
-	err = wi.writer.Write(wi.nameInArch(lom.ObjName), lom, fh /*reader*/)
+	err = wi.writer.Write(wi.nameInArch(lom.ObjName), oah, fh /*reader*/)
 	cos.Close(fh)
 	if err == nil {
 		wi.cnt.Inc()
@@ -0,0 +1,563 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Multi-object GET: one (.tar, .tgz, .zip, .tar.lz4, .tar.zst) formatted response that contains
+// requested objects and/or archived files, in the requested order. The flow:
+// - proxy generates xaction ID and sends "begin" to all targets;
+// - each target computes the designated target (DT) - the one to serve the client -
+//   as well as the owners of all requested objects (HRW);
+// - proxy redirects client's GET to the DT;
+// - the DT broadcasts "go", whereby all other targets start sending the items they own,
+//   one at a time and in order, via the xaction's data mover (`dm`);
+// - the DT, in turn, reads its own items directly and waits for (and buffers) all the rest;
+// - flow control: each sender keeps at most `gbWindow` items in flight (sent but not yet
+//   written into the resulting archive), and the DT acks each item once it's written -
+//   this way, the DT buffers a bounded number of items per sender (no matter how
+//   slow the client).
+
+const (
+	opcodeGbGo      = iota + opcodeAbrt + 1 // DT => all
+	opcodeGbMissing                         // peer => DT
+	opcodeGbErr                             // ditto
+	opcodeGbAck                             // DT => peer (credit)
+)
+
+const gbWindow = 4 // max items in flight per sender
+
+type (
+	gbFactory struct {
+		msg *cmn.GetBatchMsg
+		streamingF
+	}
+	gbSlot struct { // DT only: item received from another target
+		sgl   *memsys.SGL
+		oa    cmn.ObjAttrs
+		err   error
+		done  chan struct{}
+		recvd bool // under mu
+		ack   bool // the sender has more items to send (and awaits credit - see gbWindow)
+	}
+	XactGetBatch struct {
+		msg    *cmn.GetBatchMsg
+		dt     *meta.Snode   // designated target
+		owners []*meta.Snode // per item
+		slots  []gbSlot      // per item (DT only)
+		goCh   chan struct{}
+		doneCh chan struct{}
+		window chan struct{} // items in flight (peer only)
+		streamingX
+		mu      sync.Mutex
+		started atomic.Bool
+		closed  bool // under mu
+	}
+	// counts written bytes; refuses to write once failed (so that truncated archive won't get
+	// finalized - see Serve)
+	gbWriter struct {
+		w   io.Writer
+		err error
+		n   int64
+	}
+	// object or archived file that keeps the object rlocked (and its shard open)
+	gbReader struct {
+		io.ReadCloser
+		fh  cos.LomReader // archived file: the shard
+		lif core.LIF
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactGetBatch)(nil)
+	_ xreg.Renewable = (*gbFactory)(nil)
+)
+
+///////////////
+// gbFactory //
+///////////////
+
+func (*gbFactory) New(args xreg.Args, _ *meta.Bck) xreg.Renewable {
+	p := &gbFactory{
+		streamingF: streamingF{RenewBase: xreg.RenewBase{Args: args}, kind: apc.ActGetBatch},
+		msg:        args.Custom.(*cmn.GetBatchMsg),
+	}
+	return p
+}
+
+func (p *gbFactory) Start() error {
+	var (
+		smap = core.T.Sowner().Get()
+		msg  = p.msg
+		r    = &XactGetBatch{
+			streamingX: streamingX{p: &p.streamingF, config: cmn.GCO.Get()},
+			msg:        msg,
+			owners:     make([]*meta.Snode, len(msg.Items)),
+			goCh:       make(chan struct{}),
+			doneCh:     make(chan struct{}),
+		}
+		err error
+	)
+	if r.dt, err = smap.HrwTargetTask(p.UUID()); err != nil {
+		return err
+	}
+	for i := range msg.Items {
+		item := &msg.Items[i]
+		if r.owners[i], err = smap.HrwName2T(item.Bck.MakeUname(item.ObjName)); err != nil {
+			return err
+		}
+	}
+	if r.isDT() {
+		var (
+			nth = make([]int, len(msg.Items))
+			cnt = make(map[string]int, smap.CountActiveTs())
+		)
+		r.slots = make([]gbSlot, len(msg.Items))
+		for i := range r.slots {
+			if tid := r.owners[i].ID(); tid != r.dt.ID() {
+				r.slots[i].done = make(chan struct{})
+				nth[i] = cnt[tid]
+				cnt[tid]++
+			}
+		}
+		for i := range r.slots {
+			if r.slots[i].done != nil {
+				r.slots[i].ack = nth[i] < cnt[r.owners[i].ID()]-gbWindow
+			}
+		}
+	} else {
+		r.window = make(chan struct{}, gbWindow)
+	}
+	p.xctn = r
+	r.DemandBase.Init(p.UUID(), p.kind, nil, xact.IdleDefault)
+
+	if err := p.newDM("gb-"+p.UUID() /*trname*/, r.recv, r.config, cmn.OwtGet, 0 /*pdu*/); err != nil {
+		return err
+	}
+	if r.p.dm != nil {
+		r.p.dm.SetXact(r)
+		r.p.dm.Open()
+	}
+	xact.GoRunW(r)
+	return nil
+}
+
+//////////////////
+// XactGetBatch //
+//////////////////
+
+func (r *XactGetBatch) isDT() bool { return r.dt.ID() == core.T.SID() }
+
+func (r *XactGetBatch) Run(wg *sync.WaitGroup) {
+	nlog.Infoln(r.Name(), "num items:", len(r.msg.Items), "DT:", r.dt.StringEx())
+	wg.Done()
+	if r.isDT() {
+		select {
+		case <-r.doneCh:
+		case <-r.IdleTimer(): // client never came
+		case <-r.ChanAbort():
+		}
+	} else {
+		select {
+		case <-r.goCh:
+			r.sendAll()
+		case <-r.IdleTimer():
+		case <-r.ChanAbort():
+		}
+	}
+	r.fin(true /*unreg Rx*/)
+	r.cleanup()
+}
+
+func (r *XactGetBatch) ContentType() string {
+	switch r.msg.Mime {
+	case archive.ExtTar:
+		return cos.ContentTar
+	case archive.ExtZip:
+		return cos.ContentZip
+	default:
+		return cos.ContentBinary
+	}
+}
+
+// Serve writes the resulting archive; returns the number of bytes written.
+// Upon failure, the caller is expected to respond with an error if and only if nothing's been written.
+func (r *XactGetBatch) Serve(w io.Writer) (int64, error) {
+	debug.Assert(r.isDT())
+	if !r.started.CAS(false, true) {
+		return 0, fmt.Errorf("%s: already served", r.Name())
+	}
+	r.IncPending()
+	defer func() {
+		close(r.doneCh)
+		r.DecPending()
+	}()
+
+	if r.p.dm != nil {
+		o := transport.AllocSend()
+		o.Hdr.SID = core.T.SID()
+		o.Hdr.Opcode = opcodeGbGo
+		if err := r.p.dm.Bcast(o, nil); err != nil {
+			r.Abort(err)
+			return 0, err
+		}
+	}
+
+	var (
+		err error
+		cnt int
+		gw  = &gbWriter{w: w}
+		aw  = archive.NewWriter(r.msg.Mime, gw, nil /*cksum*/, nil /*opts*/)
+	)
+	for i := range r.msg.Items {
+		name := r.msg.NameInArch(i)
+		if err = r.write(aw, i, name); err == nil {
+			cnt++
+			continue
+		}
+		if !cos.IsNotExist(err, 0) || r.msg.OnMissing == apc.GbMissingError {
+			break
+		}
+		if cmn.Rom.FastV(5, cos.SmoduleXs) {
+			nlog.Infoln(r.Name(), "missing:", err)
+		}
+		err = nil
+		if r.msg.OnMissing == apc.GbMissingPlaceholder {
+			oah := cos.SimpleOAH{Atime: time.Now().UnixNano()}
+			if err = aw.Write(apc.GbMissingPrefix+name, oah, bytes.NewReader(nil)); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		gw.err = err // do not finalize
+		r.sendTerm(r.ID(), nil, err)
+		r.Abort(err)
+	}
+	aw.Fini()
+	if err == nil {
+		r.ObjsAdd(cnt, gw.n)
+	}
+	return gw.n, err
+}
+
+func (r *XactGetBatch) write(aw archive.Writer, i int, name string) error {
+	if r.owners[i].ID() == core.T.SID() {
+		rc, oah, err := r.open(&r.msg.Items[i])
+		if err != nil {
+			return err
+		}
+		err = aw.Write(name, oah, rc)
+		rc.Close()
+		return err
+	}
+
+	// wait for (received) item
+	slot := &r.slots[i]
+	timeout := r.config.Timeout.SendFile.D()
+	select {
+	case <-slot.done:
+	case <-r.ChanAbort():
+		return r.AbortErr()
+	case <-time.After(timeout):
+		return fmt.Errorf("%s: timed out waiting for %s from %s", r.Name(), r.msg.Items[i].Bck.Cname(r.msg.Items[i].ObjName),
+			r.owners[i].StringEx())
+	}
+	r.mu.Lock()
+	sgl, err := slot.sgl, slot.err
+	slot.sgl = nil
+	r.mu.Unlock()
+	if err == nil {
+		err = aw.Write(name, &slot.oa, sgl)
+		sgl.Free()
+	}
+	if slot.ack && (err == nil || cos.IsNotExist(err, 0)) {
+		// (otherwise, aborting)
+		if errA := r.ack(r.owners[i]); errA != nil {
+			return errA
+		}
+	}
+	return err
+}
+
+// (DT => peer) one more item can be sent
+func (r *XactGetBatch) ack(tsi *meta.Snode) error {
+	o := transport.AllocSend()
+	o.Hdr.SID = core.T.SID()
+	o.Hdr.Opcode = opcodeGbAck
+	return r.p.dm.Send(o, nil, tsi)
+}
+
+// open object or archived file; not-found is returned as cos.ErrNotFound
+// (the returned reader keeps the object rlocked - from load through the last read - until closed)
+func (r *XactGetBatch) open(item *cmn.GetBatchItem) (io.ReadCloser, cos.OAH, error) {
+	lom := core.AllocLOM(item.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(&item.Bck); err != nil {
+		return nil, nil, err
+	}
+	lom.Lock(false)
+	err := lom.Load(false /*cache it*/, true /*locked*/)
+	if err != nil && cos.IsNotExist(err, 0) && lom.Bck().IsRemote() {
+		// cold
+		lom.Unlock(false)
+		ecode, errV := core.T.GetCold(context.Background(), lom, cmn.OwtGetLock)
+		if errV != nil {
+			if cos.IsNotExist(errV, ecode) {
+				errV = cos.NewErrNotFound(core.T, lom.Cname())
+			}
+			return nil, nil, errV
+		}
+		lom.Lock(false)
+		err = lom.Load(false /*cache it*/, true /*locked*/)
+	}
+	if err != nil {
+		lom.Unlock(false)
+		return nil, nil, err
+	}
+	fh, err := lom.OpenPlain()
+	if err != nil {
+		lom.Unlock(false)
+		return nil, nil, err
+	}
+	var (
+		oah = &cos.SimpleOAH{Size: lom.PlainSize(), Atime: lom.AtimeUnix()}
+		lif = lom.LIF()
+	)
+	if item.ArchPath == "" {
+		return &gbReader{ReadCloser: fh, lif: lif}, oah, nil
+	}
+
+	// archived file
+	csl, err := r.openArch(lom, fh, item.ArchPath)
+	if err != nil || csl == nil {
+		cos.Close(fh)
+		lom.Unlock(false)
+		if err == nil {
+			err = cos.NewErrNotFound(core.T, item.ArchPath+" in "+lom.Cname())
+		}
+		return nil, nil, err
+	}
+	oah.Size = csl.Size()
+	return &gbReader{ReadCloser: csl, fh: fh, lif: lif}, oah, nil
+}
+
+// (compare with ais/tgtobj _txarch)
+func (*XactGetBatch) openArch(lom *core.LOM, fh cos.LomReader, archpath string) (cos.ReadCloseSizer, error) {
+	mime, err := archive.MimeFile(fh, core.T.ByteMM(), "", lom.ObjName)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := lom.ArchIndex(fh, mime)
+	if err != nil {
+		nlog.Warningln("failed to index", lom.Cname(), "-", err)
+	}
+	if idx != nil {
+		return idx.ReadOne(fh, archpath)
+	}
+	ar, err := archive.NewReader(mime, fh, lom.PlainSize())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", lom.Cname(), err)
+	}
+	return ar.ReadOne(archpath)
+}
+
+// wait until the number of items in flight drops below gbWindow
+func (r *XactGetBatch) credit() bool {
+	timeout := r.config.Timeout.SendFile.D()
+	select {
+	case r.window <- struct{}{}:
+		return !r.IsAborted() && !r.Finished()
+	case <-r.ChanAbort():
+	case <-time.After(timeout):
+		r.Abort(fmt.Errorf("%s: timed out waiting for %s to ack", r.Name(), r.dt.StringEx()))
+	}
+	return false
+}
+
+// (peer => DT) all owned items, in order
+func (r *XactGetBatch) sendAll() {
+	var (
+		tsi = r.dt
+		sid = core.T.SID()
+	)
+	for i := range r.msg.Items {
+		if r.owners[i].ID() != sid {
+			continue
+		}
+		if !r.credit() {
+			return
+		}
+		var (
+			item = &r.msg.Items[i]
+			o    = transport.AllocSend()
+			roc  cos.ReadOpenCloser
+		)
+		o.Hdr.SID = sid
+		o.Hdr.Opaque = []byte(strconv.Itoa(i))
+		rc, oah, err := r.open(item)
+		switch {
+		case err == nil:
+			o.Hdr.Bck = item.Bck
+			o.Hdr.ObjName = item.ObjName
+			o.Hdr.ObjAttrs.Size = oah.Lsize()
+			o.Hdr.ObjAttrs.Atime = oah.AtimeUnix()
+			roc = cos.NopOpener(rc)
+		case cos.IsNotExist(err, 0):
+			o.Hdr.Opcode = opcodeGbMissing
+			o.Hdr.ObjName = err.Error()
+		default:
+			o.Hdr.Opcode = opcodeGbErr
+			o.Hdr.ObjName = err.Error()
+		}
+		if err := r.p.dm.Send(o, roc, tsi); err != nil {
+			r.Abort(err)
+			return
+		}
+	}
+}
+
+func (r *XactGetBatch) recv(hdr *transport.ObjHdr, objReader io.Reader, err error) error {
+	defer transport.DrainAndFreeReader(objReader)
+	if err != nil && !cos.IsEOF(err) {
+		r.AddErr(err, 5, cos.SmoduleXs)
+		return err
+	}
+	switch hdr.Opcode {
+	case opcodeGbGo:
+		if r.started.CAS(false, true) {
+			close(r.goCh)
+		}
+		return nil
+	case opcodeAbrt:
+		r.Abort(cmn.NewErrAborted(r.Name(), "recv-abort from "+meta.Tname(hdr.SID), errors.New(hdr.ObjName)))
+		return nil
+	case opcodeGbAck:
+		select {
+		case <-r.window:
+		default:
+			nlog.Errorln(r.Name(), "unexpected ack from", meta.Tname(hdr.SID))
+		}
+		return nil
+	}
+
+	i, err := strconv.Atoi(cos.UnsafeS(hdr.Opaque))
+	if err != nil || i < 0 || i >= len(r.slots) || r.slots[i].done == nil {
+		err = fmt.Errorf("%s: unexpected item %q from %s", r.Name(), hdr.Opaque, meta.Tname(hdr.SID))
+		r.AddErr(err, 5, cos.SmoduleXs)
+		return err
+	}
+	var (
+		slot = &r.slots[i]
+		sgl  *memsys.SGL
+	)
+	switch hdr.Opcode {
+	case opcodeGbMissing:
+		err = cos.NewErrNotFound(core.T, hdr.ObjName)
+	case opcodeGbErr:
+		err = errors.New(hdr.ObjName)
+	default:
+		debug.Assert(hdr.Opcode == 0, hdr.Opcode)
+		sgl = core.T.PageMM().NewSGL(hdr.ObjAttrs.Size)
+		if _, err = io.Copy(sgl, objReader); err != nil {
+			sgl.Free()
+			sgl = nil
+		}
+	}
+
+	r.mu.Lock()
+	switch {
+	case r.closed:
+		if sgl != nil {
+			sgl.Free()
+		}
+	case slot.recvd: // (unlikely)
+		if sgl != nil {
+			sgl.Free()
+		}
+		nlog.Errorln(r.Name(), "duplicate item", i, "from", meta.Tname(hdr.SID))
+	default:
+		slot.sgl, slot.err, slot.recvd = sgl, err, true
+		slot.oa.Size, slot.oa.Atime = hdr.ObjAttrs.Size, hdr.ObjAttrs.Atime
+		close(slot.done)
+	}
+	r.mu.Unlock()
+	return nil
+}
+
+// free received (and not served) items
+func (r *XactGetBatch) cleanup() {
+	r.mu.Lock()
+	r.closed = true
+	for i := range r.slots {
+		if sgl := r.slots[i].sgl; sgl != nil {
+			sgl.Free()
+			r.slots[i].sgl = nil
+		}
+	}
+	r.mu.Unlock()
+}
+
+func (r *XactGetBatch) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+//////////////
+// gbWriter //
+//////////////
+
+func (gw *gbWriter) Write(b []byte) (int, error) {
+	if gw.err != nil {
+		return 0, gw.err
+	}
+	n, err := gw.w.Write(b)
+	gw.n += int64(n)
+	if err != nil {
+		gw.err = err
+	}
+	return n, err
+}
+
+//////////////
+// gbReader //
+//////////////
+
+func (gr *gbReader) Close() error {
+	err := gr.ReadCloser.Close()
+	if gr.fh != nil {
+		cos.Close(gr.fh)
+	}
+	gr.lif.Unlock(false)
+	return err
+}
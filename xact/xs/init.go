@@ -51,6 +51,8 @@ func Treg(coi COI) {
 	xreg.RegBckXact(&lsoFactory{streamingF: streamingF{kind: apc.ActList}})
 
 	xreg.RegBckXact(&blobFactory{})
+
+	xreg.RegNonBckXact(&gbFactory{streamingF: streamingF{kind: apc.ActGetBatch}})
 }
 
 //
@@ -114,6 +114,12 @@ func (r *lrit) init(xctn lrxact, msg *apc.ListRange, bck *meta.Bck, numWorkers i
 	r.msg = msg
 	r.bck = bck
 
+	if len(msg.Tags) > 0 {
+		if err := cmn.ValidateObjTags(msg.Tags); err != nil {
+			return err
+		}
+	}
+
 	if msg.IsList() {
 		r.lrp = lrpList
 	} else {
@@ -262,7 +268,7 @@ func (r *lrit) _prefix(wi lrwi, smap *meta.Smap) error {
 		lst     *cmn.LsoRes
 		lsmsg   = &apc.LsoMsg{Prefix: r.prefix, Props: apc.GetPropsStatus}
 		npg     = newNpgCtx(r.bck, lsmsg, noopCb, nil /*core.LsoInvCtx bucket inventory*/)
-		bremote = r.bck.IsRemote()
+		bremote = r.bck.IsRemote() && len(r.msg.Tags) == 0 // (tagged objects are always in-cluster)
 	)
 	lsmsg.SetFlag(apc.LsNoDirs)
 
@@ -336,6 +342,18 @@ func (r *lrit) do(lom *core.LOM, wi lrwi, smap *meta.Smap) (bool /*this lom done
 			return true, nil
 		}
 	}
+	// filter by object tags
+	if len(r.msg.Tags) > 0 {
+		if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+			if cmn.IsErrObjNought(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		if !cmn.MatchObjTags(lom.GetCustomMD(), r.msg.Tags) {
+			return true, nil
+		}
+	}
 
 	if r.workers == nil {
 		wi.do(lom, r)
@@ -623,9 +623,10 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 
 	// ls arch
 	// looking only at the file extension - not reading ("detecting") file magic (TODO: add lsmsg flag)
-	archList, err := archive.List(fqn)
+	// using (and maintaining) shard index when possible (see core/larchidx.go)
+	archList, err := r.lsarch(fqn)
 	if err != nil {
-		if archive.IsErrUnknownFileExt(err) {
+		if archive.IsErrUnknownFileExt(err) || err == cmn.ErrSkip {
 			// skip and keep going
 			err = nil
 		}
@@ -648,6 +649,21 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 	return nil
 }
 
+func (*LsoXact) lsarch(fqn string) ([]*archive.Entry, error) {
+	if _, err := archive.Mime("", fqn); err != nil {
+		return nil, err
+	}
+	lom := core.AllocLOM("")
+	defer core.FreeLOM(lom)
+	if lom.InitFQN(fqn, nil) != nil || lom.Load(false /*cache it*/, false /*locked*/) != nil {
+		return nil, cmn.ErrSkip // (e.g., removed in the meantime) - not listing possibly encoded content as is
+	}
+	if _, ok := lom.GetCustomKey(cmn.SSECKeyMD5ObjMD); ok {
+		return nil, cmn.ErrSkip // cannot be decrypted without customer-provided key
+	}
+	return lom.ListArch()
+}
+
 func (r *LsoXact) Snap() (snap *core.Snap) {
 	snap = &core.Snap{}
 	r.ToSnap(snap)
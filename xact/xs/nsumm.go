@@ -36,10 +36,12 @@ type (
 		p             *nsummFactory
 		oneRes        cmn.BsummResult
 		mapRes        map[uint64]*cmn.BsummResult
+		owners        map[uint64]map[string]*cmn.QuotaUsage // by bucket ID and object owner (see cmn.OwnerObjMD)
 		buckets       []*meta.Bck
 		_nam, _str    string
 		totalDiskSize uint64
 		xact.BckJog
+		ownersMu   sync.Mutex
 		single     bool
 		listRemote bool
 	}
@@ -86,7 +88,7 @@ func newSumm(p *nsummFactory) (r *XactNsumm, err error) {
 		return nil, err
 	}
 
-	listRemote := p.Bck.IsCloud() && !p.msg.ObjCached
+	listRemote := (p.Bck.IsCloud() || p.Bck.IsListableHT()) && !p.msg.ObjCached
 	if listRemote {
 		var (
 			smap = core.T.Sowner().Get()
@@ -184,13 +186,14 @@ func (r *XactNsumm) Run(started *sync.WaitGroup) {
 		}
 	}
 
-	// (II) calculate on-disk size.
+	// (II) calculate on-disk size (and the size of dedup chunks, if any)
 	if r.single {
 		lwg = &sync.WaitGroup{}
 		lwg.Add(1)
 		go func(wg cos.WG) {
 			res := &r.oneRes
 			res.TotalSize.OnDisk = fs.OnDiskSize(r.p.Bck.Bucket(), r.p.msg.Prefix)
+			r.dedupChunks(res)
 			wg.Done()
 		}(lwg)
 	} else {
@@ -204,6 +207,13 @@ func (r *XactNsumm) Run(started *sync.WaitGroup) {
 				wg.Done()
 			}(bck, res, lwg)
 		}
+		for _, res := range r.mapRes {
+			lwg.Add(1)
+			go func(res *cmn.BsummResult, wg cos.WG) {
+				r.dedupChunks(res)
+				wg.Done()
+			}(res, lwg)
+		}
 	}
 
 	// (III) visit objects
@@ -265,6 +275,18 @@ func (r *XactNsumm) initRes(res *cmn.BsummResult, bck *meta.Bck) {
 	res.ObjSize.Min = math.MaxInt64
 }
 
+// dedup chunks are bucket-wide - not counted when summarizing by prefix
+func (r *XactNsumm) dedupChunks(res *cmn.BsummResult) {
+	if !res.Bck.IsAIS() || !r.Full() {
+		return
+	}
+	size, err := core.DedupChunksSize(&res.Bck)
+	if err != nil {
+		r.AddErr(err)
+	}
+	res.TotalSize.DedupChunks = uint64(size)
+}
+
 func (r *XactNsumm) String() string { return r._str }
 func (r *XactNsumm) Name() string   { return r._nam }
 
@@ -297,6 +319,9 @@ func (r *XactNsumm) cloneRes(dst, src *cmn.BsummResult) {
 
 	dst.ObjCount.Present = ratomic.LoadUint64(&src.ObjCount.Present)
 	dst.TotalSize.PresentObjs = ratomic.LoadUint64(&src.TotalSize.PresentObjs)
+	dst.TotalSize.PresentLogical = ratomic.LoadUint64(&src.TotalSize.PresentLogical)
+	dst.TotalSize.DedupLogical = ratomic.LoadUint64(&src.TotalSize.DedupLogical)
+	dst.TotalSize.DedupChunks = src.TotalSize.DedupChunks
 
 	if r.listRemote {
 		dst.ObjCount.Remote = ratomic.LoadUint64(&src.ObjCount.Remote)
@@ -338,12 +363,56 @@ func (r *XactNsumm) visitObj(lom *core.LOM, _ []byte) error {
 		ratomic.CompareAndSwapInt64(&res.ObjSize.Max, cmax, size)
 	}
 	ratomic.AddUint64(&res.TotalSize.PresentObjs, uint64(size))
+	ratomic.AddUint64(&res.TotalSize.PresentLogical, uint64(lom.PlainSize()))
+	if lom.IsDeduped() {
+		ratomic.AddUint64(&res.TotalSize.DedupLogical, uint64(lom.PlainSize()))
+	}
+	if !lom.IsCopy() {
+		if owner, ok := lom.GetCustomKey(cmn.OwnerObjMD); ok && owner != "" {
+			r.addOwner(lom.Bprops().BID, owner, size)
+		}
+	}
 
 	// generic stats (same as base.LomAdd())
 	r.ObjsAdd(1, size)
 	return nil
 }
 
+func (r *XactNsumm) addOwner(bid uint64, owner string, size int64) {
+	r.ownersMu.Lock()
+	if r.owners == nil {
+		r.owners = make(map[uint64]map[string]*cmn.QuotaUsage, 4)
+	}
+	m, ok := r.owners[bid]
+	if !ok {
+		m = make(map[string]*cmn.QuotaUsage, 4)
+		r.owners[bid] = m
+	}
+	u, ok := m[owner]
+	if !ok {
+		u = &cmn.QuotaUsage{}
+		m[owner] = u
+	}
+	u.Size += size
+	u.Objs++
+	r.ownersMu.Unlock()
+}
+
+// Full returns true if the summary includes all objects (no prefix)
+func (r *XactNsumm) Full() bool { return r.p.msg.Prefix == "" }
+
+// Owners returns bucket usage by object owners (to seed quota counters - see ais/tgtquota.go)
+func (r *XactNsumm) Owners(bid uint64) map[string]cmn.QuotaUsage {
+	r.ownersMu.Lock()
+	defer r.ownersMu.Unlock()
+	m := r.owners[bid]
+	out := make(map[string]cmn.QuotaUsage, len(m))
+	for owner, u := range m {
+		out[owner] = *u
+	}
+	return out
+}
+
 //
 // listRemote
 //
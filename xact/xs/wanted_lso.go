@@ -46,10 +46,11 @@ func (wi *walkInfo) setWanted(e *cmn.LsoEnt, lom *core.LOM) {
 		case apc.GetPropsCached: // via obj.SetPresent()
 
 		case apc.GetPropsSize:
-			if e.Size > 0 && lom.Lsize() != e.Size {
+			size := lom.PlainSize() // (encrypted or compressed at rest)
+			if e.Size > 0 && size != e.Size {
 				e.SetVerChanged()
 			}
-			e.Size = lom.Lsize()
+			e.Size = size
 		case apc.GetPropsVersion:
 			e.Version = lom.Version()
 		case apc.GetPropsChecksum:
@@ -72,6 +73,9 @@ func (wi *walkInfo) setWanted(e *cmn.LsoEnt, lom *core.LOM) {
 			debug.Assert(false, name)
 		}
 	}
+	if lom.IsDirty() {
+		e.SetDirty()
+	}
 	if wi.msg.IsFlagSet(apc.LsVerChanged) && !e.IsVerChanged() {
 		//
 		// slow path: extensive 'version-changed' check
@@ -151,7 +151,8 @@ func (wi *walkInfo) _cb(lom *core.LOM, fqn string) (*cmn.LsoEnt, error) {
 	}
 
 	// shortcut #1: name-only optimizes-out loading md (NOTE: won't show misplaced and copies)
-	if wi.msg.IsFlagSet(apc.LsNameOnly) {
+	// (unless filtering by object tags)
+	if wi.msg.IsFlagSet(apc.LsNameOnly) && len(wi.msg.Tags) == 0 {
 		if !isOK(status) {
 			return nil, nil
 		}
@@ -164,6 +165,9 @@ func (wi *walkInfo) _cb(lom *core.LOM, fqn string) (*cmn.LsoEnt, error) {
 		}
 		return nil, err
 	}
+	if !cmn.MatchObjTags(lom.GetCustomMD(), wi.msg.Tags) {
+		return nil, nil
+	}
 	if local && lom.IsCopy() {
 		// still may change below
 		status = apc.LocIsCopy